@@ -74,6 +74,15 @@ func (listener *Listener) Start() {
 	// Example: Map the name of jobs to handler functions
 	listener.statement.workerPool.Job("do_send_notification", iProvider.DoSendNotification)
 	listener.statement.workerPool.Job("hcheck", iProvider.Hcheck)
+	listener.statement.workerPool.Job("do_advance_bracket", iProvider.DoAdvanceBracket)
+	listener.statement.workerPool.Job("do_return_loans", iProvider.DoReturnLoans)
+	listener.statement.workerPool.Job("do_refresh_stats", iProvider.DoRefreshStats)
+	listener.statement.workerPool.Job("do_serve_suspensions", iProvider.DoServeSuspensions)
+
+	// Example: Enqueue a job on a cron-based schedule, the first field is the seconds.
+	if spec := listener.config.GetString("loans.return.schedule"); spec != "" {
+		listener.statement.workerPool.PeriodicallyEnqueue(spec, "do_return_loans")
+	}
 
 	// Example: Customize options:
 	listener.statement.workerPool.JobWithOptions("export", work.JobOptions{Priority: 10, MaxFails: 1}, iProvider.Export)
@@ -5,17 +5,24 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"github.com/harunnryd/skeltun/config"
+	bracketParam "github.com/harunnryd/skeltun/internal/app/handler/bracket/param"
+	loanParam "github.com/harunnryd/skeltun/internal/app/handler/loan/param"
+	statParam "github.com/harunnryd/skeltun/internal/app/handler/stat/param"
+	suspensionParam "github.com/harunnryd/skeltun/internal/app/handler/suspension/param"
 	"github.com/harunnryd/skeltun/internal/app/repo"
 	"github.com/harunnryd/skeltun/internal/app/usecase"
 	"github.com/harunnryd/skeltun/internal/pkg"
 	"github.com/harunnryd/skeltun/internal/pkg/osignal/param"
 	"github.com/harunnryd/skeltun/internal/pkg/osignal/transporter"
 	"github.com/harunnryd/skeltun/job"
+	"time"
 
 	"github.com/gocraft/work"
 	"github.com/gomodule/redigo/redis"
+	"github.com/satori/uuid"
 )
 
 // IProvider is an interface that stores the methods that Provider struct will use.
@@ -35,6 +42,22 @@ type IProvider interface {
 	// Hcheck is used for checking health of redis connection.
 	// It returns any errors written.
 	Hcheck(job *work.Job) (err error)
+
+	// DoAdvanceBracket is used for moving the winner of a knockout tie into the next round.
+	// It returns any errors written.
+	DoAdvanceBracket(job *work.Job) (err error)
+
+	// DoReturnLoans is used for sending players back to the parent club once their loan ended.
+	// It returns any errors written.
+	DoReturnLoans(job *work.Job) (err error)
+
+	// DoRefreshStats is used for rebuilding the player stats of the season a finished match belongs to.
+	// It returns any errors written.
+	DoRefreshStats(job *work.Job) (err error)
+
+	// DoServeSuspensions is used for counting a finished match down from the suspensions of the players of both teams.
+	// It returns any errors written.
+	DoServeSuspensions(job *work.Job) (err error)
 }
 
 // Provider is an struct that implements IProvider methods.
@@ -108,3 +131,96 @@ func (provider *Provider) Hcheck(job *work.Job) (err error) {
 	fmt.Printf("response_desc: %s\n", responseDesc)
 	return
 }
+
+// DoAdvanceBracket is used for moving the winner of a knockout tie into the next round.
+// It returns any errors written.
+func (provider *Provider) DoAdvanceBracket(job *work.Job) (err error) {
+	var tieID = job.ArgString("tie_id")
+	if err = job.ArgError(); err != nil {
+		return
+	}
+
+	doAdvanceResponse, err := provider.usecase.GetBracket().DoAdvance(context.Background(), bracketParam.DoAdvance{
+		TieID: uuid.FromStringOrNil(tieID),
+	})
+	if err != nil {
+		return
+	}
+
+	fmt.Printf("%+v\n", doAdvanceResponse)
+	return
+}
+
+// DoReturnLoans is used for sending players back to the parent club once their loan ended.
+// The player and both clubs are notified of every return with Onesignal, even when some loans
+// could not be ended, as those players are already back and will not come up again.
+// A failed notification is logged and does not hold the others back.
+// It returns any errors written.
+func (provider *Provider) DoReturnLoans(job *work.Job) (err error) {
+	doReturnExpiredResponse, err := provider.usecase.GetLoan().DoReturnExpired(context.Background(), loanParam.DoReturnExpired{
+		Date: time.Now(),
+	})
+
+	for _, returned := range doReturnExpiredResponse {
+		var doSendNotificationParams = param.DoSendNotificationParam()
+		doSendNotificationParams = param.DoSendNotification{
+			AppID: provider.config.GetString("onesignal.api.app_id"),
+			IncludeExternalUserIDs: []interface{}{
+				returned.PlayerID.String(),
+				returned.ParentTeamID.String(),
+				returned.LoanTeamID.String(),
+			},
+			Contents: map[string]interface{}{
+				"en": fmt.Sprintf("%s is back at %s after the loan at %s ended", returned.PlayerName, returned.ParentTeamName, returned.LoanTeamName),
+			},
+		}
+
+		doSendNotificationResponse, sendErr := provider.pkg.GetOsignal().DoSendNotification(doSendNotificationParams)
+		if sendErr != nil {
+			fmt.Printf("could not notify the return of loan %s: %v\n", returned.ID, sendErr)
+			continue
+		}
+
+		fmt.Printf("%+v\n", doSendNotificationResponse)
+	}
+
+	return
+}
+
+// DoRefreshStats is used for rebuilding the player stats of the season a finished match belongs to.
+// It returns any errors written.
+func (provider *Provider) DoRefreshStats(job *work.Job) (err error) {
+	var matchID = job.ArgString("match_id")
+	if err = job.ArgError(); err != nil {
+		return
+	}
+
+	doRefreshResponse, err := provider.usecase.GetStat().DoRefresh(context.Background(), statParam.DoRefresh{
+		MatchID: uuid.FromStringOrNil(matchID),
+	})
+	if err != nil {
+		return
+	}
+
+	fmt.Printf("%+v\n", doRefreshResponse)
+	return
+}
+
+// DoServeSuspensions is used for counting a finished match down from the suspensions of the players of both teams.
+// It returns any errors written.
+func (provider *Provider) DoServeSuspensions(job *work.Job) (err error) {
+	var matchID = job.ArgString("match_id")
+	if err = job.ArgError(); err != nil {
+		return
+	}
+
+	doServeResponse, err := provider.usecase.GetSuspension().DoServe(context.Background(), suspensionParam.DoServe{
+		MatchID: uuid.FromStringOrNil(matchID),
+	})
+	if err != nil {
+		return
+	}
+
+	fmt.Printf("%+v\n", doServeResponse)
+	return
+}
@@ -5,12 +5,14 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"github.com/harunnryd/skeltun/cmd/listener"
 	"github.com/harunnryd/skeltun/cmd/migration"
 	"github.com/harunnryd/skeltun/config"
 	"github.com/harunnryd/skeltun/internal/app/driver/db"
 	"github.com/harunnryd/skeltun/internal/app/handler"
+	fixtureParam "github.com/harunnryd/skeltun/internal/app/handler/fixture/param"
 	"github.com/harunnryd/skeltun/internal/app/repo"
 	"github.com/harunnryd/skeltun/internal/app/server"
 	"github.com/harunnryd/skeltun/internal/app/usecase"
@@ -21,10 +23,12 @@ import (
 	"os"
 	"os/signal"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi"
 	"github.com/gomodule/redigo/redis"
 	"github.com/olekukonko/tablewriter"
+	"github.com/satori/uuid"
 
 	"github.com/spf13/cobra"
 )
@@ -43,9 +47,19 @@ func init() {
 	rootCmd.AddCommand(migrateUpCmd)
 	rootCmd.AddCommand(migrateDownCmd)
 	rootCmd.AddCommand(makeMigrationCmd)
+	rootCmd.AddCommand(fixturesGenerateCmd)
 	rootCmd.AddCommand(routeListCmd)
 	rootCmd.AddCommand(workerCmd)
 	cobra.OnInitialize()
+
+	fixturesGenerateCmd.Flags().Int("legs", fixtureParam.LegsDouble, "1 for a single round-robin, 2 for a double round-robin")
+	fixturesGenerateCmd.Flags().String("start-date", "", "date of the first round as YYYY-MM-DD, defaults to the season start date")
+	fixturesGenerateCmd.Flags().Int("days-between-rounds", fixtureParam.DefaultDaysBetweenRounds, "number of days between two rounds")
+	fixturesGenerateCmd.Flags().Int("round-days", 1, "number of days a round can be spread over")
+	fixturesGenerateCmd.Flags().Int("min-rest-days", 0, "minimum number of days between two matches of a team")
+	fixturesGenerateCmd.Flags().StringSlice("blackout-dates", nil, "dates as YYYY-MM-DD on which no match is played")
+	fixturesGenerateCmd.Flags().StringSlice("kickoff-slots", nil, "preferred kickoff times as hh:mm")
+	fixturesGenerateCmd.Flags().StringArray("shared-venue", nil, "comma separated ids of teams sharing a venue, repeatable")
 }
 
 var rootCmd = &cobra.Command{
@@ -113,6 +127,18 @@ var makeMigrationCmd = &cobra.Command{
 	},
 }
 
+var fixturesGenerateCmd = &cobra.Command{
+	Use:   "fixtures:generate [season_id]",
+	Short: "Generate round-robin fixtures",
+	Long:  `Generate round-robin fixtures for the teams registered in a season`,
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := generateFixtures(cmd, args[0]); err != nil {
+			fmt.Printf("Generate fixtures error: %v\n", err.Error())
+		}
+	},
+}
+
 // Execute executes the root command.
 func Execute() (err error) {
 	if err = rootCmd.Execute(); err != nil {
@@ -221,3 +247,95 @@ func getRoutes() {
 
 	table.Render() // Send output
 }
+
+func generateFixtures(cmd *cobra.Command, seasonID string) (err error) {
+	doGenerateParam := fixtureParam.DoGenerate{SeasonID: uuid.FromStringOrNil(seasonID)}
+
+	if doGenerateParam.Legs, err = cmd.Flags().GetInt("legs"); err != nil {
+		return
+	}
+
+	if doGenerateParam.DaysBetweenRounds, err = cmd.Flags().GetInt("days-between-rounds"); err != nil {
+		return
+	}
+
+	if doGenerateParam.Constraints.RoundDays, err = cmd.Flags().GetInt("round-days"); err != nil {
+		return
+	}
+
+	if doGenerateParam.Constraints.MinRestDays, err = cmd.Flags().GetInt("min-rest-days"); err != nil {
+		return
+	}
+
+	if doGenerateParam.Constraints.KickoffSlots, err = cmd.Flags().GetStringSlice("kickoff-slots"); err != nil {
+		return
+	}
+
+	blackoutDates, err := cmd.Flags().GetStringSlice("blackout-dates")
+	if err != nil {
+		return
+	}
+
+	for _, blackoutDate := range blackoutDates {
+		date, err := time.Parse("2006-01-02", blackoutDate)
+		if err != nil {
+			return err
+		}
+		doGenerateParam.Constraints.BlackoutDates = append(doGenerateParam.Constraints.BlackoutDates, date)
+	}
+
+	sharedVenues, err := cmd.Flags().GetStringArray("shared-venue")
+	if err != nil {
+		return
+	}
+
+	for _, sharedVenue := range sharedVenues {
+		var teamIDs []uuid.UUID
+		for _, teamID := range strings.Split(sharedVenue, ",") {
+			teamIDs = append(teamIDs, uuid.FromStringOrNil(strings.TrimSpace(teamID)))
+		}
+		doGenerateParam.Constraints.SharedVenues = append(doGenerateParam.Constraints.SharedVenues, teamIDs)
+	}
+
+	startDate, err := cmd.Flags().GetString("start-date")
+	if err != nil {
+		return
+	}
+
+	if startDate != "" {
+		if doGenerateParam.StartDate, err = time.Parse("2006-01-02", startDate); err != nil {
+			return
+		}
+	}
+
+	if err = doGenerateParam.Validate(); err != nil {
+		return
+	}
+
+	cfg := config.New(config.WithEnvSetup())
+	iUseCase := usecase.New(usecase.WithDependency(cfg))
+
+	doGenerateResp, err := iUseCase.GetFixture().DoGenerate(context.Background(), doGenerateParam)
+	if err != nil {
+		return
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Round", "Kickoff", "Home", "Away"})
+	for _, fixture := range doGenerateResp.Fixtures {
+		table.Append([]string{
+			fmt.Sprint(fixture.Round),
+			fixture.KickoffAt.Format("2006-01-02 15:04"),
+			fixture.HomeTeamID.String(),
+			fixture.AwayTeamID.String(),
+		})
+	}
+
+	table.Render()
+
+	for _, unsatisfied := range doGenerateResp.Unsatisfied {
+		fmt.Printf("Unsatisfied %s in round %d: %s\n", unsatisfied.Constraint, unsatisfied.Round, unsatisfied.Detail)
+	}
+
+	return
+}
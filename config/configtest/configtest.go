@@ -0,0 +1,26 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package configtest provides a config.IConfig for tests, serving values without reading params/env.yaml.
+package configtest
+
+import "time"
+
+// Fake is a config.IConfig serving the integer values it holds, every other value is empty.
+type Fake map[string]int
+
+// GetString ...
+func (Fake) GetString(string) string { return "" }
+
+// GetInt ...
+func (cfg Fake) GetInt(k string) int { return cfg[k] }
+
+// GetBool ...
+func (Fake) GetBool(string) bool { return false }
+
+// GetDuration ...
+func (Fake) GetDuration(string) time.Duration { return 0 }
+
+// GetFloat64 ...
+func (Fake) GetFloat64(string) float64 { return 0 }
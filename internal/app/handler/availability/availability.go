@@ -0,0 +1,61 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package availability
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/availability/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/availability/transporter"
+	"github.com/harunnryd/skeltun/internal/app/usecase"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// IAvailability is an interface that stores the methods that Availability struct will use.
+type IAvailability interface {
+	// GetAvailability is used for getting the players of a team who cannot play today and why.
+	// It returns getAvailabilityResp of transporter.GetAvailability and any errors written.
+	GetAvailability(w http.ResponseWriter, r *http.Request) (getAvailabilityResp interface{}, err error)
+}
+
+// Availability is an struct that implements IAvailability methods.
+type Availability struct {
+	config  config.IConfig
+	usecase usecase.IUseCase
+}
+
+// New it returns instance of Availability that implements IAvailability methods.
+func New(opts ...Option) IAvailability {
+	a := new(Availability)
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// GetAvailability is used for getting the players of a team who cannot play today and why.
+// It returns getAvailabilityResp of transporter.GetAvailability and any errors written.
+func (availability *Availability) GetAvailability(w http.ResponseWriter, r *http.Request) (getAvailabilityResp interface{}, err error) {
+	getAvailabilityParam := param.GetAvailability{TeamID: uuid.FromStringOrNil(chi.URLParam(r, "team_id"))}
+
+	if err = getAvailabilityParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getAvailabilityResp = transporter.GetAvailability{}
+	getAvailabilityResp, err = availability.usecase.GetAvailability().GetAvailability(r.Context(), getAvailabilityParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getAvailabilityResp, nil
+}
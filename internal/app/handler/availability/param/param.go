@@ -0,0 +1,34 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package param
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/satori/uuid"
+)
+
+// GetAvailability ...
+type GetAvailability struct {
+	TeamID uuid.UUID `json:"team_id"`
+	Date   time.Time `json:"-"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getAvailability GetAvailability) Validate() error {
+	return validation.ValidateStruct(&getAvailability,
+		// TeamID cannot be empty and should be in a valid uuid.
+		validation.Field(&getAvailability.TeamID, validation.Required, is.UUIDv4),
+	)
+}
+
+// GetAbsences ...
+type GetAbsences struct {
+	TeamID uuid.UUID `json:"team_id"`
+	Date   time.Time `json:"date"`
+}
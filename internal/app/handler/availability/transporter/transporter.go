@@ -0,0 +1,34 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transporter
+
+import (
+	"time"
+
+	"github.com/satori/uuid"
+)
+
+// Absence ...
+type Absence struct {
+	PlayerID         uuid.UUID  `json:"player_id"`
+	Name             string     `json:"name"`
+	Reason           string     `json:"reason"`
+	Detail           string     `json:"detail"`
+	ExpectedReturnAt *time.Time `json:"expected_return_at,omitempty"`
+	SeasonID         *uuid.UUID `json:"season_id,omitempty"`
+	MatchesRemaining int        `json:"matches_remaining,omitempty"`
+}
+
+// GetAvailability ...
+type GetAvailability struct {
+	TeamID      uuid.UUID `json:"team_id"`
+	Date        time.Time `json:"date"`
+	Unavailable []Absence `json:"unavailable"`
+}
+
+// GetAbsences ...
+type GetAbsences struct {
+	Absence
+}
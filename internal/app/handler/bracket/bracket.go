@@ -0,0 +1,92 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bracket
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/bracket/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/bracket/transporter"
+	"github.com/harunnryd/skeltun/internal/app/usecase"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// IBracket is an interface that stores the methods that Bracket struct will use.
+type IBracket interface {
+	// DoGenerate is used for drawing the single-elimination bracket of a season from its seeded teams.
+	// It returns doGenerateResp of transporter.DoGenerate and any errors written.
+	DoGenerate(w http.ResponseWriter, r *http.Request) (doGenerateResp interface{}, err error)
+
+	// GetBracket is used for getting every tie of a season bracket, round by round.
+	// It returns getBracketResp of []transporter.GetBracket and any errors written.
+	GetBracket(w http.ResponseWriter, r *http.Request) (getBracketResp interface{}, err error)
+}
+
+// Bracket is an struct that implements IBracket methods.
+type Bracket struct {
+	config  config.IConfig
+	usecase usecase.IUseCase
+}
+
+// New it returns instance of Bracket that implements IBracket methods.
+func New(opts ...Option) IBracket {
+	b := new(Bracket)
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// DoGenerate is used for drawing the single-elimination bracket of a season from its seeded teams.
+// It returns doGenerateResp of transporter.DoGenerate and any errors written.
+func (bracket *Bracket) DoGenerate(w http.ResponseWriter, r *http.Request) (doGenerateResp interface{}, err error) {
+	doGenerateParam := param.DoGenerate{}
+	if err = json.NewDecoder(r.Body).Decode(&doGenerateParam); err != nil {
+		return
+	}
+
+	doGenerateParam.SeasonID = uuid.FromStringOrNil(chi.URLParam(r, "season_id"))
+
+	if err = doGenerateParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	doGenerateResp = transporter.DoGenerate{}
+	doGenerateResp, err = bracket.usecase.GetBracket().DoGenerate(r.Context(), doGenerateParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return doGenerateResp, nil
+}
+
+// GetBracket is used for getting every tie of a season bracket, round by round.
+// It returns getBracketResp of []transporter.GetBracket and any errors written.
+func (bracket *Bracket) GetBracket(w http.ResponseWriter, r *http.Request) (getBracketResp interface{}, err error) {
+	getBracketParam := param.GetBracket{SeasonID: uuid.FromStringOrNil(chi.URLParam(r, "season_id"))}
+
+	if err = getBracketParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getBracketResp = transporter.GetBracket{}
+	getBracketResp, err = bracket.usecase.GetBracket().GetBracket(r.Context(), getBracketParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getBracketResp, nil
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package param
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/satori/uuid"
+)
+
+const (
+	// LegsSingle decides every tie in a single match.
+	LegsSingle = 1
+	// LegsDouble decides every tie but the final on aggregate over two matches, one at each ground.
+	LegsDouble = 2
+
+	// DefaultDaysBetweenRounds is used when the request does not set the gap between rounds.
+	DefaultDaysBetweenRounds = 7
+)
+
+// Tie ...
+type Tie struct {
+	ID           uuid.UUID  `json:"id"`
+	SeasonID     uuid.UUID  `json:"season_id"`
+	Round        int        `json:"round"`
+	Position     int        `json:"position"`
+	Legs         int        `json:"legs"`
+	HomeTeamID   *uuid.UUID `json:"home_team_id"`
+	AwayTeamID   *uuid.UUID `json:"away_team_id"`
+	WinnerTeamID *uuid.UUID `json:"winner_team_id"`
+	FirstLegAt   time.Time  `json:"first_leg_at"`
+	SecondLegAt  *time.Time `json:"second_leg_at"`
+}
+
+// DoGenerate ...
+type DoGenerate struct {
+	SeasonID          uuid.UUID   `json:"season_id"`
+	Seeds             []uuid.UUID `json:"seeds"`
+	FromGroups        bool        `json:"from_groups"`
+	Legs              int         `json:"legs"`
+	StartDate         time.Time   `json:"start_date"`
+	DaysBetweenRounds int         `json:"days_between_rounds"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (doGenerate DoGenerate) Validate() error {
+	return validation.ValidateStruct(&doGenerate,
+		// SeasonID cannot be empty and should be in a valid uuid.
+		validation.Field(&doGenerate.SeasonID, validation.Required, is.UUIDv4),
+		// Seeds should list at least two teams when given.
+		validation.Field(&doGenerate.Seeds, validation.Length(2, 0)),
+		// Legs cannot be empty and should be either single or double.
+		validation.Field(&doGenerate.Legs, validation.Required, validation.In(LegsSingle, LegsDouble)),
+		// DaysBetweenRounds cannot be negative.
+		validation.Field(&doGenerate.DaysBetweenRounds, validation.Min(0)),
+	)
+}
+
+// DoCreate ...
+type DoCreate struct {
+	SeasonID uuid.UUID `json:"season_id"`
+	Ties     []Tie     `json:"ties"`
+}
+
+// GetBracket ...
+type GetBracket struct {
+	SeasonID uuid.UUID `json:"season_id"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getBracket GetBracket) Validate() error {
+	return validation.ValidateStruct(&getBracket,
+		// SeasonID cannot be empty and should be in a valid uuid.
+		validation.Field(&getBracket.SeasonID, validation.Required, is.UUIDv4),
+	)
+}
+
+// GetTie ...
+type GetTie struct {
+	ID uuid.UUID `json:"id"`
+}
+
+// DoAdvance ...
+type DoAdvance struct {
+	TieID        uuid.UUID `json:"tie_id"`
+	SeasonID     uuid.UUID `json:"-"`
+	Round        int       `json:"-"`
+	Position     int       `json:"-"`
+	WinnerTeamID uuid.UUID `json:"-"`
+}
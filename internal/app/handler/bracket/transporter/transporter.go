@@ -0,0 +1,62 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transporter
+
+import (
+	"time"
+
+	"github.com/satori/uuid"
+)
+
+// Tie ...
+type Tie struct {
+	ID           uuid.UUID  `gorm:"primaryKey" json:"id"`
+	SeasonID     uuid.UUID  `json:"season_id"`
+	Round        int        `json:"round"`
+	Position     int        `json:"position"`
+	Legs         int        `json:"legs"`
+	HomeTeamID   *uuid.UUID `json:"home_team_id"`
+	AwayTeamID   *uuid.UUID `json:"away_team_id"`
+	WinnerTeamID *uuid.UUID `json:"winner_team_id"`
+	FirstLegAt   time.Time  `json:"first_leg_at"`
+	SecondLegAt  *time.Time `json:"second_leg_at,omitempty"`
+}
+
+// DoCreate ...
+type DoCreate struct {
+	Tie
+}
+
+// DoGenerate ...
+type DoGenerate struct {
+	Ties []Tie `json:"ties"`
+}
+
+// GetBracket ...
+type GetBracket struct {
+	Tie
+}
+
+// TableName ...
+func (GetBracket) TableName() string {
+	return "bracket_ties"
+}
+
+// GetTie ...
+type GetTie struct {
+	Tie
+}
+
+// TableName ...
+func (GetTie) TableName() string {
+	return "bracket_ties"
+}
+
+// DoAdvance ...
+type DoAdvance struct {
+	TieID        uuid.UUID  `json:"tie_id"`
+	WinnerTeamID uuid.UUID  `json:"winner_team_id"`
+	NextTieID    *uuid.UUID `json:"next_tie_id,omitempty"`
+}
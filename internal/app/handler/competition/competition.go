@@ -0,0 +1,171 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package competition
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/competition/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/competition/transporter"
+	"github.com/harunnryd/skeltun/internal/app/usecase"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// ICompetition is an interface that stores the methods that Competition struct will use.
+type ICompetition interface {
+	// DoCreate is used for record new competition.
+	// It returns doCreateResp of transporter.DoCreate and any errors written.
+	DoCreate(w http.ResponseWriter, r *http.Request) (doCreateResp interface{}, err error)
+
+	// GetCompetitions is used for getting all competitions with seasons.
+	// It returns getCompetitionsResp of []transporter.GetCompetitions and any errors written.
+	GetCompetitions(w http.ResponseWriter, r *http.Request) (getCompetitionsResp interface{}, err error)
+
+	// DoUpdate is used for update the record competition.
+	// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+	DoUpdate(w http.ResponseWriter, r *http.Request) (doUpdateResp interface{}, err error)
+
+	// DoDelete is used for delete the record competition.
+	// It returns doDeleteResp of transporter.DoDelete and any errors written.
+	DoDelete(w http.ResponseWriter, r *http.Request) (doDeleteResp interface{}, err error)
+
+	// GetCompetition is used for getting an competition with seasons.
+	// It returns getCompetitionResp of transporter.GetCompetition and any errors written.
+	GetCompetition(w http.ResponseWriter, r *http.Request) (getCompetitionResp interface{}, err error)
+}
+
+// Competition is an struct that implements ICompetition methods.
+type Competition struct {
+	config  config.IConfig
+	usecase usecase.IUseCase
+}
+
+// New it returns instance of Competition that implements ICompetition methods.
+func New(opts ...Option) ICompetition {
+	c := new(Competition)
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// DoCreate is used for record new competition.
+// It returns doCreateResp of transporter.DoCreate and any errors written.
+func (competition *Competition) DoCreate(w http.ResponseWriter, r *http.Request) (doCreateResp interface{}, err error) {
+	doCreateParam := param.DoCreate{}
+	if err = json.NewDecoder(r.Body).Decode(&doCreateParam); err != nil {
+		return
+	}
+
+	if err = doCreateParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	doCreateResp = transporter.DoCreate{}
+	doCreateResp, err = competition.usecase.GetCompetition().DoCreate(r.Context(), doCreateParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return doCreateResp, nil
+}
+
+// GetCompetitions is used for getting all competitions with seasons.
+// It returns getCompetitionsResp of []transporter.GetCompetitions and any errors written.
+func (competition *Competition) GetCompetitions(w http.ResponseWriter, r *http.Request) (getCompetitionsResp interface{}, err error) {
+	getCompetitionsParam := param.GetCompetitions{Pagination: param.Pagination{
+		Limit:  r.URL.Query().Get("limit"),
+		Offset: r.URL.Query().Get("offset"),
+	}}
+
+	if err = getCompetitionsParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getCompetitionsResp = transporter.GetCompetitions{}
+	getCompetitionsResp, err = competition.usecase.GetCompetition().GetCompetitions(r.Context(), getCompetitionsParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getCompetitionsResp, nil
+}
+
+// DoUpdate is used for update the record competition.
+// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+func (competition *Competition) DoUpdate(w http.ResponseWriter, r *http.Request) (doUpdateResp interface{}, err error) {
+	doUpdateParam := param.DoUpdate{Competition: param.Competition{ID: uuid.FromStringOrNil(chi.URLParam(r, "competition_id"))}}
+	if err = json.NewDecoder(r.Body).Decode(&doUpdateParam); err != nil {
+		return
+	}
+
+	if err = doUpdateParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	doUpdateResp = transporter.DoUpdate{}
+	doUpdateResp, err = competition.usecase.GetCompetition().DoUpdate(r.Context(), doUpdateParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return doUpdateResp, nil
+}
+
+// DoDelete is used for delete the record competition.
+// It returns doDeleteResp of transporter.DoDelete and any errors written.
+func (competition *Competition) DoDelete(w http.ResponseWriter, r *http.Request) (doDeleteResp interface{}, err error) {
+	doDeleteParam := param.DoDelete{ID: uuid.FromStringOrNil(chi.URLParam(r, "competition_id"))}
+
+	if err = doDeleteParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	doDeleteResp = transporter.DoDelete{}
+	doDeleteResp, err = competition.usecase.GetCompetition().DoDelete(r.Context(), doDeleteParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return doDeleteResp, nil
+}
+
+// GetCompetition is used for getting an competition with seasons.
+// It returns getCompetitionResp of transporter.GetCompetition and any errors written.
+func (competition *Competition) GetCompetition(w http.ResponseWriter, r *http.Request) (getCompetitionResp interface{}, err error) {
+	getCompetitionParam := param.GetCompetition{ID: uuid.FromStringOrNil(chi.URLParam(r, "competition_id"))}
+
+	if err = getCompetitionParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getCompetitionResp = transporter.GetCompetition{}
+	getCompetitionResp, err = competition.usecase.GetCompetition().GetCompetition(r.Context(), getCompetitionParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getCompetitionResp, nil
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package param
+
+import (
+	"errors"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/satori/uuid"
+)
+
+// Pagination ...
+type Pagination struct {
+	Limit  string `json:"limit"`
+	Offset string `json:"offset"`
+}
+
+// Validate ...
+func (pagination Pagination) Validate() error {
+	return validation.ValidateStruct(&pagination,
+		// Limit cannot be empty.
+		validation.Field(&pagination.Limit, validation.Required, is.Digit),
+		// Offset cannot be empty.
+		validation.Field(&pagination.Offset, validation.Required, is.Digit),
+	)
+}
+
+// GetLimit ...
+func (pagination Pagination) GetLimit() (limit int) {
+	limit, _ = strconv.Atoi(pagination.Limit)
+	return
+}
+
+// GetOffset ...
+func (pagination Pagination) GetOffset() (offset int) {
+	offset, _ = strconv.Atoi(pagination.Offset)
+	return
+}
+
+// Tiebreakers is an ordered list of rules used for separating teams level on points.
+type Tiebreakers []string
+
+// Validate is used for making sure every rule is known and used at most once.
+// It returns any errors written.
+func (tiebreakers Tiebreakers) Validate() error {
+	seen := make(map[string]bool)
+	for _, tiebreaker := range tiebreakers {
+		if err := validation.Validate(tiebreaker, validation.Required, validation.In(model.Tiebreakers...)); err != nil {
+			return errors.New(tiebreaker + ": " + err.Error())
+		}
+
+		if seen[tiebreaker] {
+			return errors.New(tiebreaker + ": must not be repeated")
+		}
+		seen[tiebreaker] = true
+	}
+	return nil
+}
+
+// Competition ...
+type Competition struct {
+	ID          uuid.UUID   `json:"id"`
+	Name        string      `json:"name"`
+	Type        string      `json:"type"`
+	Tiebreakers Tiebreakers `json:"tiebreakers"`
+	SalaryCap   *int64      `json:"salary_cap"`
+	BenchSize   *int        `json:"bench_size"`
+}
+
+// DoCreate ...
+type DoCreate struct {
+	Competition
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (doCreate DoCreate) Validate() error {
+	return validation.ValidateStruct(&doCreate,
+		// Name cannot be empty and length must be between 1 and 150.
+		validation.Field(&doCreate.Name, validation.Required, validation.Length(1, 150)),
+		// Type cannot be empty and should be one of the competition types.
+		validation.Field(&doCreate.Type, validation.Required, validation.In(model.CompetitionTypes...)),
+		// Tiebreakers should only contain known rules, each at most once.
+		validation.Field(&doCreate.Tiebreakers),
+		// SalaryCap cannot be negative.
+		validation.Field(&doCreate.SalaryCap, validation.Min(int64(0))),
+		// BenchSize cannot be negative.
+		validation.Field(&doCreate.BenchSize, validation.Min(0)),
+	)
+}
+
+// GetCompetitions ...
+type GetCompetitions struct {
+	Pagination
+}
+
+// DoUpdate ...
+type DoUpdate struct {
+	Competition
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (doUpdate DoUpdate) Validate() error {
+	return validation.ValidateStruct(&doUpdate,
+		// ID cannot be empty and should be in a valid uuid.
+		validation.Field(&doUpdate.ID, validation.Required, is.UUIDv4),
+		// Name cannot be empty and length must be between 1 and 150.
+		validation.Field(&doUpdate.Name, validation.Required, validation.Length(1, 150)),
+		// Type cannot be empty and should be one of the competition types.
+		validation.Field(&doUpdate.Type, validation.Required, validation.In(model.CompetitionTypes...)),
+		// Tiebreakers should only contain known rules, each at most once.
+		validation.Field(&doUpdate.Tiebreakers),
+		// SalaryCap cannot be negative.
+		validation.Field(&doUpdate.SalaryCap, validation.Min(int64(0))),
+		// BenchSize cannot be negative.
+		validation.Field(&doUpdate.BenchSize, validation.Min(0)),
+	)
+}
+
+// DoDelete ...
+type DoDelete struct {
+	ID uuid.UUID `json:"id"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (doDelete DoDelete) Validate() error {
+	return validation.ValidateStruct(&doDelete,
+		// ID cannot be empty and should be in a valid uuid.
+		validation.Field(&doDelete.ID, validation.Required, is.UUIDv4),
+	)
+}
+
+// GetCompetition ...
+type GetCompetition struct {
+	ID uuid.UUID `json:"id"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getCompetition GetCompetition) Validate() error {
+	return validation.ValidateStruct(&getCompetition,
+		// ID cannot be empty and should be in a valid uuid.
+		validation.Field(&getCompetition.ID, validation.Required, is.UUIDv4),
+	)
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transporter
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/satori/uuid"
+)
+
+// Competition ...
+type Competition struct {
+	ID          uuid.UUID      `gorm:"primaryKey" json:"id"`
+	Name        string         `json:"name"`
+	Type        string         `json:"type"`
+	Tiebreakers pq.StringArray `gorm:"type:text[]" json:"tiebreakers"`
+	SalaryCap   *int64         `json:"salary_cap"`
+	BenchSize   *int           `json:"bench_size"`
+}
+
+// Season ...
+type Season struct {
+	ID            uuid.UUID `gorm:"primaryKey" json:"id"`
+	CompetitionID uuid.UUID `json:"-"`
+	Name          string    `json:"name"`
+	StartDate     time.Time `json:"start_date"`
+	EndDate       time.Time `json:"end_date"`
+}
+
+// DoCreate ...
+type DoCreate struct {
+	Competition
+}
+
+// GetCompetitions ...
+type GetCompetitions struct {
+	Competition
+	Seasons []Season `gorm:"foreignKey:CompetitionID" json:"seasons"`
+}
+
+// TableName ...
+func (GetCompetitions) TableName() string {
+	return "competitions"
+}
+
+// DoUpdate ...
+type DoUpdate struct {
+	Competition
+}
+
+// DoDelete ...
+type DoDelete struct {
+	Competition
+}
+
+// TableName ...
+func (DoDelete) TableName() string {
+	return "competitions"
+}
+
+// GetCompetition ...
+type GetCompetition struct {
+	Competition
+	Seasons []Season `gorm:"foreignKey:CompetitionID" json:"seasons"`
+}
+
+// TableName ...
+func (GetCompetition) TableName() string {
+	return "competitions"
+}
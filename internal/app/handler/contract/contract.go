@@ -0,0 +1,151 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package contract
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/contract/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/contract/transporter"
+	"github.com/harunnryd/skeltun/internal/app/usecase"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// IContract is an interface that stores the methods that Contract struct will use.
+type IContract interface {
+	// DoCreate is used for record new contract of a player with the current team.
+	// It returns doCreateResp of transporter.DoCreate and any errors written.
+	DoCreate(w http.ResponseWriter, r *http.Request) (doCreateResp interface{}, err error)
+
+	// GetContracts is used for getting all contracts of a player, the oldest first.
+	// It returns getContractsResp of []transporter.GetContracts and any errors written.
+	GetContracts(w http.ResponseWriter, r *http.Request) (getContractsResp interface{}, err error)
+
+	// GetContract is used for getting a contract of a player.
+	// It returns getContractResp of transporter.GetContract and any errors written.
+	GetContract(w http.ResponseWriter, r *http.Request) (getContractResp interface{}, err error)
+
+	// DoUpdate is used for update the record contract.
+	// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+	DoUpdate(w http.ResponseWriter, r *http.Request) (doUpdateResp interface{}, err error)
+}
+
+// Contract is an struct that implements IContract methods.
+type Contract struct {
+	config  config.IConfig
+	usecase usecase.IUseCase
+}
+
+// New it returns instance of Contract that implements IContract methods.
+func New(opts ...Option) IContract {
+	c := new(Contract)
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// DoCreate is used for record new contract of a player with the current team.
+// It returns doCreateResp of transporter.DoCreate and any errors written.
+func (contract *Contract) DoCreate(w http.ResponseWriter, r *http.Request) (doCreateResp interface{}, err error) {
+	doCreateParam := param.DoCreate{}
+	if err = json.NewDecoder(r.Body).Decode(&doCreateParam); err != nil {
+		return
+	}
+
+	doCreateParam.PlayerID = uuid.FromStringOrNil(chi.URLParam(r, "player_id"))
+
+	if err = doCreateParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	doCreateResp = transporter.DoCreate{}
+	doCreateResp, err = contract.usecase.GetContract().DoCreate(r.Context(), doCreateParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return doCreateResp, nil
+}
+
+// GetContracts is used for getting all contracts of a player, the oldest first.
+// It returns getContractsResp of []transporter.GetContracts and any errors written.
+func (contract *Contract) GetContracts(w http.ResponseWriter, r *http.Request) (getContractsResp interface{}, err error) {
+	getContractsParam := param.GetContracts{PlayerID: uuid.FromStringOrNil(chi.URLParam(r, "player_id"))}
+
+	if err = getContractsParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getContractsResp = transporter.GetContracts{}
+	getContractsResp, err = contract.usecase.GetContract().GetContracts(r.Context(), getContractsParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getContractsResp, nil
+}
+
+// GetContract is used for getting a contract of a player.
+// It returns getContractResp of transporter.GetContract and any errors written.
+func (contract *Contract) GetContract(w http.ResponseWriter, r *http.Request) (getContractResp interface{}, err error) {
+	getContractParam := param.GetContract{
+		ID:       uuid.FromStringOrNil(chi.URLParam(r, "contract_id")),
+		PlayerID: uuid.FromStringOrNil(chi.URLParam(r, "player_id")),
+	}
+
+	if err = getContractParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getContractResp = transporter.GetContract{}
+	getContractResp, err = contract.usecase.GetContract().GetContract(r.Context(), getContractParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getContractResp, nil
+}
+
+// DoUpdate is used for update the record contract.
+// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+func (contract *Contract) DoUpdate(w http.ResponseWriter, r *http.Request) (doUpdateResp interface{}, err error) {
+	doUpdateParam := param.DoUpdate{}
+	if err = json.NewDecoder(r.Body).Decode(&doUpdateParam); err != nil {
+		return
+	}
+
+	doUpdateParam.ID = uuid.FromStringOrNil(chi.URLParam(r, "contract_id"))
+	doUpdateParam.PlayerID = uuid.FromStringOrNil(chi.URLParam(r, "player_id"))
+
+	if err = doUpdateParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	doUpdateResp = transporter.DoUpdate{}
+	doUpdateResp, err = contract.usecase.GetContract().DoUpdate(r.Context(), doUpdateParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return doUpdateResp, nil
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package param
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/satori/uuid"
+)
+
+// Terms ...
+type Terms struct {
+	EndsAt        time.Time `json:"ends_at"`
+	Wage          int64     `json:"wage"`
+	ReleaseClause *int64    `json:"release_clause"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (terms Terms) Validate() error {
+	return validation.ValidateStruct(&terms,
+		// EndsAt cannot be empty.
+		validation.Field(&terms.EndsAt, validation.Required),
+		// Wage cannot be negative.
+		validation.Field(&terms.Wage, validation.Min(int64(0))),
+		// ReleaseClause cannot be negative.
+		validation.Field(&terms.ReleaseClause, validation.Min(int64(0))),
+	)
+}
+
+// Contract ...
+type Contract struct {
+	ID       uuid.UUID `json:"id"`
+	PlayerID uuid.UUID `json:"player_id"`
+	TeamID   uuid.UUID `json:"-"`
+	StartsAt time.Time `json:"starts_at"`
+	Terms
+	Status string `json:"status"`
+}
+
+// DoCreate ...
+type DoCreate struct {
+	Contract
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (doCreate DoCreate) Validate() error {
+	return validation.ValidateStruct(&doCreate,
+		// PlayerID cannot be empty and should be in a valid uuid.
+		validation.Field(&doCreate.PlayerID, validation.Required, is.UUIDv4),
+		// StartsAt cannot be empty.
+		validation.Field(&doCreate.StartsAt, validation.Required),
+		// EndsAt cannot be empty and should be after StartsAt.
+		validation.Field(&doCreate.EndsAt, validation.Required, validation.Min(doCreate.StartsAt).Exclusive().Error("must be after starts_at")),
+		// Wage cannot be negative.
+		validation.Field(&doCreate.Wage, validation.Min(int64(0))),
+		// ReleaseClause cannot be negative.
+		validation.Field(&doCreate.ReleaseClause, validation.Min(int64(0))),
+	)
+}
+
+// GetContracts ...
+type GetContracts struct {
+	PlayerID uuid.UUID `json:"player_id"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getContracts GetContracts) Validate() error {
+	return validation.ValidateStruct(&getContracts,
+		// PlayerID cannot be empty and should be in a valid uuid.
+		validation.Field(&getContracts.PlayerID, validation.Required, is.UUIDv4),
+	)
+}
+
+// GetContract ...
+type GetContract struct {
+	ID       uuid.UUID `json:"id"`
+	PlayerID uuid.UUID `json:"player_id"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getContract GetContract) Validate() error {
+	return validation.ValidateStruct(&getContract,
+		// ID cannot be empty and should be in a valid uuid.
+		validation.Field(&getContract.ID, validation.Required, is.UUIDv4),
+		// PlayerID cannot be empty and should be in a valid uuid.
+		validation.Field(&getContract.PlayerID, validation.Required, is.UUIDv4),
+	)
+}
+
+// DoUpdate ...
+type DoUpdate struct {
+	Contract
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (doUpdate DoUpdate) Validate() error {
+	return validation.ValidateStruct(&doUpdate,
+		// ID cannot be empty and should be in a valid uuid.
+		validation.Field(&doUpdate.ID, validation.Required, is.UUIDv4),
+		// PlayerID cannot be empty and should be in a valid uuid.
+		validation.Field(&doUpdate.PlayerID, validation.Required, is.UUIDv4),
+		// StartsAt cannot be empty.
+		validation.Field(&doUpdate.StartsAt, validation.Required),
+		// EndsAt cannot be empty and should be after StartsAt.
+		validation.Field(&doUpdate.EndsAt, validation.Required, validation.Min(doUpdate.StartsAt).Exclusive().Error("must be after starts_at")),
+		// Wage cannot be negative.
+		validation.Field(&doUpdate.Wage, validation.Min(int64(0))),
+		// ReleaseClause cannot be negative.
+		validation.Field(&doUpdate.ReleaseClause, validation.Min(int64(0))),
+		// Status cannot be empty and should be either active, expired or terminated.
+		validation.Field(&doUpdate.Status, validation.Required, validation.In(model.ContractStatuses...)),
+	)
+}
+
+// GetValidContract ...
+type GetValidContract struct {
+	PlayerID uuid.UUID `json:"player_id"`
+	TeamID   uuid.UUID `json:"team_id"`
+	Date     time.Time `json:"date"`
+}
+
+// GetPayroll ...
+type GetPayroll struct {
+	TeamID    uuid.UUID `json:"team_id"`
+	Date      time.Time `json:"date"`
+	ExcludeID uuid.UUID `json:"exclude_id"`
+}
+
+// GetSalaryCaps ...
+type GetSalaryCaps struct {
+	TeamID   uuid.UUID `json:"team_id"`
+	Date     time.Time `json:"date"`
+	SeasonID uuid.UUID `json:"season_id"`
+}
+
+// CheckSalaryCap ...
+type CheckSalaryCap struct {
+	TeamID    uuid.UUID `json:"team_id"`
+	Date      time.Time `json:"date"`
+	Wage      int64     `json:"wage"`
+	ExcludeID uuid.UUID `json:"exclude_id"`
+	SeasonID  uuid.UUID `json:"season_id"`
+}
+
+// CheckContract ...
+type CheckContract struct {
+	PlayerID uuid.UUID `json:"player_id"`
+	TeamID   uuid.UUID `json:"team_id"`
+	Date     time.Time `json:"date"`
+}
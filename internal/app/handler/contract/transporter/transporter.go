@@ -0,0 +1,75 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transporter
+
+import (
+	"time"
+
+	"github.com/satori/uuid"
+)
+
+// Contract ...
+type Contract struct {
+	ID            uuid.UUID `gorm:"primaryKey" json:"id"`
+	PlayerID      uuid.UUID `json:"player_id"`
+	TeamID        uuid.UUID `json:"team_id"`
+	StartsAt      time.Time `json:"starts_at"`
+	EndsAt        time.Time `json:"ends_at"`
+	Wage          int64     `json:"wage"`
+	ReleaseClause *int64    `json:"release_clause"`
+	Status        string    `json:"status"`
+}
+
+// DoCreate ...
+type DoCreate struct {
+	Contract
+}
+
+// GetContracts ...
+type GetContracts struct {
+	Contract
+}
+
+// TableName ...
+func (GetContracts) TableName() string {
+	return "contracts"
+}
+
+// GetContract ...
+type GetContract struct {
+	Contract
+}
+
+// TableName ...
+func (GetContract) TableName() string {
+	return "contracts"
+}
+
+// DoUpdate ...
+type DoUpdate struct {
+	Contract
+}
+
+// GetValidContract ...
+type GetValidContract struct {
+	Contract
+}
+
+// TableName ...
+func (GetValidContract) TableName() string {
+	return "contracts"
+}
+
+// GetPayroll ...
+type GetPayroll struct {
+	Total int64 `json:"total"`
+}
+
+// GetSalaryCaps ...
+type GetSalaryCaps struct {
+	CompetitionID   uuid.UUID `json:"competition_id"`
+	CompetitionName string    `json:"competition_name"`
+	SalaryCap       int64     `json:"salary_cap"`
+}
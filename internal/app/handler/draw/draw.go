@@ -0,0 +1,95 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package draw
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/draw/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/draw/transporter"
+	"github.com/harunnryd/skeltun/internal/app/usecase"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// IDraw is an interface that stores the methods that Draw struct will use.
+type IDraw interface {
+	// DoDraw is used for drawing the teams of a season from seeded pots into groups or ties.
+	// It returns doDrawResp of transporter.DoDraw and any errors written.
+	DoDraw(w http.ResponseWriter, r *http.Request) (doDrawResp interface{}, err error)
+
+	// GetDraw is used for getting a draw of a season and checking that it can be reproduced from its seed.
+	// It returns getDrawResp of transporter.GetDraw and any errors written.
+	GetDraw(w http.ResponseWriter, r *http.Request) (getDrawResp interface{}, err error)
+}
+
+// Draw is an struct that implements IDraw methods.
+type Draw struct {
+	config  config.IConfig
+	usecase usecase.IUseCase
+}
+
+// New it returns instance of Draw that implements IDraw methods.
+func New(opts ...Option) IDraw {
+	d := new(Draw)
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// DoDraw is used for drawing the teams of a season from seeded pots into groups or ties.
+// It returns doDrawResp of transporter.DoDraw and any errors written.
+func (draw *Draw) DoDraw(w http.ResponseWriter, r *http.Request) (doDrawResp interface{}, err error) {
+	doDrawParam := param.DoDraw{}
+	if err = json.NewDecoder(r.Body).Decode(&doDrawParam); err != nil {
+		return
+	}
+
+	doDrawParam.SeasonID = uuid.FromStringOrNil(chi.URLParam(r, "season_id"))
+
+	if err = doDrawParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	doDrawResp = transporter.DoDraw{}
+	doDrawResp, err = draw.usecase.GetDraw().DoDraw(r.Context(), doDrawParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return doDrawResp, nil
+}
+
+// GetDraw is used for getting a draw of a season and checking that it can be reproduced from its seed.
+// It returns getDrawResp of transporter.GetDraw and any errors written.
+func (draw *Draw) GetDraw(w http.ResponseWriter, r *http.Request) (getDrawResp interface{}, err error) {
+	getDrawParam := param.GetDraw{
+		ID:       uuid.FromStringOrNil(chi.URLParam(r, "draw_id")),
+		SeasonID: uuid.FromStringOrNil(chi.URLParam(r, "season_id")),
+	}
+
+	if err = getDrawParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getDrawResp = transporter.GetDraw{}
+	getDrawResp, err = draw.usecase.GetDraw().GetDraw(r.Context(), getDrawParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getDrawResp, nil
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package param
+
+import (
+	"errors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/satori/uuid"
+)
+
+// MaxGroups is the number of groups that can be named with a single letter.
+const MaxGroups = 26
+
+// Constraints ...
+type Constraints struct {
+	SeparateAssociations bool `json:"separate_associations"`
+	SeparateGroups       bool `json:"separate_groups"`
+}
+
+// Placement ...
+type Placement struct {
+	TeamID        uuid.UUID `json:"team_id"`
+	Pot           int       `json:"pot"`
+	PotPosition   int       `json:"pot_position"`
+	Association   string    `json:"association"`
+	PreviousGroup string    `json:"previous_group"`
+	Sequence      int       `json:"sequence"`
+	GroupName     string    `json:"group_name"`
+	Tie           int       `json:"tie"`
+	Slot          string    `json:"slot"`
+}
+
+// requiredForGroups is used for requiring the number of groups on a groups draw.
+func requiredForGroups(kind string) validation.RuleFunc {
+	return func(value interface{}) error {
+		if groups, _ := value.(int); kind == model.DrawKindGroups && groups == 0 {
+			return errors.New("cannot be blank")
+		}
+		return nil
+	}
+}
+
+// DoDraw ...
+type DoDraw struct {
+	SeasonID       uuid.UUID            `json:"season_id"`
+	Kind           string               `json:"kind"`
+	Pots           [][]uuid.UUID        `json:"pots"`
+	Groups         int                  `json:"groups"`
+	PreviousGroups map[uuid.UUID]string `json:"previous_groups"`
+	Seed           *int64               `json:"seed"`
+	Constraints    Constraints          `json:"constraints"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (doDraw DoDraw) Validate() error {
+	return validation.ValidateStruct(&doDraw,
+		// SeasonID cannot be empty and should be in a valid uuid.
+		validation.Field(&doDraw.SeasonID, validation.Required, is.UUIDv4),
+		// Kind cannot be empty and should be either groups or ties.
+		validation.Field(&doDraw.Kind, validation.Required, validation.In(model.DrawKinds...)),
+		// Pots cannot be empty and every pot should hold at least one team.
+		validation.Field(&doDraw.Pots, validation.Required, validation.Each(validation.Required)),
+		// Groups is required for a groups draw and cannot be more than the letters available.
+		validation.Field(&doDraw.Groups, validation.By(requiredForGroups(doDraw.Kind)), validation.Min(0), validation.Max(MaxGroups)),
+	)
+}
+
+// DoCreate ...
+type DoCreate struct {
+	SeasonID    uuid.UUID   `json:"season_id"`
+	Kind        string      `json:"kind"`
+	Seed        int64       `json:"seed"`
+	Groups      int         `json:"groups"`
+	Constraints Constraints `json:"constraints"`
+	Placements  []Placement `json:"placements"`
+}
+
+// GetDraw ...
+type GetDraw struct {
+	ID       uuid.UUID `json:"id"`
+	SeasonID uuid.UUID `json:"season_id"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getDraw GetDraw) Validate() error {
+	return validation.ValidateStruct(&getDraw,
+		// ID cannot be empty and should be in a valid uuid.
+		validation.Field(&getDraw.ID, validation.Required, is.UUIDv4),
+		// SeasonID cannot be empty and should be in a valid uuid.
+		validation.Field(&getDraw.SeasonID, validation.Required, is.UUIDv4),
+	)
+}
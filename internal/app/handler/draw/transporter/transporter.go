@@ -0,0 +1,64 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transporter
+
+import (
+	"github.com/satori/uuid"
+)
+
+// Draw ...
+type Draw struct {
+	ID                   uuid.UUID `gorm:"primaryKey" json:"id"`
+	SeasonID             uuid.UUID `json:"season_id"`
+	Kind                 string    `json:"kind"`
+	Seed                 int64     `json:"seed"`
+	Groups               int       `json:"groups,omitempty"`
+	SeparateAssociations bool      `json:"separate_associations"`
+	SeparateGroups       bool      `json:"separate_groups"`
+}
+
+// Placement ...
+type Placement struct {
+	ID            uuid.UUID `gorm:"primaryKey" json:"-"`
+	DrawID        uuid.UUID `json:"-"`
+	TeamID        uuid.UUID `json:"team_id"`
+	Pot           int       `json:"pot"`
+	PotPosition   int       `json:"pot_position"`
+	Association   string    `json:"association,omitempty"`
+	PreviousGroup string    `json:"previous_group,omitempty"`
+	Sequence      int       `json:"sequence"`
+	GroupName     string    `json:"group_name,omitempty"`
+	Tie           int       `json:"tie,omitempty"`
+	Slot          string    `json:"slot,omitempty"`
+}
+
+// TableName ...
+func (Placement) TableName() string {
+	return "draw_placements"
+}
+
+// DoCreate ...
+type DoCreate struct {
+	Draw
+	Placements []Placement `json:"placements"`
+}
+
+// DoDraw ...
+type DoDraw struct {
+	Draw
+	Placements []Placement `json:"placements"`
+}
+
+// GetDraw ...
+type GetDraw struct {
+	Draw
+	Placements []Placement `gorm:"foreignKey:DrawID" json:"placements"`
+	Reproduced bool        `gorm:"-" json:"reproduced"`
+}
+
+// TableName ...
+func (GetDraw) TableName() string {
+	return "draws"
+}
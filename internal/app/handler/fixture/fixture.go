@@ -0,0 +1,67 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fixture
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/fixture/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/fixture/transporter"
+	"github.com/harunnryd/skeltun/internal/app/usecase"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// IFixture is an interface that stores the methods that Fixture struct will use.
+type IFixture interface {
+	// DoGenerate is used for generating the round-robin fixtures of a season.
+	// It returns doGenerateResp of transporter.DoGenerate and any errors written.
+	DoGenerate(w http.ResponseWriter, r *http.Request) (doGenerateResp interface{}, err error)
+}
+
+// Fixture is an struct that implements IFixture methods.
+type Fixture struct {
+	config  config.IConfig
+	usecase usecase.IUseCase
+}
+
+// New it returns instance of Fixture that implements IFixture methods.
+func New(opts ...Option) IFixture {
+	f := new(Fixture)
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+// DoGenerate is used for generating the round-robin fixtures of a season.
+// It returns doGenerateResp of transporter.DoGenerate and any errors written.
+func (fixture *Fixture) DoGenerate(w http.ResponseWriter, r *http.Request) (doGenerateResp interface{}, err error) {
+	doGenerateParam := param.DoGenerate{}
+	if err = json.NewDecoder(r.Body).Decode(&doGenerateParam); err != nil {
+		return
+	}
+
+	doGenerateParam.SeasonID = uuid.FromStringOrNil(chi.URLParam(r, "season_id"))
+
+	if err = doGenerateParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	doGenerateResp = transporter.DoGenerate{}
+	doGenerateResp, err = fixture.usecase.GetFixture().DoGenerate(r.Context(), doGenerateParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return doGenerateResp, nil
+}
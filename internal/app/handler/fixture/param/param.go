@@ -0,0 +1,74 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package param
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/satori/uuid"
+)
+
+const (
+	// LegsSingle plays every pairing once.
+	LegsSingle = 1
+	// LegsDouble plays every pairing twice, once at each ground.
+	LegsDouble = 2
+
+	// DefaultDaysBetweenRounds is used when the request does not set the gap between rounds.
+	DefaultDaysBetweenRounds = 7
+
+	// KickoffSlotLayout is the layout of a preferred kickoff slot.
+	KickoffSlotLayout = "15:04"
+)
+
+// Constraints ...
+type Constraints struct {
+	BlackoutDates []time.Time   `json:"blackout_dates"`
+	SharedVenues  [][]uuid.UUID `json:"shared_venues"`
+	MinRestDays   int           `json:"min_rest_days"`
+	KickoffSlots  []string      `json:"kickoff_slots"`
+	RoundDays     int           `json:"round_days"`
+}
+
+// Validate is used for validating the scheduling constraints.
+// It returns any errors written.
+func (constraints Constraints) Validate() error {
+	return validation.ValidateStruct(&constraints,
+		// SharedVenues should group at least two teams per venue.
+		validation.Field(&constraints.SharedVenues, validation.Each(validation.Length(2, 0))),
+		// MinRestDays cannot be negative.
+		validation.Field(&constraints.MinRestDays, validation.Min(0)),
+		// KickoffSlots should be written as hh:mm.
+		validation.Field(&constraints.KickoffSlots, validation.Each(validation.Date(KickoffSlotLayout))),
+		// RoundDays cannot be negative.
+		validation.Field(&constraints.RoundDays, validation.Min(0)),
+	)
+}
+
+// DoGenerate ...
+type DoGenerate struct {
+	SeasonID          uuid.UUID   `json:"season_id"`
+	Legs              int         `json:"legs"`
+	StartDate         time.Time   `json:"start_date"`
+	DaysBetweenRounds int         `json:"days_between_rounds"`
+	Constraints       Constraints `json:"constraints"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (doGenerate DoGenerate) Validate() error {
+	return validation.ValidateStruct(&doGenerate,
+		// SeasonID cannot be empty and should be in a valid uuid.
+		validation.Field(&doGenerate.SeasonID, validation.Required, is.UUIDv4),
+		// Legs cannot be empty and should be either single or double.
+		validation.Field(&doGenerate.Legs, validation.Required, validation.In(LegsSingle, LegsDouble)),
+		// DaysBetweenRounds cannot be negative.
+		validation.Field(&doGenerate.DaysBetweenRounds, validation.Min(0)),
+		// Constraints should be valid.
+		validation.Field(&doGenerate.Constraints),
+	)
+}
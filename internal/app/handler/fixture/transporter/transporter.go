@@ -0,0 +1,35 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transporter
+
+import (
+	"time"
+
+	"github.com/satori/uuid"
+)
+
+// Fixture ...
+type Fixture struct {
+	ID         uuid.UUID `json:"id"`
+	Round      int       `json:"round"`
+	HomeTeamID uuid.UUID `json:"home_team_id"`
+	AwayTeamID uuid.UUID `json:"away_team_id"`
+	KickoffAt  time.Time `json:"kickoff_at"`
+}
+
+// Unsatisfied ...
+type Unsatisfied struct {
+	Constraint string    `json:"constraint"`
+	Round      int       `json:"round"`
+	HomeTeamID uuid.UUID `json:"home_team_id"`
+	AwayTeamID uuid.UUID `json:"away_team_id"`
+	Detail     string    `json:"detail"`
+}
+
+// DoGenerate ...
+type DoGenerate struct {
+	Fixtures    []Fixture     `json:"fixtures"`
+	Unsatisfied []Unsatisfied `json:"unsatisfied"`
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package group
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/group/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/group/transporter"
+	"github.com/harunnryd/skeltun/internal/app/usecase"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// IGroup is an interface that stores the methods that Group struct will use.
+type IGroup interface {
+	// DoCreate is used for creating a group of a season out of its registered teams.
+	// It returns doCreateResp of transporter.DoCreate and any errors written.
+	DoCreate(w http.ResponseWriter, r *http.Request) (doCreateResp interface{}, err error)
+
+	// GetGroups is used for getting all groups of a season with their teams.
+	// It returns getGroupsResp of []transporter.GetGroups and any errors written.
+	GetGroups(w http.ResponseWriter, r *http.Request) (getGroupsResp interface{}, err error)
+
+	// GetGroupStandings is used for getting the table of every group of a season.
+	// It returns getGroupStandingsResp of []transporter.GetGroupStandings and any errors written.
+	GetGroupStandings(w http.ResponseWriter, r *http.Request) (getGroupStandingsResp interface{}, err error)
+
+	// DoUpdateQualification is used for setting how many teams of the groups qualify for the next phase.
+	// It returns doUpdateQualificationResp of transporter.DoUpdateQualification and any errors written.
+	DoUpdateQualification(w http.ResponseWriter, r *http.Request) (doUpdateQualificationResp interface{}, err error)
+
+	// GetQualifiedTeams is used for getting the teams qualified out of the groups, best seed first.
+	// It returns getQualifiedTeamsResp of []transporter.GetQualifiedTeams and any errors written.
+	GetQualifiedTeams(w http.ResponseWriter, r *http.Request) (getQualifiedTeamsResp interface{}, err error)
+}
+
+// Group is an struct that implements IGroup methods.
+type Group struct {
+	config  config.IConfig
+	usecase usecase.IUseCase
+}
+
+// New it returns instance of Group that implements IGroup methods.
+func New(opts ...Option) IGroup {
+	g := new(Group)
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// DoCreate is used for creating a group of a season out of its registered teams.
+// It returns doCreateResp of transporter.DoCreate and any errors written.
+func (group *Group) DoCreate(w http.ResponseWriter, r *http.Request) (doCreateResp interface{}, err error) {
+	doCreateParam := param.DoCreate{}
+	if err = json.NewDecoder(r.Body).Decode(&doCreateParam); err != nil {
+		return
+	}
+
+	doCreateParam.SeasonID = uuid.FromStringOrNil(chi.URLParam(r, "season_id"))
+
+	if err = doCreateParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	doCreateResp = transporter.DoCreate{}
+	doCreateResp, err = group.usecase.GetGroup().DoCreate(r.Context(), doCreateParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return doCreateResp, nil
+}
+
+// GetGroups is used for getting all groups of a season with their teams.
+// It returns getGroupsResp of []transporter.GetGroups and any errors written.
+func (group *Group) GetGroups(w http.ResponseWriter, r *http.Request) (getGroupsResp interface{}, err error) {
+	getGroupsParam := param.GetGroups{SeasonID: uuid.FromStringOrNil(chi.URLParam(r, "season_id"))}
+
+	if err = getGroupsParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getGroupsResp = transporter.GetGroups{}
+	getGroupsResp, err = group.usecase.GetGroup().GetGroups(r.Context(), getGroupsParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getGroupsResp, nil
+}
+
+// GetGroupStandings is used for getting the table of every group of a season.
+// It returns getGroupStandingsResp of []transporter.GetGroupStandings and any errors written.
+func (group *Group) GetGroupStandings(w http.ResponseWriter, r *http.Request) (getGroupStandingsResp interface{}, err error) {
+	getGroupStandingsParam := param.GetGroupStandings{SeasonID: uuid.FromStringOrNil(chi.URLParam(r, "season_id"))}
+
+	if err = getGroupStandingsParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getGroupStandingsResp = transporter.GetGroupStandings{}
+	getGroupStandingsResp, err = group.usecase.GetGroup().GetGroupStandings(r.Context(), getGroupStandingsParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getGroupStandingsResp, nil
+}
+
+// DoUpdateQualification is used for setting how many teams of the groups qualify for the next phase.
+// It returns doUpdateQualificationResp of transporter.DoUpdateQualification and any errors written.
+func (group *Group) DoUpdateQualification(w http.ResponseWriter, r *http.Request) (doUpdateQualificationResp interface{}, err error) {
+	doUpdateQualificationParam := param.DoUpdateQualification{}
+	if err = json.NewDecoder(r.Body).Decode(&doUpdateQualificationParam); err != nil {
+		return
+	}
+
+	doUpdateQualificationParam.SeasonID = uuid.FromStringOrNil(chi.URLParam(r, "season_id"))
+
+	if err = doUpdateQualificationParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	doUpdateQualificationResp = transporter.DoUpdateQualification{}
+	doUpdateQualificationResp, err = group.usecase.GetGroup().DoUpdateQualification(r.Context(), doUpdateQualificationParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return doUpdateQualificationResp, nil
+}
+
+// GetQualifiedTeams is used for getting the teams qualified out of the groups, best seed first.
+// It returns getQualifiedTeamsResp of []transporter.GetQualifiedTeams and any errors written.
+func (group *Group) GetQualifiedTeams(w http.ResponseWriter, r *http.Request) (getQualifiedTeamsResp interface{}, err error) {
+	getQualifiedTeamsParam := param.GetQualifiedTeams{SeasonID: uuid.FromStringOrNil(chi.URLParam(r, "season_id"))}
+
+	if err = getQualifiedTeamsParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getQualifiedTeamsResp = transporter.GetQualifiedTeams{}
+	getQualifiedTeamsResp, err = group.usecase.GetGroup().GetQualifiedTeams(r.Context(), getQualifiedTeamsParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getQualifiedTeamsResp, nil
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package param
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/satori/uuid"
+)
+
+// Group ...
+type Group struct {
+	ID       uuid.UUID `json:"id"`
+	SeasonID uuid.UUID `json:"season_id"`
+	Name     string    `json:"name"`
+}
+
+// DoCreate ...
+type DoCreate struct {
+	SeasonID uuid.UUID   `json:"season_id"`
+	Name     string      `json:"name"`
+	TeamIDs  []uuid.UUID `json:"team_ids"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (doCreate DoCreate) Validate() error {
+	return validation.ValidateStruct(&doCreate,
+		// SeasonID cannot be empty and should be in a valid uuid.
+		validation.Field(&doCreate.SeasonID, validation.Required, is.UUIDv4),
+		// Name cannot be empty, and the length must between 1 and 30.
+		validation.Field(&doCreate.Name, validation.Required, validation.Length(1, 30)),
+		// TeamIDs should list at least two teams, every one of them in a valid uuid.
+		validation.Field(&doCreate.TeamIDs, validation.Required, validation.Length(2, 0), validation.Each(is.UUIDv4)),
+	)
+}
+
+// GetGroups ...
+type GetGroups struct {
+	SeasonID uuid.UUID `json:"season_id"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getGroups GetGroups) Validate() error {
+	return validation.ValidateStruct(&getGroups,
+		// SeasonID cannot be empty and should be in a valid uuid.
+		validation.Field(&getGroups.SeasonID, validation.Required, is.UUIDv4),
+	)
+}
+
+// GetGroupStandings ...
+type GetGroupStandings struct {
+	SeasonID uuid.UUID `json:"season_id"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getGroupStandings GetGroupStandings) Validate() error {
+	return validation.ValidateStruct(&getGroupStandings,
+		// SeasonID cannot be empty and should be in a valid uuid.
+		validation.Field(&getGroupStandings.SeasonID, validation.Required, is.UUIDv4),
+	)
+}
+
+// DoUpdateQualification ...
+type DoUpdateQualification struct {
+	SeasonID    uuid.UUID `json:"season_id"`
+	TopPerGroup int       `json:"top_per_group"`
+	BestPlaced  int       `json:"best_placed"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (doUpdateQualification DoUpdateQualification) Validate() error {
+	return validation.ValidateStruct(&doUpdateQualification,
+		// SeasonID cannot be empty and should be in a valid uuid.
+		validation.Field(&doUpdateQualification.SeasonID, validation.Required, is.UUIDv4),
+		// TopPerGroup cannot be empty and should be at least one.
+		validation.Field(&doUpdateQualification.TopPerGroup, validation.Required, validation.Min(1)),
+		// BestPlaced cannot be negative.
+		validation.Field(&doUpdateQualification.BestPlaced, validation.Min(0)),
+	)
+}
+
+// GetQualification ...
+type GetQualification struct {
+	SeasonID uuid.UUID `json:"season_id"`
+}
+
+// GetQualifiedTeams ...
+type GetQualifiedTeams struct {
+	SeasonID uuid.UUID `json:"season_id"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getQualifiedTeams GetQualifiedTeams) Validate() error {
+	return validation.ValidateStruct(&getQualifiedTeams,
+		// SeasonID cannot be empty and should be in a valid uuid.
+		validation.Field(&getQualifiedTeams.SeasonID, validation.Required, is.UUIDv4),
+	)
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transporter
+
+import (
+	standingTransporter "github.com/harunnryd/skeltun/internal/app/handler/standing/transporter"
+	"github.com/satori/uuid"
+)
+
+// Group ...
+type Group struct {
+	ID       uuid.UUID `gorm:"primaryKey" json:"id"`
+	SeasonID uuid.UUID `json:"season_id"`
+	Name     string    `json:"name"`
+}
+
+// GroupTeam ...
+type GroupTeam struct {
+	GroupID uuid.UUID `gorm:"primaryKey" json:"-"`
+	TeamID  uuid.UUID `gorm:"primaryKey" json:"team_id"`
+}
+
+// TableName ...
+func (GroupTeam) TableName() string {
+	return "group_teams"
+}
+
+// DoCreate ...
+type DoCreate struct {
+	Group
+	Teams []GroupTeam `json:"teams"`
+}
+
+// GetGroups ...
+type GetGroups struct {
+	Group
+	Teams []GroupTeam `gorm:"foreignKey:GroupID" json:"teams"`
+}
+
+// TableName ...
+func (GetGroups) TableName() string {
+	return "groups"
+}
+
+// GetGroupStandings ...
+type GetGroupStandings struct {
+	Group
+	Standings []standingTransporter.Standing `json:"standings"`
+}
+
+// Qualification ...
+type Qualification struct {
+	SeasonID    uuid.UUID `gorm:"primaryKey" json:"season_id"`
+	TopPerGroup int       `json:"top_per_group"`
+	BestPlaced  int       `json:"best_placed"`
+}
+
+// DoUpdateQualification ...
+type DoUpdateQualification struct {
+	Qualification
+}
+
+// GetQualification ...
+type GetQualification struct {
+	Qualification
+}
+
+// TableName ...
+func (GetQualification) TableName() string {
+	return "qualification_rules"
+}
+
+// QualifiedTeam ...
+type QualifiedTeam struct {
+	Seed      int       `json:"seed"`
+	TeamID    uuid.UUID `json:"team_id"`
+	TeamName  string    `json:"team_name"`
+	GroupID   uuid.UUID `json:"group_id"`
+	GroupName string    `json:"group_name"`
+	Position  int       `json:"position"`
+	Points    int       `json:"points"`
+}
+
+// GetQualifiedTeams ...
+type GetQualifiedTeams struct {
+	QualifiedTeam
+}
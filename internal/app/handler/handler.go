@@ -5,9 +5,32 @@
 package handler
 
 import (
+	"github.com/harunnryd/skeltun/internal/app/handler/availability"
+	"github.com/harunnryd/skeltun/internal/app/handler/bracket"
+	"github.com/harunnryd/skeltun/internal/app/handler/competition"
+	"github.com/harunnryd/skeltun/internal/app/handler/contract"
+	"github.com/harunnryd/skeltun/internal/app/handler/draw"
+	"github.com/harunnryd/skeltun/internal/app/handler/fixture"
+	"github.com/harunnryd/skeltun/internal/app/handler/group"
 	"github.com/harunnryd/skeltun/internal/app/handler/hcheck"
+	"github.com/harunnryd/skeltun/internal/app/handler/injury"
+	"github.com/harunnryd/skeltun/internal/app/handler/leaderboard"
+	"github.com/harunnryd/skeltun/internal/app/handler/lineup"
+	"github.com/harunnryd/skeltun/internal/app/handler/loan"
+	"github.com/harunnryd/skeltun/internal/app/handler/match"
+	"github.com/harunnryd/skeltun/internal/app/handler/matchevent"
+	"github.com/harunnryd/skeltun/internal/app/handler/official"
 	"github.com/harunnryd/skeltun/internal/app/handler/player"
+	"github.com/harunnryd/skeltun/internal/app/handler/season"
+	"github.com/harunnryd/skeltun/internal/app/handler/shootoutkick"
+	"github.com/harunnryd/skeltun/internal/app/handler/squad"
+	"github.com/harunnryd/skeltun/internal/app/handler/standing"
+	"github.com/harunnryd/skeltun/internal/app/handler/stat"
+	"github.com/harunnryd/skeltun/internal/app/handler/suspension"
 	"github.com/harunnryd/skeltun/internal/app/handler/team"
+	"github.com/harunnryd/skeltun/internal/app/handler/transfer"
+	"github.com/harunnryd/skeltun/internal/app/handler/travel"
+	"github.com/harunnryd/skeltun/internal/app/handler/venue"
 )
 
 // IHandler ...
@@ -20,13 +43,105 @@ type IHandler interface {
 
 	// GetTeam it returns instance of team.Team that implements team.ITeam methods.
 	GetTeam() team.ITeam
+
+	// GetMatch it returns instance of match.Match that implements match.IMatch methods.
+	GetMatch() match.IMatch
+
+	// GetCompetition it returns instance of competition.Competition that implements competition.ICompetition methods.
+	GetCompetition() competition.ICompetition
+
+	// GetSeason it returns instance of season.Season that implements season.ISeason methods.
+	GetSeason() season.ISeason
+
+	// GetStanding it returns instance of standing.Standing that implements standing.IStanding methods.
+	GetStanding() standing.IStanding
+
+	// GetFixture it returns instance of fixture.Fixture that implements fixture.IFixture methods.
+	GetFixture() fixture.IFixture
+
+	// GetMatchEvent it returns instance of matchevent.MatchEvent that implements matchevent.IMatchEvent methods.
+	GetMatchEvent() matchevent.IMatchEvent
+
+	// GetShootoutKick it returns instance of shootoutkick.ShootoutKick that implements shootoutkick.IShootoutKick methods.
+	GetShootoutKick() shootoutkick.IShootoutKick
+
+	// GetBracket it returns instance of bracket.Bracket that implements bracket.IBracket methods.
+	GetBracket() bracket.IBracket
+
+	// GetDraw it returns instance of draw.Draw that implements draw.IDraw methods.
+	GetDraw() draw.IDraw
+
+	// GetGroup it returns instance of group.Group that implements group.IGroup methods.
+	GetGroup() group.IGroup
+
+	// GetTransfer it returns instance of transfer.Transfer that implements transfer.ITransfer methods.
+	GetTransfer() transfer.ITransfer
+
+	// GetLoan it returns instance of loan.Loan that implements loan.ILoan methods.
+	GetLoan() loan.ILoan
+
+	// GetContract it returns instance of contract.Contract that implements contract.IContract methods.
+	GetContract() contract.IContract
+
+	// GetSquad it returns instance of squad.Squad that implements squad.ISquad methods.
+	GetSquad() squad.ISquad
+
+	// GetLineup it returns instance of lineup.Lineup that implements lineup.ILineup methods.
+	GetLineup() lineup.ILineup
+
+	// GetStat it returns instance of stat.Stat that implements stat.IStat methods.
+	GetStat() stat.IStat
+
+	// GetLeaderboard it returns instance of leaderboard.Leaderboard that implements leaderboard.ILeaderboard methods.
+	GetLeaderboard() leaderboard.ILeaderboard
+
+	// GetInjury it returns instance of injury.Injury that implements injury.IInjury methods.
+	GetInjury() injury.IInjury
+
+	// GetSuspension it returns instance of suspension.Suspension that implements suspension.ISuspension methods.
+	GetSuspension() suspension.ISuspension
+
+	// GetAvailability it returns instance of availability.Availability that implements availability.IAvailability methods.
+	GetAvailability() availability.IAvailability
+
+	// GetVenue it returns instance of venue.Venue that implements venue.IVenue methods.
+	GetVenue() venue.IVenue
+
+	// GetTravel it returns instance of travel.Travel that implements travel.ITravel methods.
+	GetTravel() travel.ITravel
+
+	// GetOfficial it returns instance of official.Official that implements official.IOfficial methods.
+	GetOfficial() official.IOfficial
 }
 
 // Handler ...
 type Handler struct {
-	hcheck hcheck.IHcheck
-	player player.IPlayer
-	team   team.ITeam
+	hcheck       hcheck.IHcheck
+	player       player.IPlayer
+	team         team.ITeam
+	match        match.IMatch
+	competition  competition.ICompetition
+	season       season.ISeason
+	standing     standing.IStanding
+	fixture      fixture.IFixture
+	matchevent   matchevent.IMatchEvent
+	shootoutkick shootoutkick.IShootoutKick
+	bracket      bracket.IBracket
+	draw         draw.IDraw
+	group        group.IGroup
+	transfer     transfer.ITransfer
+	loan         loan.ILoan
+	contract     contract.IContract
+	squad        squad.ISquad
+	lineup       lineup.ILineup
+	stat         stat.IStat
+	leaderboard  leaderboard.ILeaderboard
+	injury       injury.IInjury
+	suspension   suspension.ISuspension
+	availability availability.IAvailability
+	venue        venue.IVenue
+	travel       travel.ITravel
+	official     official.IOfficial
 }
 
 // New ...
@@ -52,3 +167,118 @@ func (handler *Handler) GetPlayer() player.IPlayer {
 func (handler *Handler) GetTeam() team.ITeam {
 	return handler.team
 }
+
+// GetMatch it returns instance of match.Match that implements match.IMatch methods.
+func (handler *Handler) GetMatch() match.IMatch {
+	return handler.match
+}
+
+// GetCompetition it returns instance of competition.Competition that implements competition.ICompetition methods.
+func (handler *Handler) GetCompetition() competition.ICompetition {
+	return handler.competition
+}
+
+// GetSeason it returns instance of season.Season that implements season.ISeason methods.
+func (handler *Handler) GetSeason() season.ISeason {
+	return handler.season
+}
+
+// GetStanding it returns instance of standing.Standing that implements standing.IStanding methods.
+func (handler *Handler) GetStanding() standing.IStanding {
+	return handler.standing
+}
+
+// GetFixture it returns instance of fixture.Fixture that implements fixture.IFixture methods.
+func (handler *Handler) GetFixture() fixture.IFixture {
+	return handler.fixture
+}
+
+// GetMatchEvent it returns instance of matchevent.MatchEvent that implements matchevent.IMatchEvent methods.
+func (handler *Handler) GetMatchEvent() matchevent.IMatchEvent {
+	return handler.matchevent
+}
+
+// GetShootoutKick it returns instance of shootoutkick.ShootoutKick that implements shootoutkick.IShootoutKick methods.
+func (handler *Handler) GetShootoutKick() shootoutkick.IShootoutKick {
+	return handler.shootoutkick
+}
+
+// GetBracket it returns instance of bracket.Bracket that implements bracket.IBracket methods.
+func (handler *Handler) GetBracket() bracket.IBracket {
+	return handler.bracket
+}
+
+// GetDraw it returns instance of draw.Draw that implements draw.IDraw methods.
+func (handler *Handler) GetDraw() draw.IDraw {
+	return handler.draw
+}
+
+// GetGroup it returns instance of group.Group that implements group.IGroup methods.
+func (handler *Handler) GetGroup() group.IGroup {
+	return handler.group
+}
+
+// GetTransfer it returns instance of transfer.Transfer that implements transfer.ITransfer methods.
+func (handler *Handler) GetTransfer() transfer.ITransfer {
+	return handler.transfer
+}
+
+// GetLoan it returns instance of loan.Loan that implements loan.ILoan methods.
+func (handler *Handler) GetLoan() loan.ILoan {
+	return handler.loan
+}
+
+// GetContract it returns instance of contract.Contract that implements contract.IContract methods.
+func (handler *Handler) GetContract() contract.IContract {
+	return handler.contract
+}
+
+// GetSquad it returns instance of squad.Squad that implements squad.ISquad methods.
+func (handler *Handler) GetSquad() squad.ISquad {
+	return handler.squad
+}
+
+// GetLineup it returns instance of lineup.Lineup that implements lineup.ILineup methods.
+func (handler *Handler) GetLineup() lineup.ILineup {
+	return handler.lineup
+}
+
+// GetStat it returns instance of stat.Stat that implements stat.IStat methods.
+func (handler *Handler) GetStat() stat.IStat {
+	return handler.stat
+}
+
+// GetLeaderboard it returns instance of leaderboard.Leaderboard that implements leaderboard.ILeaderboard methods.
+func (handler *Handler) GetLeaderboard() leaderboard.ILeaderboard {
+	return handler.leaderboard
+}
+
+// GetInjury it returns instance of injury.Injury that implements injury.IInjury methods.
+func (handler *Handler) GetInjury() injury.IInjury {
+	return handler.injury
+}
+
+// GetSuspension it returns instance of suspension.Suspension that implements suspension.ISuspension methods.
+func (handler *Handler) GetSuspension() suspension.ISuspension {
+	return handler.suspension
+}
+
+// GetAvailability it returns instance of availability.Availability that implements availability.IAvailability methods.
+func (handler *Handler) GetAvailability() availability.IAvailability {
+	return handler.availability
+}
+
+// GetVenue it returns instance of venue.Venue that implements venue.IVenue methods.
+func (handler *Handler) GetVenue() venue.IVenue {
+	return handler.venue
+}
+
+// GetTravel it returns instance of travel.Travel that implements travel.ITravel methods.
+func (handler *Handler) GetTravel() travel.ITravel {
+	return handler.travel
+}
+
+// GetOfficial it returns instance of official.Official that implements official.IOfficial methods.
+func (handler *Handler) GetOfficial() official.IOfficial {
+	return handler.official
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package injury
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/injury/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/injury/transporter"
+	"github.com/harunnryd/skeltun/internal/app/usecase"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// IInjury is an interface that stores the methods that Injury struct will use.
+type IInjury interface {
+	// DoCreate is used for record new injury of a player.
+	// It returns doCreateResp of transporter.DoCreate and any errors written.
+	DoCreate(w http.ResponseWriter, r *http.Request) (doCreateResp interface{}, err error)
+
+	// GetInjuries is used for getting all injuries of a player, the latest first.
+	// It returns getInjuriesResp of []transporter.GetInjuries and any errors written.
+	GetInjuries(w http.ResponseWriter, r *http.Request) (getInjuriesResp interface{}, err error)
+
+	// DoUpdate is used for update the record injury, such as pushing back or setting the expected return.
+	// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+	DoUpdate(w http.ResponseWriter, r *http.Request) (doUpdateResp interface{}, err error)
+}
+
+// Injury is an struct that implements IInjury methods.
+type Injury struct {
+	config  config.IConfig
+	usecase usecase.IUseCase
+}
+
+// New it returns instance of Injury that implements IInjury methods.
+func New(opts ...Option) IInjury {
+	i := new(Injury)
+	for _, opt := range opts {
+		opt(i)
+	}
+	return i
+}
+
+// DoCreate is used for record new injury of a player.
+// It returns doCreateResp of transporter.DoCreate and any errors written.
+func (injury *Injury) DoCreate(w http.ResponseWriter, r *http.Request) (doCreateResp interface{}, err error) {
+	doCreateParam := param.DoCreate{}
+	if err = json.NewDecoder(r.Body).Decode(&doCreateParam); err != nil {
+		return
+	}
+
+	doCreateParam.PlayerID = uuid.FromStringOrNil(chi.URLParam(r, "player_id"))
+
+	if err = doCreateParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	doCreateResp = transporter.DoCreate{}
+	doCreateResp, err = injury.usecase.GetInjury().DoCreate(r.Context(), doCreateParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return doCreateResp, nil
+}
+
+// GetInjuries is used for getting all injuries of a player, the latest first.
+// It returns getInjuriesResp of []transporter.GetInjuries and any errors written.
+func (injury *Injury) GetInjuries(w http.ResponseWriter, r *http.Request) (getInjuriesResp interface{}, err error) {
+	getInjuriesParam := param.GetInjuries{PlayerID: uuid.FromStringOrNil(chi.URLParam(r, "player_id"))}
+
+	if err = getInjuriesParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getInjuriesResp = transporter.GetInjuries{}
+	getInjuriesResp, err = injury.usecase.GetInjury().GetInjuries(r.Context(), getInjuriesParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getInjuriesResp, nil
+}
+
+// DoUpdate is used for update the record injury, such as pushing back or setting the expected return.
+// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+func (injury *Injury) DoUpdate(w http.ResponseWriter, r *http.Request) (doUpdateResp interface{}, err error) {
+	doUpdateParam := param.DoUpdate{}
+	if err = json.NewDecoder(r.Body).Decode(&doUpdateParam); err != nil {
+		return
+	}
+
+	doUpdateParam.ID = uuid.FromStringOrNil(chi.URLParam(r, "injury_id"))
+	doUpdateParam.PlayerID = uuid.FromStringOrNil(chi.URLParam(r, "player_id"))
+
+	if err = doUpdateParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	doUpdateResp = transporter.DoUpdate{}
+	doUpdateResp, err = injury.usecase.GetInjury().DoUpdate(r.Context(), doUpdateParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return doUpdateResp, nil
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package param
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/satori/uuid"
+)
+
+// Injury ...
+type Injury struct {
+	ID               uuid.UUID  `json:"id"`
+	PlayerID         uuid.UUID  `json:"player_id"`
+	Type             string     `json:"type"`
+	StartedAt        time.Time  `json:"started_at"`
+	ExpectedReturnAt *time.Time `json:"expected_return_at"`
+}
+
+// DoCreate ...
+type DoCreate struct {
+	Injury
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (doCreate DoCreate) Validate() error {
+	return validation.ValidateStruct(&doCreate,
+		// PlayerID cannot be empty and should be in a valid uuid.
+		validation.Field(&doCreate.PlayerID, validation.Required, is.UUIDv4),
+		// Type cannot be empty and should have length between 3 and 100.
+		validation.Field(&doCreate.Type, validation.Required, validation.Length(3, 100)),
+		// StartedAt cannot be empty.
+		validation.Field(&doCreate.StartedAt, validation.Required),
+		// ExpectedReturnAt should be after StartedAt.
+		validation.Field(&doCreate.ExpectedReturnAt, validation.Min(doCreate.StartedAt).Exclusive().Error("must be after started_at")),
+	)
+}
+
+// GetInjuries ...
+type GetInjuries struct {
+	PlayerID uuid.UUID `json:"player_id"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getInjuries GetInjuries) Validate() error {
+	return validation.ValidateStruct(&getInjuries,
+		// PlayerID cannot be empty and should be in a valid uuid.
+		validation.Field(&getInjuries.PlayerID, validation.Required, is.UUIDv4),
+	)
+}
+
+// GetInjury ...
+type GetInjury struct {
+	ID       uuid.UUID `json:"id"`
+	PlayerID uuid.UUID `json:"player_id"`
+}
+
+// DoUpdate ...
+type DoUpdate struct {
+	Injury
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (doUpdate DoUpdate) Validate() error {
+	return validation.ValidateStruct(&doUpdate,
+		// ID cannot be empty and should be in a valid uuid.
+		validation.Field(&doUpdate.ID, validation.Required, is.UUIDv4),
+		// PlayerID cannot be empty and should be in a valid uuid.
+		validation.Field(&doUpdate.PlayerID, validation.Required, is.UUIDv4),
+		// Type cannot be empty and should have length between 3 and 100.
+		validation.Field(&doUpdate.Type, validation.Required, validation.Length(3, 100)),
+		// StartedAt cannot be empty.
+		validation.Field(&doUpdate.StartedAt, validation.Required),
+		// ExpectedReturnAt should be after StartedAt.
+		validation.Field(&doUpdate.ExpectedReturnAt, validation.Min(doUpdate.StartedAt).Exclusive().Error("must be after started_at")),
+	)
+}
+
+// GetTeamInjuries ...
+type GetTeamInjuries struct {
+	TeamID uuid.UUID `json:"team_id"`
+	Date   time.Time `json:"date"`
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transporter
+
+import (
+	"time"
+
+	"github.com/satori/uuid"
+)
+
+// Injury ...
+type Injury struct {
+	ID               uuid.UUID  `gorm:"primaryKey" json:"id"`
+	PlayerID         uuid.UUID  `json:"player_id"`
+	Type             string     `json:"type"`
+	StartedAt        time.Time  `json:"started_at"`
+	ExpectedReturnAt *time.Time `json:"expected_return_at"`
+}
+
+// DoCreate ...
+type DoCreate struct {
+	Injury
+}
+
+// GetInjuries ...
+type GetInjuries struct {
+	Injury
+}
+
+// TableName ...
+func (GetInjuries) TableName() string {
+	return "injuries"
+}
+
+// GetInjury ...
+type GetInjury struct {
+	Injury
+}
+
+// TableName ...
+func (GetInjury) TableName() string {
+	return "injuries"
+}
+
+// DoUpdate ...
+type DoUpdate struct {
+	Injury
+}
+
+// GetTeamInjuries ...
+type GetTeamInjuries struct {
+	Injury
+	Name string `json:"name"`
+}
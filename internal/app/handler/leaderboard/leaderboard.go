@@ -0,0 +1,67 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package leaderboard
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/leaderboard/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/leaderboard/transporter"
+	"github.com/harunnryd/skeltun/internal/app/usecase"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// ILeaderboard is an interface that stores the methods that Leaderboard struct will use.
+type ILeaderboard interface {
+	// GetLeaderboard is used for getting a page of the players of a season ranked by a metric.
+	// It returns getLeaderboardResp of transporter.GetLeaderboard and any errors written.
+	GetLeaderboard(w http.ResponseWriter, r *http.Request) (getLeaderboardResp interface{}, err error)
+}
+
+// Leaderboard is an struct that implements ILeaderboard methods.
+type Leaderboard struct {
+	config  config.IConfig
+	usecase usecase.IUseCase
+}
+
+// New it returns instance of Leaderboard that implements ILeaderboard methods.
+func New(opts ...Option) ILeaderboard {
+	l := new(Leaderboard)
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// GetLeaderboard is used for getting a page of the players of a season ranked by a metric.
+// It returns getLeaderboardResp of transporter.GetLeaderboard and any errors written.
+func (leaderboard *Leaderboard) GetLeaderboard(w http.ResponseWriter, r *http.Request) (getLeaderboardResp interface{}, err error) {
+	getLeaderboardParam := param.GetLeaderboard{
+		SeasonID:       uuid.FromStringOrNil(chi.URLParam(r, "season_id")),
+		Metric:         chi.URLParam(r, "metric"),
+		Limit:          r.URL.Query().Get("limit"),
+		Cursor:         r.URL.Query().Get("cursor"),
+		MinAppearances: r.URL.Query().Get("min_appearances"),
+	}
+
+	if err = getLeaderboardParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getLeaderboardResp = transporter.GetLeaderboard{}
+	getLeaderboardResp, err = leaderboard.usecase.GetLeaderboard().GetLeaderboard(r.Context(), getLeaderboardParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getLeaderboardResp, nil
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package param
+
+import (
+	"encoding/base64"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/satori/uuid"
+)
+
+// MaxLimit is the largest page a leaderboard can be read in, so a single cached page stays small.
+const MaxLimit = 100
+
+// NewCursor is used for pointing at the row a leaderboard page ends with, so the next page starts right after it.
+func NewCursor(value int, playerID uuid.UUID) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(value) + ":" + playerID.String()))
+}
+
+// isCursor is used for making sure the cursor was handed out by a previous page.
+func isCursor(value interface{}) error {
+	cursor, _ := value.(string)
+	if cursor == "" {
+		return nil
+	}
+
+	if _, _, ok := decodeCursor(cursor); !ok {
+		return errors.New("must be a cursor of a previous page")
+	}
+
+	return nil
+}
+
+// isWithinMaxLimit is used for making sure a page does not ask for more rows than MaxLimit.
+func isWithinMaxLimit(value interface{}) error {
+	limit, _ := value.(string)
+	if n, err := strconv.Atoi(limit); limit != "" && (err != nil || n > MaxLimit) {
+		return errors.New("must be no greater than " + strconv.Itoa(MaxLimit))
+	}
+
+	return nil
+}
+
+// decodeCursor is used for reading back the value and the player a cursor points at.
+func decodeCursor(cursor string) (value int, playerID uuid.UUID, ok bool) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return
+	}
+
+	parts := strings.SplitN(string(decoded), ":", 2)
+	if len(parts) != 2 {
+		return
+	}
+
+	if value, err = strconv.Atoi(parts[0]); err != nil {
+		return
+	}
+
+	if playerID, err = uuid.FromString(parts[1]); err != nil {
+		return
+	}
+
+	return value, playerID, true
+}
+
+// GetLeaderboard ...
+type GetLeaderboard struct {
+	SeasonID       uuid.UUID `json:"season_id"`
+	Metric         string    `json:"metric"`
+	Limit          string    `json:"limit"`
+	Cursor         string    `json:"cursor"`
+	MinAppearances string    `json:"min_appearances"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getLeaderboard GetLeaderboard) Validate() error {
+	return validation.ValidateStruct(&getLeaderboard,
+		// SeasonID cannot be empty and should be in a valid uuid.
+		validation.Field(&getLeaderboard.SeasonID, validation.Required, is.UUIDv4),
+		// Metric cannot be empty and should be one of the leaderboard metrics.
+		validation.Field(&getLeaderboard.Metric, validation.Required, validation.In(model.LeaderboardMetrics...)),
+		// Limit cannot be empty and should be a positive number no greater than MaxLimit.
+		validation.Field(&getLeaderboard.Limit, validation.Required, validation.Match(regexp.MustCompile(`^[1-9][0-9]*$`)), validation.By(isWithinMaxLimit)),
+		// Cursor should be handed out by a previous page.
+		validation.Field(&getLeaderboard.Cursor, validation.By(isCursor)),
+		// MinAppearances should be a number.
+		validation.Field(&getLeaderboard.MinAppearances, is.Digit),
+	)
+}
+
+// GetLimit ...
+func (getLeaderboard GetLeaderboard) GetLimit() (limit int) {
+	limit, _ = strconv.Atoi(getLeaderboard.Limit)
+	return
+}
+
+// GetMinAppearances ...
+func (getLeaderboard GetLeaderboard) GetMinAppearances() (minAppearances int) {
+	minAppearances, _ = strconv.Atoi(getLeaderboard.MinAppearances)
+	return
+}
+
+// GetCursor ...
+func (getLeaderboard GetLeaderboard) GetCursor() (value int, playerID uuid.UUID, ok bool) {
+	return decodeCursor(getLeaderboard.Cursor)
+}
+
+// DoInvalidate ...
+type DoInvalidate struct {
+	SeasonID uuid.UUID `json:"season_id"`
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transporter
+
+import (
+	"github.com/satori/uuid"
+)
+
+// Entry ...
+type Entry struct {
+	Rank        int       `json:"rank"`
+	PlayerID    uuid.UUID `json:"player_id"`
+	Name        string    `json:"name"`
+	TeamID      uuid.UUID `json:"team_id"`
+	Appearances int       `json:"appearances"`
+	Value       int       `json:"value"`
+}
+
+// GetLeaderboard ...
+type GetLeaderboard struct {
+	SeasonID   uuid.UUID `json:"season_id"`
+	Metric     string    `json:"metric"`
+	Entries    []Entry   `json:"entries"`
+	NextCursor string    `json:"next_cursor,omitempty"`
+}
+
+// GetEntries ...
+type GetEntries struct {
+	Entry
+}
+
+// DoInvalidate ...
+type DoInvalidate struct {
+	SeasonID uuid.UUID `json:"season_id"`
+	Version  int       `json:"version"`
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lineup
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/lineup/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/lineup/transporter"
+	"github.com/harunnryd/skeltun/internal/app/usecase"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// ILineup is an interface that stores the methods that Lineup struct will use.
+type ILineup interface {
+	// DoUpdate is used for record the lineup of a team for a match, replacing the previous one.
+	// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+	DoUpdate(w http.ResponseWriter, r *http.Request) (doUpdateResp interface{}, err error)
+
+	// GetLineups is used for getting the lineups of both teams of a match.
+	// It returns getLineupsResp of []transporter.GetLineups and any errors written.
+	GetLineups(w http.ResponseWriter, r *http.Request) (getLineupsResp interface{}, err error)
+}
+
+// Lineup is an struct that implements ILineup methods.
+type Lineup struct {
+	config  config.IConfig
+	usecase usecase.IUseCase
+}
+
+// New it returns instance of Lineup that implements ILineup methods.
+func New(opts ...Option) ILineup {
+	l := new(Lineup)
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// DoUpdate is used for record the lineup of a team for a match, replacing the previous one.
+// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+func (lineup *Lineup) DoUpdate(w http.ResponseWriter, r *http.Request) (doUpdateResp interface{}, err error) {
+	doUpdateParam := param.DoUpdate{}
+	if err = json.NewDecoder(r.Body).Decode(&doUpdateParam); err != nil {
+		return
+	}
+
+	doUpdateParam.MatchID = uuid.FromStringOrNil(chi.URLParam(r, "match_id"))
+	doUpdateParam.TeamID = uuid.FromStringOrNil(chi.URLParam(r, "team_id"))
+
+	if err = doUpdateParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	doUpdateResp = transporter.DoUpdate{}
+	doUpdateResp, err = lineup.usecase.GetLineup().DoUpdate(r.Context(), doUpdateParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return doUpdateResp, nil
+}
+
+// GetLineups is used for getting the lineups of both teams of a match.
+// It returns getLineupsResp of []transporter.GetLineups and any errors written.
+func (lineup *Lineup) GetLineups(w http.ResponseWriter, r *http.Request) (getLineupsResp interface{}, err error) {
+	getLineupsParam := param.GetLineups{MatchID: uuid.FromStringOrNil(chi.URLParam(r, "match_id"))}
+
+	if err = getLineupsParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getLineupsResp = transporter.GetLineups{}
+	getLineupsResp, err = lineup.usecase.GetLineup().GetLineups(r.Context(), getLineupsParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getLineupsResp, nil
+}
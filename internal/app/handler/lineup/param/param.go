@@ -0,0 +1,131 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package param
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/satori/uuid"
+)
+
+// Lineup ...
+type Lineup struct {
+	MatchID   uuid.UUID   `json:"match_id"`
+	TeamID    uuid.UUID   `json:"team_id"`
+	Formation string      `json:"formation"`
+	Starters  []uuid.UUID `json:"starters"`
+	Bench     []uuid.UUID `json:"bench"`
+}
+
+// isFormation is used for making sure the formation lists between two and five outfield lines,
+// adding up to every starter but the goalkeeper.
+func isFormation(value interface{}) error {
+	formation, _ := value.(string)
+
+	lines := strings.Split(formation, "-")
+	if len(lines) < 2 || len(lines) > 5 {
+		return errors.New("must list between 2 and 5 lines, such as 4-3-3")
+	}
+
+	outfield := 0
+	for _, line := range lines {
+		players, err := strconv.Atoi(line)
+		if err != nil || players < 1 {
+			return errors.New("must list the players of every line, such as 4-3-3")
+		}
+		outfield += players
+	}
+
+	if outfield != model.LineupStarters-1 {
+		return errors.New("must add up to " + strconv.Itoa(model.LineupStarters-1) + " outfield players")
+	}
+
+	return nil
+}
+
+// notNamedIn is used for making sure no player is named twice on the team sheet.
+func notNamedIn(others []uuid.UUID) validation.RuleFunc {
+	return func(value interface{}) error {
+		named := make(map[uuid.UUID]bool)
+		for _, playerID := range others {
+			named[playerID] = true
+		}
+
+		playerIDs, _ := value.([]uuid.UUID)
+		for _, playerID := range playerIDs {
+			if named[playerID] {
+				return errors.New("cannot name a player twice")
+			}
+			named[playerID] = true
+		}
+
+		return nil
+	}
+}
+
+// DoUpdate ...
+type DoUpdate struct {
+	Lineup
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (doUpdate DoUpdate) Validate() error {
+	return validation.ValidateStruct(&doUpdate,
+		// MatchID cannot be empty and should be in a valid uuid.
+		validation.Field(&doUpdate.MatchID, validation.Required, is.UUIDv4),
+		// TeamID cannot be empty and should be in a valid uuid.
+		validation.Field(&doUpdate.TeamID, validation.Required, is.UUIDv4),
+		// Formation cannot be empty and should list the outfield lines, such as 4-3-3.
+		validation.Field(&doUpdate.Formation, validation.Required, validation.Length(3, 20), validation.By(isFormation)),
+		// Starters should list exactly eleven different players, every one of them in a valid uuid.
+		validation.Field(&doUpdate.Starters,
+			validation.Required,
+			validation.Length(model.LineupStarters, model.LineupStarters),
+			validation.Each(is.UUIDv4),
+			validation.By(notNamedIn(nil)),
+		),
+		// Bench should list different players from the starters, every one of them in a valid uuid.
+		validation.Field(&doUpdate.Bench, validation.Each(is.UUIDv4), validation.By(notNamedIn(doUpdate.Starters))),
+	)
+}
+
+// GetLineups ...
+type GetLineups struct {
+	MatchID uuid.UUID `json:"match_id"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getLineups GetLineups) Validate() error {
+	return validation.ValidateStruct(&getLineups,
+		// MatchID cannot be empty and should be in a valid uuid.
+		validation.Field(&getLineups.MatchID, validation.Required, is.UUIDv4),
+	)
+}
+
+// GetLineup ...
+type GetLineup struct {
+	MatchID uuid.UUID `json:"match_id"`
+	TeamID  uuid.UUID `json:"team_id"`
+}
+
+// GetPlayers ...
+type GetPlayers struct {
+	IDs []uuid.UUID `json:"ids"`
+}
+
+// CheckSubstitution ...
+type CheckSubstitution struct {
+	MatchID         uuid.UUID `json:"match_id"`
+	TeamID          uuid.UUID `json:"team_id"`
+	PlayerID        uuid.UUID `json:"player_id"`
+	RelatedPlayerID uuid.UUID `json:"related_player_id"`
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transporter
+
+import (
+	"github.com/satori/uuid"
+)
+
+// Lineup ...
+type Lineup struct {
+	MatchID   uuid.UUID   `gorm:"primaryKey" json:"match_id"`
+	TeamID    uuid.UUID   `gorm:"primaryKey" json:"team_id"`
+	Formation string      `json:"formation"`
+	Starters  []uuid.UUID `gorm:"-" json:"starters"`
+	Bench     []uuid.UUID `gorm:"-" json:"bench"`
+}
+
+// LineupPlayer ...
+type LineupPlayer struct {
+	MatchID  uuid.UUID `gorm:"primaryKey" json:"-"`
+	TeamID   uuid.UUID `json:"team_id"`
+	PlayerID uuid.UUID `gorm:"primaryKey" json:"player_id"`
+	Starter  bool      `json:"starter"`
+	Sequence int       `json:"sequence"`
+}
+
+// TableName ...
+func (LineupPlayer) TableName() string {
+	return "lineup_players"
+}
+
+// DoUpdate ...
+type DoUpdate struct {
+	Lineup
+}
+
+// GetLineups ...
+type GetLineups struct {
+	Lineup
+}
+
+// TableName ...
+func (GetLineups) TableName() string {
+	return "lineups"
+}
+
+// GetLineup ...
+type GetLineup struct {
+	Lineup
+}
+
+// TableName ...
+func (GetLineup) TableName() string {
+	return "lineups"
+}
+
+// GetPlayers ...
+type GetPlayers struct {
+	ID       uuid.UUID `gorm:"primaryKey" json:"id"`
+	TeamID   uuid.UUID `json:"team_id"`
+	Name     string    `json:"name"`
+	Position string    `json:"position"`
+}
+
+// TableName ...
+func (GetPlayers) TableName() string {
+	return "players"
+}
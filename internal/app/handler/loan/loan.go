@@ -0,0 +1,147 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package loan
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/loan/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/loan/transporter"
+	"github.com/harunnryd/skeltun/internal/app/usecase"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// ILoan is an interface that stores the methods that Loan struct will use.
+type ILoan interface {
+	// GetLoans is used for getting all loans of a player, the oldest first.
+	// It returns getLoansResp of []transporter.GetLoans and any errors written.
+	GetLoans(w http.ResponseWriter, r *http.Request) (getLoansResp interface{}, err error)
+
+	// GetLoan is used for getting a loan.
+	// It returns getLoanResp of transporter.GetLoan and any errors written.
+	GetLoan(w http.ResponseWriter, r *http.Request) (getLoanResp interface{}, err error)
+
+	// DoRecall is used for sending a player on loan back to the parent club before the loan ends.
+	// It returns doRecallResp of transporter.DoRecall and any errors written.
+	DoRecall(w http.ResponseWriter, r *http.Request) (doRecallResp interface{}, err error)
+
+	// DoExerciseOption is used for signing a player on loan for good at the agreed option fee.
+	// It returns doExerciseOptionResp of transporter.DoExerciseOption and any errors written.
+	DoExerciseOption(w http.ResponseWriter, r *http.Request) (doExerciseOptionResp interface{}, err error)
+}
+
+// Loan is an struct that implements ILoan methods.
+type Loan struct {
+	config  config.IConfig
+	usecase usecase.IUseCase
+}
+
+// New it returns instance of Loan that implements ILoan methods.
+func New(opts ...Option) ILoan {
+	l := new(Loan)
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// GetLoans is used for getting all loans of a player, the oldest first.
+// It returns getLoansResp of []transporter.GetLoans and any errors written.
+func (loan *Loan) GetLoans(w http.ResponseWriter, r *http.Request) (getLoansResp interface{}, err error) {
+	getLoansParam := param.GetLoans{PlayerID: uuid.FromStringOrNil(chi.URLParam(r, "player_id"))}
+
+	if err = getLoansParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getLoansResp = transporter.GetLoans{}
+	getLoansResp, err = loan.usecase.GetLoan().GetLoans(r.Context(), getLoansParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getLoansResp, nil
+}
+
+// GetLoan is used for getting a loan.
+// It returns getLoanResp of transporter.GetLoan and any errors written.
+func (loan *Loan) GetLoan(w http.ResponseWriter, r *http.Request) (getLoanResp interface{}, err error) {
+	getLoanParam := param.GetLoan{ID: uuid.FromStringOrNil(chi.URLParam(r, "loan_id"))}
+
+	if err = getLoanParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getLoanResp = transporter.GetLoan{}
+	getLoanResp, err = loan.usecase.GetLoan().GetLoan(r.Context(), getLoanParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getLoanResp, nil
+}
+
+// DoRecall is used for sending a player on loan back to the parent club before the loan ends.
+// It returns doRecallResp of transporter.DoRecall and any errors written.
+func (loan *Loan) DoRecall(w http.ResponseWriter, r *http.Request) (doRecallResp interface{}, err error) {
+	doRecallParam := param.DoRecall{}
+	if err = json.NewDecoder(r.Body).Decode(&doRecallParam); err != nil {
+		return
+	}
+
+	doRecallParam.ID = uuid.FromStringOrNil(chi.URLParam(r, "loan_id"))
+
+	if err = doRecallParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	doRecallResp = transporter.DoRecall{}
+	doRecallResp, err = loan.usecase.GetLoan().DoRecall(r.Context(), doRecallParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return doRecallResp, nil
+}
+
+// DoExerciseOption is used for signing a player on loan for good at the agreed option fee.
+// It returns doExerciseOptionResp of transporter.DoExerciseOption and any errors written.
+func (loan *Loan) DoExerciseOption(w http.ResponseWriter, r *http.Request) (doExerciseOptionResp interface{}, err error) {
+	doExerciseOptionParam := param.DoExerciseOption{}
+	if err = json.NewDecoder(r.Body).Decode(&doExerciseOptionParam); err != nil {
+		return
+	}
+
+	doExerciseOptionParam.ID = uuid.FromStringOrNil(chi.URLParam(r, "loan_id"))
+
+	if err = doExerciseOptionParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	doExerciseOptionResp = transporter.DoExerciseOption{}
+	doExerciseOptionResp, err = loan.usecase.GetLoan().DoExerciseOption(r.Context(), doExerciseOptionParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return doExerciseOptionResp, nil
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package param
+
+import (
+	"errors"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	contractParam "github.com/harunnryd/skeltun/internal/app/handler/contract/param"
+	"github.com/satori/uuid"
+)
+
+// noFeeUnless is used for rejecting an option fee on a loan without an option to buy.
+func noFeeUnless(optionToBuy bool) validation.RuleFunc {
+	return func(value interface{}) error {
+		if fee, _ := value.(int64); !optionToBuy && fee != 0 {
+			return errors.New("must be empty without an option to buy")
+		}
+		return nil
+	}
+}
+
+// endsAfter is used for checking that the contract signed on buying a player runs past the given date.
+func endsAfter(date time.Time) validation.RuleFunc {
+	return func(value interface{}) error {
+		if terms, _ := value.(*contractParam.Terms); terms != nil && !terms.EndsAt.After(date) {
+			return errors.New("should end after the date")
+		}
+		return nil
+	}
+}
+
+// Terms ...
+type Terms struct {
+	EndsAt       time.Time `json:"ends_at"`
+	RecallClause bool      `json:"recall_clause"`
+	OptionToBuy  bool      `json:"option_to_buy"`
+	OptionFee    int64     `json:"option_fee"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (terms Terms) Validate() error {
+	return validation.ValidateStruct(&terms,
+		// EndsAt cannot be empty.
+		validation.Field(&terms.EndsAt, validation.Required),
+		// OptionFee cannot be negative and should be empty without an option to buy.
+		validation.Field(&terms.OptionFee, validation.Min(int64(0)), validation.By(noFeeUnless(terms.OptionToBuy))),
+	)
+}
+
+// GetLoans ...
+type GetLoans struct {
+	PlayerID uuid.UUID `json:"player_id"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getLoans GetLoans) Validate() error {
+	return validation.ValidateStruct(&getLoans,
+		// PlayerID cannot be empty and should be in a valid uuid.
+		validation.Field(&getLoans.PlayerID, validation.Required, is.UUIDv4),
+	)
+}
+
+// GetLoan ...
+type GetLoan struct {
+	ID uuid.UUID `json:"id"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getLoan GetLoan) Validate() error {
+	return validation.ValidateStruct(&getLoan,
+		// ID cannot be empty and should be in a valid uuid.
+		validation.Field(&getLoan.ID, validation.Required, is.UUIDv4),
+	)
+}
+
+// GetActiveLoan ...
+type GetActiveLoan struct {
+	PlayerID uuid.UUID `json:"player_id"`
+}
+
+// GetExpiredLoans ...
+type GetExpiredLoans struct {
+	Date time.Time `json:"date"`
+}
+
+// DoRecall ...
+type DoRecall struct {
+	ID   uuid.UUID `json:"id"`
+	Date time.Time `json:"date"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (doRecall DoRecall) Validate() error {
+	return validation.ValidateStruct(&doRecall,
+		// ID cannot be empty and should be in a valid uuid.
+		validation.Field(&doRecall.ID, validation.Required, is.UUIDv4),
+		// Date cannot be empty.
+		validation.Field(&doRecall.Date, validation.Required),
+	)
+}
+
+// DoExerciseOption ...
+type DoExerciseOption struct {
+	ID       uuid.UUID            `json:"id"`
+	Date     time.Time            `json:"date"`
+	Contract *contractParam.Terms `json:"contract"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (doExerciseOption DoExerciseOption) Validate() error {
+	return validation.ValidateStruct(&doExerciseOption,
+		// ID cannot be empty and should be in a valid uuid.
+		validation.Field(&doExerciseOption.ID, validation.Required, is.UUIDv4),
+		// Date cannot be empty.
+		validation.Field(&doExerciseOption.Date, validation.Required),
+		// Contract cannot be empty and should end after Date, the player signs with the loan club.
+		validation.Field(&doExerciseOption.Contract, validation.Required, validation.By(endsAfter(doExerciseOption.Date))),
+	)
+}
+
+// DoReturnExpired ...
+type DoReturnExpired struct {
+	Date time.Time `json:"date"`
+}
+
+// DoEnd ...
+type DoEnd struct {
+	ID         uuid.UUID            `json:"id"`
+	PlayerID   uuid.UUID            `json:"player_id"`
+	FromTeamID uuid.UUID            `json:"from_team_id"`
+	ToTeamID   uuid.UUID            `json:"to_team_id"`
+	Date       time.Time            `json:"date"`
+	Fee        int64                `json:"fee"`
+	Type       string               `json:"type"`
+	Status     string               `json:"status"`
+	Contract   *contractParam.Terms `json:"contract"`
+}
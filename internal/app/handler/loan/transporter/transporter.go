@@ -0,0 +1,91 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transporter
+
+import (
+	"time"
+
+	"github.com/satori/uuid"
+)
+
+// Loan ...
+type Loan struct {
+	ID           uuid.UUID  `gorm:"primaryKey" json:"id"`
+	TransferID   uuid.UUID  `json:"transfer_id"`
+	PlayerID     uuid.UUID  `json:"player_id"`
+	ParentTeamID uuid.UUID  `json:"parent_team_id"`
+	LoanTeamID   uuid.UUID  `json:"loan_team_id"`
+	StartsAt     time.Time  `json:"starts_at"`
+	EndsAt       time.Time  `json:"ends_at"`
+	RecallClause bool       `json:"recall_clause"`
+	OptionToBuy  bool       `json:"option_to_buy"`
+	OptionFee    int64      `json:"option_fee"`
+	Status       string     `json:"status"`
+	EndedAt      *time.Time `json:"ended_at"`
+}
+
+// GetLoans ...
+type GetLoans struct {
+	Loan
+}
+
+// TableName ...
+func (GetLoans) TableName() string {
+	return "loans"
+}
+
+// GetLoan ...
+type GetLoan struct {
+	Loan
+}
+
+// TableName ...
+func (GetLoan) TableName() string {
+	return "loans"
+}
+
+// GetActiveLoan ...
+type GetActiveLoan struct {
+	Loan
+}
+
+// TableName ...
+func (GetActiveLoan) TableName() string {
+	return "loans"
+}
+
+// GetExpiredLoans ...
+type GetExpiredLoans struct {
+	Loan
+	PlayerName     string `json:"player_name"`
+	ParentTeamName string `json:"parent_team_name"`
+	LoanTeamName   string `json:"loan_team_name"`
+}
+
+// TableName ...
+func (GetExpiredLoans) TableName() string {
+	return "loans"
+}
+
+// DoRecall ...
+type DoRecall struct {
+	Loan
+}
+
+// DoExerciseOption ...
+type DoExerciseOption struct {
+	Loan
+}
+
+// DoReturnExpired ...
+type DoReturnExpired struct {
+	GetExpiredLoans
+}
+
+// DoEnd ...
+type DoEnd struct {
+	TransferID     uuid.UUID `json:"transfer_id"`
+	NoLongerActive bool      `json:"-"`
+}
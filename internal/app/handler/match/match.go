@@ -0,0 +1,251 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package match
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/match/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/match/transporter"
+	"github.com/harunnryd/skeltun/internal/app/usecase"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// IMatch is an interface that stores the methods that Match struct will use.
+type IMatch interface {
+	// DoCreate is used for record new match.
+	// It returns doCreateResp of transporter.DoCreate and any errors written.
+	DoCreate(w http.ResponseWriter, r *http.Request) (doCreateResp interface{}, err error)
+
+	// GetMatches is used for getting all matches.
+	// It returns getMatchesResp of []transporter.GetMatches and any errors written.
+	GetMatches(w http.ResponseWriter, r *http.Request) (getMatchesResp interface{}, err error)
+
+	// DoUpdate is used for update the record match.
+	// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+	DoUpdate(w http.ResponseWriter, r *http.Request) (doUpdateResp interface{}, err error)
+
+	// DoDelete is used for delete the record match.
+	// It returns doDeleteResp of transporter.DoDelete and any errors written.
+	DoDelete(w http.ResponseWriter, r *http.Request) (doDeleteResp interface{}, err error)
+
+	// GetMatch is used for getting an match.
+	// It returns getMatchResp of transporter.GetMatch and any errors written.
+	GetMatch(w http.ResponseWriter, r *http.Request) (getMatchResp interface{}, err error)
+
+	// DoTransition is used for moving a match through its lifecycle.
+	// It returns doTransitionResp of transporter.DoTransition and any errors written.
+	DoTransition(w http.ResponseWriter, r *http.Request) (doTransitionResp interface{}, err error)
+
+	// GetClock is used for getting the live clock of a match.
+	// It returns getClockResp of transporter.GetClock and any errors written.
+	GetClock(w http.ResponseWriter, r *http.Request) (getClockResp interface{}, err error)
+
+	// GetWinner is used for getting the team that won a finished match.
+	// It returns getWinnerResp of transporter.GetWinner and any errors written.
+	GetWinner(w http.ResponseWriter, r *http.Request) (getWinnerResp interface{}, err error)
+}
+
+// Match is an struct that implements IMatch methods.
+type Match struct {
+	config  config.IConfig
+	usecase usecase.IUseCase
+}
+
+// New it returns instance of Match that implements IMatch methods.
+func New(opts ...Option) IMatch {
+	m := new(Match)
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// DoCreate is used for record new match.
+// It returns doCreateResp of transporter.DoCreate and any errors written.
+func (match *Match) DoCreate(w http.ResponseWriter, r *http.Request) (doCreateResp interface{}, err error) {
+	doCreateParam := param.DoCreate{}
+	if err = json.NewDecoder(r.Body).Decode(&doCreateParam); err != nil {
+		return
+	}
+
+	if err = doCreateParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	doCreateResp = transporter.DoCreate{}
+	doCreateResp, err = match.usecase.GetMatch().DoCreate(r.Context(), doCreateParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return doCreateResp, nil
+}
+
+// GetMatches is used for getting all matches.
+// It returns getMatchesResp of []transporter.GetMatches and any errors written.
+func (match *Match) GetMatches(w http.ResponseWriter, r *http.Request) (getMatchesResp interface{}, err error) {
+	getMatchesParam := param.GetMatches{Pagination: param.Pagination{
+		Limit:  r.URL.Query().Get("limit"),
+		Offset: r.URL.Query().Get("offset"),
+	}}
+
+	if err = getMatchesParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getMatchesResp = transporter.GetMatches{}
+	getMatchesResp, err = match.usecase.GetMatch().GetMatches(r.Context(), getMatchesParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getMatchesResp, nil
+}
+
+// DoUpdate is used for update the record match.
+// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+func (match *Match) DoUpdate(w http.ResponseWriter, r *http.Request) (doUpdateResp interface{}, err error) {
+	doUpdateParam := param.DoUpdate{Match: param.Match{ID: uuid.FromStringOrNil(chi.URLParam(r, "match_id"))}}
+	if err = json.NewDecoder(r.Body).Decode(&doUpdateParam); err != nil {
+		return
+	}
+
+	if err = doUpdateParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	doUpdateResp = transporter.DoUpdate{}
+	doUpdateResp, err = match.usecase.GetMatch().DoUpdate(r.Context(), doUpdateParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return doUpdateResp, nil
+}
+
+// DoDelete is used for delete the record match.
+// It returns doDeleteResp of transporter.DoDelete and any errors written.
+func (match *Match) DoDelete(w http.ResponseWriter, r *http.Request) (doDeleteResp interface{}, err error) {
+	doDeleteParam := param.DoDelete{ID: uuid.FromStringOrNil(chi.URLParam(r, "match_id"))}
+
+	if err = doDeleteParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	doDeleteResp = transporter.DoDelete{}
+	doDeleteResp, err = match.usecase.GetMatch().DoDelete(r.Context(), doDeleteParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return doDeleteResp, nil
+}
+
+// GetMatch is used for getting an match.
+// It returns getMatchResp of transporter.GetMatch and any errors written.
+func (match *Match) GetMatch(w http.ResponseWriter, r *http.Request) (getMatchResp interface{}, err error) {
+	getMatchParam := param.GetMatch{ID: uuid.FromStringOrNil(chi.URLParam(r, "match_id"))}
+
+	if err = getMatchParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getMatchResp = transporter.GetMatch{}
+	getMatchResp, err = match.usecase.GetMatch().GetMatch(r.Context(), getMatchParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getMatchResp, nil
+}
+
+// DoTransition is used for moving a match through its lifecycle.
+// It returns doTransitionResp of transporter.DoTransition and any errors written.
+func (match *Match) DoTransition(w http.ResponseWriter, r *http.Request) (doTransitionResp interface{}, err error) {
+	doTransitionParam := param.DoTransition{}
+	if err = json.NewDecoder(r.Body).Decode(&doTransitionParam); err != nil {
+		return
+	}
+
+	doTransitionParam.MatchID = uuid.FromStringOrNil(chi.URLParam(r, "match_id"))
+
+	if err = doTransitionParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	doTransitionResp = transporter.DoTransition{}
+	doTransitionResp, err = match.usecase.GetMatch().DoTransition(r.Context(), doTransitionParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return doTransitionResp, nil
+}
+
+// GetClock is used for getting the live clock of a match.
+// It returns getClockResp of transporter.GetClock and any errors written.
+func (match *Match) GetClock(w http.ResponseWriter, r *http.Request) (getClockResp interface{}, err error) {
+	getClockParam := param.GetClock{MatchID: uuid.FromStringOrNil(chi.URLParam(r, "match_id"))}
+
+	if err = getClockParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getClockResp = transporter.GetClock{}
+	getClockResp, err = match.usecase.GetMatch().GetClock(r.Context(), getClockParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getClockResp, nil
+}
+
+// GetWinner is used for getting the team that won a finished match.
+// It returns getWinnerResp of transporter.GetWinner and any errors written.
+func (match *Match) GetWinner(w http.ResponseWriter, r *http.Request) (getWinnerResp interface{}, err error) {
+	getWinnerParam := param.GetWinner{MatchID: uuid.FromStringOrNil(chi.URLParam(r, "match_id"))}
+
+	if err = getWinnerParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getWinnerResp = transporter.GetWinner{}
+	getWinnerResp, err = match.usecase.GetMatch().GetWinner(r.Context(), getWinnerParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getWinnerResp, nil
+}
@@ -0,0 +1,274 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package param
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/satori/uuid"
+)
+
+// Pagination ...
+type Pagination struct {
+	Limit  string `json:"limit"`
+	Offset string `json:"offset"`
+}
+
+// Validate ...
+func (pagination Pagination) Validate() error {
+	return validation.ValidateStruct(&pagination,
+		// Limit cannot be empty.
+		validation.Field(&pagination.Limit, validation.Required, is.Digit),
+		// Offset cannot be empty.
+		validation.Field(&pagination.Offset, validation.Required, is.Digit),
+	)
+}
+
+// GetLimit ...
+func (pagination Pagination) GetLimit() (limit int) {
+	limit, _ = strconv.Atoi(pagination.Limit)
+	return
+}
+
+// GetOffset ...
+func (pagination Pagination) GetOffset() (offset int) {
+	offset, _ = strconv.Atoi(pagination.Offset)
+	return
+}
+
+// Match ...
+type Match struct {
+	ID         uuid.UUID  `json:"id"`
+	SeasonID   *uuid.UUID `json:"season_id"`
+	Round      int        `json:"round"`
+	HomeTeamID uuid.UUID  `json:"home_team_id"`
+	AwayTeamID uuid.UUID  `json:"away_team_id"`
+	KickoffAt  time.Time  `json:"kickoff_at"`
+	Venue      string     `json:"venue"`
+	VenueID    *uuid.UUID `json:"venue_id"`
+	Status     string     `json:"status"`
+	HomeScore  int        `json:"home_score"`
+	AwayScore  int        `json:"away_score"`
+
+	HomeExtraTimeScore int `json:"home_extra_time_score"`
+	AwayExtraTimeScore int `json:"away_extra_time_score"`
+	HomeShootoutScore  int `json:"home_shootout_score"`
+	AwayShootoutScore  int `json:"away_shootout_score"`
+}
+
+// differentFrom is used for making sure the away team is not the home team.
+func differentFrom(homeTeamID uuid.UUID) validation.RuleFunc {
+	return func(value interface{}) error {
+		if awayTeamID, _ := value.(uuid.UUID); uuid.Equal(awayTeamID, homeTeamID) {
+			return errors.New("must be different from home_team_id")
+		}
+		return nil
+	}
+}
+
+// DoCreate ...
+type DoCreate struct {
+	Match
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (doCreate DoCreate) Validate() error {
+	return validation.ValidateStruct(&doCreate,
+		// SeasonID should be in a valid uuid.
+		validation.Field(&doCreate.SeasonID, is.UUIDv4),
+		// Round cannot be negative.
+		validation.Field(&doCreate.Round, validation.Min(0)),
+		// HomeTeamID cannot be empty and should be in a valid uuid.
+		validation.Field(&doCreate.HomeTeamID, validation.Required, is.UUIDv4),
+		// AwayTeamID cannot be empty, should be in a valid uuid and different from HomeTeamID.
+		validation.Field(&doCreate.AwayTeamID, validation.Required, is.UUIDv4, validation.By(differentFrom(doCreate.HomeTeamID))),
+		// KickoffAt cannot be empty.
+		validation.Field(&doCreate.KickoffAt, validation.Required),
+		// Venue length must be between 1 and 150.
+		validation.Field(&doCreate.Venue, validation.Length(1, 150)),
+		// VenueID should be in a valid uuid.
+		validation.Field(&doCreate.VenueID, is.UUIDv4),
+		// Status can only be scheduled, a match moves on through its status transitions.
+		validation.Field(&doCreate.Status, validation.In(model.MatchStatusScheduled)),
+		// HomeScore cannot be negative.
+		validation.Field(&doCreate.HomeScore, validation.Min(0)),
+		// AwayScore cannot be negative.
+		validation.Field(&doCreate.AwayScore, validation.Min(0)),
+		// HomeExtraTimeScore cannot be negative.
+		validation.Field(&doCreate.HomeExtraTimeScore, validation.Min(0)),
+		// AwayExtraTimeScore cannot be negative.
+		validation.Field(&doCreate.AwayExtraTimeScore, validation.Min(0)),
+		// HomeShootoutScore cannot be negative.
+		validation.Field(&doCreate.HomeShootoutScore, validation.Min(0)),
+		// AwayShootoutScore cannot be negative.
+		validation.Field(&doCreate.AwayShootoutScore, validation.Min(0)),
+	)
+}
+
+// GetMatches ...
+type GetMatches struct {
+	Pagination
+}
+
+// DoUpdate ...
+type DoUpdate struct {
+	Match
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (doUpdate DoUpdate) Validate() error {
+	return validation.ValidateStruct(&doUpdate,
+		// ID cannot be empty and should be in a valid uuid.
+		validation.Field(&doUpdate.ID, validation.Required, is.UUIDv4),
+		// SeasonID should be in a valid uuid.
+		validation.Field(&doUpdate.SeasonID, is.UUIDv4),
+		// Round cannot be negative.
+		validation.Field(&doUpdate.Round, validation.Min(0)),
+		// HomeTeamID cannot be empty and should be in a valid uuid.
+		validation.Field(&doUpdate.HomeTeamID, validation.Required, is.UUIDv4),
+		// AwayTeamID cannot be empty, should be in a valid uuid and different from HomeTeamID.
+		validation.Field(&doUpdate.AwayTeamID, validation.Required, is.UUIDv4, validation.By(differentFrom(doUpdate.HomeTeamID))),
+		// KickoffAt cannot be empty.
+		validation.Field(&doUpdate.KickoffAt, validation.Required),
+		// Venue length must be between 1 and 150.
+		validation.Field(&doUpdate.Venue, validation.Length(1, 150)),
+		// VenueID should be in a valid uuid.
+		validation.Field(&doUpdate.VenueID, is.UUIDv4),
+		// Status cannot be empty and should be one of the match statuses.
+		validation.Field(&doUpdate.Status, validation.Required, validation.In(model.MatchStatuses...)),
+		// HomeScore cannot be negative.
+		validation.Field(&doUpdate.HomeScore, validation.Min(0)),
+		// AwayScore cannot be negative.
+		validation.Field(&doUpdate.AwayScore, validation.Min(0)),
+		// HomeExtraTimeScore cannot be negative.
+		validation.Field(&doUpdate.HomeExtraTimeScore, validation.Min(0)),
+		// AwayExtraTimeScore cannot be negative.
+		validation.Field(&doUpdate.AwayExtraTimeScore, validation.Min(0)),
+		// HomeShootoutScore cannot be negative.
+		validation.Field(&doUpdate.HomeShootoutScore, validation.Min(0)),
+		// AwayShootoutScore cannot be negative.
+		validation.Field(&doUpdate.AwayShootoutScore, validation.Min(0)),
+	)
+}
+
+// DoDelete ...
+type DoDelete struct {
+	ID uuid.UUID `json:"id"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (doDelete DoDelete) Validate() error {
+	return validation.ValidateStruct(&doDelete,
+		// ID cannot be empty and should be in a valid uuid.
+		validation.Field(&doDelete.ID, validation.Required, is.UUIDv4),
+	)
+}
+
+// GetMatch ...
+type GetMatch struct {
+	ID uuid.UUID `json:"id"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getMatch GetMatch) Validate() error {
+	return validation.ValidateStruct(&getMatch,
+		// ID cannot be empty and should be in a valid uuid.
+		validation.Field(&getMatch.ID, validation.Required, is.UUIDv4),
+	)
+}
+
+// GetSeasonMatches ...
+type GetSeasonMatches struct {
+	SeasonID uuid.UUID `json:"season_id"`
+	Status   string    `json:"status"`
+}
+
+// GetTieMatches ...
+type GetTieMatches struct {
+	TieID uuid.UUID `json:"tie_id"`
+}
+
+// GetTeamMatches ...
+type GetTeamMatches struct {
+	TeamID   uuid.UUID `json:"team_id"`
+	SeasonID uuid.UUID `json:"season_id"`
+}
+
+// DoReplaceFixtures ...
+type DoReplaceFixtures struct {
+	SeasonID uuid.UUID `json:"season_id"`
+	Matches  []Match   `json:"matches"`
+}
+
+// CountPreparedFixtures ...
+type CountPreparedFixtures struct {
+	SeasonID uuid.UUID `json:"season_id"`
+}
+
+// DoSyncScore ...
+type DoSyncScore struct {
+	ID uuid.UUID `json:"id"`
+}
+
+// DoTransition ...
+type DoTransition struct {
+	MatchID    uuid.UUID  `json:"match_id"`
+	FromStatus string     `json:"-"`
+	Status     string     `json:"status"`
+	OccurredAt *time.Time `json:"occurred_at"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (doTransition DoTransition) Validate() error {
+	return validation.ValidateStruct(&doTransition,
+		// MatchID cannot be empty and should be in a valid uuid.
+		validation.Field(&doTransition.MatchID, validation.Required, is.UUIDv4),
+		// Status cannot be empty and should be one of the match statuses.
+		validation.Field(&doTransition.Status, validation.Required, validation.In(model.MatchStatuses...)),
+	)
+}
+
+// GetTransitions ...
+type GetTransitions struct {
+	MatchID uuid.UUID `json:"match_id"`
+}
+
+// GetClock ...
+type GetClock struct {
+	MatchID uuid.UUID `json:"match_id"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getClock GetClock) Validate() error {
+	return validation.ValidateStruct(&getClock,
+		// MatchID cannot be empty and should be in a valid uuid.
+		validation.Field(&getClock.MatchID, validation.Required, is.UUIDv4),
+	)
+}
+
+// GetWinner ...
+type GetWinner struct {
+	MatchID uuid.UUID `json:"match_id"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getWinner GetWinner) Validate() error {
+	return validation.ValidateStruct(&getWinner,
+		// MatchID cannot be empty and should be in a valid uuid.
+		validation.Field(&getWinner.MatchID, validation.Required, is.UUIDv4),
+	)
+}
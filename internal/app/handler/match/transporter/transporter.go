@@ -0,0 +1,162 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transporter
+
+import (
+	"time"
+
+	"github.com/satori/uuid"
+)
+
+// Match ...
+type Match struct {
+	ID         uuid.UUID  `gorm:"primaryKey" json:"id"`
+	SeasonID   *uuid.UUID `json:"season_id"`
+	Round      int        `json:"round"`
+	HomeTeamID uuid.UUID  `json:"home_team_id"`
+	AwayTeamID uuid.UUID  `json:"away_team_id"`
+	KickoffAt  time.Time  `json:"kickoff_at"`
+	Venue      string     `json:"venue"`
+	VenueID    *uuid.UUID `json:"venue_id"`
+	Status     string     `json:"status"`
+	HomeScore  int        `json:"home_score"`
+	AwayScore  int        `json:"away_score"`
+
+	HomeExtraTimeScore int `json:"home_extra_time_score"`
+	AwayExtraTimeScore int `json:"away_extra_time_score"`
+	HomeShootoutScore  int `json:"home_shootout_score"`
+	AwayShootoutScore  int `json:"away_shootout_score"`
+
+	TieID *uuid.UUID `json:"tie_id,omitempty"`
+	Leg   int        `json:"leg,omitempty"`
+}
+
+// DoCreate ...
+type DoCreate struct {
+	Match
+}
+
+// GetMatches ...
+type GetMatches struct {
+	Match
+}
+
+// TableName ...
+func (GetMatches) TableName() string {
+	return "matches"
+}
+
+// DoUpdate ...
+type DoUpdate struct {
+	Match
+}
+
+// DoDelete ...
+type DoDelete struct {
+	Match
+}
+
+// TableName ...
+func (DoDelete) TableName() string {
+	return "matches"
+}
+
+// GetMatch ...
+type GetMatch struct {
+	Match
+}
+
+// TableName ...
+func (GetMatch) TableName() string {
+	return "matches"
+}
+
+// GetSeasonMatches ...
+type GetSeasonMatches struct {
+	Match
+}
+
+// TableName ...
+func (GetSeasonMatches) TableName() string {
+	return "matches"
+}
+
+// GetTieMatches ...
+type GetTieMatches struct {
+	Match
+}
+
+// TableName ...
+func (GetTieMatches) TableName() string {
+	return "matches"
+}
+
+// GetTeamMatches ...
+type GetTeamMatches struct {
+	Match
+	Distance *float64 `json:"distance"`
+}
+
+// DoReplaceFixtures ...
+type DoReplaceFixtures struct {
+	Match
+}
+
+// CountPreparedFixtures ...
+type CountPreparedFixtures struct {
+	Total int64 `json:"total"`
+}
+
+// DoSyncScore ...
+type DoSyncScore struct {
+	ID                 uuid.UUID `json:"id"`
+	HomeScore          int       `json:"home_score"`
+	AwayScore          int       `json:"away_score"`
+	HomeExtraTimeScore int       `json:"home_extra_time_score"`
+	AwayExtraTimeScore int       `json:"away_extra_time_score"`
+	HomeShootoutScore  int       `json:"home_shootout_score"`
+	AwayShootoutScore  int       `json:"away_shootout_score"`
+}
+
+// Transition ...
+type Transition struct {
+	ID         uuid.UUID `gorm:"primaryKey" json:"id"`
+	MatchID    uuid.UUID `json:"match_id"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// DoTransition ...
+type DoTransition struct {
+	Transition
+	Minute int `json:"minute"`
+}
+
+// GetTransitions ...
+type GetTransitions struct {
+	Transition
+}
+
+// TableName ...
+func (GetTransitions) TableName() string {
+	return "match_transitions"
+}
+
+// GetClock ...
+type GetClock struct {
+	MatchID         uuid.UUID  `json:"match_id"`
+	Status          string     `json:"status"`
+	Minute          int        `json:"minute"`
+	PeriodStartedAt *time.Time `json:"period_started_at,omitempty"`
+}
+
+// GetWinner ...
+type GetWinner struct {
+	MatchID      uuid.UUID  `json:"match_id"`
+	WinnerTeamID *uuid.UUID `json:"winner_team_id"`
+	LoserTeamID  *uuid.UUID `json:"loser_team_id"`
+	DecidedIn    string     `json:"decided_in,omitempty"`
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matchevent
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/matchevent/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/matchevent/transporter"
+	"github.com/harunnryd/skeltun/internal/app/usecase"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// IMatchEvent is an interface that stores the methods that MatchEvent struct will use.
+type IMatchEvent interface {
+	// DoCreate is used for record new match event.
+	// It returns doCreateResp of transporter.DoCreate and any errors written.
+	DoCreate(w http.ResponseWriter, r *http.Request) (doCreateResp interface{}, err error)
+
+	// GetMatchEvents is used for getting the timeline of a match.
+	// It returns getMatchEventsResp of []transporter.GetMatchEvents and any errors written.
+	GetMatchEvents(w http.ResponseWriter, r *http.Request) (getMatchEventsResp interface{}, err error)
+
+	// DoDelete is used for delete the record match event.
+	// It returns doDeleteResp of transporter.DoDelete and any errors written.
+	DoDelete(w http.ResponseWriter, r *http.Request) (doDeleteResp interface{}, err error)
+}
+
+// MatchEvent is an struct that implements IMatchEvent methods.
+type MatchEvent struct {
+	config  config.IConfig
+	usecase usecase.IUseCase
+}
+
+// New it returns instance of MatchEvent that implements IMatchEvent methods.
+func New(opts ...Option) IMatchEvent {
+	m := new(MatchEvent)
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// DoCreate is used for record new match event.
+// It returns doCreateResp of transporter.DoCreate and any errors written.
+func (matchevent *MatchEvent) DoCreate(w http.ResponseWriter, r *http.Request) (doCreateResp interface{}, err error) {
+	doCreateParam := param.DoCreate{}
+	if err = json.NewDecoder(r.Body).Decode(&doCreateParam); err != nil {
+		return
+	}
+
+	doCreateParam.MatchID = uuid.FromStringOrNil(chi.URLParam(r, "match_id"))
+
+	if err = doCreateParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	doCreateResp = transporter.DoCreate{}
+	doCreateResp, err = matchevent.usecase.GetMatchEvent().DoCreate(r.Context(), doCreateParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return doCreateResp, nil
+}
+
+// GetMatchEvents is used for getting the timeline of a match.
+// It returns getMatchEventsResp of []transporter.GetMatchEvents and any errors written.
+func (matchevent *MatchEvent) GetMatchEvents(w http.ResponseWriter, r *http.Request) (getMatchEventsResp interface{}, err error) {
+	getMatchEventsParam := param.GetMatchEvents{MatchID: uuid.FromStringOrNil(chi.URLParam(r, "match_id"))}
+
+	if err = getMatchEventsParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getMatchEventsResp = transporter.GetMatchEvents{}
+	getMatchEventsResp, err = matchevent.usecase.GetMatchEvent().GetMatchEvents(r.Context(), getMatchEventsParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getMatchEventsResp, nil
+}
+
+// DoDelete is used for delete the record match event.
+// It returns doDeleteResp of transporter.DoDelete and any errors written.
+func (matchevent *MatchEvent) DoDelete(w http.ResponseWriter, r *http.Request) (doDeleteResp interface{}, err error) {
+	doDeleteParam := param.DoDelete{
+		ID:      uuid.FromStringOrNil(chi.URLParam(r, "event_id")),
+		MatchID: uuid.FromStringOrNil(chi.URLParam(r, "match_id")),
+	}
+
+	if err = doDeleteParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	doDeleteResp = transporter.DoDelete{}
+	doDeleteResp, err = matchevent.usecase.GetMatchEvent().DoDelete(r.Context(), doDeleteParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return doDeleteResp, nil
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package param
+
+import (
+	"errors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	suspensionParam "github.com/harunnryd/skeltun/internal/app/handler/suspension/param"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/satori/uuid"
+)
+
+// MatchEvent ...
+type MatchEvent struct {
+	ID              uuid.UUID  `json:"id"`
+	MatchID         uuid.UUID  `json:"match_id"`
+	TeamID          uuid.UUID  `json:"team_id"`
+	PlayerID        uuid.UUID  `json:"player_id"`
+	RelatedPlayerID *uuid.UUID `json:"related_player_id"`
+	Type            string     `json:"type"`
+	Period          string     `json:"period"`
+	Minute          int        `json:"minute"`
+}
+
+// differentFrom is used for making sure the related player is not the player.
+func differentFrom(playerID uuid.UUID) validation.RuleFunc {
+	return func(value interface{}) error {
+		if relatedPlayerID, _ := value.(*uuid.UUID); relatedPlayerID != nil && uuid.Equal(*relatedPlayerID, playerID) {
+			return errors.New("must be different from player_id")
+		}
+		return nil
+	}
+}
+
+// requiredFor is used for making sure the related player is set on the given event type.
+func requiredFor(eventType, requiredType string) validation.RuleFunc {
+	return func(value interface{}) error {
+		if relatedPlayerID, _ := value.(*uuid.UUID); eventType == requiredType && relatedPlayerID == nil {
+			return errors.New("cannot be blank for " + requiredType)
+		}
+		return nil
+	}
+}
+
+// DoCreate ...
+type DoCreate struct {
+	MatchEvent
+	Suspension *suspensionParam.Suspension `json:"-"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (doCreate DoCreate) Validate() error {
+	return validation.ValidateStruct(&doCreate,
+		// MatchID cannot be empty and should be in a valid uuid.
+		validation.Field(&doCreate.MatchID, validation.Required, is.UUIDv4),
+		// PlayerID cannot be empty and should be in a valid uuid.
+		validation.Field(&doCreate.PlayerID, validation.Required, is.UUIDv4),
+		// RelatedPlayerID is required for substitutions, should be in a valid uuid and different from PlayerID.
+		validation.Field(&doCreate.RelatedPlayerID,
+			validation.By(requiredFor(doCreate.Type, model.MatchEventTypeSubstitution)),
+			is.UUIDv4,
+			validation.By(differentFrom(doCreate.PlayerID)),
+		),
+		// Type cannot be empty and should be one of the match event types.
+		validation.Field(&doCreate.Type, validation.Required, validation.In(model.MatchEventTypes...)),
+		// Period should be one of the match periods.
+		validation.Field(&doCreate.Period, validation.In(model.MatchPeriods...)),
+		// Minute cannot be empty and must be between 1 and 150.
+		validation.Field(&doCreate.Minute, validation.Required, validation.Min(1), validation.Max(150)),
+	)
+}
+
+// GetMatchEvents ...
+type GetMatchEvents struct {
+	MatchID uuid.UUID `json:"match_id"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getMatchEvents GetMatchEvents) Validate() error {
+	return validation.ValidateStruct(&getMatchEvents,
+		// MatchID cannot be empty and should be in a valid uuid.
+		validation.Field(&getMatchEvents.MatchID, validation.Required, is.UUIDv4),
+	)
+}
+
+// DoDelete ...
+type DoDelete struct {
+	ID      uuid.UUID `json:"id"`
+	MatchID uuid.UUID `json:"match_id"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (doDelete DoDelete) Validate() error {
+	return validation.ValidateStruct(&doDelete,
+		// ID cannot be empty and should be in a valid uuid.
+		validation.Field(&doDelete.ID, validation.Required, is.UUIDv4),
+		// MatchID cannot be empty and should be in a valid uuid.
+		validation.Field(&doDelete.MatchID, validation.Required, is.UUIDv4),
+	)
+}
+
+// GetSeasonMatchEvents ...
+type GetSeasonMatchEvents struct {
+	SeasonID uuid.UUID `json:"season_id"`
+	Types    []string  `json:"types"`
+}
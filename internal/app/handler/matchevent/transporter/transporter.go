@@ -0,0 +1,56 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transporter
+
+import (
+	"github.com/satori/uuid"
+)
+
+// MatchEvent ...
+type MatchEvent struct {
+	ID              uuid.UUID  `gorm:"primaryKey" json:"id"`
+	MatchID         uuid.UUID  `json:"match_id"`
+	TeamID          uuid.UUID  `json:"team_id"`
+	PlayerID        uuid.UUID  `json:"player_id"`
+	RelatedPlayerID *uuid.UUID `json:"related_player_id"`
+	Type            string     `json:"type"`
+	Period          string     `json:"period"`
+	Minute          int        `json:"minute"`
+}
+
+// DoCreate ...
+type DoCreate struct {
+	MatchEvent
+}
+
+// GetMatchEvents ...
+type GetMatchEvents struct {
+	MatchEvent
+}
+
+// TableName ...
+func (GetMatchEvents) TableName() string {
+	return "match_events"
+}
+
+// DoDelete ...
+type DoDelete struct {
+	MatchEvent
+}
+
+// TableName ...
+func (DoDelete) TableName() string {
+	return "match_events"
+}
+
+// GetSeasonMatchEvents ...
+type GetSeasonMatchEvents struct {
+	MatchEvent
+}
+
+// TableName ...
+func (GetSeasonMatchEvents) TableName() string {
+	return "match_events"
+}
@@ -0,0 +1,283 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package official
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/official/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/official/transporter"
+	"github.com/harunnryd/skeltun/internal/app/usecase"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// IOfficial is an interface that stores the methods that Official struct will use.
+type IOfficial interface {
+	// DoCreate is used for record new official.
+	// It returns doCreateResp of transporter.DoCreate and any errors written.
+	DoCreate(w http.ResponseWriter, r *http.Request) (doCreateResp interface{}, err error)
+
+	// GetOfficials is used for getting all officials.
+	// It returns getOfficialsResp of []transporter.GetOfficials and any errors written.
+	GetOfficials(w http.ResponseWriter, r *http.Request) (getOfficialsResp interface{}, err error)
+
+	// DoUpdate is used for update the record official.
+	// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+	DoUpdate(w http.ResponseWriter, r *http.Request) (doUpdateResp interface{}, err error)
+
+	// DoDelete is used for delete the record official.
+	// It returns doDeleteResp of transporter.DoDelete and any errors written.
+	DoDelete(w http.ResponseWriter, r *http.Request) (doDeleteResp interface{}, err error)
+
+	// GetOfficial is used for getting an official.
+	// It returns getOfficialResp of transporter.GetOfficial and any errors written.
+	GetOfficial(w http.ResponseWriter, r *http.Request) (getOfficialResp interface{}, err error)
+
+	// DoAssign is used for assign the officials of a match, replacing the previous ones.
+	// It returns doAssignResp of transporter.DoAssign and any errors written.
+	DoAssign(w http.ResponseWriter, r *http.Request) (doAssignResp interface{}, err error)
+
+	// GetAssignments is used for getting the officials assigned to a match.
+	// It returns getAssignmentsResp of []transporter.GetAssignments and any errors written.
+	GetAssignments(w http.ResponseWriter, r *http.Request) (getAssignmentsResp interface{}, err error)
+
+	// GetStats is used for getting the decisions of a referee over the matches they took charge of.
+	// It returns getStatsResp of transporter.GetStats and any errors written.
+	GetStats(w http.ResponseWriter, r *http.Request) (getStatsResp interface{}, err error)
+
+	// GetRankings is used for getting the referees of a competition ranked by a metric.
+	// It returns getRankingsResp of transporter.GetRankings and any errors written.
+	GetRankings(w http.ResponseWriter, r *http.Request) (getRankingsResp interface{}, err error)
+}
+
+// Official is an struct that implements IOfficial methods.
+type Official struct {
+	config  config.IConfig
+	usecase usecase.IUseCase
+}
+
+// New it returns instance of Official that implements IOfficial methods.
+func New(opts ...Option) IOfficial {
+	o := new(Official)
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// DoCreate is used for record new official.
+// It returns doCreateResp of transporter.DoCreate and any errors written.
+func (official *Official) DoCreate(w http.ResponseWriter, r *http.Request) (doCreateResp interface{}, err error) {
+	doCreateParam := param.DoCreate{}
+	if err = json.NewDecoder(r.Body).Decode(&doCreateParam); err != nil {
+		return
+	}
+
+	if err = doCreateParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	doCreateResp = transporter.DoCreate{}
+	doCreateResp, err = official.usecase.GetOfficial().DoCreate(r.Context(), doCreateParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return doCreateResp, nil
+}
+
+// GetOfficials is used for getting all officials.
+// It returns getOfficialsResp of []transporter.GetOfficials and any errors written.
+func (official *Official) GetOfficials(w http.ResponseWriter, r *http.Request) (getOfficialsResp interface{}, err error) {
+	getOfficialsParam := param.GetOfficials{Pagination: param.Pagination{
+		Limit:  r.URL.Query().Get("limit"),
+		Offset: r.URL.Query().Get("offset"),
+	}}
+
+	if err = getOfficialsParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getOfficialsResp = transporter.GetOfficials{}
+	getOfficialsResp, err = official.usecase.GetOfficial().GetOfficials(r.Context(), getOfficialsParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getOfficialsResp, nil
+}
+
+// DoUpdate is used for update the record official.
+// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+func (official *Official) DoUpdate(w http.ResponseWriter, r *http.Request) (doUpdateResp interface{}, err error) {
+	doUpdateParam := param.DoUpdate{Official: param.Official{ID: uuid.FromStringOrNil(chi.URLParam(r, "official_id"))}}
+	if err = json.NewDecoder(r.Body).Decode(&doUpdateParam); err != nil {
+		return
+	}
+
+	if err = doUpdateParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	doUpdateResp = transporter.DoUpdate{}
+	doUpdateResp, err = official.usecase.GetOfficial().DoUpdate(r.Context(), doUpdateParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return doUpdateResp, nil
+}
+
+// DoDelete is used for delete the record official.
+// It returns doDeleteResp of transporter.DoDelete and any errors written.
+func (official *Official) DoDelete(w http.ResponseWriter, r *http.Request) (doDeleteResp interface{}, err error) {
+	doDeleteParam := param.DoDelete{ID: uuid.FromStringOrNil(chi.URLParam(r, "official_id"))}
+
+	if err = doDeleteParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	doDeleteResp = transporter.DoDelete{}
+	doDeleteResp, err = official.usecase.GetOfficial().DoDelete(r.Context(), doDeleteParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return doDeleteResp, nil
+}
+
+// GetOfficial is used for getting an official.
+// It returns getOfficialResp of transporter.GetOfficial and any errors written.
+func (official *Official) GetOfficial(w http.ResponseWriter, r *http.Request) (getOfficialResp interface{}, err error) {
+	getOfficialParam := param.GetOfficial{ID: uuid.FromStringOrNil(chi.URLParam(r, "official_id"))}
+
+	if err = getOfficialParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getOfficialResp = transporter.GetOfficial{}
+	getOfficialResp, err = official.usecase.GetOfficial().GetOfficial(r.Context(), getOfficialParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getOfficialResp, nil
+}
+
+// DoAssign is used for assign the officials of a match, replacing the previous ones.
+// It returns doAssignResp of transporter.DoAssign and any errors written.
+func (official *Official) DoAssign(w http.ResponseWriter, r *http.Request) (doAssignResp interface{}, err error) {
+	doAssignParam := param.DoAssign{}
+	if err = json.NewDecoder(r.Body).Decode(&doAssignParam); err != nil {
+		return
+	}
+
+	doAssignParam.MatchID = uuid.FromStringOrNil(chi.URLParam(r, "match_id"))
+
+	if err = doAssignParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	doAssignResp = transporter.DoAssign{}
+	doAssignResp, err = official.usecase.GetOfficial().DoAssign(r.Context(), doAssignParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return doAssignResp, nil
+}
+
+// GetAssignments is used for getting the officials assigned to a match.
+// It returns getAssignmentsResp of []transporter.GetAssignments and any errors written.
+func (official *Official) GetAssignments(w http.ResponseWriter, r *http.Request) (getAssignmentsResp interface{}, err error) {
+	getAssignmentsParam := param.GetAssignments{MatchID: uuid.FromStringOrNil(chi.URLParam(r, "match_id"))}
+
+	if err = getAssignmentsParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getAssignmentsResp = transporter.GetAssignments{}
+	getAssignmentsResp, err = official.usecase.GetOfficial().GetAssignments(r.Context(), getAssignmentsParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getAssignmentsResp, nil
+}
+
+// GetStats is used for getting the decisions of a referee over the matches they took charge of.
+// It returns getStatsResp of transporter.GetStats and any errors written.
+func (official *Official) GetStats(w http.ResponseWriter, r *http.Request) (getStatsResp interface{}, err error) {
+	getStatsParam := param.GetStats{
+		OfficialID: uuid.FromStringOrNil(chi.URLParam(r, "official_id")),
+		SeasonID:   r.URL.Query().Get("season_id"),
+	}
+
+	if err = getStatsParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getStatsResp = transporter.GetStats{}
+	getStatsResp, err = official.usecase.GetOfficial().GetStats(r.Context(), getStatsParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getStatsResp, nil
+}
+
+// GetRankings is used for getting the referees of a competition ranked by a metric.
+// It returns getRankingsResp of transporter.GetRankings and any errors written.
+func (official *Official) GetRankings(w http.ResponseWriter, r *http.Request) (getRankingsResp interface{}, err error) {
+	getRankingsParam := param.GetRankings{
+		CompetitionID: uuid.FromStringOrNil(chi.URLParam(r, "competition_id")),
+		SeasonID:      r.URL.Query().Get("season_id"),
+		Metric:        r.URL.Query().Get("metric"),
+	}
+
+	if err = getRankingsParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getRankingsResp = transporter.GetRankings{}
+	getRankingsResp, err = official.usecase.GetOfficial().GetRankings(r.Context(), getRankingsParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getRankingsResp, nil
+}
@@ -0,0 +1,286 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package param
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/satori/uuid"
+)
+
+// Official ...
+type Official struct {
+	ID               uuid.UUID  `json:"id"`
+	Name             string     `json:"name"`
+	Role             string     `json:"role"`
+	AffiliatedTeamID *uuid.UUID `json:"affiliated_team_id"`
+}
+
+// Pagination ...
+type Pagination struct {
+	Limit  string `json:"limit"`
+	Offset string `json:"offset"`
+}
+
+// Validate ...
+func (pagination Pagination) Validate() error {
+	return validation.ValidateStruct(&pagination,
+		// Limit cannot be empty.
+		validation.Field(&pagination.Limit, validation.Required, is.Digit),
+		// Offset cannot be empty.
+		validation.Field(&pagination.Offset, validation.Required, is.Digit),
+	)
+}
+
+// GetLimit ...
+func (pagination Pagination) GetLimit() (limit int) {
+	limit, _ = strconv.Atoi(pagination.Limit)
+	return
+}
+
+// GetOffset ...
+func (pagination Pagination) GetOffset() (offset int) {
+	offset, _ = strconv.Atoi(pagination.Offset)
+	return
+}
+
+// DoCreate ...
+type DoCreate struct {
+	Official
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (doCreate DoCreate) Validate() error {
+	return validation.ValidateStruct(&doCreate,
+		// Name cannot be empty and length must be between 1 and 150.
+		validation.Field(&doCreate.Name, validation.Required, validation.Length(1, 150)),
+		// Role cannot be empty and should be one of the official roles.
+		validation.Field(&doCreate.Role, validation.Required, validation.In(model.OfficialRoles...)),
+		// AffiliatedTeamID should be in a valid uuid.
+		validation.Field(&doCreate.AffiliatedTeamID, is.UUIDv4),
+	)
+}
+
+// GetOfficials ...
+type GetOfficials struct {
+	Pagination
+}
+
+// DoUpdate ...
+type DoUpdate struct {
+	Official
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (doUpdate DoUpdate) Validate() error {
+	return validation.ValidateStruct(&doUpdate,
+		// ID cannot be empty and should be in a valid uuid.
+		validation.Field(&doUpdate.ID, validation.Required, is.UUIDv4),
+		// Name cannot be empty and length must be between 1 and 150.
+		validation.Field(&doUpdate.Name, validation.Required, validation.Length(1, 150)),
+		// Role cannot be empty and should be one of the official roles.
+		validation.Field(&doUpdate.Role, validation.Required, validation.In(model.OfficialRoles...)),
+		// AffiliatedTeamID should be in a valid uuid.
+		validation.Field(&doUpdate.AffiliatedTeamID, is.UUIDv4),
+	)
+}
+
+// DoDelete ...
+type DoDelete struct {
+	ID uuid.UUID `json:"id"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (doDelete DoDelete) Validate() error {
+	return validation.ValidateStruct(&doDelete,
+		// ID cannot be empty and should be in a valid uuid.
+		validation.Field(&doDelete.ID, validation.Required, is.UUIDv4),
+	)
+}
+
+// GetOfficial ...
+type GetOfficial struct {
+	ID uuid.UUID `json:"id"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getOfficial GetOfficial) Validate() error {
+	return validation.ValidateStruct(&getOfficial,
+		// ID cannot be empty and should be in a valid uuid.
+		validation.Field(&getOfficial.ID, validation.Required, is.UUIDv4),
+	)
+}
+
+// Assignment ...
+type Assignment struct {
+	OfficialID uuid.UUID `json:"official_id"`
+	Role       string    `json:"role"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (assignment Assignment) Validate() error {
+	return validation.ValidateStruct(&assignment,
+		// OfficialID cannot be empty and should be in a valid uuid.
+		validation.Field(&assignment.OfficialID, validation.Required, is.UUIDv4),
+		// Role cannot be empty and should be one of the official roles.
+		validation.Field(&assignment.Role, validation.Required, validation.In(model.OfficialRoles...)),
+	)
+}
+
+// isTeamOfOfficials is used for making sure a match has a single referee, no official twice
+// and no more officials of a role than the role allows.
+func isTeamOfOfficials(value interface{}) error {
+	assignments, _ := value.([]Assignment)
+
+	assigned := make(map[uuid.UUID]bool, len(assignments))
+	roles := make(map[string]int, len(model.OfficialRoleLimits))
+	for _, assignment := range assignments {
+		if assigned[assignment.OfficialID] {
+			return errors.New("cannot assign an official twice")
+		}
+		assigned[assignment.OfficialID] = true
+
+		roles[assignment.Role]++
+		if limit, ok := model.OfficialRoleLimits[assignment.Role]; ok && roles[assignment.Role] > limit {
+			return errors.New("cannot have more than " + strconv.Itoa(limit) + " " + assignment.Role)
+		}
+	}
+
+	if roles[model.OfficialRoleReferee] != 1 {
+		return errors.New("must have exactly one referee")
+	}
+
+	return nil
+}
+
+// DoAssign ...
+type DoAssign struct {
+	MatchID   uuid.UUID    `json:"match_id"`
+	Officials []Assignment `json:"officials"`
+	From      time.Time    `json:"-"`
+	To        time.Time    `json:"-"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (doAssign DoAssign) Validate() error {
+	return validation.ValidateStruct(&doAssign,
+		// MatchID cannot be empty and should be in a valid uuid.
+		validation.Field(&doAssign.MatchID, validation.Required, is.UUIDv4),
+		// Officials cannot be empty, every one of them should be valid and make up a single team of officials.
+		validation.Field(&doAssign.Officials, validation.Required, validation.By(isTeamOfOfficials)),
+	)
+}
+
+// GetAssignments ...
+type GetAssignments struct {
+	MatchID uuid.UUID `json:"match_id"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getAssignments GetAssignments) Validate() error {
+	return validation.ValidateStruct(&getAssignments,
+		// MatchID cannot be empty and should be in a valid uuid.
+		validation.Field(&getAssignments.MatchID, validation.Required, is.UUIDv4),
+	)
+}
+
+// GetOfficialsByIDs ...
+type GetOfficialsByIDs struct {
+	IDs []uuid.UUID `json:"ids"`
+}
+
+// CountAssignments ...
+type CountAssignments struct {
+	OfficialID uuid.UUID `json:"official_id"`
+}
+
+// GetBookings ...
+type GetBookings struct {
+	OfficialIDs []uuid.UUID `json:"official_ids"`
+	MatchID     uuid.UUID   `json:"match_id"`
+	From        time.Time   `json:"from"`
+	To          time.Time   `json:"to"`
+}
+
+// GetStats ...
+type GetStats struct {
+	OfficialID uuid.UUID `json:"official_id"`
+	SeasonID   string    `json:"season_id"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getStats GetStats) Validate() error {
+	return validation.ValidateStruct(&getStats,
+		// OfficialID cannot be empty and should be in a valid uuid.
+		validation.Field(&getStats.OfficialID, validation.Required, is.UUIDv4),
+		// SeasonID should be in a valid uuid.
+		validation.Field(&getStats.SeasonID, is.UUIDv4),
+	)
+}
+
+// GetSeasonID ...
+func (getStats GetStats) GetSeasonID() *uuid.UUID {
+	return toSeasonID(getStats.SeasonID)
+}
+
+// GetRankings ...
+type GetRankings struct {
+	CompetitionID uuid.UUID `json:"competition_id"`
+	SeasonID      string    `json:"season_id"`
+	Metric        string    `json:"metric"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getRankings GetRankings) Validate() error {
+	return validation.ValidateStruct(&getRankings,
+		// CompetitionID cannot be empty and should be in a valid uuid.
+		validation.Field(&getRankings.CompetitionID, validation.Required, is.UUIDv4),
+		// SeasonID should be in a valid uuid.
+		validation.Field(&getRankings.SeasonID, is.UUIDv4),
+		// Metric cannot be empty and should be one of the referee metrics.
+		validation.Field(&getRankings.Metric, validation.Required, validation.In(model.RefereeMetrics...)),
+	)
+}
+
+// GetSeasonID ...
+func (getRankings GetRankings) GetSeasonID() *uuid.UUID {
+	return toSeasonID(getRankings.SeasonID)
+}
+
+// toSeasonID is used for reading the optional season filter, leaving it out when it is empty.
+func toSeasonID(value string) *uuid.UUID {
+	if value == "" {
+		return nil
+	}
+
+	seasonID := uuid.FromStringOrNil(value)
+	return &seasonID
+}
+
+// GetRefereeMatches ...
+type GetRefereeMatches struct {
+	OfficialID    *uuid.UUID `json:"official_id"`
+	CompetitionID *uuid.UUID `json:"competition_id"`
+	SeasonID      *uuid.UUID `json:"season_id"`
+}
+
+// GetDecisions ...
+type GetDecisions struct {
+	MatchIDs []uuid.UUID `json:"match_ids"`
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transporter
+
+import (
+	"time"
+
+	"github.com/satori/uuid"
+)
+
+// Official ...
+type Official struct {
+	ID               uuid.UUID  `gorm:"primaryKey" json:"id"`
+	Name             string     `json:"name"`
+	Role             string     `json:"role"`
+	AffiliatedTeamID *uuid.UUID `json:"affiliated_team_id"`
+}
+
+// DoCreate ...
+type DoCreate struct {
+	Official
+}
+
+// GetOfficials ...
+type GetOfficials struct {
+	Official
+}
+
+// TableName ...
+func (GetOfficials) TableName() string {
+	return "officials"
+}
+
+// DoUpdate ...
+type DoUpdate struct {
+	Official
+}
+
+// DoDelete ...
+type DoDelete struct {
+	Official
+}
+
+// TableName ...
+func (DoDelete) TableName() string {
+	return "officials"
+}
+
+// GetOfficial ...
+type GetOfficial struct {
+	Official
+}
+
+// TableName ...
+func (GetOfficial) TableName() string {
+	return "officials"
+}
+
+// Assignment ...
+type Assignment struct {
+	OfficialID uuid.UUID `json:"official_id"`
+	Name       string    `json:"name"`
+	Role       string    `json:"role"`
+}
+
+// DoAssign ...
+type DoAssign struct {
+	MatchID   uuid.UUID     `json:"match_id"`
+	Officials []Assignment  `json:"officials"`
+	Bookings  []GetBookings `json:"-"`
+}
+
+// GetAssignments ...
+type GetAssignments struct {
+	Assignment
+}
+
+// GetOfficialsByIDs ...
+type GetOfficialsByIDs struct {
+	Official
+}
+
+// TableName ...
+func (GetOfficialsByIDs) TableName() string {
+	return "officials"
+}
+
+// CountAssignments ...
+type CountAssignments struct {
+	Total int64 `json:"total"`
+}
+
+// GetBookings ...
+type GetBookings struct {
+	OfficialID uuid.UUID `json:"official_id"`
+	MatchID    uuid.UUID `json:"match_id"`
+	KickoffAt  time.Time `json:"kickoff_at"`
+}
+
+// Split ...
+type Split struct {
+	Home int `json:"home"`
+	Away int `json:"away"`
+}
+
+// RefereeStats ...
+type RefereeStats struct {
+	OfficialID        uuid.UUID `json:"official_id"`
+	Name              string    `json:"name"`
+	MatchesOfficiated int       `json:"matches_officiated"`
+	YellowCards       int       `json:"yellow_cards"`
+	RedCards          int       `json:"red_cards"`
+	CardsPerGame      float64   `json:"cards_per_game"`
+	PenaltiesAwarded  int       `json:"penalties_awarded"`
+	Cards             Split     `json:"cards"`
+	Penalties         Split     `json:"penalties"`
+}
+
+// GetStats ...
+type GetStats struct {
+	SeasonID *uuid.UUID `json:"season_id"`
+	RefereeStats
+}
+
+// Ranking ...
+type Ranking struct {
+	Rank int `json:"rank"`
+	RefereeStats
+}
+
+// GetRankings ...
+type GetRankings struct {
+	CompetitionID uuid.UUID  `json:"competition_id"`
+	SeasonID      *uuid.UUID `json:"season_id"`
+	Metric        string     `json:"metric"`
+	Referees      []Ranking  `json:"referees"`
+}
+
+// GetRefereeMatches ...
+type GetRefereeMatches struct {
+	OfficialID uuid.UUID `json:"official_id"`
+	Name       string    `json:"name"`
+	MatchID    uuid.UUID `json:"match_id"`
+	HomeTeamID uuid.UUID `json:"home_team_id"`
+	AwayTeamID uuid.UUID `json:"away_team_id"`
+}
+
+// GetDecisions ...
+type GetDecisions struct {
+	MatchID uuid.UUID `json:"match_id"`
+	TeamID  uuid.UUID `json:"team_id"`
+	Type    string    `json:"type"`
+}
+
+// TableName ...
+func (GetDecisions) TableName() string {
+	return "match_events"
+}
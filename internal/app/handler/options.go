@@ -6,9 +6,32 @@ package handler
 
 import (
 	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/availability"
+	"github.com/harunnryd/skeltun/internal/app/handler/bracket"
+	"github.com/harunnryd/skeltun/internal/app/handler/competition"
+	"github.com/harunnryd/skeltun/internal/app/handler/contract"
+	"github.com/harunnryd/skeltun/internal/app/handler/draw"
+	"github.com/harunnryd/skeltun/internal/app/handler/fixture"
+	"github.com/harunnryd/skeltun/internal/app/handler/group"
 	"github.com/harunnryd/skeltun/internal/app/handler/hcheck"
+	"github.com/harunnryd/skeltun/internal/app/handler/injury"
+	"github.com/harunnryd/skeltun/internal/app/handler/leaderboard"
+	"github.com/harunnryd/skeltun/internal/app/handler/lineup"
+	"github.com/harunnryd/skeltun/internal/app/handler/loan"
+	"github.com/harunnryd/skeltun/internal/app/handler/match"
+	"github.com/harunnryd/skeltun/internal/app/handler/matchevent"
+	"github.com/harunnryd/skeltun/internal/app/handler/official"
 	"github.com/harunnryd/skeltun/internal/app/handler/player"
+	"github.com/harunnryd/skeltun/internal/app/handler/season"
+	"github.com/harunnryd/skeltun/internal/app/handler/shootoutkick"
+	"github.com/harunnryd/skeltun/internal/app/handler/squad"
+	"github.com/harunnryd/skeltun/internal/app/handler/standing"
+	"github.com/harunnryd/skeltun/internal/app/handler/stat"
+	"github.com/harunnryd/skeltun/internal/app/handler/suspension"
 	"github.com/harunnryd/skeltun/internal/app/handler/team"
+	"github.com/harunnryd/skeltun/internal/app/handler/transfer"
+	"github.com/harunnryd/skeltun/internal/app/handler/travel"
+	"github.com/harunnryd/skeltun/internal/app/handler/venue"
 	"github.com/harunnryd/skeltun/internal/app/usecase"
 )
 
@@ -39,5 +62,120 @@ func WithHandler(config config.IConfig) Option {
 			player.WithConfig(config),
 			player.WithUseCase(iUsecase),
 		)
+
+		handler.match = match.New(
+			match.WithConfig(config),
+			match.WithUseCase(iUsecase),
+		)
+
+		handler.competition = competition.New(
+			competition.WithConfig(config),
+			competition.WithUseCase(iUsecase),
+		)
+
+		handler.season = season.New(
+			season.WithConfig(config),
+			season.WithUseCase(iUsecase),
+		)
+
+		handler.standing = standing.New(
+			standing.WithConfig(config),
+			standing.WithUseCase(iUsecase),
+		)
+
+		handler.fixture = fixture.New(
+			fixture.WithConfig(config),
+			fixture.WithUseCase(iUsecase),
+		)
+
+		handler.matchevent = matchevent.New(
+			matchevent.WithConfig(config),
+			matchevent.WithUseCase(iUsecase),
+		)
+
+		handler.shootoutkick = shootoutkick.New(
+			shootoutkick.WithConfig(config),
+			shootoutkick.WithUseCase(iUsecase),
+		)
+
+		handler.bracket = bracket.New(
+			bracket.WithConfig(config),
+			bracket.WithUseCase(iUsecase),
+		)
+
+		handler.draw = draw.New(
+			draw.WithConfig(config),
+			draw.WithUseCase(iUsecase),
+		)
+
+		handler.group = group.New(
+			group.WithConfig(config),
+			group.WithUseCase(iUsecase),
+		)
+
+		handler.transfer = transfer.New(
+			transfer.WithConfig(config),
+			transfer.WithUseCase(iUsecase),
+		)
+
+		handler.loan = loan.New(
+			loan.WithConfig(config),
+			loan.WithUseCase(iUsecase),
+		)
+
+		handler.contract = contract.New(
+			contract.WithConfig(config),
+			contract.WithUseCase(iUsecase),
+		)
+
+		handler.squad = squad.New(
+			squad.WithConfig(config),
+			squad.WithUseCase(iUsecase),
+		)
+
+		handler.lineup = lineup.New(
+			lineup.WithConfig(config),
+			lineup.WithUseCase(iUsecase),
+		)
+
+		handler.stat = stat.New(
+			stat.WithConfig(config),
+			stat.WithUseCase(iUsecase),
+		)
+
+		handler.leaderboard = leaderboard.New(
+			leaderboard.WithConfig(config),
+			leaderboard.WithUseCase(iUsecase),
+		)
+
+		handler.injury = injury.New(
+			injury.WithConfig(config),
+			injury.WithUseCase(iUsecase),
+		)
+
+		handler.suspension = suspension.New(
+			suspension.WithConfig(config),
+			suspension.WithUseCase(iUsecase),
+		)
+
+		handler.availability = availability.New(
+			availability.WithConfig(config),
+			availability.WithUseCase(iUsecase),
+		)
+
+		handler.venue = venue.New(
+			venue.WithConfig(config),
+			venue.WithUseCase(iUsecase),
+		)
+
+		handler.travel = travel.New(
+			travel.WithConfig(config),
+			travel.WithUseCase(iUsecase),
+		)
+
+		handler.official = official.New(
+			official.WithConfig(config),
+			official.WithUseCase(iUsecase),
+		)
 	}
 }
@@ -5,10 +5,15 @@
 package param
 
 import (
+	"errors"
+	"strconv"
+	"time"
+
 	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/go-ozzo/ozzo-validation/is"
+	contractParam "github.com/harunnryd/skeltun/internal/app/handler/contract/param"
+	"github.com/harunnryd/skeltun/internal/app/model"
 	"github.com/satori/uuid"
-	"strconv"
 )
 
 // Pagination ...
@@ -41,14 +46,54 @@ func (pagination Pagination) GetOffset() (offset int) {
 
 // Player ...
 type Player struct {
-	ID     uuid.UUID `json:"id"`
-	Name   string    `json:"name"`
-	TeamID uuid.UUID `json:"team_id"`
+	ID            uuid.UUID  `json:"id"`
+	Name          string     `json:"name"`
+	TeamID        uuid.UUID  `json:"team_id"`
+	Position      string     `json:"position"`
+	Role          string     `json:"role"`
+	ShirtNumber   *int       `json:"shirt_number"`
+	DateOfBirth   *time.Time `json:"date_of_birth"`
+	Nationality   string     `json:"nationality"`
+	Height        int        `json:"height"`
+	Weight        int        `json:"weight"`
+	PreferredFoot string     `json:"preferred_foot"`
+	Homegrown     *bool      `json:"homegrown"`
+}
+
+// playedIn is used for checking that a detailed role belongs to the given position.
+func playedIn(position string) validation.RuleFunc {
+	return func(value interface{}) error {
+		role, _ := value.(string)
+		if role == "" {
+			return nil
+		}
+
+		rolePosition, ok := model.PlayerRoles[role]
+		if !ok {
+			return errors.New("must be a valid role")
+		}
+
+		if position != "" && rolePosition != position {
+			return errors.New("is not played as " + position)
+		}
+
+		return nil
+	}
+}
+
+// endsInFuture is used for checking that the contract a player signs on joining has not ended already.
+func endsInFuture(value interface{}) error {
+	if terms, _ := value.(*contractParam.Terms); terms != nil && !terms.EndsAt.After(time.Now()) {
+		return errors.New("should end in the future")
+	}
+	return nil
 }
 
 // DoCreate is an struct
 type DoCreate struct {
 	Player
+	Contract *contractParam.Terms `json:"contract"`
+	Date     time.Time            `json:"-"`
 }
 
 // Validate is used for validating request payload.
@@ -59,6 +104,24 @@ func (doCreate DoCreate) Validate() error {
 		validation.Field(&doCreate.Name, validation.Required, validation.Length(1, 150)),
 		// TeamID cannot be empty and should be in a valid uuid.
 		validation.Field(&doCreate.TeamID, validation.Required, is.UUIDv4),
+		// Position cannot be empty and should be either GK, DF, MF or FW.
+		validation.Field(&doCreate.Position, validation.Required, validation.In(model.PlayerPositions...)),
+		// Role should be a valid role of the player position.
+		validation.Field(&doCreate.Role, validation.By(playedIn(doCreate.Position))),
+		// ShirtNumber should be between 1 and 99.
+		validation.Field(&doCreate.ShirtNumber, validation.Min(1), validation.Max(99)),
+		// DateOfBirth cannot be in the future.
+		validation.Field(&doCreate.DateOfBirth, validation.Max(time.Now()).Error("cannot be in the future")),
+		// Nationality should be a three letters country code.
+		validation.Field(&doCreate.Nationality, validation.Length(3, 3), is.UpperCase, is.Alpha),
+		// Height should be between 100 and 250 centimetres.
+		validation.Field(&doCreate.Height, validation.Min(100), validation.Max(250)),
+		// Weight should be between 30 and 150 kilograms.
+		validation.Field(&doCreate.Weight, validation.Min(30), validation.Max(150)),
+		// PreferredFoot should be either left, right or both.
+		validation.Field(&doCreate.PreferredFoot, validation.In(model.PreferredFeet...)),
+		// Contract cannot be empty, a player joins the team with a contract running from today.
+		validation.Field(&doCreate.Contract, validation.Required, validation.By(endsInFuture)),
 	)
 }
 
@@ -82,9 +145,31 @@ func (doUpdate DoUpdate) Validate() error {
 		validation.Field(&doUpdate.Name, validation.Required, validation.Length(1, 150)),
 		// TeamID cannot be empty and should be in a valid uuid.
 		validation.Field(&doUpdate.TeamID, validation.Required, is.UUIDv4),
+		// Position should be either GK, DF, MF or FW.
+		validation.Field(&doUpdate.Position, validation.In(model.PlayerPositions...)),
+		// Role should be a valid role of the player position.
+		validation.Field(&doUpdate.Role, validation.By(playedIn(doUpdate.Position))),
+		// ShirtNumber should be between 1 and 99.
+		validation.Field(&doUpdate.ShirtNumber, validation.Min(1), validation.Max(99)),
+		// DateOfBirth cannot be in the future.
+		validation.Field(&doUpdate.DateOfBirth, validation.Max(time.Now()).Error("cannot be in the future")),
+		// Nationality should be a three letters country code.
+		validation.Field(&doUpdate.Nationality, validation.Length(3, 3), is.UpperCase, is.Alpha),
+		// Height should be between 100 and 250 centimetres.
+		validation.Field(&doUpdate.Height, validation.Min(100), validation.Max(250)),
+		// Weight should be between 30 and 150 kilograms.
+		validation.Field(&doUpdate.Weight, validation.Min(30), validation.Max(150)),
+		// PreferredFoot should be either left, right or both.
+		validation.Field(&doUpdate.PreferredFoot, validation.In(model.PreferredFeet...)),
 	)
 }
 
+// GetShirtNumber ...
+type GetShirtNumber struct {
+	TeamID      uuid.UUID `json:"team_id"`
+	ShirtNumber int       `json:"shirt_number"`
+}
+
 // DoDelete ...
 type DoDelete struct {
 	ID uuid.UUID `json:"id"`
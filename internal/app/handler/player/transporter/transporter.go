@@ -4,18 +4,33 @@
 
 package transporter
 
-import "github.com/satori/uuid"
+import (
+	"time"
+
+	contractTransporter "github.com/harunnryd/skeltun/internal/app/handler/contract/transporter"
+	"github.com/satori/uuid"
+)
 
 // Player ...
 type Player struct {
-	ID     uuid.UUID `gorm:"primaryKey" json:"id"`
-	TeamID uuid.UUID `json:"team_id"`
-	Name   string    `json:"name"`
+	ID            uuid.UUID  `gorm:"primaryKey" json:"id"`
+	TeamID        uuid.UUID  `json:"team_id"`
+	Name          string     `json:"name"`
+	Position      string     `json:"position"`
+	Role          string     `json:"role,omitempty"`
+	ShirtNumber   *int       `json:"shirt_number"`
+	DateOfBirth   *time.Time `json:"date_of_birth"`
+	Nationality   string     `json:"nationality,omitempty"`
+	Height        int        `json:"height,omitempty"`
+	Weight        int        `json:"weight,omitempty"`
+	PreferredFoot string     `json:"preferred_foot,omitempty"`
+	Homegrown     *bool      `json:"homegrown"`
 }
 
 // DoCreate ...
 type DoCreate struct {
 	Player
+	Contract *contractTransporter.Contract `json:"contract,omitempty"`
 }
 
 // GetPlayers ...
@@ -43,6 +58,16 @@ func (DoDelete) TableName() string {
 	return "players"
 }
 
+// GetShirtNumber ...
+type GetShirtNumber struct {
+	Player
+}
+
+// TableName ...
+func (GetShirtNumber) TableName() string {
+	return "players"
+}
+
 // GetPlayer ...
 type GetPlayer struct {
 	Player
@@ -0,0 +1,188 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package param
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/satori/uuid"
+)
+
+// Pagination ...
+type Pagination struct {
+	Limit  string `json:"limit"`
+	Offset string `json:"offset"`
+}
+
+// Validate ...
+func (pagination Pagination) Validate() error {
+	return validation.ValidateStruct(&pagination,
+		// Limit cannot be empty.
+		validation.Field(&pagination.Limit, validation.Required, is.Digit),
+		// Offset cannot be empty.
+		validation.Field(&pagination.Offset, validation.Required, is.Digit),
+	)
+}
+
+// GetLimit ...
+func (pagination Pagination) GetLimit() (limit int) {
+	limit, _ = strconv.Atoi(pagination.Limit)
+	return
+}
+
+// GetOffset ...
+func (pagination Pagination) GetOffset() (offset int) {
+	offset, _ = strconv.Atoi(pagination.Offset)
+	return
+}
+
+// Season ...
+type Season struct {
+	ID            uuid.UUID `json:"id"`
+	CompetitionID uuid.UUID `json:"competition_id"`
+	Name          string    `json:"name"`
+	StartDate     time.Time `json:"start_date"`
+	EndDate       time.Time `json:"end_date"`
+}
+
+// after is used for making sure a date comes after the given date.
+func after(date time.Time, field string) validation.RuleFunc {
+	return func(value interface{}) error {
+		if t, _ := value.(time.Time); !t.After(date) {
+			return errors.New("must be after " + field)
+		}
+		return nil
+	}
+}
+
+// DoCreate ...
+type DoCreate struct {
+	Season
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (doCreate DoCreate) Validate() error {
+	return validation.ValidateStruct(&doCreate,
+		// CompetitionID cannot be empty and should be in a valid uuid.
+		validation.Field(&doCreate.CompetitionID, validation.Required, is.UUIDv4),
+		// Name cannot be empty and length must be between 1 and 150.
+		validation.Field(&doCreate.Name, validation.Required, validation.Length(1, 150)),
+		// StartDate cannot be empty.
+		validation.Field(&doCreate.StartDate, validation.Required),
+		// EndDate cannot be empty and should be after StartDate.
+		validation.Field(&doCreate.EndDate, validation.Required, validation.By(after(doCreate.StartDate, "start_date"))),
+	)
+}
+
+// GetSeasons ...
+type GetSeasons struct {
+	CompetitionID uuid.UUID `json:"competition_id"`
+	Pagination
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getSeasons GetSeasons) Validate() error {
+	return validation.ValidateStruct(&getSeasons,
+		// CompetitionID cannot be empty and should be in a valid uuid.
+		validation.Field(&getSeasons.CompetitionID, validation.Required, is.UUIDv4),
+		// Pagination should be valid.
+		validation.Field(&getSeasons.Pagination),
+	)
+}
+
+// DoUpdate ...
+type DoUpdate struct {
+	Season
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (doUpdate DoUpdate) Validate() error {
+	return validation.ValidateStruct(&doUpdate,
+		// ID cannot be empty and should be in a valid uuid.
+		validation.Field(&doUpdate.ID, validation.Required, is.UUIDv4),
+		// Name cannot be empty and length must be between 1 and 150.
+		validation.Field(&doUpdate.Name, validation.Required, validation.Length(1, 150)),
+		// StartDate cannot be empty.
+		validation.Field(&doUpdate.StartDate, validation.Required),
+		// EndDate cannot be empty and should be after StartDate.
+		validation.Field(&doUpdate.EndDate, validation.Required, validation.By(after(doUpdate.StartDate, "start_date"))),
+	)
+}
+
+// DoDelete ...
+type DoDelete struct {
+	ID uuid.UUID `json:"id"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (doDelete DoDelete) Validate() error {
+	return validation.ValidateStruct(&doDelete,
+		// ID cannot be empty and should be in a valid uuid.
+		validation.Field(&doDelete.ID, validation.Required, is.UUIDv4),
+	)
+}
+
+// GetSeason ...
+type GetSeason struct {
+	ID uuid.UUID `json:"id"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getSeason GetSeason) Validate() error {
+	return validation.ValidateStruct(&getSeason,
+		// ID cannot be empty and should be in a valid uuid.
+		validation.Field(&getSeason.ID, validation.Required, is.UUIDv4),
+	)
+}
+
+// SeasonTeam ...
+type SeasonTeam struct {
+	SeasonID uuid.UUID `json:"season_id"`
+	TeamID   uuid.UUID `json:"team_id"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (seasonTeam SeasonTeam) Validate() error {
+	return validation.ValidateStruct(&seasonTeam,
+		// SeasonID cannot be empty and should be in a valid uuid.
+		validation.Field(&seasonTeam.SeasonID, validation.Required, is.UUIDv4),
+		// TeamID cannot be empty and should be in a valid uuid.
+		validation.Field(&seasonTeam.TeamID, validation.Required, is.UUIDv4),
+	)
+}
+
+// DoRegisterTeam ...
+type DoRegisterTeam struct {
+	SeasonTeam
+}
+
+// GetSeasonTeams ...
+type GetSeasonTeams struct {
+	SeasonID uuid.UUID `json:"season_id"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getSeasonTeams GetSeasonTeams) Validate() error {
+	return validation.ValidateStruct(&getSeasonTeams,
+		// SeasonID cannot be empty and should be in a valid uuid.
+		validation.Field(&getSeasonTeams.SeasonID, validation.Required, is.UUIDv4),
+	)
+}
+
+// DoUnregisterTeam ...
+type DoUnregisterTeam struct {
+	SeasonTeam
+}
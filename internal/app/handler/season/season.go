@@ -0,0 +1,257 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package season
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/season/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/season/transporter"
+	"github.com/harunnryd/skeltun/internal/app/usecase"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// ISeason is an interface that stores the methods that Season struct will use.
+type ISeason interface {
+	// DoCreate is used for record new season.
+	// It returns doCreateResp of transporter.DoCreate and any errors written.
+	DoCreate(w http.ResponseWriter, r *http.Request) (doCreateResp interface{}, err error)
+
+	// GetSeasons is used for getting all seasons of a competition.
+	// It returns getSeasonsResp of []transporter.GetSeasons and any errors written.
+	GetSeasons(w http.ResponseWriter, r *http.Request) (getSeasonsResp interface{}, err error)
+
+	// DoUpdate is used for update the record season.
+	// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+	DoUpdate(w http.ResponseWriter, r *http.Request) (doUpdateResp interface{}, err error)
+
+	// DoDelete is used for delete the record season.
+	// It returns doDeleteResp of transporter.DoDelete and any errors written.
+	DoDelete(w http.ResponseWriter, r *http.Request) (doDeleteResp interface{}, err error)
+
+	// GetSeason is used for getting an season.
+	// It returns getSeasonResp of transporter.GetSeason and any errors written.
+	GetSeason(w http.ResponseWriter, r *http.Request) (getSeasonResp interface{}, err error)
+
+	// DoRegisterTeam is used for registering a team into the season.
+	// It returns doRegisterTeamResp of transporter.DoRegisterTeam and any errors written.
+	DoRegisterTeam(w http.ResponseWriter, r *http.Request) (doRegisterTeamResp interface{}, err error)
+
+	// GetSeasonTeams is used for getting all teams registered in the season.
+	// It returns getSeasonTeamsResp of []transporter.GetSeasonTeams and any errors written.
+	GetSeasonTeams(w http.ResponseWriter, r *http.Request) (getSeasonTeamsResp interface{}, err error)
+
+	// DoUnregisterTeam is used for removing a team from the season.
+	// It returns doUnregisterTeamResp of transporter.DoUnregisterTeam and any errors written.
+	DoUnregisterTeam(w http.ResponseWriter, r *http.Request) (doUnregisterTeamResp interface{}, err error)
+}
+
+// Season is an struct that implements ISeason methods.
+type Season struct {
+	config  config.IConfig
+	usecase usecase.IUseCase
+}
+
+// New it returns instance of Season that implements ISeason methods.
+func New(opts ...Option) ISeason {
+	s := new(Season)
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// DoCreate is used for record new season.
+// It returns doCreateResp of transporter.DoCreate and any errors written.
+func (season *Season) DoCreate(w http.ResponseWriter, r *http.Request) (doCreateResp interface{}, err error) {
+	doCreateParam := param.DoCreate{Season: param.Season{CompetitionID: uuid.FromStringOrNil(chi.URLParam(r, "competition_id"))}}
+	if err = json.NewDecoder(r.Body).Decode(&doCreateParam); err != nil {
+		return
+	}
+
+	if err = doCreateParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	doCreateResp = transporter.DoCreate{}
+	doCreateResp, err = season.usecase.GetSeason().DoCreate(r.Context(), doCreateParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return doCreateResp, nil
+}
+
+// GetSeasons is used for getting all seasons of a competition.
+// It returns getSeasonsResp of []transporter.GetSeasons and any errors written.
+func (season *Season) GetSeasons(w http.ResponseWriter, r *http.Request) (getSeasonsResp interface{}, err error) {
+	getSeasonsParam := param.GetSeasons{
+		CompetitionID: uuid.FromStringOrNil(chi.URLParam(r, "competition_id")),
+		Pagination: param.Pagination{
+			Limit:  r.URL.Query().Get("limit"),
+			Offset: r.URL.Query().Get("offset"),
+		},
+	}
+
+	if err = getSeasonsParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getSeasonsResp = transporter.GetSeasons{}
+	getSeasonsResp, err = season.usecase.GetSeason().GetSeasons(r.Context(), getSeasonsParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getSeasonsResp, nil
+}
+
+// DoUpdate is used for update the record season.
+// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+func (season *Season) DoUpdate(w http.ResponseWriter, r *http.Request) (doUpdateResp interface{}, err error) {
+	doUpdateParam := param.DoUpdate{Season: param.Season{ID: uuid.FromStringOrNil(chi.URLParam(r, "season_id"))}}
+	if err = json.NewDecoder(r.Body).Decode(&doUpdateParam); err != nil {
+		return
+	}
+
+	if err = doUpdateParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	doUpdateResp = transporter.DoUpdate{}
+	doUpdateResp, err = season.usecase.GetSeason().DoUpdate(r.Context(), doUpdateParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return doUpdateResp, nil
+}
+
+// DoDelete is used for delete the record season.
+// It returns doDeleteResp of transporter.DoDelete and any errors written.
+func (season *Season) DoDelete(w http.ResponseWriter, r *http.Request) (doDeleteResp interface{}, err error) {
+	doDeleteParam := param.DoDelete{ID: uuid.FromStringOrNil(chi.URLParam(r, "season_id"))}
+
+	if err = doDeleteParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	doDeleteResp = transporter.DoDelete{}
+	doDeleteResp, err = season.usecase.GetSeason().DoDelete(r.Context(), doDeleteParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return doDeleteResp, nil
+}
+
+// GetSeason is used for getting an season.
+// It returns getSeasonResp of transporter.GetSeason and any errors written.
+func (season *Season) GetSeason(w http.ResponseWriter, r *http.Request) (getSeasonResp interface{}, err error) {
+	getSeasonParam := param.GetSeason{ID: uuid.FromStringOrNil(chi.URLParam(r, "season_id"))}
+
+	if err = getSeasonParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getSeasonResp = transporter.GetSeason{}
+	getSeasonResp, err = season.usecase.GetSeason().GetSeason(r.Context(), getSeasonParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getSeasonResp, nil
+}
+
+// DoRegisterTeam is used for registering a team into the season.
+// It returns doRegisterTeamResp of transporter.DoRegisterTeam and any errors written.
+func (season *Season) DoRegisterTeam(w http.ResponseWriter, r *http.Request) (doRegisterTeamResp interface{}, err error) {
+	doRegisterTeamParam := param.DoRegisterTeam{}
+	if err = json.NewDecoder(r.Body).Decode(&doRegisterTeamParam); err != nil {
+		return
+	}
+
+	doRegisterTeamParam.SeasonID = uuid.FromStringOrNil(chi.URLParam(r, "season_id"))
+
+	if err = doRegisterTeamParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	doRegisterTeamResp = transporter.DoRegisterTeam{}
+	doRegisterTeamResp, err = season.usecase.GetSeason().DoRegisterTeam(r.Context(), doRegisterTeamParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return doRegisterTeamResp, nil
+}
+
+// GetSeasonTeams is used for getting all teams registered in the season.
+// It returns getSeasonTeamsResp of []transporter.GetSeasonTeams and any errors written.
+func (season *Season) GetSeasonTeams(w http.ResponseWriter, r *http.Request) (getSeasonTeamsResp interface{}, err error) {
+	getSeasonTeamsParam := param.GetSeasonTeams{SeasonID: uuid.FromStringOrNil(chi.URLParam(r, "season_id"))}
+
+	if err = getSeasonTeamsParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getSeasonTeamsResp = transporter.GetSeasonTeams{}
+	getSeasonTeamsResp, err = season.usecase.GetSeason().GetSeasonTeams(r.Context(), getSeasonTeamsParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getSeasonTeamsResp, nil
+}
+
+// DoUnregisterTeam is used for removing a team from the season.
+// It returns doUnregisterTeamResp of transporter.DoUnregisterTeam and any errors written.
+func (season *Season) DoUnregisterTeam(w http.ResponseWriter, r *http.Request) (doUnregisterTeamResp interface{}, err error) {
+	doUnregisterTeamParam := param.DoUnregisterTeam{SeasonTeam: param.SeasonTeam{
+		SeasonID: uuid.FromStringOrNil(chi.URLParam(r, "season_id")),
+		TeamID:   uuid.FromStringOrNil(chi.URLParam(r, "team_id")),
+	}}
+
+	if err = doUnregisterTeamParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	doUnregisterTeamResp = transporter.DoUnregisterTeam{}
+	doUnregisterTeamResp, err = season.usecase.GetSeason().DoUnregisterTeam(r.Context(), doUnregisterTeamParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return doUnregisterTeamResp, nil
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transporter
+
+import (
+	"time"
+
+	"github.com/satori/uuid"
+)
+
+// Season ...
+type Season struct {
+	ID            uuid.UUID `gorm:"primaryKey" json:"id"`
+	CompetitionID uuid.UUID `json:"competition_id"`
+	Name          string    `json:"name"`
+	StartDate     time.Time `json:"start_date"`
+	EndDate       time.Time `json:"end_date"`
+}
+
+// Team ...
+type Team struct {
+	ID          uuid.UUID `gorm:"primaryKey" json:"id"`
+	Name        string    `json:"name"`
+	Association string    `json:"association"`
+}
+
+// SeasonTeam ...
+type SeasonTeam struct {
+	SeasonID uuid.UUID `json:"season_id"`
+	TeamID   uuid.UUID `json:"team_id"`
+}
+
+// DoCreate ...
+type DoCreate struct {
+	Season
+}
+
+// GetSeasons ...
+type GetSeasons struct {
+	Season
+}
+
+// TableName ...
+func (GetSeasons) TableName() string {
+	return "seasons"
+}
+
+// DoUpdate ...
+type DoUpdate struct {
+	Season
+}
+
+// DoDelete ...
+type DoDelete struct {
+	Season
+}
+
+// TableName ...
+func (DoDelete) TableName() string {
+	return "seasons"
+}
+
+// GetSeason ...
+type GetSeason struct {
+	Season
+}
+
+// TableName ...
+func (GetSeason) TableName() string {
+	return "seasons"
+}
+
+// DoRegisterTeam ...
+type DoRegisterTeam struct {
+	SeasonTeam
+}
+
+// GetSeasonTeams ...
+type GetSeasonTeams struct {
+	Team
+}
+
+// TableName ...
+func (GetSeasonTeams) TableName() string {
+	return "teams"
+}
+
+// DoUnregisterTeam ...
+type DoUnregisterTeam struct {
+	SeasonTeam
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package param
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/satori/uuid"
+)
+
+// ShootoutKick ...
+type ShootoutKick struct {
+	ID       uuid.UUID `json:"id"`
+	MatchID  uuid.UUID `json:"match_id"`
+	TeamID   uuid.UUID `json:"team_id"`
+	PlayerID uuid.UUID `json:"player_id"`
+	Sequence int       `json:"sequence"`
+	Scored   *bool     `json:"scored"`
+}
+
+// DoCreate ...
+type DoCreate struct {
+	ShootoutKick
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (doCreate DoCreate) Validate() error {
+	return validation.ValidateStruct(&doCreate,
+		// MatchID cannot be empty and should be in a valid uuid.
+		validation.Field(&doCreate.MatchID, validation.Required, is.UUIDv4),
+		// PlayerID cannot be empty and should be in a valid uuid.
+		validation.Field(&doCreate.PlayerID, validation.Required, is.UUIDv4),
+		// Scored cannot be empty.
+		validation.Field(&doCreate.Scored, validation.NotNil),
+	)
+}
+
+// GetShootoutKicks ...
+type GetShootoutKicks struct {
+	MatchID uuid.UUID `json:"match_id"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getShootoutKicks GetShootoutKicks) Validate() error {
+	return validation.ValidateStruct(&getShootoutKicks,
+		// MatchID cannot be empty and should be in a valid uuid.
+		validation.Field(&getShootoutKicks.MatchID, validation.Required, is.UUIDv4),
+	)
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shootoutkick
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/shootoutkick/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/shootoutkick/transporter"
+	"github.com/harunnryd/skeltun/internal/app/usecase"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// IShootoutKick is an interface that stores the methods that ShootoutKick struct will use.
+type IShootoutKick interface {
+	// DoCreate is used for record new shootout kick.
+	// It returns doCreateResp of transporter.DoCreate and any errors written.
+	DoCreate(w http.ResponseWriter, r *http.Request) (doCreateResp interface{}, err error)
+
+	// GetShootoutKicks is used for getting the kicks of a penalty shootout.
+	// It returns getShootoutKicksResp of []transporter.GetShootoutKicks and any errors written.
+	GetShootoutKicks(w http.ResponseWriter, r *http.Request) (getShootoutKicksResp interface{}, err error)
+}
+
+// ShootoutKick is an struct that implements IShootoutKick methods.
+type ShootoutKick struct {
+	config  config.IConfig
+	usecase usecase.IUseCase
+}
+
+// New it returns instance of ShootoutKick that implements IShootoutKick methods.
+func New(opts ...Option) IShootoutKick {
+	s := new(ShootoutKick)
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// DoCreate is used for record new shootout kick.
+// It returns doCreateResp of transporter.DoCreate and any errors written.
+func (shootoutkick *ShootoutKick) DoCreate(w http.ResponseWriter, r *http.Request) (doCreateResp interface{}, err error) {
+	doCreateParam := param.DoCreate{}
+	if err = json.NewDecoder(r.Body).Decode(&doCreateParam); err != nil {
+		return
+	}
+
+	doCreateParam.MatchID = uuid.FromStringOrNil(chi.URLParam(r, "match_id"))
+
+	if err = doCreateParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	doCreateResp = transporter.DoCreate{}
+	doCreateResp, err = shootoutkick.usecase.GetShootoutKick().DoCreate(r.Context(), doCreateParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return doCreateResp, nil
+}
+
+// GetShootoutKicks is used for getting the kicks of a penalty shootout.
+// It returns getShootoutKicksResp of []transporter.GetShootoutKicks and any errors written.
+func (shootoutkick *ShootoutKick) GetShootoutKicks(w http.ResponseWriter, r *http.Request) (getShootoutKicksResp interface{}, err error) {
+	getShootoutKicksParam := param.GetShootoutKicks{MatchID: uuid.FromStringOrNil(chi.URLParam(r, "match_id"))}
+
+	if err = getShootoutKicksParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getShootoutKicksResp = transporter.GetShootoutKicks{}
+	getShootoutKicksResp, err = shootoutkick.usecase.GetShootoutKick().GetShootoutKicks(r.Context(), getShootoutKicksParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getShootoutKicksResp, nil
+}
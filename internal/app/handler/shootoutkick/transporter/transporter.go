@@ -0,0 +1,34 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transporter
+
+import (
+	"github.com/satori/uuid"
+)
+
+// ShootoutKick ...
+type ShootoutKick struct {
+	ID       uuid.UUID `gorm:"primaryKey" json:"id"`
+	MatchID  uuid.UUID `json:"match_id"`
+	TeamID   uuid.UUID `json:"team_id"`
+	PlayerID uuid.UUID `json:"player_id"`
+	Sequence int       `json:"sequence"`
+	Scored   bool      `json:"scored"`
+}
+
+// DoCreate ...
+type DoCreate struct {
+	ShootoutKick
+}
+
+// GetShootoutKicks ...
+type GetShootoutKicks struct {
+	ShootoutKick
+}
+
+// TableName ...
+func (GetShootoutKicks) TableName() string {
+	return "shootout_kicks"
+}
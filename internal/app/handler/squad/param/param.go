@@ -0,0 +1,110 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package param
+
+import (
+	"errors"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/satori/uuid"
+)
+
+// nationalityFor is used for requiring the domestic nationality once a minimum of domestic players is set.
+func nationalityFor(minDomestic int) validation.RuleFunc {
+	return func(value interface{}) error {
+		if nationality, _ := value.(string); minDomestic > 0 && nationality == "" {
+			return errors.New("cannot be blank with a minimum of domestic players")
+		}
+		return nil
+	}
+}
+
+// Rules ...
+type Rules struct {
+	SeasonID     uuid.UUID `json:"season_id"`
+	MaxPlayers   int       `json:"max_players"`
+	MinHomegrown int       `json:"min_homegrown"`
+	MinDomestic  int       `json:"min_domestic"`
+	Nationality  string    `json:"nationality"`
+}
+
+// DoUpdateRules ...
+type DoUpdateRules struct {
+	Rules
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (doUpdateRules DoUpdateRules) Validate() error {
+	return validation.ValidateStruct(&doUpdateRules,
+		// SeasonID cannot be empty and should be in a valid uuid.
+		validation.Field(&doUpdateRules.SeasonID, validation.Required, is.UUIDv4),
+		// MaxPlayers cannot be negative, zero means no limit.
+		validation.Field(&doUpdateRules.MaxPlayers, validation.Min(0)),
+		// MinHomegrown cannot be negative, zero means no minimum.
+		validation.Field(&doUpdateRules.MinHomegrown, validation.Min(0)),
+		// MinDomestic cannot be negative, zero means no minimum.
+		validation.Field(&doUpdateRules.MinDomestic, validation.Min(0)),
+		// Nationality should be a three letters country code, given along with MinDomestic.
+		validation.Field(&doUpdateRules.Nationality, validation.Length(3, 3), is.UpperCase, is.Alpha, validation.By(nationalityFor(doUpdateRules.MinDomestic))),
+	)
+}
+
+// GetRules ...
+type GetRules struct {
+	SeasonID uuid.UUID `json:"season_id"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getRules GetRules) Validate() error {
+	return validation.ValidateStruct(&getRules,
+		// SeasonID cannot be empty and should be in a valid uuid.
+		validation.Field(&getRules.SeasonID, validation.Required, is.UUIDv4),
+	)
+}
+
+// GetTeamRules ...
+type GetTeamRules struct {
+	TeamID uuid.UUID `json:"team_id"`
+	Date   time.Time `json:"date"`
+}
+
+// GetSquad ...
+type GetSquad struct {
+	TeamID uuid.UUID `json:"team_id"`
+}
+
+// GetCompliance ...
+type GetCompliance struct {
+	TeamID uuid.UUID `json:"team_id"`
+	Date   time.Time `json:"-"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getCompliance GetCompliance) Validate() error {
+	return validation.ValidateStruct(&getCompliance,
+		// TeamID cannot be empty and should be in a valid uuid.
+		validation.Field(&getCompliance.TeamID, validation.Required, is.UUIDv4),
+	)
+}
+
+// Member ...
+type Member struct {
+	ID          uuid.UUID `json:"id"`
+	Nationality string    `json:"nationality"`
+	Homegrown   *bool     `json:"homegrown"`
+}
+
+// CheckSquad ...
+type CheckSquad struct {
+	TeamID uuid.UUID `json:"team_id"`
+	Date   time.Time `json:"date"`
+	In     *Member   `json:"in"`
+	Out    *Member   `json:"out"`
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package squad
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/squad/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/squad/transporter"
+	"github.com/harunnryd/skeltun/internal/app/usecase"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// ISquad is an interface that stores the methods that Squad struct will use.
+type ISquad interface {
+	// DoUpdateRules is used for record the squad rules of a season, replacing the previous ones.
+	// It returns doUpdateRulesResp of transporter.DoUpdateRules and any errors written.
+	DoUpdateRules(w http.ResponseWriter, r *http.Request) (doUpdateRulesResp interface{}, err error)
+
+	// GetRules is used for getting the squad rules of a season.
+	// It returns getRulesResp of transporter.GetRules and any errors written.
+	GetRules(w http.ResponseWriter, r *http.Request) (getRulesResp interface{}, err error)
+
+	// GetCompliance is used for measuring the squad of a team against the rules of every season it plays in.
+	// It returns getComplianceResp of transporter.GetCompliance and any errors written.
+	GetCompliance(w http.ResponseWriter, r *http.Request) (getComplianceResp interface{}, err error)
+}
+
+// Squad is an struct that implements ISquad methods.
+type Squad struct {
+	config  config.IConfig
+	usecase usecase.IUseCase
+}
+
+// New it returns instance of Squad that implements ISquad methods.
+func New(opts ...Option) ISquad {
+	s := new(Squad)
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// DoUpdateRules is used for record the squad rules of a season, replacing the previous ones.
+// It returns doUpdateRulesResp of transporter.DoUpdateRules and any errors written.
+func (squad *Squad) DoUpdateRules(w http.ResponseWriter, r *http.Request) (doUpdateRulesResp interface{}, err error) {
+	doUpdateRulesParam := param.DoUpdateRules{}
+	if err = json.NewDecoder(r.Body).Decode(&doUpdateRulesParam); err != nil {
+		return
+	}
+
+	doUpdateRulesParam.SeasonID = uuid.FromStringOrNil(chi.URLParam(r, "season_id"))
+
+	if err = doUpdateRulesParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	doUpdateRulesResp = transporter.DoUpdateRules{}
+	doUpdateRulesResp, err = squad.usecase.GetSquad().DoUpdateRules(r.Context(), doUpdateRulesParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return doUpdateRulesResp, nil
+}
+
+// GetRules is used for getting the squad rules of a season.
+// It returns getRulesResp of transporter.GetRules and any errors written.
+func (squad *Squad) GetRules(w http.ResponseWriter, r *http.Request) (getRulesResp interface{}, err error) {
+	getRulesParam := param.GetRules{SeasonID: uuid.FromStringOrNil(chi.URLParam(r, "season_id"))}
+
+	if err = getRulesParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getRulesResp = transporter.GetRules{}
+	getRulesResp, err = squad.usecase.GetSquad().GetRules(r.Context(), getRulesParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getRulesResp, nil
+}
+
+// GetCompliance is used for measuring the squad of a team against the rules of every season it plays in.
+// It returns getComplianceResp of transporter.GetCompliance and any errors written.
+func (squad *Squad) GetCompliance(w http.ResponseWriter, r *http.Request) (getComplianceResp interface{}, err error) {
+	getComplianceParam := param.GetCompliance{TeamID: uuid.FromStringOrNil(chi.URLParam(r, "team_id"))}
+
+	if err = getComplianceParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getComplianceResp = transporter.GetCompliance{}
+	getComplianceResp, err = squad.usecase.GetSquad().GetCompliance(r.Context(), getComplianceParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getComplianceResp, nil
+}
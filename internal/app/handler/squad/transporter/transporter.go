@@ -0,0 +1,67 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transporter
+
+import (
+	"github.com/satori/uuid"
+)
+
+// Rules ...
+type Rules struct {
+	SeasonID     uuid.UUID `gorm:"primaryKey" json:"season_id"`
+	MaxPlayers   int       `json:"max_players"`
+	MinHomegrown int       `json:"min_homegrown"`
+	MinDomestic  int       `json:"min_domestic"`
+	Nationality  string    `json:"nationality,omitempty"`
+}
+
+// DoUpdateRules ...
+type DoUpdateRules struct {
+	Rules
+}
+
+// GetRules ...
+type GetRules struct {
+	Rules
+}
+
+// TableName ...
+func (GetRules) TableName() string {
+	return "squad_rules"
+}
+
+// GetTeamRules ...
+type GetTeamRules struct {
+	Rules
+	SeasonName string `json:"season_name"`
+}
+
+// GetSquad ...
+type GetSquad struct {
+	ID          uuid.UUID `gorm:"primaryKey" json:"id"`
+	Nationality string    `json:"nationality"`
+	Homegrown   *bool     `json:"homegrown"`
+}
+
+// TableName ...
+func (GetSquad) TableName() string {
+	return "players"
+}
+
+// Compliance ...
+type Compliance struct {
+	SeasonID   uuid.UUID `json:"season_id"`
+	SeasonName string    `json:"season_name"`
+	Rule       string    `json:"rule"`
+	Limit      int       `json:"limit"`
+	Count      int       `json:"count"`
+	Passed     bool      `json:"passed"`
+}
+
+// GetCompliance ...
+type GetCompliance struct {
+	TeamID uuid.UUID    `json:"team_id"`
+	Rules  []Compliance `json:"rules"`
+}
@@ -0,0 +1,26 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package param
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/satori/uuid"
+)
+
+// GetStandings ...
+type GetStandings struct {
+	SeasonID uuid.UUID   `json:"season_id"`
+	TeamIDs  []uuid.UUID `json:"-"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getStandings GetStandings) Validate() error {
+	return validation.ValidateStruct(&getStandings,
+		// SeasonID cannot be empty and should be in a valid uuid.
+		validation.Field(&getStandings.SeasonID, validation.Required, is.UUIDv4),
+	)
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package standing
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/standing/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/standing/transporter"
+	"github.com/harunnryd/skeltun/internal/app/usecase"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// IStanding is an interface that stores the methods that Standing struct will use.
+type IStanding interface {
+	// GetStandings is used for getting the league table of a season.
+	// It returns getStandingsResp of []transporter.GetStandings and any errors written.
+	GetStandings(w http.ResponseWriter, r *http.Request) (getStandingsResp interface{}, err error)
+}
+
+// Standing is an struct that implements IStanding methods.
+type Standing struct {
+	config  config.IConfig
+	usecase usecase.IUseCase
+}
+
+// New it returns instance of Standing that implements IStanding methods.
+func New(opts ...Option) IStanding {
+	s := new(Standing)
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// GetStandings is used for getting the league table of a season.
+// It returns getStandingsResp of []transporter.GetStandings and any errors written.
+func (standing *Standing) GetStandings(w http.ResponseWriter, r *http.Request) (getStandingsResp interface{}, err error) {
+	getStandingsParam := param.GetStandings{SeasonID: uuid.FromStringOrNil(chi.URLParam(r, "season_id"))}
+
+	if err = getStandingsParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getStandingsResp = transporter.GetStandings{}
+	getStandingsResp, err = standing.usecase.GetStanding().GetStandings(r.Context(), getStandingsParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getStandingsResp, nil
+}
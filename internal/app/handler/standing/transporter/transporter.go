@@ -0,0 +1,32 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transporter
+
+import (
+	"github.com/satori/uuid"
+)
+
+// Standing ...
+type Standing struct {
+	Rank           int       `json:"rank"`
+	TeamID         uuid.UUID `json:"team_id"`
+	TeamName       string    `json:"team_name"`
+	Played         int       `json:"played"`
+	Won            int       `json:"won"`
+	Drawn          int       `json:"drawn"`
+	Lost           int       `json:"lost"`
+	GoalsFor       int       `json:"goals_for"`
+	GoalsAgainst   int       `json:"goals_against"`
+	GoalDifference int       `json:"goal_difference"`
+	Points         int       `json:"points"`
+	AwayGoalsFor   int       `json:"away_goals_for"`
+	FairPlayPoints int       `json:"fair_play_points"`
+	SeparatedBy    string    `json:"separated_by,omitempty"`
+}
+
+// GetStandings ...
+type GetStandings struct {
+	Standing
+}
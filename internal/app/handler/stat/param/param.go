@@ -0,0 +1,63 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package param
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/satori/uuid"
+)
+
+// Stat ...
+type Stat struct {
+	PlayerID      uuid.UUID `json:"player_id"`
+	Appearances   int       `json:"appearances"`
+	Starts        int       `json:"starts"`
+	MinutesPlayed int       `json:"minutes_played"`
+	Goals         int       `json:"goals"`
+	Assists       int       `json:"assists"`
+	YellowCards   int       `json:"yellow_cards"`
+	RedCards      int       `json:"red_cards"`
+	CleanSheets   int       `json:"clean_sheets"`
+}
+
+// GetPlayerStats ...
+type GetPlayerStats struct {
+	PlayerID uuid.UUID `json:"player_id"`
+	SeasonID uuid.UUID `json:"season_id"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getPlayerStats GetPlayerStats) Validate() error {
+	return validation.ValidateStruct(&getPlayerStats,
+		// PlayerID cannot be empty and should be in a valid uuid.
+		validation.Field(&getPlayerStats.PlayerID, validation.Required, is.UUIDv4),
+		// SeasonID cannot be empty and should be in a valid uuid.
+		validation.Field(&getPlayerStats.SeasonID, validation.Required, is.UUIDv4),
+	)
+}
+
+// DoRefresh ...
+type DoRefresh struct {
+	MatchID  uuid.UUID `json:"match_id"`
+	SeasonID uuid.UUID `json:"-"`
+	Stats    []Stat    `json:"-"`
+}
+
+// GetMatches ...
+type GetMatches struct {
+	SeasonID uuid.UUID `json:"season_id"`
+}
+
+// GetLineupPlayers ...
+type GetLineupPlayers struct {
+	MatchIDs []uuid.UUID `json:"match_ids"`
+}
+
+// GetMatchEvents ...
+type GetMatchEvents struct {
+	MatchIDs []uuid.UUID `json:"match_ids"`
+}
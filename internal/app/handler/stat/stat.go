@@ -0,0 +1,64 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/stat/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/stat/transporter"
+	"github.com/harunnryd/skeltun/internal/app/usecase"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// IStat is an interface that stores the methods that Stat struct will use.
+type IStat interface {
+	// GetPlayerStats is used for getting the stats of a player over a season.
+	// It returns getPlayerStatsResp of transporter.GetPlayerStats and any errors written.
+	GetPlayerStats(w http.ResponseWriter, r *http.Request) (getPlayerStatsResp interface{}, err error)
+}
+
+// Stat is an struct that implements IStat methods.
+type Stat struct {
+	config  config.IConfig
+	usecase usecase.IUseCase
+}
+
+// New it returns instance of Stat that implements IStat methods.
+func New(opts ...Option) IStat {
+	s := new(Stat)
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// GetPlayerStats is used for getting the stats of a player over a season.
+// It returns getPlayerStatsResp of transporter.GetPlayerStats and any errors written.
+func (stat *Stat) GetPlayerStats(w http.ResponseWriter, r *http.Request) (getPlayerStatsResp interface{}, err error) {
+	getPlayerStatsParam := param.GetPlayerStats{
+		PlayerID: uuid.FromStringOrNil(chi.URLParam(r, "player_id")),
+		SeasonID: uuid.FromStringOrNil(r.URL.Query().Get("season_id")),
+	}
+
+	if err = getPlayerStatsParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getPlayerStatsResp = transporter.GetPlayerStats{}
+	getPlayerStatsResp, err = stat.usecase.GetStat().GetPlayerStats(r.Context(), getPlayerStatsParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getPlayerStatsResp, nil
+}
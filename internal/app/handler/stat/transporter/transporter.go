@@ -0,0 +1,84 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transporter
+
+import (
+	"github.com/satori/uuid"
+)
+
+// Stat ...
+type Stat struct {
+	SeasonID      uuid.UUID `gorm:"primaryKey" json:"season_id"`
+	PlayerID      uuid.UUID `gorm:"primaryKey" json:"player_id"`
+	Appearances   int       `json:"appearances"`
+	Starts        int       `json:"starts"`
+	MinutesPlayed int       `json:"minutes_played"`
+	Goals         int       `json:"goals"`
+	Assists       int       `json:"assists"`
+	YellowCards   int       `json:"yellow_cards"`
+	RedCards      int       `json:"red_cards"`
+	CleanSheets   int       `json:"clean_sheets"`
+}
+
+// GetPlayerStats ...
+type GetPlayerStats struct {
+	Stat
+}
+
+// TableName ...
+func (GetPlayerStats) TableName() string {
+	return "player_stats"
+}
+
+// DoRefresh ...
+type DoRefresh struct {
+	SeasonID uuid.UUID `json:"season_id"`
+	Players  int       `json:"players"`
+}
+
+// GetMatches ...
+type GetMatches struct {
+	ID                 uuid.UUID `gorm:"primaryKey" json:"id"`
+	HomeTeamID         uuid.UUID `json:"home_team_id"`
+	AwayTeamID         uuid.UUID `json:"away_team_id"`
+	HomeScore          int       `json:"home_score"`
+	AwayScore          int       `json:"away_score"`
+	HomeExtraTimeScore int       `json:"home_extra_time_score"`
+	AwayExtraTimeScore int       `json:"away_extra_time_score"`
+	ExtraTime          bool      `json:"extra_time"`
+}
+
+// TableName ...
+func (GetMatches) TableName() string {
+	return "matches"
+}
+
+// GetLineupPlayers ...
+type GetLineupPlayers struct {
+	MatchID  uuid.UUID `json:"match_id"`
+	TeamID   uuid.UUID `json:"team_id"`
+	PlayerID uuid.UUID `json:"player_id"`
+	Starter  bool      `json:"starter"`
+}
+
+// TableName ...
+func (GetLineupPlayers) TableName() string {
+	return "lineup_players"
+}
+
+// GetMatchEvents ...
+type GetMatchEvents struct {
+	MatchID         uuid.UUID  `json:"match_id"`
+	TeamID          uuid.UUID  `json:"team_id"`
+	PlayerID        uuid.UUID  `json:"player_id"`
+	RelatedPlayerID *uuid.UUID `json:"related_player_id"`
+	Type            string     `json:"type"`
+	Minute          int        `json:"minute"`
+}
+
+// TableName ...
+func (GetMatchEvents) TableName() string {
+	return "match_events"
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package param
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/satori/uuid"
+)
+
+// Suspension ...
+type Suspension struct {
+	PlayerID         uuid.UUID  `json:"player_id"`
+	SeasonID         uuid.UUID  `json:"season_id"`
+	MatchID          *uuid.UUID `json:"match_id"`
+	MatchEventID     *uuid.UUID `json:"match_event_id"`
+	Reason           string     `json:"reason"`
+	MatchesRemaining int        `json:"matches_remaining"`
+}
+
+// DoCreate ...
+type DoCreate struct {
+	Suspension
+}
+
+// GetSuspensions ...
+type GetSuspensions struct {
+	PlayerID uuid.UUID `json:"player_id"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getSuspensions GetSuspensions) Validate() error {
+	return validation.ValidateStruct(&getSuspensions,
+		// PlayerID cannot be empty and should be in a valid uuid.
+		validation.Field(&getSuspensions.PlayerID, validation.Required, is.UUIDv4),
+	)
+}
+
+// GetPenalty ...
+type GetPenalty struct {
+	SeasonID *uuid.UUID `json:"season_id"`
+	PlayerID uuid.UUID  `json:"player_id"`
+	Type     string     `json:"type"`
+}
+
+// CountYellowCards ...
+type CountYellowCards struct {
+	PlayerID uuid.UUID `json:"player_id"`
+	SeasonID uuid.UUID `json:"season_id"`
+}
+
+// DoServe ...
+type DoServe struct {
+	MatchID  uuid.UUID   `json:"match_id"`
+	SeasonID uuid.UUID   `json:"-"`
+	TeamIDs  []uuid.UUID `json:"-"`
+}
+
+// GetTeamSuspensions ...
+type GetTeamSuspensions struct {
+	TeamID uuid.UUID `json:"team_id"`
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package suspension
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/suspension/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/suspension/transporter"
+	"github.com/harunnryd/skeltun/internal/app/usecase"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// ISuspension is an interface that stores the methods that Suspension struct will use.
+type ISuspension interface {
+	// GetSuspensions is used for getting all suspensions of a player, the latest first.
+	// It returns getSuspensionsResp of []transporter.GetSuspensions and any errors written.
+	GetSuspensions(w http.ResponseWriter, r *http.Request) (getSuspensionsResp interface{}, err error)
+}
+
+// Suspension is an struct that implements ISuspension methods.
+type Suspension struct {
+	config  config.IConfig
+	usecase usecase.IUseCase
+}
+
+// New it returns instance of Suspension that implements ISuspension methods.
+func New(opts ...Option) ISuspension {
+	s := new(Suspension)
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// GetSuspensions is used for getting all suspensions of a player, the latest first.
+// It returns getSuspensionsResp of []transporter.GetSuspensions and any errors written.
+func (suspension *Suspension) GetSuspensions(w http.ResponseWriter, r *http.Request) (getSuspensionsResp interface{}, err error) {
+	getSuspensionsParam := param.GetSuspensions{PlayerID: uuid.FromStringOrNil(chi.URLParam(r, "player_id"))}
+
+	if err = getSuspensionsParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getSuspensionsResp = transporter.GetSuspensions{}
+	getSuspensionsResp, err = suspension.usecase.GetSuspension().GetSuspensions(r.Context(), getSuspensionsParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getSuspensionsResp, nil
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transporter
+
+import (
+	"github.com/satori/uuid"
+)
+
+// Suspension ...
+type Suspension struct {
+	ID               uuid.UUID  `gorm:"primaryKey" json:"id"`
+	PlayerID         uuid.UUID  `json:"player_id"`
+	SeasonID         uuid.UUID  `json:"season_id"`
+	MatchID          *uuid.UUID `json:"match_id"`
+	Reason           string     `json:"reason"`
+	MatchesRemaining int        `json:"matches_remaining"`
+}
+
+// DoCreate ...
+type DoCreate struct {
+	Suspension
+}
+
+// GetSuspensions ...
+type GetSuspensions struct {
+	Suspension
+}
+
+// TableName ...
+func (GetSuspensions) TableName() string {
+	return "suspensions"
+}
+
+// GetPenalty ...
+type GetPenalty struct {
+	Reason           string `json:"reason"`
+	MatchesRemaining int    `json:"matches_remaining"`
+}
+
+// CountYellowCards ...
+type CountYellowCards struct {
+	Total int64 `json:"total"`
+}
+
+// DoServe ...
+type DoServe struct {
+	Served int64 `json:"served"`
+}
+
+// GetTeamSuspensions ...
+type GetTeamSuspensions struct {
+	Suspension
+	Name string `json:"name"`
+}
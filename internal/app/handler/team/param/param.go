@@ -13,8 +13,10 @@ import (
 
 // Team ...
 type Team struct {
-	ID   uuid.UUID `json:"id"`
-	Name string    `json:"name"`
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	Association string     `json:"association"`
+	VenueID     *uuid.UUID `json:"venue_id"`
 }
 
 // Pagination ...
@@ -56,6 +58,10 @@ func (doCreate DoCreate) Validate() error {
 	return validation.ValidateStruct(&doCreate,
 		// Name cannot be empty and length must be between 1 and 150.
 		validation.Field(&doCreate.Name, validation.Required, validation.Length(1, 150)),
+		// Association should be an uppercase code of 2 up to 10 letters.
+		validation.Field(&doCreate.Association, validation.Length(2, 10), is.UpperCase, is.Alpha),
+		// VenueID should be in a valid uuid.
+		validation.Field(&doCreate.VenueID, is.UUIDv4),
 	)
 }
 
@@ -75,6 +81,10 @@ func (doUpdate DoUpdate) Validate() error {
 	return validation.ValidateStruct(&doUpdate,
 		// Name cannot be empty and length must be between 1 and 150.
 		validation.Field(&doUpdate.Name, validation.Required, validation.Length(1, 150)),
+		// Association should be an uppercase code of 2 up to 10 letters.
+		validation.Field(&doUpdate.Association, validation.Length(2, 10), is.UpperCase, is.Alpha),
+		// VenueID should be in a valid uuid.
+		validation.Field(&doUpdate.VenueID, is.UUIDv4),
 	)
 }
 
@@ -8,8 +8,10 @@ import "github.com/satori/uuid"
 
 // Team ...
 type Team struct {
-	ID   uuid.UUID `json:"id"`
-	Name string    `json:"name"`
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	Association string     `json:"association"`
+	VenueID     *uuid.UUID `json:"venue_id"`
 }
 
 // Player ...
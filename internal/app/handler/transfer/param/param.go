@@ -0,0 +1,173 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package param
+
+import (
+	"errors"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	contractParam "github.com/harunnryd/skeltun/internal/app/handler/contract/param"
+	loanParam "github.com/harunnryd/skeltun/internal/app/handler/loan/param"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/satori/uuid"
+)
+
+// noFeeOn is used for rejecting a fee on a free transfer.
+func noFeeOn(transferType string) validation.RuleFunc {
+	return func(value interface{}) error {
+		if fee, _ := value.(int64); transferType == model.TransferTypeFree && fee != 0 {
+			return errors.New("must be empty on a free transfer")
+		}
+		return nil
+	}
+}
+
+// loanTermsOn is used for requiring the loan terms on a loan and only on a loan.
+// A loan has to end in the future, the transfer is dated today.
+func loanTermsOn(transferType string) validation.RuleFunc {
+	return func(value interface{}) error {
+		terms, _ := value.(*loanParam.Terms)
+		if transferType != model.TransferTypeLoan {
+			if terms != nil {
+				return errors.New("must be empty unless the transfer is a loan")
+			}
+			return nil
+		}
+
+		if terms == nil {
+			return errors.New("cannot be blank on a loan")
+		}
+
+		if !terms.EndsAt.After(time.Now()) {
+			return errors.New("should end in the future")
+		}
+		return nil
+	}
+}
+
+// contractTermsOn is used for requiring the terms of the contract signed with the new team on every
+// transfer but a loan, the player keeps the contract with the parent team while on loan.
+// A contract has to end in the future, the transfer is dated today.
+func contractTermsOn(transferType string) validation.RuleFunc {
+	return func(value interface{}) error {
+		terms, _ := value.(*contractParam.Terms)
+		if transferType == model.TransferTypeLoan {
+			if terms != nil {
+				return errors.New("must be empty on a loan")
+			}
+			return nil
+		}
+
+		if terms == nil {
+			return errors.New("cannot be blank")
+		}
+
+		if !terms.EndsAt.After(time.Now()) {
+			return errors.New("should end in the future")
+		}
+		return nil
+	}
+}
+
+// DoCreate ...
+type DoCreate struct {
+	PlayerID   uuid.UUID            `json:"player_id"`
+	FromTeamID *uuid.UUID           `json:"-"`
+	ToTeamID   uuid.UUID            `json:"to_team_id"`
+	Date       time.Time            `json:"-"`
+	Fee        int64                `json:"fee"`
+	Type       string               `json:"type"`
+	Loan       *loanParam.Terms     `json:"loan"`
+	Contract   *contractParam.Terms `json:"contract"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (doCreate DoCreate) Validate() error {
+	return validation.ValidateStruct(&doCreate,
+		// PlayerID cannot be empty and should be in a valid uuid.
+		validation.Field(&doCreate.PlayerID, validation.Required, is.UUIDv4),
+		// ToTeamID cannot be empty and should be in a valid uuid.
+		validation.Field(&doCreate.ToTeamID, validation.Required, is.UUIDv4),
+		// Fee cannot be negative and should be empty on a free transfer.
+		validation.Field(&doCreate.Fee, validation.Min(int64(0)), validation.By(noFeeOn(doCreate.Type))),
+		// Type cannot be empty and should be either permanent, loan or free.
+		validation.Field(&doCreate.Type, validation.Required, validation.In(model.TransferTypes...)),
+		// Loan should be given on a loan only and end in the future.
+		validation.Field(&doCreate.Loan, validation.By(loanTermsOn(doCreate.Type))),
+		// Contract should be given on every transfer but a loan and end in the future.
+		validation.Field(&doCreate.Contract, validation.By(contractTermsOn(doCreate.Type))),
+	)
+}
+
+// GetTransfers ...
+type GetTransfers struct {
+	PlayerID uuid.UUID `json:"player_id"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getTransfers GetTransfers) Validate() error {
+	return validation.ValidateStruct(&getTransfers,
+		// PlayerID cannot be empty and should be in a valid uuid.
+		validation.Field(&getTransfers.PlayerID, validation.Required, is.UUIDv4),
+	)
+}
+
+// GetCareer ...
+type GetCareer struct {
+	PlayerID uuid.UUID `json:"player_id"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getCareer GetCareer) Validate() error {
+	return validation.ValidateStruct(&getCareer,
+		// PlayerID cannot be empty and should be in a valid uuid.
+		validation.Field(&getCareer.PlayerID, validation.Required, is.UUIDv4),
+	)
+}
+
+// DoCreateWindow ...
+type DoCreateWindow struct {
+	SeasonID uuid.UUID `json:"season_id"`
+	OpensAt  time.Time `json:"opens_at"`
+	ClosesAt time.Time `json:"closes_at"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (doCreateWindow DoCreateWindow) Validate() error {
+	return validation.ValidateStruct(&doCreateWindow,
+		// SeasonID cannot be empty and should be in a valid uuid.
+		validation.Field(&doCreateWindow.SeasonID, validation.Required, is.UUIDv4),
+		// OpensAt cannot be empty.
+		validation.Field(&doCreateWindow.OpensAt, validation.Required),
+		// ClosesAt cannot be empty and should be after OpensAt.
+		validation.Field(&doCreateWindow.ClosesAt, validation.Required, validation.Min(doCreateWindow.OpensAt).Exclusive().Error("must be after opens_at")),
+	)
+}
+
+// GetWindows ...
+type GetWindows struct {
+	SeasonID uuid.UUID `json:"season_id"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getWindows GetWindows) Validate() error {
+	return validation.ValidateStruct(&getWindows,
+		// SeasonID cannot be empty and should be in a valid uuid.
+		validation.Field(&getWindows.SeasonID, validation.Required, is.UUIDv4),
+	)
+}
+
+// GetOpenWindow ...
+type GetOpenWindow struct {
+	TeamID uuid.UUID `json:"team_id"`
+	Date   time.Time `json:"date"`
+}
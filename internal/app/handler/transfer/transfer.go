@@ -0,0 +1,172 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transfer
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/transfer/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/transfer/transporter"
+	"github.com/harunnryd/skeltun/internal/app/usecase"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// ITransfer is an interface that stores the methods that Transfer struct will use.
+type ITransfer interface {
+	// DoCreate is used for moving a player to another team.
+	// It returns doCreateResp of transporter.DoCreate and any errors written.
+	DoCreate(w http.ResponseWriter, r *http.Request) (doCreateResp interface{}, err error)
+
+	// GetTransfers is used for getting all transfers of a player, the oldest first.
+	// It returns getTransfersResp of []transporter.GetTransfers and any errors written.
+	GetTransfers(w http.ResponseWriter, r *http.Request) (getTransfersResp interface{}, err error)
+
+	// GetCareer is used for getting the club history of a player.
+	// It returns getCareerResp of transporter.GetCareer and any errors written.
+	GetCareer(w http.ResponseWriter, r *http.Request) (getCareerResp interface{}, err error)
+
+	// DoCreateWindow is used for record new transfer window of a season.
+	// It returns doCreateWindowResp of transporter.DoCreateWindow and any errors written.
+	DoCreateWindow(w http.ResponseWriter, r *http.Request) (doCreateWindowResp interface{}, err error)
+
+	// GetWindows is used for getting all transfer windows of a season.
+	// It returns getWindowsResp of []transporter.GetWindows and any errors written.
+	GetWindows(w http.ResponseWriter, r *http.Request) (getWindowsResp interface{}, err error)
+}
+
+// Transfer is an struct that implements ITransfer methods.
+type Transfer struct {
+	config  config.IConfig
+	usecase usecase.IUseCase
+}
+
+// New it returns instance of Transfer that implements ITransfer methods.
+func New(opts ...Option) ITransfer {
+	t := new(Transfer)
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// DoCreate is used for moving a player to another team.
+// It returns doCreateResp of transporter.DoCreate and any errors written.
+func (transfer *Transfer) DoCreate(w http.ResponseWriter, r *http.Request) (doCreateResp interface{}, err error) {
+	doCreateParam := param.DoCreate{}
+	if err = json.NewDecoder(r.Body).Decode(&doCreateParam); err != nil {
+		return
+	}
+
+	doCreateParam.PlayerID = uuid.FromStringOrNil(chi.URLParam(r, "player_id"))
+
+	if err = doCreateParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	doCreateResp = transporter.DoCreate{}
+	doCreateResp, err = transfer.usecase.GetTransfer().DoCreate(r.Context(), doCreateParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return doCreateResp, nil
+}
+
+// GetTransfers is used for getting all transfers of a player, the oldest first.
+// It returns getTransfersResp of []transporter.GetTransfers and any errors written.
+func (transfer *Transfer) GetTransfers(w http.ResponseWriter, r *http.Request) (getTransfersResp interface{}, err error) {
+	getTransfersParam := param.GetTransfers{PlayerID: uuid.FromStringOrNil(chi.URLParam(r, "player_id"))}
+
+	if err = getTransfersParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getTransfersResp = transporter.GetTransfers{}
+	getTransfersResp, err = transfer.usecase.GetTransfer().GetTransfers(r.Context(), getTransfersParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getTransfersResp, nil
+}
+
+// GetCareer is used for getting the club history of a player.
+// It returns getCareerResp of transporter.GetCareer and any errors written.
+func (transfer *Transfer) GetCareer(w http.ResponseWriter, r *http.Request) (getCareerResp interface{}, err error) {
+	getCareerParam := param.GetCareer{PlayerID: uuid.FromStringOrNil(chi.URLParam(r, "player_id"))}
+
+	if err = getCareerParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getCareerResp = transporter.GetCareer{}
+	getCareerResp, err = transfer.usecase.GetTransfer().GetCareer(r.Context(), getCareerParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getCareerResp, nil
+}
+
+// DoCreateWindow is used for record new transfer window of a season.
+// It returns doCreateWindowResp of transporter.DoCreateWindow and any errors written.
+func (transfer *Transfer) DoCreateWindow(w http.ResponseWriter, r *http.Request) (doCreateWindowResp interface{}, err error) {
+	doCreateWindowParam := param.DoCreateWindow{}
+	if err = json.NewDecoder(r.Body).Decode(&doCreateWindowParam); err != nil {
+		return
+	}
+
+	doCreateWindowParam.SeasonID = uuid.FromStringOrNil(chi.URLParam(r, "season_id"))
+
+	if err = doCreateWindowParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	doCreateWindowResp = transporter.DoCreateWindow{}
+	doCreateWindowResp, err = transfer.usecase.GetTransfer().DoCreateWindow(r.Context(), doCreateWindowParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return doCreateWindowResp, nil
+}
+
+// GetWindows is used for getting all transfer windows of a season.
+// It returns getWindowsResp of []transporter.GetWindows and any errors written.
+func (transfer *Transfer) GetWindows(w http.ResponseWriter, r *http.Request) (getWindowsResp interface{}, err error) {
+	getWindowsParam := param.GetWindows{SeasonID: uuid.FromStringOrNil(chi.URLParam(r, "season_id"))}
+
+	if err = getWindowsParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getWindowsResp = transporter.GetWindows{}
+	getWindowsResp, err = transfer.usecase.GetTransfer().GetWindows(r.Context(), getWindowsParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getWindowsResp, nil
+}
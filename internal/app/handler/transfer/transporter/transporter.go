@@ -0,0 +1,92 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transporter
+
+import (
+	"time"
+
+	contractTransporter "github.com/harunnryd/skeltun/internal/app/handler/contract/transporter"
+	loanTransporter "github.com/harunnryd/skeltun/internal/app/handler/loan/transporter"
+	"github.com/satori/uuid"
+)
+
+// Transfer ...
+type Transfer struct {
+	ID         uuid.UUID  `gorm:"primaryKey" json:"id"`
+	PlayerID   uuid.UUID  `json:"player_id"`
+	FromTeamID *uuid.UUID `json:"from_team_id"`
+	ToTeamID   uuid.UUID  `json:"to_team_id"`
+	Date       time.Time  `json:"date"`
+	Fee        int64      `json:"fee"`
+	Type       string     `json:"type"`
+}
+
+// DoCreate ...
+type DoCreate struct {
+	Transfer
+	Loan     *loanTransporter.Loan         `json:"loan,omitempty"`
+	Contract *contractTransporter.Contract `json:"contract,omitempty"`
+}
+
+// GetTransfers ...
+type GetTransfers struct {
+	Transfer
+	FromTeamName string `json:"from_team_name,omitempty"`
+	ToTeamName   string `json:"to_team_name"`
+}
+
+// TableName ...
+func (GetTransfers) TableName() string {
+	return "transfers"
+}
+
+// Spell ...
+type Spell struct {
+	TeamID   uuid.UUID  `json:"team_id"`
+	TeamName string     `json:"team_name"`
+	From     *time.Time `json:"from"`
+	Until    *time.Time `json:"until"`
+	Type     string     `json:"type,omitempty"`
+}
+
+// GetCareer ...
+type GetCareer struct {
+	PlayerID   uuid.UUID `json:"player_id"`
+	PlayerName string    `json:"player_name"`
+	Spells     []Spell   `json:"spells"`
+}
+
+// Window ...
+type Window struct {
+	ID       uuid.UUID `gorm:"primaryKey" json:"id"`
+	SeasonID uuid.UUID `json:"season_id"`
+	OpensAt  time.Time `json:"opens_at"`
+	ClosesAt time.Time `json:"closes_at"`
+}
+
+// DoCreateWindow ...
+type DoCreateWindow struct {
+	Window
+}
+
+// GetWindows ...
+type GetWindows struct {
+	Window
+}
+
+// TableName ...
+func (GetWindows) TableName() string {
+	return "transfer_windows"
+}
+
+// GetOpenWindow ...
+type GetOpenWindow struct {
+	Window
+}
+
+// TableName ...
+func (GetOpenWindow) TableName() string {
+	return "transfer_windows"
+}
@@ -0,0 +1,28 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package param
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/satori/uuid"
+)
+
+// GetTravel ...
+type GetTravel struct {
+	TeamID   uuid.UUID `json:"team_id"`
+	SeasonID uuid.UUID `json:"season_id"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getTravel GetTravel) Validate() error {
+	return validation.ValidateStruct(&getTravel,
+		// TeamID cannot be empty and should be in a valid uuid.
+		validation.Field(&getTravel.TeamID, validation.Required, is.UUIDv4),
+		// SeasonID cannot be empty and should be in a valid uuid.
+		validation.Field(&getTravel.SeasonID, validation.Required, is.UUIDv4),
+	)
+}
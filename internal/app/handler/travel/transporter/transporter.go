@@ -0,0 +1,48 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transporter
+
+import (
+	"time"
+
+	"github.com/satori/uuid"
+)
+
+// Trip ...
+type Trip struct {
+	MatchID    uuid.UUID  `json:"match_id"`
+	OpponentID uuid.UUID  `json:"opponent_id"`
+	KickoffAt  time.Time  `json:"kickoff_at"`
+	Venue      string     `json:"venue"`
+	VenueID    *uuid.UUID `json:"venue_id"`
+	Kilometres *float64   `json:"kilometres"`
+}
+
+// Rest ...
+type Rest struct {
+	FromMatchID uuid.UUID `json:"from_match_id"`
+	ToMatchID   uuid.UUID `json:"to_match_id"`
+	Days        int       `json:"days"`
+	Congested   bool      `json:"congested"`
+}
+
+// Stretch ...
+type Stretch struct {
+	StartedAt time.Time   `json:"started_at"`
+	EndedAt   time.Time   `json:"ended_at"`
+	MatchIDs  []uuid.UUID `json:"match_ids"`
+}
+
+// GetTravel ...
+type GetTravel struct {
+	TeamID             uuid.UUID `json:"team_id"`
+	SeasonID           uuid.UUID `json:"season_id"`
+	MinimumRestDays    int       `json:"minimum_rest_days"`
+	TotalKilometres    float64   `json:"total_kilometres"`
+	UnknownGroundTrips int       `json:"unknown_ground_trips"`
+	Trips              []Trip    `json:"trips"`
+	Rests              []Rest    `json:"rests"`
+	Congestion         []Stretch `json:"congestion"`
+}
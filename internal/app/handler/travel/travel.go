@@ -0,0 +1,64 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package travel
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/travel/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/travel/transporter"
+	"github.com/harunnryd/skeltun/internal/app/usecase"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// ITravel is an interface that stores the methods that Travel struct will use.
+type ITravel interface {
+	// GetTravel is used for getting the away trips of a team over a season and the rest it gets between matches.
+	// It returns getTravelResp of transporter.GetTravel and any errors written.
+	GetTravel(w http.ResponseWriter, r *http.Request) (getTravelResp interface{}, err error)
+}
+
+// Travel is an struct that implements ITravel methods.
+type Travel struct {
+	config  config.IConfig
+	usecase usecase.IUseCase
+}
+
+// New it returns instance of Travel that implements ITravel methods.
+func New(opts ...Option) ITravel {
+	t := new(Travel)
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// GetTravel is used for getting the away trips of a team over a season and the rest it gets between matches.
+// It returns getTravelResp of transporter.GetTravel and any errors written.
+func (travel *Travel) GetTravel(w http.ResponseWriter, r *http.Request) (getTravelResp interface{}, err error) {
+	getTravelParam := param.GetTravel{
+		TeamID:   uuid.FromStringOrNil(chi.URLParam(r, "team_id")),
+		SeasonID: uuid.FromStringOrNil(r.URL.Query().Get("season_id")),
+	}
+
+	if err = getTravelParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getTravelResp = transporter.GetTravel{}
+	getTravelResp, err = travel.usecase.GetTravel().GetTravel(r.Context(), getTravelParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getTravelResp, nil
+}
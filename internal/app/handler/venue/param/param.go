@@ -0,0 +1,159 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package param
+
+import (
+	"errors"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/satori/uuid"
+)
+
+// NearbyRadiusMax is the widest radius, in metres, a nearby search can cover.
+const NearbyRadiusMax = 500000
+
+// Venue ...
+type Venue struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	City      string    `json:"city"`
+	Latitude  *float64  `json:"latitude"`
+	Longitude *float64  `json:"longitude"`
+	Capacity  int       `json:"capacity"`
+	Surface   string    `json:"surface"`
+}
+
+// GetLatitude ...
+func (venue Venue) GetLatitude() (latitude float64) {
+	if venue.Latitude != nil {
+		latitude = *venue.Latitude
+	}
+	return
+}
+
+// GetLongitude ...
+func (venue Venue) GetLongitude() (longitude float64) {
+	if venue.Longitude != nil {
+		longitude = *venue.Longitude
+	}
+	return
+}
+
+// between is used for making sure a number given as a string falls within min and max, both inclusive.
+func between(min, max float64) validation.RuleFunc {
+	return func(value interface{}) error {
+		number, err := strconv.ParseFloat(value.(string), 64)
+		if err != nil || number < min || number > max {
+			return errors.New("must be between " + strconv.FormatFloat(min, 'f', -1, 64) + " and " + strconv.FormatFloat(max, 'f', -1, 64))
+		}
+
+		return nil
+	}
+}
+
+// DoCreate ...
+type DoCreate struct {
+	Venue
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (doCreate DoCreate) Validate() error {
+	return validation.ValidateStruct(&doCreate,
+		// Name cannot be empty and length must be between 1 and 150.
+		validation.Field(&doCreate.Name, validation.Required, validation.Length(1, 150)),
+		// City length must be no more than 100.
+		validation.Field(&doCreate.City, validation.Length(0, 100)),
+		// Latitude cannot be empty and should be between -90 and 90.
+		validation.Field(&doCreate.Latitude, validation.NotNil, validation.Min(-90.0), validation.Max(90.0)),
+		// Longitude cannot be empty and should be between -180 and 180.
+		validation.Field(&doCreate.Longitude, validation.NotNil, validation.Min(-180.0), validation.Max(180.0)),
+		// Capacity cannot be negative.
+		validation.Field(&doCreate.Capacity, validation.Min(0)),
+		// Surface cannot be empty and should be one of grass, hybrid or artificial.
+		validation.Field(&doCreate.Surface, validation.Required, validation.In(model.VenueSurfaces...)),
+	)
+}
+
+// GetVenue ...
+type GetVenue struct {
+	ID uuid.UUID `json:"id"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getVenue GetVenue) Validate() error {
+	return validation.ValidateStruct(&getVenue,
+		// ID cannot be empty and should be in a valid uuid.
+		validation.Field(&getVenue.ID, validation.Required, is.UUIDv4),
+	)
+}
+
+// DoUpdate ...
+type DoUpdate struct {
+	Venue
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (doUpdate DoUpdate) Validate() error {
+	return validation.ValidateStruct(&doUpdate,
+		// ID cannot be empty and should be in a valid uuid.
+		validation.Field(&doUpdate.ID, validation.Required, is.UUIDv4),
+		// Name cannot be empty and length must be between 1 and 150.
+		validation.Field(&doUpdate.Name, validation.Required, validation.Length(1, 150)),
+		// City length must be no more than 100.
+		validation.Field(&doUpdate.City, validation.Length(0, 100)),
+		// Latitude cannot be empty and should be between -90 and 90.
+		validation.Field(&doUpdate.Latitude, validation.NotNil, validation.Min(-90.0), validation.Max(90.0)),
+		// Longitude cannot be empty and should be between -180 and 180.
+		validation.Field(&doUpdate.Longitude, validation.NotNil, validation.Min(-180.0), validation.Max(180.0)),
+		// Capacity cannot be negative.
+		validation.Field(&doUpdate.Capacity, validation.Min(0)),
+		// Surface cannot be empty and should be one of grass, hybrid or artificial.
+		validation.Field(&doUpdate.Surface, validation.Required, validation.In(model.VenueSurfaces...)),
+	)
+}
+
+// GetNearby ...
+type GetNearby struct {
+	Lat    string `json:"lat"`
+	Lng    string `json:"lng"`
+	Radius string `json:"radius"`
+}
+
+// Validate is used for validating request payload.
+// It returns any errors written.
+func (getNearby GetNearby) Validate() error {
+	return validation.ValidateStruct(&getNearby,
+		// Lat cannot be empty and should be a latitude between -90 and 90.
+		validation.Field(&getNearby.Lat, validation.Required, is.Float, validation.By(between(-90, 90))),
+		// Lng cannot be empty and should be a longitude between -180 and 180.
+		validation.Field(&getNearby.Lng, validation.Required, is.Float, validation.By(between(-180, 180))),
+		// Radius cannot be empty and should be in metres, up to 500 km.
+		validation.Field(&getNearby.Radius, validation.Required, is.Float, validation.By(between(1, NearbyRadiusMax))),
+	)
+}
+
+// GetLat ...
+func (getNearby GetNearby) GetLat() (lat float64) {
+	lat, _ = strconv.ParseFloat(getNearby.Lat, 64)
+	return
+}
+
+// GetLng ...
+func (getNearby GetNearby) GetLng() (lng float64) {
+	lng, _ = strconv.ParseFloat(getNearby.Lng, 64)
+	return
+}
+
+// GetRadius ...
+func (getNearby GetNearby) GetRadius() (radius float64) {
+	radius, _ = strconv.ParseFloat(getNearby.Radius, 64)
+	return
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transporter
+
+import "github.com/satori/uuid"
+
+// Venue ...
+type Venue struct {
+	ID        uuid.UUID `gorm:"primaryKey" json:"id"`
+	Name      string    `json:"name"`
+	City      string    `json:"city"`
+	Latitude  float64   `json:"latitude"`
+	Longitude float64   `json:"longitude"`
+	Capacity  int       `json:"capacity"`
+	Surface   string    `json:"surface"`
+}
+
+// DoCreate ...
+type DoCreate struct {
+	Venue
+}
+
+// GetVenue ...
+type GetVenue struct {
+	Venue
+}
+
+// TableName ...
+func (GetVenue) TableName() string {
+	return "venues"
+}
+
+// DoUpdate ...
+type DoUpdate struct {
+	Venue
+}
+
+// GetNearby ...
+type GetNearby struct {
+	Venue
+	Distance float64 `json:"distance"`
+}
+
+// TableName ...
+func (GetNearby) TableName() string {
+	return "venues"
+}
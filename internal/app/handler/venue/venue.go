@@ -0,0 +1,149 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package venue
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/venue/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/venue/transporter"
+	"github.com/harunnryd/skeltun/internal/app/usecase"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// IVenue is an interface that stores the methods that Venue struct will use.
+type IVenue interface {
+	// DoCreate is used for record new venue.
+	// It returns doCreateResp of transporter.DoCreate and any errors written.
+	DoCreate(w http.ResponseWriter, r *http.Request) (doCreateResp interface{}, err error)
+
+	// GetVenue is used for getting a venue.
+	// It returns getVenueResp of transporter.GetVenue and any errors written.
+	GetVenue(w http.ResponseWriter, r *http.Request) (getVenueResp interface{}, err error)
+
+	// DoUpdate is used for update the record venue.
+	// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+	DoUpdate(w http.ResponseWriter, r *http.Request) (doUpdateResp interface{}, err error)
+
+	// GetNearby is used for getting the venues within a radius of a point, the closest first.
+	// It returns getNearbyResp of []transporter.GetNearby and any errors written.
+	GetNearby(w http.ResponseWriter, r *http.Request) (getNearbyResp interface{}, err error)
+}
+
+// Venue is an struct that implements IVenue methods.
+type Venue struct {
+	config  config.IConfig
+	usecase usecase.IUseCase
+}
+
+// New it returns instance of Venue that implements IVenue methods.
+func New(opts ...Option) IVenue {
+	v := new(Venue)
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+// DoCreate is used for record new venue.
+// It returns doCreateResp of transporter.DoCreate and any errors written.
+func (venue *Venue) DoCreate(w http.ResponseWriter, r *http.Request) (doCreateResp interface{}, err error) {
+	doCreateParam := param.DoCreate{}
+	if err = json.NewDecoder(r.Body).Decode(&doCreateParam); err != nil {
+		return
+	}
+
+	if err = doCreateParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	doCreateResp = transporter.DoCreate{}
+	doCreateResp, err = venue.usecase.GetVenue().DoCreate(r.Context(), doCreateParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return doCreateResp, nil
+}
+
+// GetVenue is used for getting a venue.
+// It returns getVenueResp of transporter.GetVenue and any errors written.
+func (venue *Venue) GetVenue(w http.ResponseWriter, r *http.Request) (getVenueResp interface{}, err error) {
+	getVenueParam := param.GetVenue{ID: uuid.FromStringOrNil(chi.URLParam(r, "venue_id"))}
+
+	if err = getVenueParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getVenueResp = transporter.GetVenue{}
+	getVenueResp, err = venue.usecase.GetVenue().GetVenue(r.Context(), getVenueParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getVenueResp, nil
+}
+
+// DoUpdate is used for update the record venue.
+// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+func (venue *Venue) DoUpdate(w http.ResponseWriter, r *http.Request) (doUpdateResp interface{}, err error) {
+	doUpdateParam := param.DoUpdate{}
+	if err = json.NewDecoder(r.Body).Decode(&doUpdateParam); err != nil {
+		return
+	}
+
+	doUpdateParam.ID = uuid.FromStringOrNil(chi.URLParam(r, "venue_id"))
+
+	if err = doUpdateParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	doUpdateResp = transporter.DoUpdate{}
+	doUpdateResp, err = venue.usecase.GetVenue().DoUpdate(r.Context(), doUpdateParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return doUpdateResp, nil
+}
+
+// GetNearby is used for getting the venues within a radius of a point, the closest first.
+// It returns getNearbyResp of []transporter.GetNearby and any errors written.
+func (venue *Venue) GetNearby(w http.ResponseWriter, r *http.Request) (getNearbyResp interface{}, err error) {
+	getNearbyParam := param.GetNearby{
+		Lat:    r.URL.Query().Get("lat"),
+		Lng:    r.URL.Query().Get("lng"),
+		Radius: r.URL.Query().Get("radius"),
+	}
+
+	if err = getNearbyParam.Validate(); err != nil {
+		err = &iPkgError.ValidationError{Err: errors.New(err.Error())}
+		return
+	}
+
+	getNearbyResp = transporter.GetNearby{}
+	getNearbyResp, err = venue.usecase.GetVenue().GetNearby(r.Context(), getNearbyParam)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	return getNearbyResp, nil
+}
@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+
+	"github.com/satori/uuid"
+)
+
+const (
+	// BracketSlotHome is the upper slot of a tie, its team hosts the first leg.
+	BracketSlotHome = "home"
+	// BracketSlotAway is the lower slot of a tie.
+	BracketSlotAway = "away"
+)
+
+// BracketTie is an `bracket_ties` table abstractions.
+// The winner of the tie at Position moves into the tie at Position/2 of the next round,
+// taking its home slot when Position is even and its away slot otherwise.
+// A tie with a single team is a bye, its winner is known as soon as the bracket is drawn.
+type BracketTie struct {
+	Model
+	SeasonID     uuid.UUID
+	Round        int
+	Position     int
+	Legs         int
+	HomeTeamID   *uuid.UUID
+	AwayTeamID   *uuid.UUID
+	WinnerTeamID *uuid.UUID
+	FirstLegAt   time.Time
+	SecondLegAt  *time.Time
+}
@@ -0,0 +1,56 @@
+package model
+
+import "github.com/lib/pq"
+
+const (
+	// CompetitionTypeLeague is a competition played as a round-robin league.
+	CompetitionTypeLeague = "league"
+	// CompetitionTypeCup is a competition played as a knockout cup.
+	CompetitionTypeCup = "cup"
+)
+
+// CompetitionTypes is a list of every valid competition type.
+var CompetitionTypes = []interface{}{
+	CompetitionTypeLeague,
+	CompetitionTypeCup,
+}
+
+const (
+	// TiebreakerHeadToHead ranks tied teams by the points earned in the matches between them.
+	TiebreakerHeadToHead = "head_to_head"
+	// TiebreakerGoalDifference ranks tied teams by overall goal difference.
+	TiebreakerGoalDifference = "goal_difference"
+	// TiebreakerGoalsScored ranks tied teams by overall goals scored.
+	TiebreakerGoalsScored = "goals_scored"
+	// TiebreakerAwayGoals ranks tied teams by goals scored away from home.
+	TiebreakerAwayGoals = "away_goals"
+	// TiebreakerFairPlay ranks tied teams by the fewest disciplinary points.
+	TiebreakerFairPlay = "fair_play"
+)
+
+// Tiebreakers is a list of every valid tiebreaker rule.
+var Tiebreakers = []interface{}{
+	TiebreakerHeadToHead,
+	TiebreakerGoalDifference,
+	TiebreakerGoalsScored,
+	TiebreakerAwayGoals,
+	TiebreakerFairPlay,
+}
+
+// DefaultTiebreakers is the rule chain used when a competition does not set its own.
+var DefaultTiebreakers = []string{
+	TiebreakerGoalDifference,
+	TiebreakerGoalsScored,
+}
+
+// Competition is an `competitions` table abstractions.
+// SalaryCap is the most the teams of a running season may pay in wages, nil for no cap.
+// BenchSize is the most substitutes a team may name for a match, nil for no limit.
+type Competition struct {
+	Model
+	Name        string
+	Type        string
+	Tiebreakers pq.StringArray `gorm:"type:text[]"`
+	SalaryCap   *int64
+	BenchSize   *int
+}
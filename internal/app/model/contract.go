@@ -0,0 +1,37 @@
+package model
+
+import (
+	"time"
+
+	"github.com/satori/uuid"
+)
+
+const (
+	// ContractStatusActive is a contract binding the player to the team.
+	ContractStatusActive = "active"
+	// ContractStatusExpired is a contract that ran until its end date.
+	ContractStatusExpired = "expired"
+	// ContractStatusTerminated is a contract ended early, like by a transfer.
+	ContractStatusTerminated = "terminated"
+)
+
+// ContractStatuses is a list of every valid contract status.
+var ContractStatuses = []interface{}{
+	ContractStatusActive,
+	ContractStatusExpired,
+	ContractStatusTerminated,
+}
+
+// Contract is an `contracts` table abstractions.
+// Wage is paid yearly and, like ReleaseClause, is in the smallest currency unit.
+// A contract is valid on the days between StartsAt and EndsAt while it is active.
+type Contract struct {
+	Model
+	PlayerID      uuid.UUID
+	TeamID        uuid.UUID
+	StartsAt      time.Time
+	EndsAt        time.Time
+	Wage          int64
+	ReleaseClause *int64
+	Status        string
+}
@@ -0,0 +1,45 @@
+package model
+
+import "github.com/satori/uuid"
+
+const (
+	// DrawKindGroups is a draw placing teams into groups.
+	DrawKindGroups = "groups"
+	// DrawKindTies is a draw pairing teams into knockout ties.
+	DrawKindTies = "ties"
+)
+
+// DrawKinds is a list of every valid draw kind.
+var DrawKinds = []interface{}{
+	DrawKindGroups,
+	DrawKindTies,
+}
+
+// Draw is an `draws` table abstractions.
+// Running the draw again from Seed with the same placements input gives the same outcome.
+type Draw struct {
+	Model
+	SeasonID             uuid.UUID
+	Kind                 string
+	Seed                 int64
+	Groups               int
+	SeparateAssociations bool
+	SeparateGroups       bool
+}
+
+// DrawPlacement is an `draw_placements` table abstractions.
+// Pot, PotPosition, Association and PreviousGroup are the input of the draw for the team,
+// Sequence, GroupName, Tie and Slot are where the team was drawn.
+type DrawPlacement struct {
+	Model
+	DrawID        uuid.UUID
+	TeamID        uuid.UUID
+	Pot           int
+	PotPosition   int
+	Association   string
+	PreviousGroup string
+	Sequence      int
+	GroupName     string
+	Tie           int
+	Slot          string
+}
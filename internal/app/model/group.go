@@ -0,0 +1,32 @@
+package model
+
+import (
+	"time"
+
+	"github.com/satori/uuid"
+)
+
+// Group is an `groups` table abstractions.
+type Group struct {
+	Model
+	SeasonID uuid.UUID
+	Name     string
+}
+
+// GroupTeam is an `group_teams` table abstractions.
+type GroupTeam struct {
+	GroupID   uuid.UUID `gorm:"primaryKey"`
+	TeamID    uuid.UUID `gorm:"primaryKey"`
+	CreatedAt time.Time
+}
+
+// QualificationRule is an `qualification_rules` table abstractions.
+// The best TopPerGroup teams of every group qualify, along with the BestPlaced best teams
+// finishing right below them across all groups.
+type QualificationRule struct {
+	SeasonID    uuid.UUID `gorm:"primaryKey"`
+	TopPerGroup int
+	BestPlaced  int
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
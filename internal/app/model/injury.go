@@ -0,0 +1,18 @@
+package model
+
+import (
+	"time"
+
+	"github.com/satori/uuid"
+)
+
+// Injury is an `injuries` table abstractions.
+// Type describes the injury, such as a hamstring strain.
+// The player is unavailable from StartedAt until ExpectedReturnAt, or until further notice without one.
+type Injury struct {
+	Model
+	PlayerID         uuid.UUID
+	Type             string
+	StartedAt        time.Time
+	ExpectedReturnAt *time.Time
+}
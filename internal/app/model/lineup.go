@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+
+	"github.com/satori/uuid"
+)
+
+// LineupStarters is the number of players a team starts a match with.
+const LineupStarters = 11
+
+// Lineup is an `lineups` table abstractions.
+// Formation lists the outfield lines from defence to attack, such as 4-3-3.
+type Lineup struct {
+	MatchID   uuid.UUID `gorm:"primaryKey"`
+	TeamID    uuid.UUID `gorm:"primaryKey"`
+	Formation string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// LineupPlayer is an `lineup_players` table abstractions.
+// Sequence is the position of the player on the team sheet, starting from 1 with the starters.
+type LineupPlayer struct {
+	MatchID   uuid.UUID `gorm:"primaryKey"`
+	TeamID    uuid.UUID
+	PlayerID  uuid.UUID `gorm:"primaryKey"`
+	Starter   bool
+	Sequence  int
+	CreatedAt time.Time
+}
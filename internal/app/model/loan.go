@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"github.com/satori/uuid"
+)
+
+const (
+	// LoanStatusActive is a loan the player is still playing.
+	LoanStatusActive = "active"
+	// LoanStatusReturned is a loan that ran until its end date.
+	LoanStatusReturned = "returned"
+	// LoanStatusRecalled is a loan cut short by the parent club.
+	LoanStatusRecalled = "recalled"
+	// LoanStatusBought is a loan turned into a permanent transfer by the loan club.
+	LoanStatusBought = "bought"
+)
+
+// Loan is an `loans` table abstractions.
+// A loan is made with a transfer of type loan, the player goes back to ParentTeamID when it ends.
+// RecallClause lets the parent club end it early, OptionToBuy lets the loan club sign the player for OptionFee.
+type Loan struct {
+	Model
+	TransferID   uuid.UUID
+	PlayerID     uuid.UUID
+	ParentTeamID uuid.UUID
+	LoanTeamID   uuid.UUID
+	StartsAt     time.Time
+	EndsAt       time.Time
+	RecallClause bool
+	OptionToBuy  bool
+	OptionFee    int64
+	Status       string
+	EndedAt      *time.Time
+}
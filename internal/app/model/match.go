@@ -0,0 +1,78 @@
+package model
+
+import (
+	"time"
+
+	"github.com/satori/uuid"
+)
+
+const (
+	// MatchStatusScheduled is a match that has not kicked off yet.
+	MatchStatusScheduled = "scheduled"
+	// MatchStatusFirstHalf is a match being played in its first half.
+	MatchStatusFirstHalf = "first_half"
+	// MatchStatusHalfTime is a match in the half-time break.
+	MatchStatusHalfTime = "half_time"
+	// MatchStatusSecondHalf is a match being played in its second half.
+	MatchStatusSecondHalf = "second_half"
+	// MatchStatusExtraTime is a match being played in extra time.
+	MatchStatusExtraTime = "extra_time"
+	// MatchStatusPenalties is a match being decided by a penalty shootout.
+	MatchStatusPenalties = "penalties"
+	// MatchStatusFinished is a match with a final result.
+	MatchStatusFinished = "finished"
+	// MatchStatusPostponed is a match moved to a later date.
+	MatchStatusPostponed = "postponed"
+	// MatchStatusAbandoned is a match stopped before it could be completed.
+	MatchStatusAbandoned = "abandoned"
+	// MatchStatusCancelled is a match that will not be played.
+	MatchStatusCancelled = "cancelled"
+)
+
+// MatchStatuses is a list of every valid match status.
+var MatchStatuses = []interface{}{
+	MatchStatusScheduled,
+	MatchStatusFirstHalf,
+	MatchStatusHalfTime,
+	MatchStatusSecondHalf,
+	MatchStatusExtraTime,
+	MatchStatusPenalties,
+	MatchStatusFinished,
+	MatchStatusPostponed,
+	MatchStatusAbandoned,
+	MatchStatusCancelled,
+}
+
+const (
+	// MatchDecidedInRegularTime is a match won within the 90 minutes.
+	MatchDecidedInRegularTime = "regular_time"
+	// MatchDecidedInExtraTime is a match won once extra time goals are added.
+	MatchDecidedInExtraTime = "extra_time"
+	// MatchDecidedInPenalties is a match won in the penalty shootout.
+	MatchDecidedInPenalties = "penalties"
+)
+
+// Match is an `matches` table abstractions.
+// VenueID is where the match is played, and Venue is its name kept in sync from it.
+// Venue is only free text for matches played at a ground that is not a venue.
+type Match struct {
+	Model
+	SeasonID   *uuid.UUID
+	Round      int
+	HomeTeamID uuid.UUID
+	AwayTeamID uuid.UUID
+	KickoffAt  time.Time
+	Venue      string
+	VenueID    *uuid.UUID
+	Status     string
+	HomeScore  int
+	AwayScore  int
+
+	HomeExtraTimeScore int
+	AwayExtraTimeScore int
+	HomeShootoutScore  int
+	AwayShootoutScore  int
+
+	TieID *uuid.UUID
+	Leg   int
+}
@@ -0,0 +1,67 @@
+package model
+
+import "github.com/satori/uuid"
+
+const (
+	// MatchEventTypeGoal is a goal scored from open play or a set piece.
+	MatchEventTypeGoal = "goal"
+	// MatchEventTypeOwnGoal is a goal scored into the player's own net.
+	MatchEventTypeOwnGoal = "own_goal"
+	// MatchEventTypePenalty is a goal scored from the penalty spot.
+	MatchEventTypePenalty = "penalty"
+	// MatchEventTypePenaltyMissed is a penalty kick that was saved or missed.
+	MatchEventTypePenaltyMissed = "penalty_missed"
+	// MatchEventTypeYellowCard is a caution.
+	MatchEventTypeYellowCard = "yellow_card"
+	// MatchEventTypeSecondYellow is a second caution, which sends the player off.
+	MatchEventTypeSecondYellow = "second_yellow"
+	// MatchEventTypeRedCard is a straight sending off.
+	MatchEventTypeRedCard = "red_card"
+	// MatchEventTypeSubstitution is a player leaving the pitch for a substitute.
+	MatchEventTypeSubstitution = "substitution"
+)
+
+// MatchEventTypes is a list of every valid match event type.
+var MatchEventTypes = []interface{}{
+	MatchEventTypeGoal,
+	MatchEventTypeOwnGoal,
+	MatchEventTypePenalty,
+	MatchEventTypePenaltyMissed,
+	MatchEventTypeYellowCard,
+	MatchEventTypeSecondYellow,
+	MatchEventTypeRedCard,
+	MatchEventTypeSubstitution,
+}
+
+// MatchEventCardTypes is a list of every match event type that is a card.
+var MatchEventCardTypes = []string{
+	MatchEventTypeYellowCard,
+	MatchEventTypeSecondYellow,
+	MatchEventTypeRedCard,
+}
+
+const (
+	// MatchPeriodRegularTime is the 90 minutes of a match, stoppage time included.
+	MatchPeriodRegularTime = "regular_time"
+	// MatchPeriodExtraTime is the 30 minutes played after a draw in regular time.
+	MatchPeriodExtraTime = "extra_time"
+)
+
+// MatchPeriods is a list of every period a match event can happen in.
+var MatchPeriods = []interface{}{
+	MatchPeriodRegularTime,
+	MatchPeriodExtraTime,
+}
+
+// MatchEvent is an `match_events` table abstractions.
+// RelatedPlayerID is the assisting player of a goal or the substitute coming on.
+type MatchEvent struct {
+	Model
+	MatchID         uuid.UUID
+	TeamID          uuid.UUID
+	PlayerID        uuid.UUID
+	RelatedPlayerID *uuid.UUID
+	Type            string
+	Period          string
+	Minute          int
+}
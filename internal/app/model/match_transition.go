@@ -0,0 +1,16 @@
+package model
+
+import (
+	"time"
+
+	"github.com/satori/uuid"
+)
+
+// MatchTransition is an `match_transitions` table abstractions.
+type MatchTransition struct {
+	Model
+	MatchID    uuid.UUID
+	FromStatus string
+	ToStatus   string
+	OccurredAt time.Time
+}
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"time"
+
+	"github.com/satori/uuid"
+)
+
+const (
+	// OfficialRoleReferee is the official in charge of a match.
+	OfficialRoleReferee = "referee"
+	// OfficialRoleAssistant is an assistant referee running the touchline.
+	OfficialRoleAssistant = "assistant"
+	// OfficialRoleFourthOfficial is the official looking after the technical areas and substitutions.
+	OfficialRoleFourthOfficial = "fourth_official"
+	// OfficialRoleVAR is the video assistant referee.
+	OfficialRoleVAR = "var"
+)
+
+// OfficialRoles is a list of every valid official role.
+var OfficialRoles = []interface{}{
+	OfficialRoleReferee,
+	OfficialRoleAssistant,
+	OfficialRoleFourthOfficial,
+	OfficialRoleVAR,
+}
+
+// OfficialRoleLimits is the number of officials of every role a match can have.
+var OfficialRoleLimits = map[string]int{
+	OfficialRoleReferee:        1,
+	OfficialRoleAssistant:      2,
+	OfficialRoleFourthOfficial: 1,
+	OfficialRoleVAR:            1,
+}
+
+const (
+	// RefereeMetricMatches ranks the referees by the matches they took charge of.
+	RefereeMetricMatches = "matches_officiated"
+	// RefereeMetricCardsPerGame ranks the referees by the cards they showed on average in a match.
+	RefereeMetricCardsPerGame = "cards_per_game"
+	// RefereeMetricPenalties ranks the referees by the penalties they awarded.
+	RefereeMetricPenalties = "penalties_awarded"
+)
+
+// RefereeMetrics is a list of every referee stat a ranking can rank by.
+var RefereeMetrics = []interface{}{
+	RefereeMetricMatches,
+	RefereeMetricCardsPerGame,
+	RefereeMetricPenalties,
+}
+
+// Official is an `officials` table abstractions.
+// Role is what the official is registered for, a referee can still be assigned to a match in any role.
+// AffiliatedTeamID is the club the official declared an affiliation with, if any.
+type Official struct {
+	Model
+	Name             string
+	Role             string
+	AffiliatedTeamID *uuid.UUID
+}
+
+// MatchOfficial is an `match_officials` table abstractions.
+type MatchOfficial struct {
+	MatchID    uuid.UUID `gorm:"primaryKey"`
+	OfficialID uuid.UUID `gorm:"primaryKey"`
+	Role       string
+	CreatedAt  time.Time
+}
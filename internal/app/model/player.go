@@ -1,10 +1,81 @@
 package model
 
-import "github.com/satori/uuid"
+import (
+	"time"
+
+	"github.com/satori/uuid"
+)
+
+const (
+	// PlayerPositionGoalkeeper is a player keeping goal.
+	PlayerPositionGoalkeeper = "GK"
+	// PlayerPositionDefender is a player playing in defence.
+	PlayerPositionDefender = "DF"
+	// PlayerPositionMidfielder is a player playing in midfield.
+	PlayerPositionMidfielder = "MF"
+	// PlayerPositionForward is a player playing up front.
+	PlayerPositionForward = "FW"
+)
+
+// PlayerPositions is a list of every valid player position.
+var PlayerPositions = []interface{}{
+	PlayerPositionGoalkeeper,
+	PlayerPositionDefender,
+	PlayerPositionMidfielder,
+	PlayerPositionForward,
+}
+
+// PlayerRoles maps every detailed role to the position it is played in.
+var PlayerRoles = map[string]string{
+	"GK":  PlayerPositionGoalkeeper,
+	"SW":  PlayerPositionDefender,
+	"CB":  PlayerPositionDefender,
+	"LB":  PlayerPositionDefender,
+	"RB":  PlayerPositionDefender,
+	"LWB": PlayerPositionDefender,
+	"RWB": PlayerPositionDefender,
+	"DM":  PlayerPositionMidfielder,
+	"CM":  PlayerPositionMidfielder,
+	"LM":  PlayerPositionMidfielder,
+	"RM":  PlayerPositionMidfielder,
+	"AM":  PlayerPositionMidfielder,
+	"LW":  PlayerPositionForward,
+	"RW":  PlayerPositionForward,
+	"SS":  PlayerPositionForward,
+	"CF":  PlayerPositionForward,
+	"ST":  PlayerPositionForward,
+}
+
+const (
+	// PreferredFootLeft is a left-footed player.
+	PreferredFootLeft = "left"
+	// PreferredFootRight is a right-footed player.
+	PreferredFootRight = "right"
+	// PreferredFootBoth is a two-footed player.
+	PreferredFootBoth = "both"
+)
+
+// PreferredFeet is a list of every valid preferred foot.
+var PreferredFeet = []interface{}{
+	PreferredFootLeft,
+	PreferredFootRight,
+	PreferredFootBoth,
+}
 
 // Player is an `players` table abstractions.
+// Height is in centimetres and Weight in kilograms.
+// Homegrown is nil while unknown, such a player does not count as homegrown.
 type Player struct {
 	Model
-	TeamID uuid.UUID
-	Name   string
+	TeamID        uuid.UUID
+	Name          string
+	Position      string
+	Role          string
+	ShirtNumber   *int
+	DateOfBirth   *time.Time
+	Nationality   string
+	Height        int
+	Weight        int
+	PreferredFoot string
+	Homegrown     *bool
 }
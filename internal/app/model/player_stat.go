@@ -0,0 +1,42 @@
+package model
+
+import (
+	"time"
+
+	"github.com/satori/uuid"
+)
+
+// PlayerStatCleanSheetMinutes is the fewest minutes a player should play in a match without conceding for a clean sheet.
+const PlayerStatCleanSheetMinutes = 60
+
+const (
+	// LeaderboardMetricGoals ranks the players by the goals they scored.
+	LeaderboardMetricGoals = "goals"
+	// LeaderboardMetricAssists ranks the players by the goals they set up.
+	LeaderboardMetricAssists = "assists"
+	// LeaderboardMetricCleanSheets ranks the players by the matches they kept a clean sheet in.
+	LeaderboardMetricCleanSheets = "clean_sheets"
+)
+
+// LeaderboardMetrics is a list of every player stat a leaderboard can rank by.
+var LeaderboardMetrics = []interface{}{
+	LeaderboardMetricGoals,
+	LeaderboardMetricAssists,
+	LeaderboardMetricCleanSheets,
+}
+
+// PlayerStat is an `player_stats` table abstractions.
+// It sums up every finished match of the season for the player, and is rebuilt whenever one of them finishes.
+type PlayerStat struct {
+	SeasonID      uuid.UUID `gorm:"primaryKey"`
+	PlayerID      uuid.UUID `gorm:"primaryKey"`
+	Appearances   int
+	Starts        int
+	MinutesPlayed int
+	Goals         int
+	Assists       int
+	YellowCards   int
+	RedCards      int
+	CleanSheets   int
+	UpdatedAt     time.Time
+}
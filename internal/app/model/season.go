@@ -0,0 +1,23 @@
+package model
+
+import (
+	"time"
+
+	"github.com/satori/uuid"
+)
+
+// Season is an `seasons` table abstractions.
+type Season struct {
+	Model
+	CompetitionID uuid.UUID
+	Name          string
+	StartDate     time.Time
+	EndDate       time.Time
+}
+
+// SeasonTeam is an `season_teams` table abstractions.
+type SeasonTeam struct {
+	SeasonID  uuid.UUID `gorm:"primaryKey"`
+	TeamID    uuid.UUID `gorm:"primaryKey"`
+	CreatedAt time.Time
+}
@@ -0,0 +1,14 @@
+package model
+
+import "github.com/satori/uuid"
+
+// ShootoutKick is an `shootout_kicks` table abstractions.
+// Sequence is the position of the kick in the shootout, starting from 1.
+type ShootoutKick struct {
+	Model
+	MatchID  uuid.UUID
+	TeamID   uuid.UUID
+	PlayerID uuid.UUID
+	Sequence int
+	Scored   bool
+}
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"time"
+
+	"github.com/satori/uuid"
+)
+
+const (
+	// SquadRuleMaxPlayers is the most players a team may have in the squad.
+	SquadRuleMaxPlayers = "max_players"
+	// SquadRuleMinHomegrown is the fewest homegrown players a team may have in the squad.
+	SquadRuleMinHomegrown = "min_homegrown"
+	// SquadRuleMinDomestic is the fewest players of the domestic nationality a team may have in the squad.
+	SquadRuleMinDomestic = "min_domestic"
+)
+
+// SquadRule is an `squad_rules` table abstractions.
+// A zero MaxPlayers, MinHomegrown or MinDomestic leaves the rule out.
+// Players of Nationality count as domestic.
+type SquadRule struct {
+	SeasonID     uuid.UUID `gorm:"primaryKey"`
+	MaxPlayers   int
+	MinHomegrown int
+	MinDomestic  int
+	Nationality  string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"time"
+
+	"github.com/satori/uuid"
+)
+
+const (
+	// SuspensionReasonYellowCards is a ban for reaching the yellow card threshold of the season.
+	SuspensionReasonYellowCards = "yellow_cards"
+	// SuspensionReasonSecondYellow is a ban for being sent off with a second caution.
+	SuspensionReasonSecondYellow = "second_yellow"
+	// SuspensionReasonRedCard is a ban for a straight sending off.
+	SuspensionReasonRedCard = "red_card"
+)
+
+const (
+	// AvailabilityInjured is a player who cannot play until the injury heals.
+	AvailabilityInjured = "injured"
+	// AvailabilitySuspended is a player who cannot play until the suspension is served.
+	AvailabilitySuspended = "suspended"
+)
+
+// Suspension is an `suspensions` table abstractions.
+// MatchID and MatchEventID are the match and the card the ban comes from, MatchesRemaining goes down by one
+// for every match of the season the team of the player finishes, LastServedMatchID being the last one of them.
+// Every match served is recorded as a SuspensionMatch.
+type Suspension struct {
+	Model
+	PlayerID          uuid.UUID
+	SeasonID          uuid.UUID
+	MatchID           *uuid.UUID
+	MatchEventID      *uuid.UUID
+	Reason            string
+	MatchesRemaining  int
+	LastServedMatchID *uuid.UUID
+}
+
+// SuspensionMatch is an `suspension_matches` table abstractions.
+// It records a match a suspension has been served by, so the match is only taken off once.
+type SuspensionMatch struct {
+	SuspensionID uuid.UUID `gorm:"primaryKey"`
+	MatchID      uuid.UUID `gorm:"primaryKey"`
+	CreatedAt    time.Time
+}
@@ -1,7 +1,13 @@
 package model
 
+import "github.com/satori/uuid"
+
 // Team is an `teams` table abstractions.
+// Association is the code of the national football association the team belongs to, e.g. ENG.
+// VenueID is the home ground of the team.
 type Team struct {
 	Model
-	Name string
+	Name        string
+	Association string
+	VenueID     *uuid.UUID
 }
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"time"
+
+	"github.com/satori/uuid"
+)
+
+const (
+	// TransferTypePermanent is a player bought outright by the new team.
+	TransferTypePermanent = "permanent"
+	// TransferTypeLoan is a player lent to the new team for a while.
+	TransferTypeLoan = "loan"
+	// TransferTypeFree is a player joining the new team without a fee.
+	TransferTypeFree = "free"
+	// TransferTypeLoanReturn is a player going back to the parent club at the end of a loan.
+	// It is only recorded by the loans themselves, so it is left out of TransferTypes.
+	TransferTypeLoanReturn = "loan_return"
+)
+
+// TransferTypes is a list of every valid transfer type.
+var TransferTypes = []interface{}{
+	TransferTypePermanent,
+	TransferTypeLoan,
+	TransferTypeFree,
+}
+
+// Transfer is an `transfers` table abstractions.
+// FromTeamID is nil for a player joining without a club, Fee is in the smallest currency unit.
+type Transfer struct {
+	Model
+	PlayerID   uuid.UUID
+	FromTeamID *uuid.UUID
+	ToTeamID   uuid.UUID
+	Date       time.Time
+	Fee        int64
+	Type       string
+}
+
+// TransferWindow is an `transfer_windows` table abstractions.
+// Teams registered in the season can only sign players between OpensAt and ClosesAt.
+type TransferWindow struct {
+	Model
+	SeasonID uuid.UUID
+	OpensAt  time.Time
+	ClosesAt time.Time
+}
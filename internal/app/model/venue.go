@@ -0,0 +1,54 @@
+package model
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// VenueSurfaceGrass is a natural grass pitch.
+	VenueSurfaceGrass = "grass"
+	// VenueSurfaceHybrid is a natural grass pitch reinforced with synthetic fibres.
+	VenueSurfaceHybrid = "hybrid"
+	// VenueSurfaceArtificial is an artificial turf pitch.
+	VenueSurfaceArtificial = "artificial"
+)
+
+// VenueSurfaces is a list of every valid venue surface.
+var VenueSurfaces = []interface{}{
+	VenueSurfaceGrass,
+	VenueSurfaceHybrid,
+	VenueSurfaceArtificial,
+}
+
+// Point is a PostGIS geography point in WGS 84.
+type Point struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// GormDataType ...
+func (Point) GormDataType() string {
+	return "geography(Point,4326)"
+}
+
+// GormValue is used for writing the point through PostGIS, which takes the longitude first.
+func (point Point) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
+	return clause.Expr{
+		SQL:  "ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography",
+		Vars: []interface{}{point.Longitude, point.Latitude},
+	}
+}
+
+// Venue is an `venues` table abstractions.
+// Teams play their home matches at a venue, and every match can be played at one.
+type Venue struct {
+	Model
+	Name     string
+	City     string
+	Location Point
+	Capacity int
+	Surface  string
+}
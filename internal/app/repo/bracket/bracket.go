@@ -0,0 +1,262 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bracket
+
+import (
+	"context"
+	"time"
+
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/bracket/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/bracket/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IBracket is an interface that stores the methods that Bracket struct will use.
+type IBracket interface {
+	// DoCreate is used for record the ties of a knockout bracket along with the matches that can already be played.
+	// It returns doCreateResp of []transporter.DoCreate and any errors written.
+	DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp []transporter.DoCreate, err error)
+
+	// GetBracket is used for getting every tie of a season bracket, round by round.
+	// It returns getBracketResp of []transporter.GetBracket and any errors written.
+	GetBracket(ctx context.Context, params param.GetBracket) (getBracketResp []transporter.GetBracket, err error)
+
+	// GetTie is used for getting a tie.
+	// It returns getTieResp of transporter.GetTie and any errors written.
+	GetTie(ctx context.Context, params param.GetTie) (getTieResp transporter.GetTie, err error)
+
+	// DoAdvance is used for recording the winner of a tie and moving it into the next round in a single transaction.
+	// It returns doAdvanceResp of transporter.DoAdvance and any errors written.
+	DoAdvance(ctx context.Context, params param.DoAdvance) (doAdvanceResp transporter.DoAdvance, err error)
+}
+
+// Bracket is an struct that implements IBracket methods.
+type Bracket struct {
+	config   config.IConfig
+	ormMySQL *gorm.DB
+	ormPgSQL *gorm.DB
+	statement
+}
+
+type statement struct {
+	ormTX       *gorm.DB
+	ormChaining *gorm.DB
+}
+
+// New it returns instance of Bracket that implements IBracket methods.
+func New(opts ...Option) IBracket {
+	b := new(Bracket)
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	return b
+}
+
+// DoCreate is used for record the ties of a knockout bracket along with the matches that can already be played.
+// It returns doCreateResp of []transporter.DoCreate and any errors written.
+func (bracket *Bracket) DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp []transporter.DoCreate, err error) {
+	recordTies := make([]model.BracketTie, 0, len(params.Ties))
+	for _, tie := range params.Ties {
+		recordTies = append(recordTies, model.BracketTie{
+			SeasonID:     params.SeasonID,
+			Round:        tie.Round,
+			Position:     tie.Position,
+			Legs:         tie.Legs,
+			HomeTeamID:   tie.HomeTeamID,
+			AwayTeamID:   tie.AwayTeamID,
+			WinnerTeamID: tie.WinnerTeamID,
+			FirstLegAt:   tie.FirstLegAt,
+			SecondLegAt:  tie.SecondLegAt,
+		})
+	}
+
+	bracket.ormTX = bracket.ormPgSQL.WithContext(ctx).Begin()
+
+	if err = bracket.ormTX.Create(&recordTies).Error; err != nil {
+		bracket.ormTX.Rollback()
+		return
+	}
+
+	var recordMatches []model.Match
+	for _, recordTie := range recordTies {
+		recordMatches = append(recordMatches, legs(recordTie)...)
+	}
+
+	if len(recordMatches) > 0 {
+		if err = bracket.ormTX.Create(&recordMatches).Error; err != nil {
+			bracket.ormTX.Rollback()
+			return
+		}
+	}
+
+	if err = bracket.ormTX.Commit().Error; err != nil {
+		return
+	}
+
+	for _, recordTie := range recordTies {
+		doCreateResp = append(doCreateResp, transporter.DoCreate{
+			Tie: transporter.Tie{
+				ID:           recordTie.ID,
+				SeasonID:     recordTie.SeasonID,
+				Round:        recordTie.Round,
+				Position:     recordTie.Position,
+				Legs:         recordTie.Legs,
+				HomeTeamID:   recordTie.HomeTeamID,
+				AwayTeamID:   recordTie.AwayTeamID,
+				WinnerTeamID: recordTie.WinnerTeamID,
+				FirstLegAt:   recordTie.FirstLegAt,
+				SecondLegAt:  recordTie.SecondLegAt,
+			},
+		})
+	}
+
+	return
+}
+
+// GetBracket is used for getting every tie of a season bracket, round by round.
+// It returns getBracketResp of []transporter.GetBracket and any errors written.
+func (bracket *Bracket) GetBracket(ctx context.Context, params param.GetBracket) (getBracketResp []transporter.GetBracket, err error) {
+	bracket.ormChaining = bracket.ormPgSQL.
+		WithContext(ctx).
+		Where("season_id = ?", params.SeasonID).
+		Order("round, position")
+
+	if err = bracket.ormChaining.Find(&getBracketResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// GetTie is used for getting a tie.
+// It returns getTieResp of transporter.GetTie and any errors written.
+func (bracket *Bracket) GetTie(ctx context.Context, params param.GetTie) (getTieResp transporter.GetTie, err error) {
+	bracket.ormChaining = bracket.ormPgSQL.
+		WithContext(ctx).
+		Where("id = ?", params.ID).
+		Limit(1)
+
+	if err = bracket.ormChaining.Find(&getTieResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// DoAdvance is used for recording the winner of a tie and moving it into the next round in a single transaction.
+// The next tie is locked while its slot is filled, so whichever of its two feeding ties is decided last
+// sees both teams and creates its matches. A tie that already has a winner is left untouched and
+// an empty response is returned, which makes the advance safe to retry.
+// It returns doAdvanceResp of transporter.DoAdvance and any errors written.
+func (bracket *Bracket) DoAdvance(ctx context.Context, params param.DoAdvance) (doAdvanceResp transporter.DoAdvance, err error) {
+	bracket.ormTX = bracket.ormPgSQL.WithContext(ctx).Begin()
+
+	decided := bracket.ormTX.
+		Model(&model.BracketTie{}).
+		Where("id = ? AND winner_team_id IS NULL", params.TieID).
+		Updates(map[string]interface{}{
+			"winner_team_id": params.WinnerTeamID,
+			"updated_at":     time.Now(),
+		})
+	if err = decided.Error; err != nil {
+		bracket.ormTX.Rollback()
+		return
+	}
+
+	if decided.RowsAffected == 0 {
+		bracket.ormTX.Rollback()
+		return
+	}
+
+	var nextTie model.BracketTie
+	if err = bracket.ormTX.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("season_id = ? AND round = ? AND position = ?", params.SeasonID, params.Round+1, params.Position/2).
+		Limit(1).
+		Find(&nextTie).Error; err != nil {
+		bracket.ormTX.Rollback()
+		return
+	}
+
+	// The final has no next tie to move into.
+	if nextTie.Round > 0 {
+		winnerTeamID := params.WinnerTeamID
+		slot := "home_team_id"
+		if params.Position%2 == 0 {
+			nextTie.HomeTeamID = &winnerTeamID
+		} else {
+			slot = "away_team_id"
+			nextTie.AwayTeamID = &winnerTeamID
+		}
+
+		if err = bracket.ormTX.
+			Model(&model.BracketTie{}).
+			Where("id = ?", nextTie.ID).
+			Updates(map[string]interface{}{
+				slot:         winnerTeamID,
+				"updated_at": time.Now(),
+			}).Error; err != nil {
+			bracket.ormTX.Rollback()
+			return
+		}
+
+		if recordMatches := legs(nextTie); len(recordMatches) > 0 {
+			if err = bracket.ormTX.Create(&recordMatches).Error; err != nil {
+				bracket.ormTX.Rollback()
+				return
+			}
+		}
+
+		doAdvanceResp.NextTieID = &nextTie.ID
+	}
+
+	if err = bracket.ormTX.Commit().Error; err != nil {
+		return
+	}
+
+	doAdvanceResp.TieID = params.TieID
+	doAdvanceResp.WinnerTeamID = params.WinnerTeamID
+
+	return
+}
+
+// legs is used for building the matches of a tie once both of its teams are known.
+// The home team hosts the first leg and the away team hosts the second one.
+func legs(tie model.BracketTie) (matches []model.Match) {
+	if tie.HomeTeamID == nil || tie.AwayTeamID == nil || tie.WinnerTeamID != nil {
+		return
+	}
+
+	tieID, seasonID := tie.ID, tie.SeasonID
+	matches = append(matches, model.Match{
+		SeasonID:   &seasonID,
+		Round:      tie.Round,
+		HomeTeamID: *tie.HomeTeamID,
+		AwayTeamID: *tie.AwayTeamID,
+		KickoffAt:  tie.FirstLegAt,
+		Status:     model.MatchStatusScheduled,
+		TieID:      &tieID,
+		Leg:        1,
+	})
+
+	if tie.SecondLegAt != nil {
+		matches = append(matches, model.Match{
+			SeasonID:   &seasonID,
+			Round:      tie.Round,
+			HomeTeamID: *tie.AwayTeamID,
+			AwayTeamID: *tie.HomeTeamID,
+			KickoffAt:  *tie.SecondLegAt,
+			Status:     model.MatchStatusScheduled,
+			TieID:      &tieID,
+			Leg:        2,
+		})
+	}
+
+	return
+}
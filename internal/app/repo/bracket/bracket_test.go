@@ -0,0 +1,259 @@
+package bracket
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/handler/bracket/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/bracket/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	pgsqlConn *gorm.DB
+	mock      sqlmock.Sqlmock
+
+	bracket IBracket
+	helper
+	response
+}
+
+type helper struct {
+	db  *sql.DB
+	err error
+}
+
+type response struct {
+	doCreateResp   []transporter.DoCreate
+	getBracketResp []transporter.GetBracket
+	getTieResp     transporter.GetTie
+	doAdvanceResp  transporter.DoAdvance
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.bracket = New(
+		WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+}
+
+// TestDoCreate ...
+func (suite *Suite) TestDoCreate() {
+	homeTeamID, awayTeamID, byeTeamID := uuid.NewV4(), uuid.NewV4(), uuid.NewV4()
+	firstLegAt := time.Date(2021, 1, 2, 15, 0, 0, 0, time.UTC)
+	params := param.DoCreate{
+		SeasonID: uuid.NewV4(),
+		Ties: []param.Tie{
+			{Round: 1, Position: 0, Legs: 1, HomeTeamID: &byeTeamID, WinnerTeamID: &byeTeamID, FirstLegAt: firstLegAt},
+			{Round: 1, Position: 1, Legs: 1, HomeTeamID: &homeTeamID, AwayTeamID: &awayTeamID, FirstLegAt: firstLegAt},
+			{Round: 2, Position: 0, Legs: 1, HomeTeamID: &byeTeamID, FirstLegAt: firstLegAt.AddDate(0, 0, 7)},
+		},
+	}
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "bracket_ties" ("created_at","updated_at","deleted_at","season_id","round","position","legs","home_team_id","away_team_id","winner_team_id","first_leg_at","second_leg_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12),($13,$14,$15,$16,$17,$18,$19,$20,$21,$22,$23,$24),($25,$26,$27,$28,$29,$30,$31,$32,$33,$34,$35,$36) RETURNING "id"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()).
+			AddRow(uuid.NewV4()).
+			AddRow(uuid.NewV4()))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "matches" ("created_at","updated_at","deleted_at","season_id","round","home_team_id","away_team_id","kickoff_at","venue","venue_id","status","home_score","away_score","home_extra_time_score","away_extra_time_score","home_shootout_score","away_shootout_score","tie_id","leg") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19) RETURNING "id"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.SeasonID, 1, homeTeamID, awayTeamID, firstLegAt, "", nil, model.MatchStatusScheduled, 0, 0, 0, 0, 0, 0, sqlmock.AnyArg(), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.mock.ExpectCommit()
+
+	suite.response.doCreateResp, suite.helper.err = suite.bracket.DoCreate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Len(suite.T(), suite.response.doCreateResp, 3)
+}
+
+// TestGetBracket ...
+func (suite *Suite) TestGetBracket() {
+	params := param.GetBracket{
+		SeasonID: uuid.NewV4(),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "bracket_ties" WHERE season_id = $1 ORDER BY round, position`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "season_id", "round", "position"}).
+			AddRow(uuid.NewV4(), params.SeasonID, 1, 0).
+			AddRow(uuid.NewV4(), params.SeasonID, 1, 1).
+			AddRow(uuid.NewV4(), params.SeasonID, 2, 0))
+
+	suite.response.getBracketResp, suite.helper.err = suite.bracket.GetBracket(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Len(suite.T(), suite.response.getBracketResp, 3)
+}
+
+// TestGetTie ...
+func (suite *Suite) TestGetTie() {
+	params := param.GetTie{
+		ID: uuid.NewV4(),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "bracket_ties" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "round", "position", "legs"}).
+			AddRow(params.ID, 1, 3, 2))
+
+	suite.response.getTieResp, suite.helper.err = suite.bracket.GetTie(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), 2, suite.response.getTieResp.Legs)
+}
+
+// TestDoAdvance ...
+func (suite *Suite) TestDoAdvance() {
+	params := param.DoAdvance{
+		TieID:        uuid.NewV4(),
+		SeasonID:     uuid.NewV4(),
+		Round:        1,
+		Position:     3,
+		WinnerTeamID: uuid.NewV4(),
+	}
+	nextTieID, homeTeamID := uuid.NewV4(), uuid.NewV4()
+	firstLegAt := time.Date(2021, 2, 16, 20, 0, 0, 0, time.UTC)
+	secondLegAt := firstLegAt.AddDate(0, 0, 7)
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "bracket_ties" SET "updated_at"=$1,"winner_team_id"=$2 WHERE id = $3 AND winner_team_id IS NULL`)).
+		WithArgs(sqlmock.AnyArg(), params.WinnerTeamID, params.TieID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "bracket_ties" WHERE season_id = $1 AND round = $2 AND position = $3 LIMIT 1 FOR UPDATE`)).
+		WithArgs(params.SeasonID, 2, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "season_id", "round", "position", "legs", "home_team_id", "first_leg_at", "second_leg_at"}).
+			AddRow(nextTieID, params.SeasonID, 2, 1, 2, homeTeamID, firstLegAt, secondLegAt))
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "bracket_ties" SET "away_team_id"=$1,"updated_at"=$2 WHERE id = $3`)).
+		WithArgs(params.WinnerTeamID, sqlmock.AnyArg(), nextTieID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "matches"`)).
+		WithArgs(
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.SeasonID, 2, homeTeamID, params.WinnerTeamID, firstLegAt, "", nil, model.MatchStatusScheduled, 0, 0, 0, 0, 0, 0, nextTieID, 1,
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.SeasonID, 2, params.WinnerTeamID, homeTeamID, secondLegAt, "", nil, model.MatchStatusScheduled, 0, 0, 0, 0, 0, 0, nextTieID, 2,
+		).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()).
+			AddRow(uuid.NewV4()))
+
+	suite.mock.ExpectCommit()
+
+	suite.response.doAdvanceResp, suite.helper.err = suite.bracket.DoAdvance(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), nextTieID, *suite.response.doAdvanceResp.NextTieID)
+}
+
+// TestDoAdvanceFinal ...
+func (suite *Suite) TestDoAdvanceFinal() {
+	params := param.DoAdvance{
+		TieID:        uuid.NewV4(),
+		SeasonID:     uuid.NewV4(),
+		Round:        3,
+		Position:     0,
+		WinnerTeamID: uuid.NewV4(),
+	}
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "bracket_ties" SET "updated_at"=$1,"winner_team_id"=$2 WHERE id = $3 AND winner_team_id IS NULL`)).
+		WithArgs(sqlmock.AnyArg(), params.WinnerTeamID, params.TieID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "bracket_ties" WHERE season_id = $1 AND round = $2 AND position = $3 LIMIT 1 FOR UPDATE`)).
+		WithArgs(params.SeasonID, 4, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.mock.ExpectCommit()
+
+	suite.response.doAdvanceResp, suite.helper.err = suite.bracket.DoAdvance(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), params.WinnerTeamID, suite.response.doAdvanceResp.WinnerTeamID)
+	require.Nil(suite.T(), suite.response.doAdvanceResp.NextTieID)
+}
+
+// TestDoAdvanceAlreadyDecided ...
+func (suite *Suite) TestDoAdvanceAlreadyDecided() {
+	params := param.DoAdvance{
+		TieID:        uuid.NewV4(),
+		SeasonID:     uuid.NewV4(),
+		Round:        1,
+		Position:     0,
+		WinnerTeamID: uuid.NewV4(),
+	}
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "bracket_ties" SET "updated_at"=$1,"winner_team_id"=$2 WHERE id = $3 AND winner_team_id IS NULL`)).
+		WithArgs(sqlmock.AnyArg(), params.WinnerTeamID, params.TieID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	suite.mock.ExpectRollback()
+
+	suite.response.doAdvanceResp, suite.helper.err = suite.bracket.DoAdvance(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.True(suite.T(), uuid.Equal(uuid.Nil, suite.response.doAdvanceResp.TieID))
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
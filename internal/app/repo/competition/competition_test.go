@@ -0,0 +1,185 @@
+package competition
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/handler/competition/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/competition/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"regexp"
+	"testing"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	pgsqlConn *gorm.DB
+	mock      sqlmock.Sqlmock
+
+	competition ICompetition
+	helper
+	response
+}
+
+type helper struct {
+	db  *sql.DB
+	err error
+}
+
+type response struct {
+	doCreateResp        transporter.DoCreate
+	getCompetitionsResp []transporter.GetCompetitions
+	getCompetitionResp  transporter.GetCompetition
+	doUpdateResp        transporter.DoUpdate
+	doDeleteResp        transporter.DoDelete
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.competition = New(
+		WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+}
+
+// TestDoCreate ...
+func (suite *Suite) TestDoCreate() {
+	params := param.DoCreate{
+		Competition: param.Competition{
+			Name:        "Premier League",
+			Type:        model.CompetitionTypeLeague,
+			Tiebreakers: param.Tiebreakers{model.TiebreakerGoalDifference, model.TiebreakerGoalsScored},
+		},
+	}
+
+	suite.mock.MatchExpectationsInOrder(false)
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "competitions" ("created_at","updated_at","deleted_at","name","type","tiebreakers","salary_cap","bench_size") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING "id"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.Name, params.Type, `{"goal_difference","goals_scored"}`, nil, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.response.doCreateResp, suite.helper.err = suite.competition.DoCreate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.Equal(suite.T(), "Premier League", suite.response.doCreateResp.Name)
+}
+
+// TestGetCompetitions ...
+func (suite *Suite) TestGetCompetitions() {
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "competitions" LIMIT 10 OFFSET 1`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(uuid.NewV4(), "Serie A"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seasons" WHERE "seasons"."competition_id" = $1`)).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(uuid.NewV4(), "2020/21"))
+
+	suite.response.getCompetitionsResp, suite.helper.err = suite.competition.GetCompetitions(context.Background(), param.GetCompetitions{Pagination: param.Pagination{
+		Limit:  "10",
+		Offset: "1",
+	}})
+
+	require.NoError(suite.T(), suite.helper.err)
+}
+
+// TestGetCompetition ...
+func (suite *Suite) TestGetCompetition() {
+	params := param.GetCompetition{ID: uuid.NewV4()}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "competitions" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(uuid.NewV4(), "La Liga"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seasons" WHERE "seasons"."competition_id" = $1`)).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(uuid.NewV4(), "2020/21"))
+
+	suite.response.getCompetitionResp, suite.helper.err = suite.competition.GetCompetition(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+}
+
+// TestDoUpdate ...
+func (suite *Suite) TestDoUpdate() {
+	params := param.DoUpdate{
+		Competition: param.Competition{
+			ID:          uuid.NewV4(),
+			Name:        "Bundesliga",
+			Type:        model.CompetitionTypeLeague,
+			Tiebreakers: param.Tiebreakers{model.TiebreakerHeadToHead, model.TiebreakerGoalDifference},
+		},
+	}
+
+	suite.mock.MatchExpectationsInOrder(false)
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "competitions" SET "updated_at"=$1,"name"=$2,"type"=$3,"tiebreakers"=$4 WHERE id = $5`)).
+		WithArgs(sqlmock.AnyArg(), params.Name, params.Type, `{"head_to_head","goal_difference"}`, params.ID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	suite.response.doUpdateResp, suite.helper.err = suite.competition.DoUpdate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+}
+
+func (suite *Suite) TestDoDelete() {
+	params := param.DoDelete{
+		ID: uuid.NewV4(),
+	}
+
+	suite.mock.MatchExpectationsInOrder(false)
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`DELETE FROM "competitions" WHERE id = $1`)).
+		WithArgs(params.ID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	suite.response.doDeleteResp, suite.helper.err = suite.competition.DoDelete(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
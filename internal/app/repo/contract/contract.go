@@ -0,0 +1,237 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package contract
+
+import (
+	"context"
+	"time"
+
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/contract/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/contract/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// IContract is an interface that stores the methods that Contract struct will use.
+type IContract interface {
+	// DoCreate is used for record new contract.
+	// It returns doCreateResp of transporter.DoCreate and any errors written.
+	DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error)
+
+	// GetContracts is used for getting all contracts of a player, the oldest first.
+	// It returns getContractsResp of []transporter.GetContracts and any errors written.
+	GetContracts(ctx context.Context, params param.GetContracts) (getContractsResp []transporter.GetContracts, err error)
+
+	// GetContract is used for getting a contract of a player.
+	// It returns getContractResp of transporter.GetContract and any errors written.
+	GetContract(ctx context.Context, params param.GetContract) (getContractResp transporter.GetContract, err error)
+
+	// DoUpdate is used for update the record contract.
+	// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+	DoUpdate(ctx context.Context, params param.DoUpdate) (doUpdateResp transporter.DoUpdate, err error)
+
+	// GetValidContract is used for getting the active contract binding a player to a team on the given date.
+	// It returns getValidContractResp of transporter.GetValidContract and any errors written.
+	GetValidContract(ctx context.Context, params param.GetValidContract) (getValidContractResp transporter.GetValidContract, err error)
+
+	// GetPayroll is used for getting the wages a team pays on the given date.
+	// It returns getPayrollResp of transporter.GetPayroll and any errors written.
+	GetPayroll(ctx context.Context, params param.GetPayroll) (getPayrollResp transporter.GetPayroll, err error)
+
+	// GetSalaryCaps is used for getting the salary caps a team has to stay under on the given date.
+	// It returns getSalaryCapsResp of []transporter.GetSalaryCaps and any errors written.
+	GetSalaryCaps(ctx context.Context, params param.GetSalaryCaps) (getSalaryCapsResp []transporter.GetSalaryCaps, err error)
+}
+
+// Contract is an struct that implements IContract methods.
+type Contract struct {
+	config   config.IConfig
+	ormMySQL *gorm.DB
+	ormPgSQL *gorm.DB
+	statement
+}
+
+type statement struct {
+	ormTX       *gorm.DB
+	ormChaining *gorm.DB
+}
+
+// New it returns instance of Contract that implements IContract methods.
+func New(opts ...Option) IContract {
+	c := new(Contract)
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// DoCreate is used for record new contract.
+// It returns doCreateResp of transporter.DoCreate and any errors written.
+func (contract *Contract) DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error) {
+	recordContract := model.Contract{
+		PlayerID:      params.PlayerID,
+		TeamID:        params.TeamID,
+		StartsAt:      params.StartsAt,
+		EndsAt:        params.EndsAt,
+		Wage:          params.Wage,
+		ReleaseClause: params.ReleaseClause,
+		Status:        model.ContractStatusActive,
+	}
+
+	if err = contract.ormPgSQL.WithContext(ctx).Create(&recordContract).Error; err != nil {
+		return
+	}
+
+	doCreateResp.Contract = transporter.Contract{
+		ID:            recordContract.ID,
+		PlayerID:      recordContract.PlayerID,
+		TeamID:        recordContract.TeamID,
+		StartsAt:      recordContract.StartsAt,
+		EndsAt:        recordContract.EndsAt,
+		Wage:          recordContract.Wage,
+		ReleaseClause: recordContract.ReleaseClause,
+		Status:        recordContract.Status,
+	}
+
+	return
+}
+
+// GetContracts is used for getting all contracts of a player, the oldest first.
+// It returns getContractsResp of []transporter.GetContracts and any errors written.
+func (contract *Contract) GetContracts(ctx context.Context, params param.GetContracts) (getContractsResp []transporter.GetContracts, err error) {
+	contract.ormChaining = contract.ormPgSQL.
+		WithContext(ctx).
+		Where("player_id = ?", params.PlayerID).
+		Order("starts_at")
+
+	if err = contract.ormChaining.Find(&getContractsResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// GetContract is used for getting a contract of a player.
+// It returns getContractResp of transporter.GetContract and any errors written.
+func (contract *Contract) GetContract(ctx context.Context, params param.GetContract) (getContractResp transporter.GetContract, err error) {
+	contract.ormChaining = contract.ormPgSQL.
+		WithContext(ctx).
+		Where("id = ? AND player_id = ?", params.ID, params.PlayerID).
+		Limit(1)
+
+	if err = contract.ormChaining.Find(&getContractResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// DoUpdate is used for update the record contract.
+// Every term is written, so a wage or release clause can be cleared.
+// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+func (contract *Contract) DoUpdate(ctx context.Context, params param.DoUpdate) (doUpdateResp transporter.DoUpdate, err error) {
+	recordContract := model.Contract{
+		StartsAt:      params.StartsAt,
+		EndsAt:        params.EndsAt,
+		Wage:          params.Wage,
+		ReleaseClause: params.ReleaseClause,
+		Status:        params.Status,
+	}
+
+	contract.ormChaining = contract.ormPgSQL.
+		WithContext(ctx).
+		Select("updated_at", "starts_at", "ends_at", "wage", "release_clause", "status").
+		Where("id = ? AND player_id = ?", params.ID, params.PlayerID)
+
+	if err = contract.ormChaining.Updates(&recordContract).Error; err != nil {
+		return
+	}
+
+	doUpdateResp.Contract = transporter.Contract{
+		ID:            params.ID,
+		PlayerID:      params.PlayerID,
+		TeamID:        params.TeamID,
+		StartsAt:      recordContract.StartsAt,
+		EndsAt:        recordContract.EndsAt,
+		Wage:          recordContract.Wage,
+		ReleaseClause: recordContract.ReleaseClause,
+		Status:        recordContract.Status,
+	}
+
+	return
+}
+
+// GetValidContract is used for getting the active contract binding a player to a team on the given date.
+// It returns getValidContractResp of transporter.GetValidContract and any errors written.
+func (contract *Contract) GetValidContract(ctx context.Context, params param.GetValidContract) (getValidContractResp transporter.GetValidContract, err error) {
+	contract.ormChaining = contract.ormPgSQL.
+		WithContext(ctx).
+		Where("player_id = ? AND team_id = ? AND status = ? AND starts_at <= ? AND ends_at >= ?", params.PlayerID, params.TeamID, model.ContractStatusActive, params.Date, params.Date).
+		Limit(1)
+
+	if err = contract.ormChaining.Find(&getValidContractResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// GetPayroll is used for getting the wages a team pays on the given date.
+// The contract with ExcludeID is left out, so it can be counted with its new wage instead.
+// It returns getPayrollResp of transporter.GetPayroll and any errors written.
+func (contract *Contract) GetPayroll(ctx context.Context, params param.GetPayroll) (getPayrollResp transporter.GetPayroll, err error) {
+	contract.ormChaining = contract.ormPgSQL.
+		WithContext(ctx).
+		Model(&model.Contract{}).
+		Select("COALESCE(SUM(wage), 0) AS total").
+		Where("team_id = ? AND status = ? AND starts_at <= ? AND ends_at >= ? AND id <> ?", params.TeamID, model.ContractStatusActive, params.Date, params.Date, params.ExcludeID)
+
+	if err = contract.ormChaining.Scan(&getPayrollResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// GetSalaryCaps is used for getting the salary caps a team has to stay under on the given date.
+// They come from the competitions of every season the team is registered in that has not ended yet,
+// along with the competition of SeasonID, a season the team is about to join.
+// It returns getSalaryCapsResp of []transporter.GetSalaryCaps and any errors written.
+func (contract *Contract) GetSalaryCaps(ctx context.Context, params param.GetSalaryCaps) (getSalaryCapsResp []transporter.GetSalaryCaps, err error) {
+	contract.ormChaining = contract.ormPgSQL.
+		WithContext(ctx).
+		Model(&model.Competition{}).
+		Distinct("competitions.id AS competition_id", "competitions.name AS competition_name", "competitions.salary_cap").
+		Joins("JOIN seasons ON seasons.competition_id = competitions.id").
+		Where("competitions.salary_cap IS NOT NULL").
+		Where("seasons.id = ? OR (seasons.end_date >= ? AND seasons.id IN (SELECT season_id FROM season_teams WHERE team_id = ?))", params.SeasonID, params.Date, params.TeamID).
+		Order("competitions.salary_cap")
+
+	if err = contract.ormChaining.Scan(&getSalaryCapsResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// Replace is used for terminating the active contract of the player on the given connection
+// and signing recordContract in its place, so a transfer or the end of a loan can sign
+// the new contract within its own transaction.
+// It returns any errors written.
+func Replace(db *gorm.DB, recordContract *model.Contract) (err error) {
+	if err = db.
+		Model(&model.Contract{}).
+		Where("player_id = ? AND status = ?", recordContract.PlayerID, model.ContractStatusActive).
+		Updates(map[string]interface{}{
+			"status":     model.ContractStatusTerminated,
+			"updated_at": time.Now(),
+		}).Error; err != nil {
+		return
+	}
+
+	return db.Create(recordContract).Error
+}
@@ -0,0 +1,173 @@
+package contract
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/handler/contract/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/contract/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	pgsqlConn *gorm.DB
+	mock      sqlmock.Sqlmock
+
+	contract IContract
+	helper
+	response
+}
+
+type helper struct {
+	db  *sql.DB
+	err error
+}
+
+type response struct {
+	doCreateResp      transporter.DoCreate
+	getPayrollResp    transporter.GetPayroll
+	getSalaryCapsResp []transporter.GetSalaryCaps
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.contract = New(
+		WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+}
+
+// TestDoCreate ...
+func (suite *Suite) TestDoCreate() {
+	params := param.DoCreate{
+		Contract: param.Contract{
+			PlayerID: uuid.NewV4(),
+			TeamID:   uuid.NewV4(),
+			StartsAt: time.Date(2021, time.July, 1, 0, 0, 0, 0, time.UTC),
+			Terms: param.Terms{
+				EndsAt: time.Date(2024, time.June, 30, 0, 0, 0, 0, time.UTC),
+				Wage:   2500000,
+			},
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "contracts" ("created_at","updated_at","deleted_at","player_id","team_id","starts_at","ends_at","wage","release_clause","status") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10) RETURNING "id"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.PlayerID, params.TeamID, params.StartsAt, params.EndsAt, params.Wage, nil, model.ContractStatusActive).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.response.doCreateResp, suite.helper.err = suite.contract.DoCreate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), model.ContractStatusActive, suite.response.doCreateResp.Status)
+}
+
+// TestGetPayroll ...
+func (suite *Suite) TestGetPayroll() {
+	params := param.GetPayroll{
+		TeamID: uuid.NewV4(),
+		Date:   time.Date(2021, time.July, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(wage), 0) AS total FROM "contracts" WHERE team_id = $1 AND status = $2 AND starts_at <= $3 AND ends_at >= $4 AND id <> $5`)).
+		WithArgs(params.TeamID, model.ContractStatusActive, params.Date, params.Date, params.ExcludeID).
+		WillReturnRows(sqlmock.NewRows([]string{"total"}).
+			AddRow(7500000))
+
+	suite.response.getPayrollResp, suite.helper.err = suite.contract.GetPayroll(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), int64(7500000), suite.response.getPayrollResp.Total)
+}
+
+// TestGetSalaryCaps ...
+func (suite *Suite) TestGetSalaryCaps() {
+	params := param.GetSalaryCaps{
+		TeamID:   uuid.NewV4(),
+		Date:     time.Date(2021, time.July, 1, 0, 0, 0, 0, time.UTC),
+		SeasonID: uuid.NewV4(),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT DISTINCT competitions.id AS competition_id,competitions.name AS competition_name,competitions.salary_cap FROM "competitions" JOIN seasons ON seasons.competition_id = competitions.id WHERE competitions.salary_cap IS NOT NULL AND (seasons.id = $1 OR (seasons.end_date >= $2 AND seasons.id IN (SELECT season_id FROM season_teams WHERE team_id = $3))) ORDER BY competitions.salary_cap`)).
+		WithArgs(params.SeasonID, params.Date, params.TeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"competition_id", "competition_name", "salary_cap"}).
+			AddRow(uuid.NewV4(), "Premier League", 90000000))
+
+	suite.response.getSalaryCapsResp, suite.helper.err = suite.contract.GetSalaryCaps(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Len(suite.T(), suite.response.getSalaryCapsResp, 1)
+	require.Equal(suite.T(), int64(90000000), suite.response.getSalaryCapsResp[0].SalaryCap)
+}
+
+// TestReplace ...
+func (suite *Suite) TestReplace() {
+	recordContract := model.Contract{
+		PlayerID: uuid.NewV4(),
+		TeamID:   uuid.NewV4(),
+		StartsAt: time.Date(2021, time.July, 1, 0, 0, 0, 0, time.UTC),
+		EndsAt:   time.Date(2024, time.June, 30, 0, 0, 0, 0, time.UTC),
+		Wage:     2500000,
+		Status:   model.ContractStatusActive,
+	}
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "contracts" SET "status"=$1,"updated_at"=$2 WHERE player_id = $3 AND status = $4`)).
+		WithArgs(model.ContractStatusTerminated, sqlmock.AnyArg(), recordContract.PlayerID, model.ContractStatusActive).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "contracts"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), recordContract.PlayerID, recordContract.TeamID, recordContract.StartsAt, recordContract.EndsAt, recordContract.Wage, nil, model.ContractStatusActive).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.helper.err = Replace(suite.pgsqlConn, &recordContract)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.NotEqual(suite.T(), uuid.Nil, recordContract.ID)
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package draw
+
+import (
+	"context"
+
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/draw/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/draw/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// IDraw is an interface that stores the methods that Draw struct will use.
+type IDraw interface {
+	// DoCreate is used for record a draw along with every placement in a single transaction.
+	// It returns doCreateResp of transporter.DoCreate and any errors written.
+	DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error)
+
+	// GetDraw is used for getting a draw of a season with its placements in the order they were drawn.
+	// It returns getDrawResp of transporter.GetDraw and any errors written.
+	GetDraw(ctx context.Context, params param.GetDraw) (getDrawResp transporter.GetDraw, err error)
+}
+
+// Draw is an struct that implements IDraw methods.
+type Draw struct {
+	config   config.IConfig
+	ormMySQL *gorm.DB
+	ormPgSQL *gorm.DB
+	statement
+}
+
+type statement struct {
+	ormTX       *gorm.DB
+	ormChaining *gorm.DB
+}
+
+// New it returns instance of Draw that implements IDraw methods.
+func New(opts ...Option) IDraw {
+	d := new(Draw)
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
+}
+
+// DoCreate is used for record a draw along with every placement in a single transaction.
+// It returns doCreateResp of transporter.DoCreate and any errors written.
+func (draw *Draw) DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error) {
+	recordDraw := model.Draw{
+		SeasonID:             params.SeasonID,
+		Kind:                 params.Kind,
+		Seed:                 params.Seed,
+		Groups:               params.Groups,
+		SeparateAssociations: params.Constraints.SeparateAssociations,
+		SeparateGroups:       params.Constraints.SeparateGroups,
+	}
+
+	draw.ormTX = draw.ormPgSQL.WithContext(ctx).Begin()
+
+	if err = draw.ormTX.Create(&recordDraw).Error; err != nil {
+		draw.ormTX.Rollback()
+		return
+	}
+
+	recordDrawPlacements := make([]model.DrawPlacement, 0, len(params.Placements))
+	for _, placement := range params.Placements {
+		recordDrawPlacements = append(recordDrawPlacements, model.DrawPlacement{
+			DrawID:        recordDraw.ID,
+			TeamID:        placement.TeamID,
+			Pot:           placement.Pot,
+			PotPosition:   placement.PotPosition,
+			Association:   placement.Association,
+			PreviousGroup: placement.PreviousGroup,
+			Sequence:      placement.Sequence,
+			GroupName:     placement.GroupName,
+			Tie:           placement.Tie,
+			Slot:          placement.Slot,
+		})
+	}
+
+	if len(recordDrawPlacements) > 0 {
+		if err = draw.ormTX.Create(&recordDrawPlacements).Error; err != nil {
+			draw.ormTX.Rollback()
+			return
+		}
+	}
+
+	if err = draw.ormTX.Commit().Error; err != nil {
+		return
+	}
+
+	doCreateResp.Draw = transporter.Draw{
+		ID:                   recordDraw.ID,
+		SeasonID:             recordDraw.SeasonID,
+		Kind:                 recordDraw.Kind,
+		Seed:                 recordDraw.Seed,
+		Groups:               recordDraw.Groups,
+		SeparateAssociations: recordDraw.SeparateAssociations,
+		SeparateGroups:       recordDraw.SeparateGroups,
+	}
+
+	for _, recordDrawPlacement := range recordDrawPlacements {
+		doCreateResp.Placements = append(doCreateResp.Placements, transporter.Placement{
+			ID:            recordDrawPlacement.ID,
+			DrawID:        recordDrawPlacement.DrawID,
+			TeamID:        recordDrawPlacement.TeamID,
+			Pot:           recordDrawPlacement.Pot,
+			PotPosition:   recordDrawPlacement.PotPosition,
+			Association:   recordDrawPlacement.Association,
+			PreviousGroup: recordDrawPlacement.PreviousGroup,
+			Sequence:      recordDrawPlacement.Sequence,
+			GroupName:     recordDrawPlacement.GroupName,
+			Tie:           recordDrawPlacement.Tie,
+			Slot:          recordDrawPlacement.Slot,
+		})
+	}
+
+	return
+}
+
+// GetDraw is used for getting a draw of a season with its placements in the order they were drawn.
+// It returns getDrawResp of transporter.GetDraw and any errors written.
+func (draw *Draw) GetDraw(ctx context.Context, params param.GetDraw) (getDrawResp transporter.GetDraw, err error) {
+	draw.ormChaining = draw.ormPgSQL.
+		WithContext(ctx).
+		Preload("Placements", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sequence")
+		}).
+		Where("id = ? AND season_id = ?", params.ID, params.SeasonID).
+		Limit(1)
+
+	if err = draw.ormChaining.Find(&getDrawResp).Error; err != nil {
+		return
+	}
+
+	return
+}
@@ -0,0 +1,144 @@
+package draw
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/handler/draw/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/draw/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	pgsqlConn *gorm.DB
+	mock      sqlmock.Sqlmock
+
+	draw IDraw
+	helper
+	response
+}
+
+type helper struct {
+	db  *sql.DB
+	err error
+}
+
+type response struct {
+	doCreateResp transporter.DoCreate
+	getDrawResp  transporter.GetDraw
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.draw = New(
+		WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+}
+
+// TestDoCreate ...
+func (suite *Suite) TestDoCreate() {
+	drawID := uuid.NewV4()
+	params := param.DoCreate{
+		SeasonID: uuid.NewV4(),
+		Kind:     model.DrawKindTies,
+		Seed:     42,
+		Constraints: param.Constraints{
+			SeparateGroups: true,
+		},
+		Placements: []param.Placement{
+			{TeamID: uuid.NewV4(), Pot: 2, PotPosition: 1, Association: "ENG", PreviousGroup: "B", Sequence: 1, Tie: 1, Slot: model.BracketSlotHome},
+			{TeamID: uuid.NewV4(), Pot: 1, PotPosition: 1, Association: "ESP", PreviousGroup: "A", Sequence: 2, Tie: 1, Slot: model.BracketSlotAway},
+		},
+	}
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "draws" ("created_at","updated_at","deleted_at","season_id","kind","seed","groups","separate_associations","separate_groups") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING "id"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.SeasonID, params.Kind, params.Seed, 0, false, true).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(drawID))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "draw_placements" ("created_at","updated_at","deleted_at","draw_id","team_id","pot","pot_position","association","previous_group","sequence","group_name","tie","slot") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13),($14,$15,$16,$17,$18,$19,$20,$21,$22,$23,$24,$25,$26) RETURNING "id"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()).
+			AddRow(uuid.NewV4()))
+
+	suite.mock.ExpectCommit()
+
+	suite.response.doCreateResp, suite.helper.err = suite.draw.DoCreate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), drawID, suite.response.doCreateResp.ID)
+	require.Len(suite.T(), suite.response.doCreateResp.Placements, 2)
+	require.Equal(suite.T(), drawID, suite.response.doCreateResp.Placements[1].DrawID)
+}
+
+// TestGetDraw ...
+func (suite *Suite) TestGetDraw() {
+	params := param.GetDraw{
+		ID:       uuid.NewV4(),
+		SeasonID: uuid.NewV4(),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "draws" WHERE id = $1 AND season_id = $2 LIMIT 1`)).
+		WithArgs(params.ID, params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "season_id", "kind", "seed", "groups"}).
+			AddRow(params.ID, params.SeasonID, model.DrawKindGroups, 7, 2))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "draw_placements" WHERE "draw_placements"."draw_id" = $1 ORDER BY sequence`)).
+		WithArgs(params.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "draw_id", "team_id", "pot", "pot_position", "sequence", "group_name"}).
+			AddRow(uuid.NewV4(), params.ID, uuid.NewV4(), 1, 1, 1, "B").
+			AddRow(uuid.NewV4(), params.ID, uuid.NewV4(), 1, 2, 2, "A"))
+
+	suite.response.getDrawResp, suite.helper.err = suite.draw.GetDraw(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), int64(7), suite.response.getDrawResp.Seed)
+	require.Len(suite.T(), suite.response.getDrawResp.Placements, 2)
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package group
+
+import (
+	"context"
+
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/group/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/group/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IGroup is an interface that stores the methods that Group struct will use.
+type IGroup interface {
+	// DoCreate is used for record new group along with its teams in a single transaction.
+	// It returns doCreateResp of transporter.DoCreate and any errors written.
+	DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error)
+
+	// GetGroups is used for getting all groups of a season with their teams.
+	// It returns getGroupsResp of []transporter.GetGroups and any errors written.
+	GetGroups(ctx context.Context, params param.GetGroups) (getGroupsResp []transporter.GetGroups, err error)
+
+	// DoUpdateQualification is used for record the qualification rules of a season, replacing the previous ones.
+	// It returns doUpdateQualificationResp of transporter.DoUpdateQualification and any errors written.
+	DoUpdateQualification(ctx context.Context, params param.DoUpdateQualification) (doUpdateQualificationResp transporter.DoUpdateQualification, err error)
+
+	// GetQualification is used for getting the qualification rules of a season.
+	// It returns getQualificationResp of transporter.GetQualification and any errors written.
+	GetQualification(ctx context.Context, params param.GetQualification) (getQualificationResp transporter.GetQualification, err error)
+}
+
+// Group is an struct that implements IGroup methods.
+type Group struct {
+	config   config.IConfig
+	ormMySQL *gorm.DB
+	ormPgSQL *gorm.DB
+	statement
+}
+
+type statement struct {
+	ormTX       *gorm.DB
+	ormChaining *gorm.DB
+}
+
+// New it returns instance of Group that implements IGroup methods.
+func New(opts ...Option) IGroup {
+	g := new(Group)
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	return g
+}
+
+// DoCreate is used for record new group along with its teams in a single transaction.
+// It returns doCreateResp of transporter.DoCreate and any errors written.
+func (group *Group) DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error) {
+	recordGroup := model.Group{
+		SeasonID: params.SeasonID,
+		Name:     params.Name,
+	}
+
+	group.ormTX = group.ormPgSQL.WithContext(ctx).Begin()
+
+	if err = group.ormTX.Create(&recordGroup).Error; err != nil {
+		group.ormTX.Rollback()
+		return
+	}
+
+	recordGroupTeams := make([]model.GroupTeam, 0, len(params.TeamIDs))
+	for _, teamID := range params.TeamIDs {
+		recordGroupTeams = append(recordGroupTeams, model.GroupTeam{
+			GroupID: recordGroup.ID,
+			TeamID:  teamID,
+		})
+	}
+
+	if err = group.ormTX.Create(&recordGroupTeams).Error; err != nil {
+		group.ormTX.Rollback()
+		return
+	}
+
+	if err = group.ormTX.Commit().Error; err != nil {
+		return
+	}
+
+	doCreateResp.Group = transporter.Group{
+		ID:       recordGroup.ID,
+		SeasonID: recordGroup.SeasonID,
+		Name:     recordGroup.Name,
+	}
+
+	for _, recordGroupTeam := range recordGroupTeams {
+		doCreateResp.Teams = append(doCreateResp.Teams, transporter.GroupTeam{
+			GroupID: recordGroupTeam.GroupID,
+			TeamID:  recordGroupTeam.TeamID,
+		})
+	}
+
+	return
+}
+
+// GetGroups is used for getting all groups of a season with their teams.
+// It returns getGroupsResp of []transporter.GetGroups and any errors written.
+func (group *Group) GetGroups(ctx context.Context, params param.GetGroups) (getGroupsResp []transporter.GetGroups, err error) {
+	group.ormChaining = group.ormPgSQL.
+		WithContext(ctx).
+		Preload("Teams").
+		Where("season_id = ?", params.SeasonID).
+		Order("name")
+
+	if err = group.ormChaining.Find(&getGroupsResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// DoUpdateQualification is used for record the qualification rules of a season, replacing the previous ones.
+// It returns doUpdateQualificationResp of transporter.DoUpdateQualification and any errors written.
+func (group *Group) DoUpdateQualification(ctx context.Context, params param.DoUpdateQualification) (doUpdateQualificationResp transporter.DoUpdateQualification, err error) {
+	recordQualificationRule := model.QualificationRule{
+		SeasonID:    params.SeasonID,
+		TopPerGroup: params.TopPerGroup,
+		BestPlaced:  params.BestPlaced,
+	}
+
+	group.ormChaining = group.ormPgSQL.
+		WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "season_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"top_per_group", "best_placed", "updated_at"}),
+		})
+
+	if err = group.ormChaining.Create(&recordQualificationRule).Error; err != nil {
+		return
+	}
+
+	doUpdateQualificationResp.Qualification = transporter.Qualification{
+		SeasonID:    recordQualificationRule.SeasonID,
+		TopPerGroup: recordQualificationRule.TopPerGroup,
+		BestPlaced:  recordQualificationRule.BestPlaced,
+	}
+
+	return
+}
+
+// GetQualification is used for getting the qualification rules of a season.
+// It returns getQualificationResp of transporter.GetQualification and any errors written.
+func (group *Group) GetQualification(ctx context.Context, params param.GetQualification) (getQualificationResp transporter.GetQualification, err error) {
+	group.ormChaining = group.ormPgSQL.
+		WithContext(ctx).
+		Where("season_id = ?", params.SeasonID).
+		Limit(1)
+
+	if err = group.ormChaining.Find(&getQualificationResp).Error; err != nil {
+		return
+	}
+
+	return
+}
@@ -0,0 +1,176 @@
+package group
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/handler/group/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/group/transporter"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	pgsqlConn *gorm.DB
+	mock      sqlmock.Sqlmock
+
+	group IGroup
+	helper
+	response
+}
+
+type helper struct {
+	db  *sql.DB
+	err error
+}
+
+type response struct {
+	doCreateResp              transporter.DoCreate
+	getGroupsResp             []transporter.GetGroups
+	doUpdateQualificationResp transporter.DoUpdateQualification
+	getQualificationResp      transporter.GetQualification
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.group = New(
+		WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+}
+
+// TestDoCreate ...
+func (suite *Suite) TestDoCreate() {
+	groupID := uuid.NewV4()
+	params := param.DoCreate{
+		SeasonID: uuid.NewV4(),
+		Name:     "A",
+		TeamIDs:  []uuid.UUID{uuid.NewV4(), uuid.NewV4()},
+	}
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "groups" ("created_at","updated_at","deleted_at","season_id","name") VALUES ($1,$2,$3,$4,$5) RETURNING "id"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.SeasonID, params.Name).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(groupID))
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`INSERT INTO "group_teams" ("group_id","team_id","created_at") VALUES ($1,$2,$3),($4,$5,$6)`)).
+		WithArgs(groupID, params.TeamIDs[0], sqlmock.AnyArg(), groupID, params.TeamIDs[1], sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	suite.mock.ExpectCommit()
+
+	suite.response.doCreateResp, suite.helper.err = suite.group.DoCreate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), groupID, suite.response.doCreateResp.ID)
+	require.Len(suite.T(), suite.response.doCreateResp.Teams, 2)
+}
+
+// TestGetGroups ...
+func (suite *Suite) TestGetGroups() {
+	params := param.GetGroups{
+		SeasonID: uuid.NewV4(),
+	}
+	groupAID, groupBID := uuid.NewV4(), uuid.NewV4()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "groups" WHERE season_id = $1 ORDER BY name`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "season_id", "name"}).
+			AddRow(groupAID, params.SeasonID, "A").
+			AddRow(groupBID, params.SeasonID, "B"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "group_teams" WHERE "group_teams"."group_id" IN ($1,$2)`)).
+		WithArgs(groupAID, groupBID).
+		WillReturnRows(sqlmock.NewRows([]string{"group_id", "team_id"}).
+			AddRow(groupAID, uuid.NewV4()).
+			AddRow(groupAID, uuid.NewV4()).
+			AddRow(groupBID, uuid.NewV4()))
+
+	suite.response.getGroupsResp, suite.helper.err = suite.group.GetGroups(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Len(suite.T(), suite.response.getGroupsResp, 2)
+	require.Len(suite.T(), suite.response.getGroupsResp[0].Teams, 2)
+	require.Len(suite.T(), suite.response.getGroupsResp[1].Teams, 1)
+}
+
+// TestDoUpdateQualification ...
+func (suite *Suite) TestDoUpdateQualification() {
+	params := param.DoUpdateQualification{
+		SeasonID:    uuid.NewV4(),
+		TopPerGroup: 2,
+		BestPlaced:  4,
+	}
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`INSERT INTO "qualification_rules" ("season_id","top_per_group","best_placed","created_at","updated_at") VALUES ($1,$2,$3,$4,$5) ON CONFLICT ("season_id") DO UPDATE SET "top_per_group"="excluded"."top_per_group","best_placed"="excluded"."best_placed","updated_at"="excluded"."updated_at"`)).
+		WithArgs(params.SeasonID, 2, 4, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	suite.response.doUpdateQualificationResp, suite.helper.err = suite.group.DoUpdateQualification(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), 4, suite.response.doUpdateQualificationResp.BestPlaced)
+}
+
+// TestGetQualification ...
+func (suite *Suite) TestGetQualification() {
+	params := param.GetQualification{
+		SeasonID: uuid.NewV4(),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "qualification_rules" WHERE season_id = $1 LIMIT 1`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"season_id", "top_per_group", "best_placed"}).
+			AddRow(params.SeasonID, 2, 0))
+
+	suite.response.getQualificationResp, suite.helper.err = suite.group.GetQualification(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), 2, suite.response.getQualificationResp.TopPerGroup)
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package injury
+
+import (
+	"context"
+
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/injury/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/injury/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// IInjury is an interface that stores the methods that Injury struct will use.
+type IInjury interface {
+	// DoCreate is used for record new injury.
+	// It returns doCreateResp of transporter.DoCreate and any errors written.
+	DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error)
+
+	// GetInjuries is used for getting all injuries of a player, the latest first.
+	// It returns getInjuriesResp of []transporter.GetInjuries and any errors written.
+	GetInjuries(ctx context.Context, params param.GetInjuries) (getInjuriesResp []transporter.GetInjuries, err error)
+
+	// GetInjury is used for getting an injury of a player.
+	// It returns getInjuryResp of transporter.GetInjury and any errors written.
+	GetInjury(ctx context.Context, params param.GetInjury) (getInjuryResp transporter.GetInjury, err error)
+
+	// DoUpdate is used for update the record injury.
+	// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+	DoUpdate(ctx context.Context, params param.DoUpdate) (doUpdateResp transporter.DoUpdate, err error)
+
+	// GetTeamInjuries is used for getting the injuries keeping players of a team out on the given date.
+	// It returns getTeamInjuriesResp of []transporter.GetTeamInjuries and any errors written.
+	GetTeamInjuries(ctx context.Context, params param.GetTeamInjuries) (getTeamInjuriesResp []transporter.GetTeamInjuries, err error)
+}
+
+// Injury is an struct that implements IInjury methods.
+type Injury struct {
+	config   config.IConfig
+	ormMySQL *gorm.DB
+	ormPgSQL *gorm.DB
+	statement
+}
+
+type statement struct {
+	ormTX       *gorm.DB
+	ormChaining *gorm.DB
+}
+
+// New it returns instance of Injury that implements IInjury methods.
+func New(opts ...Option) IInjury {
+	i := new(Injury)
+	for _, opt := range opts {
+		opt(i)
+	}
+
+	return i
+}
+
+// DoCreate is used for record new injury.
+// It returns doCreateResp of transporter.DoCreate and any errors written.
+func (injury *Injury) DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error) {
+	recordInjury := model.Injury{
+		PlayerID:         params.PlayerID,
+		Type:             params.Type,
+		StartedAt:        params.StartedAt,
+		ExpectedReturnAt: params.ExpectedReturnAt,
+	}
+
+	if err = injury.ormPgSQL.WithContext(ctx).Create(&recordInjury).Error; err != nil {
+		return
+	}
+
+	doCreateResp.Injury = transporter.Injury{
+		ID:               recordInjury.ID,
+		PlayerID:         recordInjury.PlayerID,
+		Type:             recordInjury.Type,
+		StartedAt:        recordInjury.StartedAt,
+		ExpectedReturnAt: recordInjury.ExpectedReturnAt,
+	}
+
+	return
+}
+
+// GetInjuries is used for getting all injuries of a player, the latest first.
+// It returns getInjuriesResp of []transporter.GetInjuries and any errors written.
+func (injury *Injury) GetInjuries(ctx context.Context, params param.GetInjuries) (getInjuriesResp []transporter.GetInjuries, err error) {
+	injury.ormChaining = injury.ormPgSQL.
+		WithContext(ctx).
+		Where("player_id = ? AND deleted_at IS NULL", params.PlayerID).
+		Order("started_at DESC")
+
+	if err = injury.ormChaining.Find(&getInjuriesResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// GetInjury is used for getting an injury of a player.
+// It returns getInjuryResp of transporter.GetInjury and any errors written.
+func (injury *Injury) GetInjury(ctx context.Context, params param.GetInjury) (getInjuryResp transporter.GetInjury, err error) {
+	injury.ormChaining = injury.ormPgSQL.
+		WithContext(ctx).
+		Where("id = ? AND player_id = ? AND deleted_at IS NULL", params.ID, params.PlayerID).
+		Limit(1)
+
+	if err = injury.ormChaining.Find(&getInjuryResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// DoUpdate is used for update the record injury.
+// Every field is written, so an expected return can be cleared.
+// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+func (injury *Injury) DoUpdate(ctx context.Context, params param.DoUpdate) (doUpdateResp transporter.DoUpdate, err error) {
+	recordInjury := model.Injury{
+		Type:             params.Type,
+		StartedAt:        params.StartedAt,
+		ExpectedReturnAt: params.ExpectedReturnAt,
+	}
+
+	injury.ormChaining = injury.ormPgSQL.
+		WithContext(ctx).
+		Select("updated_at", "type", "started_at", "expected_return_at").
+		Where("id = ? AND player_id = ?", params.ID, params.PlayerID)
+
+	if err = injury.ormChaining.Updates(&recordInjury).Error; err != nil {
+		return
+	}
+
+	doUpdateResp.Injury = transporter.Injury{
+		ID:               params.ID,
+		PlayerID:         params.PlayerID,
+		Type:             recordInjury.Type,
+		StartedAt:        recordInjury.StartedAt,
+		ExpectedReturnAt: recordInjury.ExpectedReturnAt,
+	}
+
+	return
+}
+
+// GetTeamInjuries is used for getting the injuries keeping players of a team out on the given date.
+// An injury without an expected return keeps the player out until it is given one.
+// It returns getTeamInjuriesResp of []transporter.GetTeamInjuries and any errors written.
+func (injury *Injury) GetTeamInjuries(ctx context.Context, params param.GetTeamInjuries) (getTeamInjuriesResp []transporter.GetTeamInjuries, err error) {
+	injury.ormChaining = injury.ormPgSQL.
+		WithContext(ctx).
+		Model(&model.Injury{}).
+		Select("injuries.*, players.name").
+		Joins("JOIN players ON players.id = injuries.player_id").
+		Where("players.team_id = ? AND players.deleted_at IS NULL AND injuries.deleted_at IS NULL", params.TeamID).
+		Where("injuries.started_at <= ? AND (injuries.expected_return_at IS NULL OR injuries.expected_return_at > ?)", params.Date, params.Date).
+		Order("players.name")
+
+	if err = injury.ormChaining.Scan(&getTeamInjuriesResp).Error; err != nil {
+		return
+	}
+
+	return
+}
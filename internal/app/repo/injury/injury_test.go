@@ -0,0 +1,147 @@
+package injury
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/handler/injury/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/injury/transporter"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	pgsqlConn *gorm.DB
+	mock      sqlmock.Sqlmock
+
+	injury IInjury
+	helper
+	response
+}
+
+type helper struct {
+	db  *sql.DB
+	err error
+}
+
+type response struct {
+	doCreateResp        transporter.DoCreate
+	doUpdateResp        transporter.DoUpdate
+	getTeamInjuriesResp []transporter.GetTeamInjuries
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.injury = New(
+		WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+}
+
+// TestDoCreate ...
+func (suite *Suite) TestDoCreate() {
+	params := param.DoCreate{
+		Injury: param.Injury{
+			PlayerID:  uuid.NewV4(),
+			Type:      "hamstring strain",
+			StartedAt: time.Date(2021, time.September, 11, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "injuries" ("created_at","updated_at","deleted_at","player_id","type","started_at","expected_return_at") VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING "id"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.PlayerID, params.Type, params.StartedAt, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.response.doCreateResp, suite.helper.err = suite.injury.DoCreate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), params.Type, suite.response.doCreateResp.Type)
+	require.Nil(suite.T(), suite.response.doCreateResp.ExpectedReturnAt)
+}
+
+// TestDoUpdate ...
+func (suite *Suite) TestDoUpdate() {
+	expectedReturnAt := time.Date(2021, time.October, 2, 0, 0, 0, 0, time.UTC)
+	params := param.DoUpdate{
+		Injury: param.Injury{
+			ID:               uuid.NewV4(),
+			PlayerID:         uuid.NewV4(),
+			Type:             "hamstring strain",
+			StartedAt:        time.Date(2021, time.September, 11, 0, 0, 0, 0, time.UTC),
+			ExpectedReturnAt: &expectedReturnAt,
+		},
+	}
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "injuries" SET "updated_at"=$1,"type"=$2,"started_at"=$3,"expected_return_at"=$4 WHERE id = $5 AND player_id = $6`)).
+		WithArgs(sqlmock.AnyArg(), params.Type, params.StartedAt, expectedReturnAt, params.ID, params.PlayerID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	suite.response.doUpdateResp, suite.helper.err = suite.injury.DoUpdate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), &expectedReturnAt, suite.response.doUpdateResp.ExpectedReturnAt)
+}
+
+// TestGetTeamInjuries ...
+func (suite *Suite) TestGetTeamInjuries() {
+	params := param.GetTeamInjuries{
+		TeamID: uuid.NewV4(),
+		Date:   time.Date(2021, time.September, 18, 15, 0, 0, 0, time.UTC),
+	}
+	playerID := uuid.NewV4()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT injuries.*, players.name FROM "injuries" JOIN players ON players.id = injuries.player_id WHERE (players.team_id = $1 AND players.deleted_at IS NULL AND injuries.deleted_at IS NULL) AND (injuries.started_at <= $2 AND (injuries.expected_return_at IS NULL OR injuries.expected_return_at > $3)) ORDER BY players.name`)).
+		WithArgs(params.TeamID, params.Date, params.Date).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "player_id", "type", "started_at", "expected_return_at", "name"}).
+			AddRow(uuid.NewV4(), playerID, "broken metatarsal", params.Date.AddDate(0, -1, 0), nil, "Wayne Rooney"))
+
+	suite.response.getTeamInjuriesResp, suite.helper.err = suite.injury.GetTeamInjuries(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Len(suite.T(), suite.response.getTeamInjuriesResp, 1)
+	require.Equal(suite.T(), playerID, suite.response.getTeamInjuriesResp[0].PlayerID)
+	require.Equal(suite.T(), "Wayne Rooney", suite.response.getTeamInjuriesResp[0].Name)
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package leaderboard
+
+import (
+	"context"
+	"errors"
+
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/leaderboard/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/leaderboard/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// columns maps every leaderboard metric to the player_stats column it ranks by.
+var columns = map[string]string{
+	model.LeaderboardMetricGoals:       "player_stats.goals",
+	model.LeaderboardMetricAssists:     "player_stats.assists",
+	model.LeaderboardMetricCleanSheets: "player_stats.clean_sheets",
+}
+
+// ILeaderboard is an interface that stores the methods that Leaderboard struct will use.
+type ILeaderboard interface {
+	// GetEntries is used for getting a page of the players of a season ranked by a metric.
+	// It returns getEntriesResp of []transporter.GetEntries and any errors written.
+	GetEntries(ctx context.Context, params param.GetLeaderboard) (getEntriesResp []transporter.GetEntries, err error)
+}
+
+// Leaderboard is an struct that implements ILeaderboard methods.
+type Leaderboard struct {
+	config   config.IConfig
+	ormMySQL *gorm.DB
+	ormPgSQL *gorm.DB
+	statement
+}
+
+type statement struct {
+	ormTX       *gorm.DB
+	ormChaining *gorm.DB
+}
+
+// New it returns instance of Leaderboard that implements ILeaderboard methods.
+func New(opts ...Option) ILeaderboard {
+	l := new(Leaderboard)
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	return l
+}
+
+// GetEntries is used for getting a page of the players of a season ranked by a metric.
+// Players level on the metric share the same rank, the next one skipping as many ranks as were shared.
+// Players yet to register the metric are left out, and one more entry than the limit is given back
+// to tell whether there is a next page.
+// It returns getEntriesResp of []transporter.GetEntries and any errors written.
+func (leaderboard *Leaderboard) GetEntries(ctx context.Context, params param.GetLeaderboard) (getEntriesResp []transporter.GetEntries, err error) {
+	column, ok := columns[params.Metric]
+	if !ok {
+		err = errors.New("unknown leaderboard metric " + params.Metric)
+		return
+	}
+
+	ranked := leaderboard.ormPgSQL.
+		Table("player_stats").
+		Select("player_stats.player_id, players.name, players.team_id, player_stats.appearances, "+column+" AS value, RANK() OVER (ORDER BY "+column+" DESC) AS rank").
+		Joins("JOIN players ON players.id = player_stats.player_id").
+		Where("player_stats.season_id = ? AND player_stats.appearances >= ? AND "+column+" > 0", params.SeasonID, params.GetMinAppearances())
+
+	leaderboard.ormChaining = leaderboard.ormPgSQL.
+		WithContext(ctx).
+		Table("(?) AS leaderboard", ranked)
+
+	if value, playerID, ok := params.GetCursor(); ok {
+		leaderboard.ormChaining = leaderboard.ormChaining.Where("value < ? OR (value = ? AND player_id > ?)", value, value, playerID)
+	}
+
+	leaderboard.ormChaining = leaderboard.ormChaining.
+		Order("value DESC, player_id").
+		Limit(params.GetLimit() + 1)
+
+	if err = leaderboard.ormChaining.Find(&getEntriesResp).Error; err != nil {
+		return
+	}
+
+	return
+}
@@ -0,0 +1,134 @@
+package leaderboard
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/handler/leaderboard/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/leaderboard/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	pgsqlConn *gorm.DB
+	mock      sqlmock.Sqlmock
+
+	leaderboard ILeaderboard
+	helper
+	response
+}
+
+type helper struct {
+	db  *sql.DB
+	err error
+}
+
+type response struct {
+	getEntriesResp []transporter.GetEntries
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.leaderboard = New(
+		WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+}
+
+// TestGetEntries ...
+func (suite *Suite) TestGetEntries() {
+	params := param.GetLeaderboard{
+		SeasonID:       uuid.NewV4(),
+		Metric:         model.LeaderboardMetricGoals,
+		Limit:          "2",
+		MinAppearances: "5",
+	}
+	firstPlayerID, secondPlayerID := uuid.NewV4(), uuid.NewV4()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM (SELECT player_stats.player_id, players.name, players.team_id, player_stats.appearances, player_stats.goals AS value, RANK() OVER (ORDER BY player_stats.goals DESC) AS rank FROM "player_stats" JOIN players ON players.id = player_stats.player_id WHERE player_stats.season_id = $1 AND player_stats.appearances >= $2 AND player_stats.goals > 0) AS leaderboard ORDER BY value DESC, player_id LIMIT 3`)).
+		WithArgs(params.SeasonID, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"player_id", "name", "rank", "value"}).
+			AddRow(firstPlayerID, "John Doe", 1, 12).
+			AddRow(secondPlayerID, "Richard Roe", 1, 12))
+
+	suite.response.getEntriesResp, suite.helper.err = suite.leaderboard.GetEntries(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Len(suite.T(), suite.response.getEntriesResp, 2)
+	require.Equal(suite.T(), 1, suite.response.getEntriesResp[1].Rank)
+}
+
+// TestGetEntriesAfterCursor ...
+func (suite *Suite) TestGetEntriesAfterCursor() {
+	playerID := uuid.NewV4()
+	params := param.GetLeaderboard{
+		SeasonID: uuid.NewV4(),
+		Metric:   model.LeaderboardMetricCleanSheets,
+		Limit:    "10",
+		Cursor:   param.NewCursor(4, playerID),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`AS leaderboard WHERE value < $3 OR (value = $4 AND player_id > $5) ORDER BY value DESC, player_id LIMIT 11`)).
+		WithArgs(params.SeasonID, 0, 4, 4, playerID).
+		WillReturnRows(sqlmock.NewRows([]string{"player_id", "rank", "value"}))
+
+	suite.response.getEntriesResp, suite.helper.err = suite.leaderboard.GetEntries(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Empty(suite.T(), suite.response.getEntriesResp)
+}
+
+// TestGetEntriesUnknownMetric ...
+func (suite *Suite) TestGetEntriesUnknownMetric() {
+	params := param.GetLeaderboard{
+		SeasonID: uuid.NewV4(),
+		Metric:   "own_goals",
+		Limit:    "10",
+	}
+
+	suite.response.getEntriesResp, suite.helper.err = suite.leaderboard.GetEntries(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "unknown leaderboard metric own_goals")
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
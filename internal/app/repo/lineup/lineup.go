@@ -0,0 +1,221 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lineup
+
+import (
+	"context"
+
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/lineup/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/lineup/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/satori/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ILineup is an interface that stores the methods that Lineup struct will use.
+type ILineup interface {
+	// DoUpdate is used for record the lineup of a team for a match, replacing the previous one in a single transaction.
+	// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+	DoUpdate(ctx context.Context, params param.DoUpdate) (doUpdateResp transporter.DoUpdate, err error)
+
+	// GetLineups is used for getting the lineups of both teams of a match.
+	// It returns getLineupsResp of []transporter.GetLineups and any errors written.
+	GetLineups(ctx context.Context, params param.GetLineups) (getLineupsResp []transporter.GetLineups, err error)
+
+	// GetLineup is used for getting the lineup of a team for a match.
+	// It returns getLineupResp of transporter.GetLineup and any errors written.
+	GetLineup(ctx context.Context, params param.GetLineup) (getLineupResp transporter.GetLineup, err error)
+
+	// GetPlayers is used for getting the players named on a team sheet.
+	// It returns getPlayersResp of []transporter.GetPlayers and any errors written.
+	GetPlayers(ctx context.Context, params param.GetPlayers) (getPlayersResp []transporter.GetPlayers, err error)
+}
+
+// Lineup is an struct that implements ILineup methods.
+type Lineup struct {
+	config   config.IConfig
+	ormMySQL *gorm.DB
+	ormPgSQL *gorm.DB
+	statement
+}
+
+type statement struct {
+	ormTX       *gorm.DB
+	ormChaining *gorm.DB
+}
+
+// New it returns instance of Lineup that implements ILineup methods.
+func New(opts ...Option) ILineup {
+	l := new(Lineup)
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	return l
+}
+
+// DoUpdate is used for record the lineup of a team for a match, replacing the previous one in a single transaction.
+// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+func (lineup *Lineup) DoUpdate(ctx context.Context, params param.DoUpdate) (doUpdateResp transporter.DoUpdate, err error) {
+	recordLineup := model.Lineup{
+		MatchID:   params.MatchID,
+		TeamID:    params.TeamID,
+		Formation: params.Formation,
+	}
+
+	lineup.ormTX = lineup.ormPgSQL.WithContext(ctx).Begin()
+
+	lineup.ormChaining = lineup.ormTX.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "match_id"}, {Name: "team_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"formation", "updated_at"}),
+		})
+
+	if err = lineup.ormChaining.Create(&recordLineup).Error; err != nil {
+		lineup.ormTX.Rollback()
+		return
+	}
+
+	if err = lineup.ormTX.
+		Where("match_id = ? AND team_id = ?", params.MatchID, params.TeamID).
+		Delete(&model.LineupPlayer{}).Error; err != nil {
+		lineup.ormTX.Rollback()
+		return
+	}
+
+	recordLineupPlayers := make([]model.LineupPlayer, 0, len(params.Starters)+len(params.Bench))
+	for i, playerID := range params.Starters {
+		recordLineupPlayers = append(recordLineupPlayers, model.LineupPlayer{
+			MatchID:  params.MatchID,
+			TeamID:   params.TeamID,
+			PlayerID: playerID,
+			Starter:  true,
+			Sequence: i + 1,
+		})
+	}
+	for i, playerID := range params.Bench {
+		recordLineupPlayers = append(recordLineupPlayers, model.LineupPlayer{
+			MatchID:  params.MatchID,
+			TeamID:   params.TeamID,
+			PlayerID: playerID,
+			Sequence: len(params.Starters) + i + 1,
+		})
+	}
+
+	if err = lineup.ormTX.Create(&recordLineupPlayers).Error; err != nil {
+		lineup.ormTX.Rollback()
+		return
+	}
+
+	if err = lineup.ormTX.Commit().Error; err != nil {
+		return
+	}
+
+	doUpdateResp.Lineup = transporter.Lineup{
+		MatchID:   recordLineup.MatchID,
+		TeamID:    recordLineup.TeamID,
+		Formation: recordLineup.Formation,
+		Starters:  params.Starters,
+		Bench:     params.Bench,
+	}
+
+	return
+}
+
+// GetLineups is used for getting the lineups of both teams of a match.
+// It returns getLineupsResp of []transporter.GetLineups and any errors written.
+func (lineup *Lineup) GetLineups(ctx context.Context, params param.GetLineups) (getLineupsResp []transporter.GetLineups, err error) {
+	lineup.ormChaining = lineup.ormPgSQL.
+		WithContext(ctx).
+		Where("match_id = ?", params.MatchID)
+
+	if err = lineup.ormChaining.Find(&getLineupsResp).Error; err != nil {
+		return
+	}
+
+	if len(getLineupsResp) == 0 {
+		return
+	}
+
+	var lineupPlayers []transporter.LineupPlayer
+
+	lineup.ormChaining = lineup.ormPgSQL.
+		WithContext(ctx).
+		Where("match_id = ?", params.MatchID).
+		Order("sequence")
+
+	if err = lineup.ormChaining.Find(&lineupPlayers).Error; err != nil {
+		return
+	}
+
+	for i := range getLineupsResp {
+		fill(&getLineupsResp[i].Lineup, lineupPlayers)
+	}
+
+	return
+}
+
+// GetLineup is used for getting the lineup of a team for a match.
+// It returns getLineupResp of transporter.GetLineup and any errors written.
+func (lineup *Lineup) GetLineup(ctx context.Context, params param.GetLineup) (getLineupResp transporter.GetLineup, err error) {
+	lineup.ormChaining = lineup.ormPgSQL.
+		WithContext(ctx).
+		Where("match_id = ? AND team_id = ?", params.MatchID, params.TeamID).
+		Limit(1)
+
+	if err = lineup.ormChaining.Find(&getLineupResp).Error; err != nil {
+		return
+	}
+
+	if uuid.Equal(getLineupResp.MatchID, uuid.Nil) {
+		return
+	}
+
+	var lineupPlayers []transporter.LineupPlayer
+
+	lineup.ormChaining = lineup.ormPgSQL.
+		WithContext(ctx).
+		Where("match_id = ? AND team_id = ?", params.MatchID, params.TeamID).
+		Order("sequence")
+
+	if err = lineup.ormChaining.Find(&lineupPlayers).Error; err != nil {
+		return
+	}
+
+	fill(&getLineupResp.Lineup, lineupPlayers)
+
+	return
+}
+
+// GetPlayers is used for getting the players named on a team sheet.
+// It returns getPlayersResp of []transporter.GetPlayers and any errors written.
+func (lineup *Lineup) GetPlayers(ctx context.Context, params param.GetPlayers) (getPlayersResp []transporter.GetPlayers, err error) {
+	lineup.ormChaining = lineup.ormPgSQL.
+		WithContext(ctx).
+		Where("id IN ? AND deleted_at IS NULL", params.IDs)
+
+	if err = lineup.ormChaining.Find(&getPlayersResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// fill is used for splitting the players of a match between the starters and the bench of a lineup.
+func fill(lineup *transporter.Lineup, lineupPlayers []transporter.LineupPlayer) {
+	for _, lineupPlayer := range lineupPlayers {
+		if !uuid.Equal(lineupPlayer.TeamID, lineup.TeamID) {
+			continue
+		}
+
+		if lineupPlayer.Starter {
+			lineup.Starters = append(lineup.Starters, lineupPlayer.PlayerID)
+			continue
+		}
+		lineup.Bench = append(lineup.Bench, lineupPlayer.PlayerID)
+	}
+}
@@ -0,0 +1,149 @@
+package lineup
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/handler/lineup/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/lineup/transporter"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	pgsqlConn *gorm.DB
+	mock      sqlmock.Sqlmock
+
+	lineup ILineup
+	helper
+	response
+}
+
+type helper struct {
+	db  *sql.DB
+	err error
+}
+
+type response struct {
+	doUpdateResp   transporter.DoUpdate
+	getLineupsResp []transporter.GetLineups
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.lineup = New(
+		WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+}
+
+// TestDoUpdate ...
+func (suite *Suite) TestDoUpdate() {
+	params := param.DoUpdate{
+		Lineup: param.Lineup{
+			MatchID:   uuid.NewV4(),
+			TeamID:    uuid.NewV4(),
+			Formation: "4-3-3",
+			Starters:  []uuid.UUID{uuid.NewV4(), uuid.NewV4()},
+			Bench:     []uuid.UUID{uuid.NewV4()},
+		},
+	}
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`INSERT INTO "lineups" ("match_id","team_id","formation","created_at","updated_at") VALUES ($1,$2,$3,$4,$5) ON CONFLICT ("match_id","team_id") DO UPDATE SET "formation"="excluded"."formation","updated_at"="excluded"."updated_at"`)).
+		WithArgs(params.MatchID, params.TeamID, params.Formation, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`DELETE FROM "lineup_players" WHERE match_id = $1 AND team_id = $2`)).
+		WithArgs(params.MatchID, params.TeamID).
+		WillReturnResult(sqlmock.NewResult(0, 14))
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`INSERT INTO "lineup_players" ("match_id","team_id","player_id","starter","sequence","created_at") VALUES ($1,$2,$3,$4,$5,$6),($7,$8,$9,$10,$11,$12),($13,$14,$15,$16,$17,$18)`)).
+		WithArgs(
+			params.MatchID, params.TeamID, params.Starters[0], true, 1, sqlmock.AnyArg(),
+			params.MatchID, params.TeamID, params.Starters[1], true, 2, sqlmock.AnyArg(),
+			params.MatchID, params.TeamID, params.Bench[0], false, 3, sqlmock.AnyArg(),
+		).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	suite.mock.ExpectCommit()
+
+	suite.response.doUpdateResp, suite.helper.err = suite.lineup.DoUpdate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), params.Bench, suite.response.doUpdateResp.Bench)
+}
+
+// TestGetLineups ...
+func (suite *Suite) TestGetLineups() {
+	homeTeamID, awayTeamID := uuid.NewV4(), uuid.NewV4()
+	goalkeeperID, substituteID, strikerID := uuid.NewV4(), uuid.NewV4(), uuid.NewV4()
+	params := param.GetLineups{
+		MatchID: uuid.NewV4(),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "lineups" WHERE match_id = $1`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"match_id", "team_id", "formation"}).
+			AddRow(params.MatchID, homeTeamID, "4-3-3").
+			AddRow(params.MatchID, awayTeamID, "3-5-2"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "lineup_players" WHERE match_id = $1 ORDER BY sequence`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"match_id", "team_id", "player_id", "starter", "sequence"}).
+			AddRow(params.MatchID, homeTeamID, goalkeeperID, true, 1).
+			AddRow(params.MatchID, awayTeamID, strikerID, true, 11).
+			AddRow(params.MatchID, homeTeamID, substituteID, false, 12))
+
+	suite.response.getLineupsResp, suite.helper.err = suite.lineup.GetLineups(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Len(suite.T(), suite.response.getLineupsResp, 2)
+	require.Equal(suite.T(), []uuid.UUID{goalkeeperID}, suite.response.getLineupsResp[0].Starters)
+	require.Equal(suite.T(), []uuid.UUID{substituteID}, suite.response.getLineupsResp[0].Bench)
+	require.Equal(suite.T(), []uuid.UUID{strikerID}, suite.response.getLineupsResp[1].Starters)
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package loan
+
+import (
+	"context"
+	"time"
+
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/loan/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/loan/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo/contract"
+	"gorm.io/gorm"
+)
+
+// ILoan is an interface that stores the methods that Loan struct will use.
+type ILoan interface {
+	// GetLoans is used for getting all loans of a player, the oldest first.
+	// It returns getLoansResp of []transporter.GetLoans and any errors written.
+	GetLoans(ctx context.Context, params param.GetLoans) (getLoansResp []transporter.GetLoans, err error)
+
+	// GetLoan is used for getting a loan.
+	// It returns getLoanResp of transporter.GetLoan and any errors written.
+	GetLoan(ctx context.Context, params param.GetLoan) (getLoanResp transporter.GetLoan, err error)
+
+	// GetActiveLoan is used for getting the loan a player is currently on.
+	// It returns getActiveLoanResp of transporter.GetActiveLoan and any errors written.
+	GetActiveLoan(ctx context.Context, params param.GetActiveLoan) (getActiveLoanResp transporter.GetActiveLoan, err error)
+
+	// GetExpiredLoans is used for getting the active loans that ended by the given date.
+	// It returns getExpiredLoansResp of []transporter.GetExpiredLoans and any errors written.
+	GetExpiredLoans(ctx context.Context, params param.GetExpiredLoans) (getExpiredLoansResp []transporter.GetExpiredLoans, err error)
+
+	// DoEnd is used for ending a loan, recording the transfer and moving the player in a single transaction.
+	// The loan is only ended while it is still active, so a recall and the expiry job cannot both end it.
+	// A loan ended in the meantime is reported back and nothing is written.
+	// A new contract, when given, replaces the active contract of the player.
+	// It returns doEndResp of transporter.DoEnd and any errors written.
+	DoEnd(ctx context.Context, params param.DoEnd) (doEndResp transporter.DoEnd, err error)
+}
+
+// Loan is an struct that implements ILoan methods.
+type Loan struct {
+	config   config.IConfig
+	ormMySQL *gorm.DB
+	ormPgSQL *gorm.DB
+	statement
+}
+
+type statement struct {
+	ormTX       *gorm.DB
+	ormChaining *gorm.DB
+}
+
+// New it returns instance of Loan that implements ILoan methods.
+func New(opts ...Option) ILoan {
+	l := new(Loan)
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	return l
+}
+
+// GetLoans is used for getting all loans of a player, the oldest first.
+// It returns getLoansResp of []transporter.GetLoans and any errors written.
+func (loan *Loan) GetLoans(ctx context.Context, params param.GetLoans) (getLoansResp []transporter.GetLoans, err error) {
+	loan.ormChaining = loan.ormPgSQL.
+		WithContext(ctx).
+		Where("player_id = ?", params.PlayerID).
+		Order("starts_at")
+
+	if err = loan.ormChaining.Find(&getLoansResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// GetLoan is used for getting a loan.
+// It returns getLoanResp of transporter.GetLoan and any errors written.
+func (loan *Loan) GetLoan(ctx context.Context, params param.GetLoan) (getLoanResp transporter.GetLoan, err error) {
+	loan.ormChaining = loan.ormPgSQL.
+		WithContext(ctx).
+		Where("id = ?", params.ID).
+		Limit(1)
+
+	if err = loan.ormChaining.Find(&getLoanResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// GetActiveLoan is used for getting the loan a player is currently on.
+// It returns getActiveLoanResp of transporter.GetActiveLoan and any errors written.
+func (loan *Loan) GetActiveLoan(ctx context.Context, params param.GetActiveLoan) (getActiveLoanResp transporter.GetActiveLoan, err error) {
+	loan.ormChaining = loan.ormPgSQL.
+		WithContext(ctx).
+		Where("player_id = ? AND status = ?", params.PlayerID, model.LoanStatusActive).
+		Limit(1)
+
+	if err = loan.ormChaining.Find(&getActiveLoanResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// GetExpiredLoans is used for getting the active loans that ended by the given date.
+// Names of the player and both clubs come along, so the returns can be announced.
+// It returns getExpiredLoansResp of []transporter.GetExpiredLoans and any errors written.
+func (loan *Loan) GetExpiredLoans(ctx context.Context, params param.GetExpiredLoans) (getExpiredLoansResp []transporter.GetExpiredLoans, err error) {
+	loan.ormChaining = loan.ormPgSQL.
+		WithContext(ctx).
+		Select("loans.*, players.name AS player_name, parent_teams.name AS parent_team_name, loan_teams.name AS loan_team_name").
+		Joins("JOIN players ON players.id = loans.player_id").
+		Joins("JOIN teams parent_teams ON parent_teams.id = loans.parent_team_id").
+		Joins("JOIN teams loan_teams ON loan_teams.id = loans.loan_team_id").
+		Where("loans.status = ? AND loans.ends_at <= ?", model.LoanStatusActive, params.Date).
+		Order("loans.ends_at")
+
+	if err = loan.ormChaining.Find(&getExpiredLoansResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// DoEnd is used for ending a loan, recording the transfer and moving the player in a single transaction.
+// The loan is only ended while it is still active, so a recall and the expiry job cannot both end it,
+// a loan ended in the meantime is reported back and nothing is written.
+// A player already at the destination, like one bought by the loan club, keeps the team and shirt number.
+// A new contract, when given, replaces the active contract of the player.
+// It returns doEndResp of transporter.DoEnd and any errors written.
+func (loan *Loan) DoEnd(ctx context.Context, params param.DoEnd) (doEndResp transporter.DoEnd, err error) {
+	recordTransfer := model.Transfer{
+		PlayerID:   params.PlayerID,
+		FromTeamID: &params.FromTeamID,
+		ToTeamID:   params.ToTeamID,
+		Date:       params.Date,
+		Fee:        params.Fee,
+		Type:       params.Type,
+	}
+
+	loan.ormTX = loan.ormPgSQL.WithContext(ctx).Begin()
+
+	ended := loan.ormTX.
+		Model(&model.Loan{}).
+		Where("id = ? AND status = ?", params.ID, model.LoanStatusActive).
+		Updates(map[string]interface{}{
+			"status":     params.Status,
+			"ended_at":   params.Date,
+			"updated_at": time.Now(),
+		})
+	if err = ended.Error; err != nil {
+		loan.ormTX.Rollback()
+		return
+	}
+
+	if ended.RowsAffected == 0 {
+		loan.ormTX.Rollback()
+		doEndResp.NoLongerActive = true
+		return
+	}
+
+	if err = loan.ormTX.Create(&recordTransfer).Error; err != nil {
+		loan.ormTX.Rollback()
+		return
+	}
+
+	if err = loan.ormTX.
+		Model(&model.Player{}).
+		Where("id = ? AND team_id <> ?", params.PlayerID, params.ToTeamID).
+		Updates(map[string]interface{}{
+			"team_id":      params.ToTeamID,
+			"shirt_number": nil,
+			"updated_at":   time.Now(),
+		}).Error; err != nil {
+		loan.ormTX.Rollback()
+		return
+	}
+
+	if params.Contract != nil {
+		recordContract := model.Contract{
+			PlayerID:      params.PlayerID,
+			TeamID:        params.ToTeamID,
+			StartsAt:      params.Date,
+			EndsAt:        params.Contract.EndsAt,
+			Wage:          params.Contract.Wage,
+			ReleaseClause: params.Contract.ReleaseClause,
+			Status:        model.ContractStatusActive,
+		}
+
+		if err = contract.Replace(loan.ormTX, &recordContract); err != nil {
+			loan.ormTX.Rollback()
+			return
+		}
+	}
+
+	if err = loan.ormTX.Commit().Error; err != nil {
+		return
+	}
+
+	doEndResp.TransferID = recordTransfer.ID
+
+	return
+}
@@ -0,0 +1,194 @@
+package loan
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/handler/loan/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/loan/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	pgsqlConn *gorm.DB
+	mock      sqlmock.Sqlmock
+
+	loan ILoan
+	helper
+	response
+}
+
+type helper struct {
+	db  *sql.DB
+	err error
+}
+
+type response struct {
+	getExpiredLoansResp []transporter.GetExpiredLoans
+	doEndResp           transporter.DoEnd
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.loan = New(
+		WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+}
+
+// TestGetExpiredLoans ...
+func (suite *Suite) TestGetExpiredLoans() {
+	params := param.GetExpiredLoans{
+		Date: time.Date(2021, time.July, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT loans.*, players.name AS player_name, parent_teams.name AS parent_team_name, loan_teams.name AS loan_team_name FROM "loans" JOIN players ON players.id = loans.player_id JOIN teams parent_teams ON parent_teams.id = loans.parent_team_id JOIN teams loan_teams ON loan_teams.id = loans.loan_team_id WHERE loans.status = $1 AND loans.ends_at <= $2 ORDER BY loans.ends_at`)).
+		WithArgs(model.LoanStatusActive, params.Date).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "player_name", "parent_team_name", "loan_team_name"}).
+			AddRow(uuid.NewV4(), "John Doe", "Arsenal", "Fulham"))
+
+	suite.response.getExpiredLoansResp, suite.helper.err = suite.loan.GetExpiredLoans(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Len(suite.T(), suite.response.getExpiredLoansResp, 1)
+	require.Equal(suite.T(), "Arsenal", suite.response.getExpiredLoansResp[0].ParentTeamName)
+}
+
+// TestDoEnd ...
+func (suite *Suite) TestDoEnd() {
+	transferID := uuid.NewV4()
+	params := param.DoEnd{
+		ID:         uuid.NewV4(),
+		PlayerID:   uuid.NewV4(),
+		FromTeamID: uuid.NewV4(),
+		ToTeamID:   uuid.NewV4(),
+		Date:       time.Date(2021, time.June, 30, 0, 0, 0, 0, time.UTC),
+		Type:       model.TransferTypeLoanReturn,
+		Status:     model.LoanStatusReturned,
+	}
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "loans" SET "ended_at"=$1,"status"=$2,"updated_at"=$3 WHERE id = $4 AND status = $5`)).
+		WithArgs(params.Date, params.Status, sqlmock.AnyArg(), params.ID, model.LoanStatusActive).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "transfers" ("created_at","updated_at","deleted_at","player_id","from_team_id","to_team_id","date","fee","type") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING "id"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.PlayerID, params.FromTeamID, params.ToTeamID, params.Date, int64(0), params.Type).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(transferID))
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "players" SET "shirt_number"=$1,"team_id"=$2,"updated_at"=$3 WHERE id = $4 AND team_id <> $5`)).
+		WithArgs(nil, params.ToTeamID, sqlmock.AnyArg(), params.PlayerID, params.ToTeamID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	suite.mock.ExpectCommit()
+
+	suite.response.doEndResp, suite.helper.err = suite.loan.DoEnd(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), transferID, suite.response.doEndResp.TransferID)
+}
+
+// TestDoEndRollback ...
+func (suite *Suite) TestDoEndRollback() {
+	params := param.DoEnd{
+		ID:       uuid.NewV4(),
+		PlayerID: uuid.NewV4(),
+		Date:     time.Date(2021, time.June, 30, 0, 0, 0, 0, time.UTC),
+		Type:     model.TransferTypeLoanReturn,
+		Status:   model.LoanStatusReturned,
+	}
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "loans"`)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "transfers"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "players"`)).
+		WillReturnError(sql.ErrConnDone)
+
+	suite.mock.ExpectRollback()
+
+	suite.response.doEndResp, suite.helper.err = suite.loan.DoEnd(context.Background(), params)
+
+	require.Error(suite.T(), suite.helper.err)
+}
+
+// TestDoEndNoLongerActive ...
+func (suite *Suite) TestDoEndNoLongerActive() {
+	params := param.DoEnd{
+		ID:       uuid.NewV4(),
+		PlayerID: uuid.NewV4(),
+		Date:     time.Date(2021, time.June, 30, 0, 0, 0, 0, time.UTC),
+		Type:     model.TransferTypeLoanReturn,
+		Status:   model.LoanStatusReturned,
+	}
+
+	suite.mock.ExpectBegin()
+
+	// The loan was recalled in the meantime, so the expiry job finds nothing left to end.
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "loans"`)).
+		WithArgs(params.Date, params.Status, sqlmock.AnyArg(), params.ID, model.LoanStatusActive).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	suite.mock.ExpectRollback()
+
+	suite.response.doEndResp, suite.helper.err = suite.loan.DoEnd(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.True(suite.T(), suite.response.doEndResp.NoLongerActive)
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
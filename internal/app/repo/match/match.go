@@ -0,0 +1,498 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package match
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/match/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/match/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+	"gorm.io/gorm"
+)
+
+// IMatch is an interface that stores the methods that Match struct will use.
+type IMatch interface {
+	// DoCreate is used for record new match.
+	// It returns doCreateResp of transporter.DoCreate and any errors written.
+	DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error)
+
+	// GetMatches is used for getting all matches.
+	// It returns getMatchesResp of []transporter.GetMatches and any errors written.
+	GetMatches(ctx context.Context, params param.GetMatches) (getMatchesResp []transporter.GetMatches, err error)
+
+	// DoUpdate is used for update the record match.
+	// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+	DoUpdate(ctx context.Context, params param.DoUpdate) (doUpdateResp transporter.DoUpdate, err error)
+
+	// DoDelete is used for delete the record match.
+	// It returns doDeleteResp of transporter.DoDelete and any errors written.
+	DoDelete(ctx context.Context, params param.DoDelete) (doDeleteResp transporter.DoDelete, err error)
+
+	// GetMatch is used for getting an match.
+	// It returns getMatchResp of transporter.GetMatch and any errors written.
+	GetMatch(ctx context.Context, params param.GetMatch) (getMatchResp transporter.GetMatch, err error)
+
+	// GetSeasonMatches is used for getting all matches of a season, optionally filtered by status.
+	// It returns getSeasonMatchesResp of []transporter.GetSeasonMatches and any errors written.
+	GetSeasonMatches(ctx context.Context, params param.GetSeasonMatches) (getSeasonMatchesResp []transporter.GetSeasonMatches, err error)
+
+	// GetTieMatches is used for getting the legs of a knockout tie in the order they are played.
+	// It returns getTieMatchesResp of []transporter.GetTieMatches and any errors written.
+	GetTieMatches(ctx context.Context, params param.GetTieMatches) (getTieMatchesResp []transporter.GetTieMatches, err error)
+
+	// GetTeamMatches is used for getting the matches of a team over a season with how far each venue is from its home ground.
+	// It returns getTeamMatchesResp of []transporter.GetTeamMatches and any errors written.
+	GetTeamMatches(ctx context.Context, params param.GetTeamMatches) (getTeamMatchesResp []transporter.GetTeamMatches, err error)
+
+	// DoReplaceFixtures is used for replacing the scheduled matches of a season in a single transaction.
+	// It returns doReplaceFixturesResp of []transporter.DoReplaceFixtures and any errors written.
+	DoReplaceFixtures(ctx context.Context, params param.DoReplaceFixtures) (doReplaceFixturesResp []transporter.DoReplaceFixtures, err error)
+
+	// CountPreparedFixtures is used for counting the scheduled matches of a season a lineup or an official is assigned to.
+	// It returns countPreparedFixturesResp of transporter.CountPreparedFixtures and any errors written.
+	CountPreparedFixtures(ctx context.Context, params param.CountPreparedFixtures) (countPreparedFixturesResp transporter.CountPreparedFixtures, err error)
+
+	// DoSyncScore is used for recomputing the scores of a match from its goal events and shootout kicks.
+	// It returns doSyncScoreResp of transporter.DoSyncScore and any errors written.
+	DoSyncScore(ctx context.Context, params param.DoSyncScore) (doSyncScoreResp transporter.DoSyncScore, err error)
+
+	// DoTransition is used for moving a match into a new status and recording the transition in a single transaction.
+	// It returns doTransitionResp of transporter.DoTransition and any errors written.
+	DoTransition(ctx context.Context, params param.DoTransition) (doTransitionResp transporter.DoTransition, err error)
+
+	// GetTransitions is used for getting every status transition of a match in the order they happened.
+	// It returns getTransitionsResp of []transporter.GetTransitions and any errors written.
+	GetTransitions(ctx context.Context, params param.GetTransitions) (getTransitionsResp []transporter.GetTransitions, err error)
+}
+
+// syncScoreQuery counts goals and penalties for the scoring team and own goals for the opponent,
+// separately for regular time and extra time, along with the scored kicks of the shootout.
+const syncScoreQuery = `UPDATE matches SET
+	home_score = (SELECT COUNT(*) FROM match_events WHERE match_events.match_id = matches.id AND match_events.deleted_at IS NULL AND match_events.period = @regular_time AND (
+		(match_events.type IN @scoring AND match_events.team_id = matches.home_team_id) OR
+		(match_events.type = @own_goal AND match_events.team_id = matches.away_team_id))),
+	away_score = (SELECT COUNT(*) FROM match_events WHERE match_events.match_id = matches.id AND match_events.deleted_at IS NULL AND match_events.period = @regular_time AND (
+		(match_events.type IN @scoring AND match_events.team_id = matches.away_team_id) OR
+		(match_events.type = @own_goal AND match_events.team_id = matches.home_team_id))),
+	home_extra_time_score = (SELECT COUNT(*) FROM match_events WHERE match_events.match_id = matches.id AND match_events.deleted_at IS NULL AND match_events.period = @extra_time AND (
+		(match_events.type IN @scoring AND match_events.team_id = matches.home_team_id) OR
+		(match_events.type = @own_goal AND match_events.team_id = matches.away_team_id))),
+	away_extra_time_score = (SELECT COUNT(*) FROM match_events WHERE match_events.match_id = matches.id AND match_events.deleted_at IS NULL AND match_events.period = @extra_time AND (
+		(match_events.type IN @scoring AND match_events.team_id = matches.away_team_id) OR
+		(match_events.type = @own_goal AND match_events.team_id = matches.home_team_id))),
+	home_shootout_score = (SELECT COUNT(*) FROM shootout_kicks WHERE shootout_kicks.match_id = matches.id AND shootout_kicks.deleted_at IS NULL AND shootout_kicks.scored AND
+		shootout_kicks.team_id = matches.home_team_id),
+	away_shootout_score = (SELECT COUNT(*) FROM shootout_kicks WHERE shootout_kicks.match_id = matches.id AND shootout_kicks.deleted_at IS NULL AND shootout_kicks.scored AND
+		shootout_kicks.team_id = matches.away_team_id),
+	updated_at = @updated_at
+WHERE id = @id
+RETURNING id, home_score, away_score, home_extra_time_score, away_extra_time_score, home_shootout_score, away_shootout_score`
+
+// Match is an struct that implements IMatch methods.
+type Match struct {
+	config   config.IConfig
+	ormMySQL *gorm.DB
+	ormPgSQL *gorm.DB
+	statement
+}
+
+type statement struct {
+	ormTX       *gorm.DB
+	ormChaining *gorm.DB
+}
+
+// New it returns instance of Match that implements IMatch methods.
+func New(opts ...Option) IMatch {
+	m := new(Match)
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// DoCreate is used for record new match.
+// It returns doCreateResp of transporter.DoCreate and any errors written.
+func (match *Match) DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error) {
+	recordMatch := model.Match{
+		SeasonID:   params.SeasonID,
+		Round:      params.Round,
+		HomeTeamID: params.HomeTeamID,
+		AwayTeamID: params.AwayTeamID,
+		KickoffAt:  params.KickoffAt,
+		Venue:      params.Venue,
+		VenueID:    params.VenueID,
+		Status:     params.Status,
+		HomeScore:  params.HomeScore,
+		AwayScore:  params.AwayScore,
+
+		HomeExtraTimeScore: params.HomeExtraTimeScore,
+		AwayExtraTimeScore: params.AwayExtraTimeScore,
+		HomeShootoutScore:  params.HomeShootoutScore,
+		AwayShootoutScore:  params.AwayShootoutScore,
+	}
+
+	match.ormChaining = match.ormPgSQL.WithContext(ctx)
+
+	if err = match.ormChaining.Create(&recordMatch).Error; err != nil {
+		return
+	}
+
+	doCreateResp = transporter.DoCreate{
+		Match: transporter.Match{
+			ID:         recordMatch.ID,
+			SeasonID:   recordMatch.SeasonID,
+			Round:      recordMatch.Round,
+			HomeTeamID: recordMatch.HomeTeamID,
+			AwayTeamID: recordMatch.AwayTeamID,
+			KickoffAt:  recordMatch.KickoffAt,
+			Venue:      recordMatch.Venue,
+			VenueID:    recordMatch.VenueID,
+			Status:     recordMatch.Status,
+			HomeScore:  recordMatch.HomeScore,
+			AwayScore:  recordMatch.AwayScore,
+
+			HomeExtraTimeScore: recordMatch.HomeExtraTimeScore,
+			AwayExtraTimeScore: recordMatch.AwayExtraTimeScore,
+			HomeShootoutScore:  recordMatch.HomeShootoutScore,
+			AwayShootoutScore:  recordMatch.AwayShootoutScore,
+		},
+	}
+
+	return
+}
+
+// GetMatches is used for getting all matches.
+// It returns getMatchesResp of []transporter.GetMatches and any errors written.
+func (match *Match) GetMatches(ctx context.Context, params param.GetMatches) (getMatchesResp []transporter.GetMatches, err error) {
+	match.ormChaining = match.ormPgSQL.
+		WithContext(ctx).
+		Order("kickoff_at").
+		Limit(params.GetLimit()).
+		Offset(params.GetOffset())
+
+	if err = match.ormChaining.Find(&getMatchesResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// DoUpdate is used for update the record match.
+// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+func (match *Match) DoUpdate(ctx context.Context, params param.DoUpdate) (doUpdateResp transporter.DoUpdate, err error) {
+	recordMatch := model.Match{
+		SeasonID:   params.SeasonID,
+		Round:      params.Round,
+		HomeTeamID: params.HomeTeamID,
+		AwayTeamID: params.AwayTeamID,
+		KickoffAt:  params.KickoffAt,
+		Venue:      params.Venue,
+		VenueID:    params.VenueID,
+		Status:     params.Status,
+		HomeScore:  params.HomeScore,
+		AwayScore:  params.AwayScore,
+
+		HomeExtraTimeScore: params.HomeExtraTimeScore,
+		AwayExtraTimeScore: params.AwayExtraTimeScore,
+		HomeShootoutScore:  params.HomeShootoutScore,
+		AwayShootoutScore:  params.AwayShootoutScore,
+	}
+
+	// Scores are selected explicitly, so a zero score is written instead of being skipped.
+	match.ormChaining = match.ormPgSQL.
+		WithContext(ctx).
+		Select("updated_at", "season_id", "round", "home_team_id", "away_team_id", "kickoff_at", "venue", "venue_id", "status", "home_score", "away_score",
+			"home_extra_time_score", "away_extra_time_score", "home_shootout_score", "away_shootout_score").
+		Where("id = ?", params.ID)
+
+	if err = match.ormChaining.Updates(&recordMatch).Error; err != nil {
+		return
+	}
+
+	doUpdateResp = transporter.DoUpdate{
+		Match: transporter.Match{
+			ID:         params.ID,
+			SeasonID:   recordMatch.SeasonID,
+			Round:      recordMatch.Round,
+			HomeTeamID: recordMatch.HomeTeamID,
+			AwayTeamID: recordMatch.AwayTeamID,
+			KickoffAt:  recordMatch.KickoffAt,
+			Venue:      recordMatch.Venue,
+			VenueID:    recordMatch.VenueID,
+			Status:     recordMatch.Status,
+			HomeScore:  recordMatch.HomeScore,
+			AwayScore:  recordMatch.AwayScore,
+
+			HomeExtraTimeScore: recordMatch.HomeExtraTimeScore,
+			AwayExtraTimeScore: recordMatch.AwayExtraTimeScore,
+			HomeShootoutScore:  recordMatch.HomeShootoutScore,
+			AwayShootoutScore:  recordMatch.AwayShootoutScore,
+		},
+	}
+
+	return
+}
+
+// DoDelete is used for delete the record match.
+// It returns doDeleteResp of transporter.DoDelete and any errors written.
+func (match *Match) DoDelete(ctx context.Context, params param.DoDelete) (doDeleteResp transporter.DoDelete, err error) {
+	match.ormChaining = match.ormPgSQL.
+		WithContext(ctx).
+		Where("id = ?", params.ID)
+
+	if err = match.ormChaining.Delete(&doDeleteResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// GetMatch is used for getting an match.
+// It returns getMatchResp of transporter.GetMatch and any errors written.
+func (match *Match) GetMatch(ctx context.Context, params param.GetMatch) (getMatchResp transporter.GetMatch, err error) {
+	match.ormChaining = match.ormPgSQL.
+		WithContext(ctx).
+		Where("id = ?", params.ID).
+		Limit(1)
+
+	if err = match.ormChaining.Find(&getMatchResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// GetSeasonMatches is used for getting all league and group matches of a season, optionally filtered by status.
+// Legs of knockout ties are left out, they are no part of any table.
+// It returns getSeasonMatchesResp of []transporter.GetSeasonMatches and any errors written.
+func (match *Match) GetSeasonMatches(ctx context.Context, params param.GetSeasonMatches) (getSeasonMatchesResp []transporter.GetSeasonMatches, err error) {
+	match.ormChaining = match.ormPgSQL.
+		WithContext(ctx).
+		Where("season_id = ? AND tie_id IS NULL", params.SeasonID)
+
+	if params.Status != "" {
+		match.ormChaining = match.ormChaining.Where("status = ?", params.Status)
+	}
+
+	if err = match.ormChaining.Order("kickoff_at").Find(&getSeasonMatchesResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// GetTieMatches is used for getting the legs of a knockout tie in the order they are played.
+// It returns getTieMatchesResp of []transporter.GetTieMatches and any errors written.
+func (match *Match) GetTieMatches(ctx context.Context, params param.GetTieMatches) (getTieMatchesResp []transporter.GetTieMatches, err error) {
+	match.ormChaining = match.ormPgSQL.
+		WithContext(ctx).
+		Where("tie_id = ?", params.TieID).
+		Order("leg")
+
+	if err = match.ormChaining.Find(&getTieMatchesResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// GetTeamMatches is used for getting the matches of a team over a season with how far each venue is from its home ground.
+// A match without a venue of its own is played at the home ground of the home team. The distance is in metres,
+// and left empty when either venue is unknown.
+// It returns getTeamMatchesResp of []transporter.GetTeamMatches and any errors written.
+func (match *Match) GetTeamMatches(ctx context.Context, params param.GetTeamMatches) (getTeamMatchesResp []transporter.GetTeamMatches, err error) {
+	match.ormChaining = match.ormPgSQL.
+		WithContext(ctx).
+		Model(&model.Match{}).
+		Select("matches.id, matches.season_id, matches.round, matches.home_team_id, matches.away_team_id, matches.kickoff_at, "+
+			"COALESCE(NULLIF(matches.venue, ''), venues.name, '') AS venue, venues.id AS venue_id, matches.status, "+
+			"ST_Distance(grounds.location, venues.location) AS distance").
+		Joins("JOIN teams AS hosts ON hosts.id = matches.home_team_id").
+		Joins("LEFT JOIN venues ON venues.id = COALESCE(matches.venue_id, hosts.venue_id)").
+		Joins("LEFT JOIN venues AS grounds ON grounds.id = (SELECT venue_id FROM teams WHERE id = ?)", params.TeamID).
+		Where("matches.season_id = ? AND (matches.home_team_id = ? OR matches.away_team_id = ?) AND matches.deleted_at IS NULL", params.SeasonID, params.TeamID, params.TeamID).
+		Order("matches.kickoff_at")
+
+	if err = match.ormChaining.Scan(&getTeamMatchesResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// DoReplaceFixtures is used for replacing the scheduled matches of a season in a single transaction.
+// Matches that are not scheduled anymore and the matches of a knockout bracket are left untouched.
+// It returns doReplaceFixturesResp of []transporter.DoReplaceFixtures and any errors written.
+func (match *Match) DoReplaceFixtures(ctx context.Context, params param.DoReplaceFixtures) (doReplaceFixturesResp []transporter.DoReplaceFixtures, err error) {
+	recordMatches := make([]model.Match, 0, len(params.Matches))
+	for _, fixture := range params.Matches {
+		recordMatches = append(recordMatches, model.Match{
+			SeasonID:   &params.SeasonID,
+			Round:      fixture.Round,
+			HomeTeamID: fixture.HomeTeamID,
+			AwayTeamID: fixture.AwayTeamID,
+			KickoffAt:  fixture.KickoffAt,
+			Venue:      fixture.Venue,
+			VenueID:    fixture.VenueID,
+			Status:     fixture.Status,
+		})
+	}
+
+	match.ormTX = match.ormPgSQL.WithContext(ctx).Begin()
+
+	if err = match.ormTX.
+		Where("season_id = ? AND status = ? AND tie_id IS NULL", params.SeasonID, model.MatchStatusScheduled).
+		Delete(&model.Match{}).Error; err != nil {
+		match.ormTX.Rollback()
+		return
+	}
+
+	if len(recordMatches) > 0 {
+		if err = match.ormTX.Create(&recordMatches).Error; err != nil {
+			match.ormTX.Rollback()
+			return
+		}
+	}
+
+	if err = match.ormTX.Commit().Error; err != nil {
+		return
+	}
+
+	for _, recordMatch := range recordMatches {
+		doReplaceFixturesResp = append(doReplaceFixturesResp, transporter.DoReplaceFixtures{
+			Match: transporter.Match{
+				ID:         recordMatch.ID,
+				SeasonID:   recordMatch.SeasonID,
+				Round:      recordMatch.Round,
+				HomeTeamID: recordMatch.HomeTeamID,
+				AwayTeamID: recordMatch.AwayTeamID,
+				KickoffAt:  recordMatch.KickoffAt,
+				Venue:      recordMatch.Venue,
+				VenueID:    recordMatch.VenueID,
+				Status:     recordMatch.Status,
+			},
+		})
+	}
+
+	return
+}
+
+// CountPreparedFixtures is used for counting the scheduled matches of a season a lineup or an official is assigned to.
+// Only the matches DoReplaceFixtures would replace are counted.
+// It returns countPreparedFixturesResp of transporter.CountPreparedFixtures and any errors written.
+func (match *Match) CountPreparedFixtures(ctx context.Context, params param.CountPreparedFixtures) (countPreparedFixturesResp transporter.CountPreparedFixtures, err error) {
+	match.ormChaining = match.ormPgSQL.
+		WithContext(ctx).
+		Model(&model.Match{}).
+		Select("COUNT(*) AS total").
+		Where("season_id = ? AND status = ? AND tie_id IS NULL", params.SeasonID, model.MatchStatusScheduled).
+		Where("EXISTS (SELECT 1 FROM lineups WHERE lineups.match_id = matches.id) OR EXISTS (SELECT 1 FROM match_officials WHERE match_officials.match_id = matches.id)")
+
+	if err = match.ormChaining.Scan(&countPreparedFixturesResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// DoSyncScore is used for recomputing the scores of a match from its goal events and shootout kicks.
+// It returns doSyncScoreResp of transporter.DoSyncScore and any errors written.
+func (match *Match) DoSyncScore(ctx context.Context, params param.DoSyncScore) (doSyncScoreResp transporter.DoSyncScore, err error) {
+	match.ormChaining = SyncScore(match.ormPgSQL.WithContext(ctx), params.ID)
+
+	if err = match.ormChaining.Scan(&doSyncScoreResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// SyncScore is used for building the query recomputing the scores of a match on the given connection,
+// so a write to match events or shootout kicks can recompute them within its own transaction.
+func SyncScore(db *gorm.DB, id uuid.UUID) *gorm.DB {
+	return db.Raw(syncScoreQuery, map[string]interface{}{
+		"regular_time": model.MatchPeriodRegularTime,
+		"extra_time":   model.MatchPeriodExtraTime,
+		"scoring":      []string{model.MatchEventTypeGoal, model.MatchEventTypePenalty},
+		"own_goal":     model.MatchEventTypeOwnGoal,
+		"updated_at":   time.Now(),
+		"id":           id,
+	})
+}
+
+// DoTransition is used for moving a match into a new status and recording the transition in a single transaction.
+// The match is only updated while it still holds params.FromStatus, so concurrent transitions cannot both succeed.
+// It returns doTransitionResp of transporter.DoTransition and any errors written.
+func (match *Match) DoTransition(ctx context.Context, params param.DoTransition) (doTransitionResp transporter.DoTransition, err error) {
+	recordMatchTransition := model.MatchTransition{
+		MatchID:    params.MatchID,
+		FromStatus: params.FromStatus,
+		ToStatus:   params.Status,
+		OccurredAt: *params.OccurredAt,
+	}
+
+	match.ormTX = match.ormPgSQL.WithContext(ctx).Begin()
+
+	updated := match.ormTX.
+		Model(&model.Match{}).
+		Where("id = ? AND status = ?", params.MatchID, params.FromStatus).
+		Updates(map[string]interface{}{
+			"status":     params.Status,
+			"updated_at": time.Now(),
+		})
+	if err = updated.Error; err != nil {
+		match.ormTX.Rollback()
+		return
+	}
+
+	if updated.RowsAffected == 0 {
+		match.ormTX.Rollback()
+		err = &iPkgError.InvalidTransitionError{Err: errors.New("match is no longer " + params.FromStatus)}
+		return
+	}
+
+	if err = match.ormTX.Create(&recordMatchTransition).Error; err != nil {
+		match.ormTX.Rollback()
+		return
+	}
+
+	if err = match.ormTX.Commit().Error; err != nil {
+		return
+	}
+
+	doTransitionResp = transporter.DoTransition{
+		Transition: transporter.Transition{
+			ID:         recordMatchTransition.ID,
+			MatchID:    recordMatchTransition.MatchID,
+			FromStatus: recordMatchTransition.FromStatus,
+			ToStatus:   recordMatchTransition.ToStatus,
+			OccurredAt: recordMatchTransition.OccurredAt,
+		},
+	}
+
+	return
+}
+
+// GetTransitions is used for getting every status transition of a match in the order they happened.
+// It returns getTransitionsResp of []transporter.GetTransitions and any errors written.
+func (match *Match) GetTransitions(ctx context.Context, params param.GetTransitions) (getTransitionsResp []transporter.GetTransitions, err error) {
+	match.ormChaining = match.ormPgSQL.
+		WithContext(ctx).
+		Where("match_id = ?", params.MatchID).
+		Order("occurred_at, created_at")
+
+	if err = match.ormChaining.Find(&getTransitionsResp).Error; err != nil {
+		return
+	}
+
+	return
+}
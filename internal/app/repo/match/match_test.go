@@ -0,0 +1,394 @@
+package match
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/handler/match/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/match/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo/match/matchtest"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	pgsqlConn *gorm.DB
+	mock      sqlmock.Sqlmock
+
+	match IMatch
+	helper
+	response
+}
+
+type helper struct {
+	db  *sql.DB
+	err error
+}
+
+type response struct {
+	doCreateResp          transporter.DoCreate
+	getMatchesResp        []transporter.GetMatches
+	getMatchResp          transporter.GetMatch
+	getSeasonMatchesResp  []transporter.GetSeasonMatches
+	getTeamMatchesResp    []transporter.GetTeamMatches
+	doReplaceFixturesResp []transporter.DoReplaceFixtures
+	doUpdateResp          transporter.DoUpdate
+	doDeleteResp          transporter.DoDelete
+	doSyncScoreResp       transporter.DoSyncScore
+	doTransitionResp      transporter.DoTransition
+	getTransitionsResp    []transporter.GetTransitions
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.match = New(
+		WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+}
+
+// TestDoCreate ...
+func (suite *Suite) TestDoCreate() {
+	params := param.DoCreate{
+		Match: param.Match{
+			HomeTeamID: uuid.NewV4(),
+			AwayTeamID: uuid.NewV4(),
+			KickoffAt:  time.Date(2021, 1, 16, 15, 0, 0, 0, time.UTC),
+			Venue:      "Anfield",
+			Status:     model.MatchStatusScheduled,
+		},
+	}
+
+	suite.mock.MatchExpectationsInOrder(false)
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "matches" ("created_at","updated_at","deleted_at","season_id","round","home_team_id","away_team_id","kickoff_at","venue","venue_id","status","home_score","away_score","home_extra_time_score","away_extra_time_score","home_shootout_score","away_shootout_score","tie_id","leg") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19) RETURNING "id"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.SeasonID, params.Round, params.HomeTeamID, params.AwayTeamID, params.KickoffAt, params.Venue, nil, params.Status, 0, 0, 0, 0, 0, 0, nil, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.response.doCreateResp, suite.helper.err = suite.match.DoCreate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.Equal(suite.T(), "Anfield", suite.response.doCreateResp.Venue)
+}
+
+// TestGetMatches ...
+func (suite *Suite) TestGetMatches() {
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" ORDER BY kickoff_at LIMIT 10 OFFSET 1`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "home_team_id", "away_team_id", "status"}).
+			AddRow(uuid.NewV4(), uuid.NewV4(), uuid.NewV4(), model.MatchStatusScheduled))
+
+	suite.response.getMatchesResp, suite.helper.err = suite.match.GetMatches(context.Background(), param.GetMatches{Pagination: param.Pagination{
+		Limit:  "10",
+		Offset: "1",
+	}})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.Len(suite.T(), suite.response.getMatchesResp, 1)
+}
+
+// TestGetMatch ...
+func (suite *Suite) TestGetMatch() {
+	params := param.GetMatch{ID: uuid.NewV4()}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "home_score", "away_score"}).
+			AddRow(params.ID, 2, 1))
+
+	suite.response.getMatchResp, suite.helper.err = suite.match.GetMatch(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.Equal(suite.T(), 2, suite.response.getMatchResp.HomeScore)
+}
+
+// TestGetSeasonMatches ...
+func (suite *Suite) TestGetSeasonMatches() {
+	params := param.GetSeasonMatches{SeasonID: uuid.NewV4(), Status: model.MatchStatusFinished}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE (season_id = $1 AND tie_id IS NULL) AND status = $2 ORDER BY kickoff_at`)).
+		WithArgs(params.SeasonID, params.Status).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "home_team_id", "away_team_id", "status", "home_score", "away_score"}).
+			AddRow(uuid.NewV4(), uuid.NewV4(), uuid.NewV4(), model.MatchStatusFinished, 2, 1))
+
+	suite.response.getSeasonMatchesResp, suite.helper.err = suite.match.GetSeasonMatches(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.Len(suite.T(), suite.response.getSeasonMatchesResp, 1)
+}
+
+// TestGetTeamMatches ...
+func (suite *Suite) TestGetTeamMatches() {
+	params := param.GetTeamMatches{
+		TeamID:   uuid.NewV4(),
+		SeasonID: uuid.NewV4(),
+	}
+	venueID := uuid.NewV4()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT matches.id, matches.season_id, matches.round, matches.home_team_id, matches.away_team_id, matches.kickoff_at, `+
+			`COALESCE(NULLIF(matches.venue, ''), venues.name, '') AS venue, venues.id AS venue_id, matches.status, `+
+			`ST_Distance(grounds.location, venues.location) AS distance FROM "matches" `+
+			`JOIN teams AS hosts ON hosts.id = matches.home_team_id `+
+			`LEFT JOIN venues ON venues.id = COALESCE(matches.venue_id, hosts.venue_id) `+
+			`LEFT JOIN venues AS grounds ON grounds.id = (SELECT venue_id FROM teams WHERE id = $1) `+
+			`WHERE matches.season_id = $2 AND (matches.home_team_id = $3 OR matches.away_team_id = $4) AND matches.deleted_at IS NULL ORDER BY matches.kickoff_at`)).
+		WithArgs(params.TeamID, params.SeasonID, params.TeamID, params.TeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "home_team_id", "away_team_id", "venue", "venue_id", "distance"}).
+			AddRow(uuid.NewV4(), params.TeamID, uuid.NewV4(), "Old Trafford", nil, 0).
+			AddRow(uuid.NewV4(), uuid.NewV4(), params.TeamID, "Anfield", venueID, 49717.3))
+
+	suite.response.getTeamMatchesResp, suite.helper.err = suite.match.GetTeamMatches(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Len(suite.T(), suite.response.getTeamMatchesResp, 2)
+	require.Equal(suite.T(), &venueID, suite.response.getTeamMatchesResp[1].VenueID)
+	require.Equal(suite.T(), 49717.3, *suite.response.getTeamMatchesResp[1].Distance)
+}
+
+// TestDoReplaceFixtures ...
+func (suite *Suite) TestDoReplaceFixtures() {
+	params := param.DoReplaceFixtures{
+		SeasonID: uuid.NewV4(),
+		Matches: []param.Match{
+			{
+				Round:      1,
+				HomeTeamID: uuid.NewV4(),
+				AwayTeamID: uuid.NewV4(),
+				KickoffAt:  time.Date(2020, 8, 1, 15, 0, 0, 0, time.UTC),
+				Status:     model.MatchStatusScheduled,
+			},
+			{
+				Round:      1,
+				HomeTeamID: uuid.NewV4(),
+				AwayTeamID: uuid.NewV4(),
+				KickoffAt:  time.Date(2020, 8, 1, 15, 0, 0, 0, time.UTC),
+				Status:     model.MatchStatusScheduled,
+			},
+		},
+	}
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`DELETE FROM "matches" WHERE season_id = $1 AND status = $2 AND tie_id IS NULL`)).
+		WithArgs(params.SeasonID, model.MatchStatusScheduled).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "matches" ("created_at","updated_at","deleted_at","season_id","round","home_team_id","away_team_id","kickoff_at","venue","venue_id","status","home_score","away_score","home_extra_time_score","away_extra_time_score","home_shootout_score","away_shootout_score","tie_id","leg") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19),($20,$21,$22,$23,$24,$25,$26,$27,$28,$29,$30,$31,$32,$33,$34,$35,$36,$37,$38) RETURNING "id"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()).
+			AddRow(uuid.NewV4()))
+
+	suite.mock.ExpectCommit()
+
+	suite.response.doReplaceFixturesResp, suite.helper.err = suite.match.DoReplaceFixtures(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.Len(suite.T(), suite.response.doReplaceFixturesResp, 2)
+}
+
+// TestDoReplaceFixturesRollback ...
+func (suite *Suite) TestDoReplaceFixturesRollback() {
+	params := param.DoReplaceFixtures{SeasonID: uuid.NewV4()}
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`DELETE FROM "matches" WHERE season_id = $1 AND status = $2 AND tie_id IS NULL`)).
+		WithArgs(params.SeasonID, model.MatchStatusScheduled).
+		WillReturnError(sql.ErrConnDone)
+
+	suite.mock.ExpectRollback()
+
+	suite.response.doReplaceFixturesResp, suite.helper.err = suite.match.DoReplaceFixtures(context.Background(), params)
+
+	require.Error(suite.T(), suite.helper.err)
+}
+
+// TestDoUpdate ...
+func (suite *Suite) TestDoUpdate() {
+	params := param.DoUpdate{
+		Match: param.Match{
+			ID:         uuid.NewV4(),
+			HomeTeamID: uuid.NewV4(),
+			AwayTeamID: uuid.NewV4(),
+			KickoffAt:  time.Date(2021, 1, 16, 15, 0, 0, 0, time.UTC),
+			Venue:      "Old Trafford",
+			Status:     model.MatchStatusFinished,
+			HomeScore:  0,
+			AwayScore:  3,
+		},
+	}
+
+	suite.mock.MatchExpectationsInOrder(false)
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "matches" SET "updated_at"=$1,"season_id"=$2,"round"=$3,"home_team_id"=$4,"away_team_id"=$5,"kickoff_at"=$6,"venue"=$7,"venue_id"=$8,"status"=$9,"home_score"=$10,"away_score"=$11,"home_extra_time_score"=$12,"away_extra_time_score"=$13,"home_shootout_score"=$14,"away_shootout_score"=$15 WHERE id = $16`)).
+		WithArgs(sqlmock.AnyArg(), params.SeasonID, params.Round, params.HomeTeamID, params.AwayTeamID, params.KickoffAt, params.Venue, nil, params.Status, 0, 3, 0, 0, 0, 0, params.ID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	suite.response.doUpdateResp, suite.helper.err = suite.match.DoUpdate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+}
+
+func (suite *Suite) TestDoDelete() {
+	params := param.DoDelete{
+		ID: uuid.NewV4(),
+	}
+
+	suite.mock.MatchExpectationsInOrder(false)
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`DELETE FROM "matches" WHERE id = $1`)).
+		WithArgs(params.ID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	suite.response.doDeleteResp, suite.helper.err = suite.match.DoDelete(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+}
+
+// TestDoSyncScore ...
+func (suite *Suite) TestDoSyncScore() {
+	params := param.DoSyncScore{
+		ID: uuid.NewV4(),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`UPDATE matches SET`)).
+		WithArgs(matchtest.SyncScoreArgs(params.ID)...).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "home_score", "away_score"}).
+			AddRow(params.ID, 2, 1))
+
+	suite.response.doSyncScoreResp, suite.helper.err = suite.match.DoSyncScore(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), params.ID, suite.response.doSyncScoreResp.ID)
+	require.Equal(suite.T(), 2, suite.response.doSyncScoreResp.HomeScore)
+	require.Equal(suite.T(), 1, suite.response.doSyncScoreResp.AwayScore)
+}
+
+// TestDoTransition ...
+func (suite *Suite) TestDoTransition() {
+	occurredAt := time.Date(2021, 1, 16, 15, 1, 0, 0, time.UTC)
+	params := param.DoTransition{
+		MatchID:    uuid.NewV4(),
+		FromStatus: model.MatchStatusScheduled,
+		Status:     model.MatchStatusFirstHalf,
+		OccurredAt: &occurredAt,
+	}
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "matches" SET "status"=$1,"updated_at"=$2 WHERE id = $3 AND status = $4`)).
+		WithArgs(params.Status, sqlmock.AnyArg(), params.MatchID, params.FromStatus).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "match_transitions" ("created_at","updated_at","deleted_at","match_id","from_status","to_status","occurred_at") VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING "id"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.MatchID, params.FromStatus, params.Status, occurredAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.mock.ExpectCommit()
+
+	suite.response.doTransitionResp, suite.helper.err = suite.match.DoTransition(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), model.MatchStatusFirstHalf, suite.response.doTransitionResp.ToStatus)
+}
+
+// TestDoTransitionStale ...
+func (suite *Suite) TestDoTransitionStale() {
+	occurredAt := time.Date(2021, 1, 16, 15, 1, 0, 0, time.UTC)
+	params := param.DoTransition{
+		MatchID:    uuid.NewV4(),
+		FromStatus: model.MatchStatusScheduled,
+		Status:     model.MatchStatusFirstHalf,
+		OccurredAt: &occurredAt,
+	}
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "matches" SET "status"=$1,"updated_at"=$2 WHERE id = $3 AND status = $4`)).
+		WithArgs(params.Status, sqlmock.AnyArg(), params.MatchID, params.FromStatus).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	suite.mock.ExpectRollback()
+
+	suite.response.doTransitionResp, suite.helper.err = suite.match.DoTransition(context.Background(), params)
+
+	require.IsType(suite.T(), &iPkgError.InvalidTransitionError{}, suite.helper.err)
+}
+
+// TestGetTransitions ...
+func (suite *Suite) TestGetTransitions() {
+	params := param.GetTransitions{
+		MatchID: uuid.NewV4(),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "match_transitions" WHERE match_id = $1 ORDER BY occurred_at, created_at`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "match_id", "from_status", "to_status", "occurred_at"}).
+			AddRow(uuid.NewV4(), params.MatchID, model.MatchStatusScheduled, model.MatchStatusFirstHalf, time.Date(2021, 1, 16, 15, 1, 0, 0, time.UTC)))
+
+	suite.response.getTransitionsResp, suite.helper.err = suite.match.GetTransitions(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Len(suite.T(), suite.response.getTransitionsResp, 1)
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
@@ -0,0 +1,22 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package matchtest provides helpers for tests expecting the queries of the match repo.
+package matchtest
+
+import (
+	"database/sql/driver"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/satori/uuid"
+)
+
+// SyncScoreArgs returns the arguments of the score sync query for the given match.
+func SyncScoreArgs(id uuid.UUID) (args []driver.Value) {
+	for _, period := range []string{model.MatchPeriodRegularTime, model.MatchPeriodRegularTime, model.MatchPeriodExtraTime, model.MatchPeriodExtraTime} {
+		args = append(args, period, model.MatchEventTypeGoal, model.MatchEventTypePenalty, model.MatchEventTypeOwnGoal)
+	}
+	return append(args, sqlmock.AnyArg(), id)
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package match
+
+import (
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"gorm.io/gorm"
+)
+
+// Option is a closure that is used for accessing the local variables.
+type Option func(match *Match)
+
+// WithConfig ...
+func WithConfig(config config.IConfig) Option {
+	return func(match *Match) {
+		match.config = config
+	}
+}
+
+// WithDatabase ...
+func WithDatabase(dialect string, conn *gorm.DB) Option {
+	return func(match *Match) {
+		if dialect == db.MysqlDialectParam {
+			match.ormMySQL = conn
+		}
+		if dialect == db.PgsqlDialectParam {
+			match.ormPgSQL = conn
+		}
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matchevent
+
+import (
+	"context"
+
+	"github.com/harunnryd/skeltun/config"
+	matchTransporter "github.com/harunnryd/skeltun/internal/app/handler/match/transporter"
+	"github.com/harunnryd/skeltun/internal/app/handler/matchevent/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/matchevent/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo/match"
+	"gorm.io/gorm"
+)
+
+// IMatchEvent is an interface that stores the methods that MatchEvent struct will use.
+type IMatchEvent interface {
+	// DoCreate is used for record new match event, along with the suspension it leads to, and recomputing the match score
+	// in a single transaction.
+	// It returns doCreateResp of transporter.DoCreate and any errors written.
+	DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error)
+
+	// GetMatchEvents is used for getting the timeline of a match.
+	// It returns getMatchEventsResp of []transporter.GetMatchEvents and any errors written.
+	GetMatchEvents(ctx context.Context, params param.GetMatchEvents) (getMatchEventsResp []transporter.GetMatchEvents, err error)
+
+	// DoDelete is used for delete the record match event and recomputing the match score in a single transaction.
+	// It returns doDeleteResp of transporter.DoDelete and any errors written.
+	DoDelete(ctx context.Context, params param.DoDelete) (doDeleteResp transporter.DoDelete, err error)
+
+	// GetSeasonMatchEvents is used for getting the events of the given types across a season.
+	// It returns getSeasonMatchEventsResp of []transporter.GetSeasonMatchEvents and any errors written.
+	GetSeasonMatchEvents(ctx context.Context, params param.GetSeasonMatchEvents) (getSeasonMatchEventsResp []transporter.GetSeasonMatchEvents, err error)
+}
+
+// MatchEvent is an struct that implements IMatchEvent methods.
+type MatchEvent struct {
+	config   config.IConfig
+	ormMySQL *gorm.DB
+	ormPgSQL *gorm.DB
+	statement
+}
+
+type statement struct {
+	ormTX       *gorm.DB
+	ormChaining *gorm.DB
+}
+
+// New it returns instance of MatchEvent that implements IMatchEvent methods.
+func New(opts ...Option) IMatchEvent {
+	m := new(MatchEvent)
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// DoCreate is used for record new match event, along with the suspension it leads to, and recomputing the match score
+// in a single transaction, so a failure part way leaves neither the event nor a stale score behind.
+// It returns doCreateResp of transporter.DoCreate and any errors written.
+func (matchEvent *MatchEvent) DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error) {
+	recordMatchEvent := model.MatchEvent{
+		MatchID:         params.MatchID,
+		TeamID:          params.TeamID,
+		PlayerID:        params.PlayerID,
+		RelatedPlayerID: params.RelatedPlayerID,
+		Type:            params.Type,
+		Period:          params.Period,
+		Minute:          params.Minute,
+	}
+
+	matchEvent.ormTX = matchEvent.ormPgSQL.WithContext(ctx).Begin()
+
+	if err = matchEvent.ormTX.Create(&recordMatchEvent).Error; err != nil {
+		matchEvent.ormTX.Rollback()
+		return
+	}
+
+	if params.Suspension != nil {
+		recordSuspension := model.Suspension{
+			PlayerID:         params.Suspension.PlayerID,
+			SeasonID:         params.Suspension.SeasonID,
+			MatchID:          &recordMatchEvent.MatchID,
+			MatchEventID:     &recordMatchEvent.ID,
+			Reason:           params.Suspension.Reason,
+			MatchesRemaining: params.Suspension.MatchesRemaining,
+		}
+
+		if err = matchEvent.ormTX.Create(&recordSuspension).Error; err != nil {
+			matchEvent.ormTX.Rollback()
+			return
+		}
+	}
+
+	if err = match.SyncScore(matchEvent.ormTX, params.MatchID).Scan(&matchTransporter.DoSyncScore{}).Error; err != nil {
+		matchEvent.ormTX.Rollback()
+		return
+	}
+
+	if err = matchEvent.ormTX.Commit().Error; err != nil {
+		return
+	}
+
+	doCreateResp = transporter.DoCreate{
+		MatchEvent: transporter.MatchEvent{
+			ID:              recordMatchEvent.ID,
+			MatchID:         recordMatchEvent.MatchID,
+			TeamID:          recordMatchEvent.TeamID,
+			PlayerID:        recordMatchEvent.PlayerID,
+			RelatedPlayerID: recordMatchEvent.RelatedPlayerID,
+			Type:            recordMatchEvent.Type,
+			Period:          recordMatchEvent.Period,
+			Minute:          recordMatchEvent.Minute,
+		},
+	}
+
+	return
+}
+
+// GetMatchEvents is used for getting the timeline of a match.
+// It returns getMatchEventsResp of []transporter.GetMatchEvents and any errors written.
+func (matchEvent *MatchEvent) GetMatchEvents(ctx context.Context, params param.GetMatchEvents) (getMatchEventsResp []transporter.GetMatchEvents, err error) {
+	matchEvent.ormChaining = matchEvent.ormPgSQL.
+		WithContext(ctx).
+		Where("match_id = ?", params.MatchID).
+		Order("minute, created_at")
+
+	if err = matchEvent.ormChaining.Find(&getMatchEventsResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// DoDelete is used for delete the record match event and recomputing the match score in a single transaction.
+// It returns doDeleteResp of transporter.DoDelete and any errors written.
+func (matchEvent *MatchEvent) DoDelete(ctx context.Context, params param.DoDelete) (doDeleteResp transporter.DoDelete, err error) {
+	matchEvent.ormTX = matchEvent.ormPgSQL.WithContext(ctx).Begin()
+
+	if err = matchEvent.ormTX.Where("id = ? AND match_id = ?", params.ID, params.MatchID).Delete(&doDeleteResp).Error; err != nil {
+		matchEvent.ormTX.Rollback()
+		return
+	}
+
+	if err = match.SyncScore(matchEvent.ormTX, params.MatchID).Scan(&matchTransporter.DoSyncScore{}).Error; err != nil {
+		matchEvent.ormTX.Rollback()
+		return
+	}
+
+	if err = matchEvent.ormTX.Commit().Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// GetSeasonMatchEvents is used for getting the events of the given types across a season.
+// It returns getSeasonMatchEventsResp of []transporter.GetSeasonMatchEvents and any errors written.
+func (matchEvent *MatchEvent) GetSeasonMatchEvents(ctx context.Context, params param.GetSeasonMatchEvents) (getSeasonMatchEventsResp []transporter.GetSeasonMatchEvents, err error) {
+	matchEvent.ormChaining = matchEvent.ormPgSQL.
+		WithContext(ctx).
+		Joins("JOIN matches ON matches.id = match_events.match_id").
+		Where("matches.season_id = ? AND match_events.type IN ?", params.SeasonID, params.Types)
+
+	if err = matchEvent.ormChaining.Find(&getSeasonMatchEventsResp).Error; err != nil {
+		return
+	}
+
+	return
+}
@@ -0,0 +1,251 @@
+package matchevent
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/handler/matchevent/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/matchevent/transporter"
+	suspensionParam "github.com/harunnryd/skeltun/internal/app/handler/suspension/param"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo/match/matchtest"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	pgsqlConn *gorm.DB
+	mock      sqlmock.Sqlmock
+
+	matchEvent IMatchEvent
+	helper
+	response
+}
+
+type helper struct {
+	db  *sql.DB
+	err error
+}
+
+type response struct {
+	doCreateResp             transporter.DoCreate
+	getMatchEventsResp       []transporter.GetMatchEvents
+	doDeleteResp             transporter.DoDelete
+	getSeasonMatchEventsResp []transporter.GetSeasonMatchEvents
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.matchEvent = New(
+		WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+}
+
+// TestDoCreate ...
+func (suite *Suite) TestDoCreate() {
+	relatedPlayerID := uuid.NewV4()
+	params := param.DoCreate{
+		MatchEvent: param.MatchEvent{
+			MatchID:         uuid.NewV4(),
+			TeamID:          uuid.NewV4(),
+			PlayerID:        uuid.NewV4(),
+			RelatedPlayerID: &relatedPlayerID,
+			Type:            model.MatchEventTypeSubstitution,
+			Period:          model.MatchPeriodRegularTime,
+			Minute:          63,
+		},
+	}
+
+	suite.mock.ExpectBegin()
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "match_events" ("created_at","updated_at","deleted_at","match_id","team_id","player_id","related_player_id","type","period","minute") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10) RETURNING "id"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.MatchID, params.TeamID, params.PlayerID, relatedPlayerID, params.Type, params.Period, params.Minute).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+	suite.mock.
+		ExpectQuery(`UPDATE matches SET (.+) RETURNING id, home_score, away_score, home_extra_time_score, away_extra_time_score, home_shootout_score, away_shootout_score`).
+		WithArgs(matchtest.SyncScoreArgs(params.MatchID)...).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "home_score", "away_score"}).
+			AddRow(params.MatchID, 0, 0))
+	suite.mock.ExpectCommit()
+
+	suite.response.doCreateResp, suite.helper.err = suite.matchEvent.DoCreate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), params.Type, suite.response.doCreateResp.Type)
+}
+
+// TestDoCreateWithSuspension ...
+func (suite *Suite) TestDoCreateWithSuspension() {
+	matchEventID, seasonID := uuid.NewV4(), uuid.NewV4()
+	params := param.DoCreate{
+		MatchEvent: param.MatchEvent{
+			MatchID:  uuid.NewV4(),
+			TeamID:   uuid.NewV4(),
+			PlayerID: uuid.NewV4(),
+			Type:     model.MatchEventTypeRedCard,
+			Period:   model.MatchPeriodRegularTime,
+			Minute:   71,
+		},
+	}
+	params.Suspension = &suspensionParam.Suspension{
+		PlayerID:         params.PlayerID,
+		SeasonID:         seasonID,
+		Reason:           model.SuspensionReasonRedCard,
+		MatchesRemaining: 1,
+	}
+
+	suite.mock.ExpectBegin()
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "match_events"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(matchEventID))
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "suspensions" ("created_at","updated_at","deleted_at","player_id","season_id","match_id","match_event_id","reason","matches_remaining","last_served_match_id") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10) RETURNING "id"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.PlayerID, seasonID, params.MatchID, matchEventID, model.SuspensionReasonRedCard, 1, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+	suite.mock.
+		ExpectQuery(`UPDATE matches SET (.+)`).
+		WithArgs(matchtest.SyncScoreArgs(params.MatchID)...).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(params.MatchID))
+	suite.mock.ExpectCommit()
+
+	suite.response.doCreateResp, suite.helper.err = suite.matchEvent.DoCreate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), matchEventID, suite.response.doCreateResp.ID)
+}
+
+// TestDoCreateRollback ...
+func (suite *Suite) TestDoCreateRollback() {
+	params := param.DoCreate{
+		MatchEvent: param.MatchEvent{
+			MatchID:  uuid.NewV4(),
+			TeamID:   uuid.NewV4(),
+			PlayerID: uuid.NewV4(),
+			Type:     model.MatchEventTypeGoal,
+			Period:   model.MatchPeriodRegularTime,
+			Minute:   12,
+		},
+	}
+
+	suite.mock.ExpectBegin()
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "match_events"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+	suite.mock.
+		ExpectQuery(`UPDATE matches SET (.+)`).
+		WithArgs(matchtest.SyncScoreArgs(params.MatchID)...).
+		WillReturnError(errors.New("connection reset"))
+	suite.mock.ExpectRollback()
+
+	suite.response.doCreateResp, suite.helper.err = suite.matchEvent.DoCreate(context.Background(), params)
+
+	require.Error(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), uuid.Nil, suite.response.doCreateResp.ID)
+}
+
+// TestGetMatchEvents ...
+func (suite *Suite) TestGetMatchEvents() {
+	params := param.GetMatchEvents{
+		MatchID: uuid.NewV4(),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "match_events" WHERE match_id = $1 ORDER BY minute, created_at`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "match_id", "type", "minute"}).
+			AddRow(uuid.NewV4(), params.MatchID, model.MatchEventTypeGoal, 9).
+			AddRow(uuid.NewV4(), params.MatchID, model.MatchEventTypeYellowCard, 41))
+
+	suite.response.getMatchEventsResp, suite.helper.err = suite.matchEvent.GetMatchEvents(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Len(suite.T(), suite.response.getMatchEventsResp, 2)
+}
+
+// TestDoDelete ...
+func (suite *Suite) TestDoDelete() {
+	params := param.DoDelete{
+		ID:      uuid.NewV4(),
+		MatchID: uuid.NewV4(),
+	}
+
+	suite.mock.ExpectBegin()
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`DELETE FROM "match_events" WHERE id = $1 AND match_id = $2`)).
+		WithArgs(params.ID, params.MatchID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	suite.mock.
+		ExpectQuery(`UPDATE matches SET (.+)`).
+		WithArgs(matchtest.SyncScoreArgs(params.MatchID)...).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(params.MatchID))
+	suite.mock.ExpectCommit()
+
+	suite.response.doDeleteResp, suite.helper.err = suite.matchEvent.DoDelete(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+}
+
+// TestGetSeasonMatchEvents ...
+func (suite *Suite) TestGetSeasonMatchEvents() {
+	params := param.GetSeasonMatchEvents{
+		SeasonID: uuid.NewV4(),
+		Types:    []string{model.MatchEventTypeYellowCard, model.MatchEventTypeRedCard},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT "match_events"."id","match_events"."match_id","match_events"."team_id","match_events"."player_id","match_events"."related_player_id","match_events"."type","match_events"."period","match_events"."minute" FROM "match_events" JOIN matches ON matches.id = match_events.match_id WHERE matches.season_id = $1 AND match_events.type IN ($2,$3)`)).
+		WithArgs(params.SeasonID, model.MatchEventTypeYellowCard, model.MatchEventTypeRedCard).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "type"}).
+			AddRow(uuid.NewV4(), model.MatchEventTypeRedCard))
+
+	suite.response.getSeasonMatchEventsResp, suite.helper.err = suite.matchEvent.GetSeasonMatchEvents(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Len(suite.T(), suite.response.getSeasonMatchEventsResp, 1)
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
@@ -0,0 +1,388 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package official
+
+import (
+	"context"
+
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/official/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/official/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/satori/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IOfficial is an interface that stores the methods that Official struct will use.
+type IOfficial interface {
+	// DoCreate is used for record new official.
+	// It returns doCreateResp of transporter.DoCreate and any errors written.
+	DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error)
+
+	// GetOfficials is used for getting all officials.
+	// It returns getOfficialsResp of []transporter.GetOfficials and any errors written.
+	GetOfficials(ctx context.Context, params param.GetOfficials) (getOfficialsResp []transporter.GetOfficials, err error)
+
+	// DoUpdate is used for update the record official.
+	// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+	DoUpdate(ctx context.Context, params param.DoUpdate) (doUpdateResp transporter.DoUpdate, err error)
+
+	// DoDelete is used for delete the record official.
+	// It returns doDeleteResp of transporter.DoDelete and any errors written.
+	DoDelete(ctx context.Context, params param.DoDelete) (doDeleteResp transporter.DoDelete, err error)
+
+	// GetOfficial is used for getting an official.
+	// It returns getOfficialResp of transporter.GetOfficial and any errors written.
+	GetOfficial(ctx context.Context, params param.GetOfficial) (getOfficialResp transporter.GetOfficial, err error)
+
+	// GetOfficialsByIDs is used for getting the officials with the given ids.
+	// It returns getOfficialsByIDsResp of []transporter.GetOfficialsByIDs and any errors written.
+	GetOfficialsByIDs(ctx context.Context, params param.GetOfficialsByIDs) (getOfficialsByIDsResp []transporter.GetOfficialsByIDs, err error)
+
+	// DoAssign is used for assign the officials of a match, replacing the previous ones in a single transaction,
+	// unless one of the officials is booked for another match kicking off between params.From and params.To.
+	// It returns doAssignResp of transporter.DoAssign and any errors written.
+	DoAssign(ctx context.Context, params param.DoAssign) (doAssignResp transporter.DoAssign, err error)
+
+	// GetAssignments is used for getting the officials assigned to a match.
+	// It returns getAssignmentsResp of []transporter.GetAssignments and any errors written.
+	GetAssignments(ctx context.Context, params param.GetAssignments) (getAssignmentsResp []transporter.GetAssignments, err error)
+
+	// CountAssignments is used for counting the matches an official is assigned to.
+	// It returns countAssignmentsResp of transporter.CountAssignments and any errors written.
+	CountAssignments(ctx context.Context, params param.CountAssignments) (countAssignmentsResp transporter.CountAssignments, err error)
+
+	// GetBookings is used for getting the other matches the given officials are assigned to between two kickoffs.
+	// It returns getBookingsResp of []transporter.GetBookings and any errors written.
+	GetBookings(ctx context.Context, params param.GetBookings) (getBookingsResp []transporter.GetBookings, err error)
+
+	// GetRefereeMatches is used for getting the finished matches refereed by an official or in a competition.
+	// It returns getRefereeMatchesResp of []transporter.GetRefereeMatches and any errors written.
+	GetRefereeMatches(ctx context.Context, params param.GetRefereeMatches) (getRefereeMatchesResp []transporter.GetRefereeMatches, err error)
+
+	// GetDecisions is used for getting the cards shown and the penalties awarded in the given matches.
+	// It returns getDecisionsResp of []transporter.GetDecisions and any errors written.
+	GetDecisions(ctx context.Context, params param.GetDecisions) (getDecisionsResp []transporter.GetDecisions, err error)
+}
+
+// Official is an struct that implements IOfficial methods.
+type Official struct {
+	config   config.IConfig
+	ormMySQL *gorm.DB
+	ormPgSQL *gorm.DB
+	statement
+}
+
+type statement struct {
+	ormTX       *gorm.DB
+	ormChaining *gorm.DB
+}
+
+// New it returns instance of Official that implements IOfficial methods.
+func New(opts ...Option) IOfficial {
+	o := new(Official)
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// DoCreate is used for record new official.
+// It returns doCreateResp of transporter.DoCreate and any errors written.
+func (official *Official) DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error) {
+	recordOfficial := model.Official{
+		Name:             params.Name,
+		Role:             params.Role,
+		AffiliatedTeamID: params.AffiliatedTeamID,
+	}
+
+	if err = official.ormPgSQL.WithContext(ctx).Create(&recordOfficial).Error; err != nil {
+		return
+	}
+
+	doCreateResp.Official = transporter.Official{
+		ID:               recordOfficial.ID,
+		Name:             recordOfficial.Name,
+		Role:             recordOfficial.Role,
+		AffiliatedTeamID: recordOfficial.AffiliatedTeamID,
+	}
+
+	return
+}
+
+// GetOfficials is used for getting all officials.
+// It returns getOfficialsResp of []transporter.GetOfficials and any errors written.
+func (official *Official) GetOfficials(ctx context.Context, params param.GetOfficials) (getOfficialsResp []transporter.GetOfficials, err error) {
+	official.ormChaining = official.ormPgSQL.
+		WithContext(ctx).
+		Where("deleted_at IS NULL").
+		Order("name").
+		Limit(params.GetLimit()).
+		Offset(params.GetOffset())
+
+	if err = official.ormChaining.Find(&getOfficialsResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// DoUpdate is used for update the record official.
+// Every field is written, so an affiliation can be cleared.
+// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+func (official *Official) DoUpdate(ctx context.Context, params param.DoUpdate) (doUpdateResp transporter.DoUpdate, err error) {
+	recordOfficial := model.Official{
+		Name:             params.Name,
+		Role:             params.Role,
+		AffiliatedTeamID: params.AffiliatedTeamID,
+	}
+
+	official.ormChaining = official.ormPgSQL.
+		WithContext(ctx).
+		Select("updated_at", "name", "role", "affiliated_team_id").
+		Where("id = ?", params.ID)
+
+	if err = official.ormChaining.Updates(&recordOfficial).Error; err != nil {
+		return
+	}
+
+	doUpdateResp.Official = transporter.Official{
+		ID:               params.ID,
+		Name:             recordOfficial.Name,
+		Role:             recordOfficial.Role,
+		AffiliatedTeamID: recordOfficial.AffiliatedTeamID,
+	}
+
+	return
+}
+
+// DoDelete is used for delete the record official.
+// It returns doDeleteResp of transporter.DoDelete and any errors written.
+func (official *Official) DoDelete(ctx context.Context, params param.DoDelete) (doDeleteResp transporter.DoDelete, err error) {
+	official.ormChaining = official.ormPgSQL.
+		WithContext(ctx).
+		Where("id = ?", params.ID)
+
+	if err = official.ormChaining.Delete(&doDeleteResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// GetOfficial is used for getting an official.
+// It returns getOfficialResp of transporter.GetOfficial and any errors written.
+func (official *Official) GetOfficial(ctx context.Context, params param.GetOfficial) (getOfficialResp transporter.GetOfficial, err error) {
+	official.ormChaining = official.ormPgSQL.
+		WithContext(ctx).
+		Where("id = ? AND deleted_at IS NULL", params.ID).
+		Limit(1)
+
+	if err = official.ormChaining.Find(&getOfficialResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// GetOfficialsByIDs is used for getting the officials with the given ids.
+// It returns getOfficialsByIDsResp of []transporter.GetOfficialsByIDs and any errors written.
+func (official *Official) GetOfficialsByIDs(ctx context.Context, params param.GetOfficialsByIDs) (getOfficialsByIDsResp []transporter.GetOfficialsByIDs, err error) {
+	official.ormChaining = official.ormPgSQL.
+		WithContext(ctx).
+		Where("id IN ? AND deleted_at IS NULL", params.IDs)
+
+	if err = official.ormChaining.Find(&getOfficialsByIDsResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// DoAssign is used for assign the officials of a match, replacing the previous ones in a single transaction,
+// unless one of the officials is booked for another match kicking off between params.From and params.To.
+// The officials are locked before their bookings are looked at, so two matches assigned at the same time
+// cannot both book the same official. The bookings in the way are given back, with nothing assigned.
+// It returns doAssignResp of transporter.DoAssign and any errors written.
+func (official *Official) DoAssign(ctx context.Context, params param.DoAssign) (doAssignResp transporter.DoAssign, err error) {
+	officialIDs := make([]uuid.UUID, 0, len(params.Officials))
+	recordMatchOfficials := make([]model.MatchOfficial, 0, len(params.Officials))
+	for _, assignment := range params.Officials {
+		officialIDs = append(officialIDs, assignment.OfficialID)
+		recordMatchOfficials = append(recordMatchOfficials, model.MatchOfficial{
+			MatchID:    params.MatchID,
+			OfficialID: assignment.OfficialID,
+			Role:       assignment.Role,
+		})
+	}
+
+	official.ormTX = official.ormPgSQL.WithContext(ctx).Begin()
+
+	if err = official.ormTX.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		Where("id IN ?", officialIDs).
+		Order("id").
+		Find(&[]model.Official{}).Error; err != nil {
+		official.ormTX.Rollback()
+		return
+	}
+
+	if err = official.bookings(official.ormTX, param.GetBookings{
+		OfficialIDs: officialIDs,
+		MatchID:     params.MatchID,
+		From:        params.From,
+		To:          params.To,
+	}).Scan(&doAssignResp.Bookings).Error; err != nil {
+		official.ormTX.Rollback()
+		return
+	}
+
+	if len(doAssignResp.Bookings) > 0 {
+		official.ormTX.Rollback()
+		return
+	}
+
+	if err = official.ormTX.
+		Where("match_id = ?", params.MatchID).
+		Delete(&model.MatchOfficial{}).Error; err != nil {
+		official.ormTX.Rollback()
+		return
+	}
+
+	if err = official.ormTX.Create(&recordMatchOfficials).Error; err != nil {
+		official.ormTX.Rollback()
+		return
+	}
+
+	if err = official.ormTX.Commit().Error; err != nil {
+		return
+	}
+
+	doAssignResp.MatchID = params.MatchID
+	for _, recordMatchOfficial := range recordMatchOfficials {
+		doAssignResp.Officials = append(doAssignResp.Officials, transporter.Assignment{
+			OfficialID: recordMatchOfficial.OfficialID,
+			Role:       recordMatchOfficial.Role,
+		})
+	}
+
+	return
+}
+
+// GetAssignments is used for getting the officials assigned to a match.
+// It returns getAssignmentsResp of []transporter.GetAssignments and any errors written.
+func (official *Official) GetAssignments(ctx context.Context, params param.GetAssignments) (getAssignmentsResp []transporter.GetAssignments, err error) {
+	official.ormChaining = official.ormPgSQL.
+		WithContext(ctx).
+		Model(&model.MatchOfficial{}).
+		Select("match_officials.official_id, officials.name, match_officials.role").
+		Joins("JOIN officials ON officials.id = match_officials.official_id").
+		Where("match_officials.match_id = ?", params.MatchID).
+		Order("match_officials.created_at, officials.name")
+
+	if err = official.ormChaining.Scan(&getAssignmentsResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// CountAssignments is used for counting the matches an official is assigned to.
+// It returns countAssignmentsResp of transporter.CountAssignments and any errors written.
+func (official *Official) CountAssignments(ctx context.Context, params param.CountAssignments) (countAssignmentsResp transporter.CountAssignments, err error) {
+	official.ormChaining = official.ormPgSQL.
+		WithContext(ctx).
+		Model(&model.MatchOfficial{}).
+		Select("COUNT(*) AS total").
+		Where("official_id = ?", params.OfficialID)
+
+	if err = official.ormChaining.Scan(&countAssignmentsResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// GetBookings is used for getting the other matches the given officials are assigned to between two kickoffs.
+// Postponed and cancelled matches do not keep an official busy.
+// It returns getBookingsResp of []transporter.GetBookings and any errors written.
+func (official *Official) GetBookings(ctx context.Context, params param.GetBookings) (getBookingsResp []transporter.GetBookings, err error) {
+	official.ormChaining = official.bookings(official.ormPgSQL.WithContext(ctx), params)
+
+	if err = official.ormChaining.Scan(&getBookingsResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// bookings is used for building the query of the other matches the given officials are assigned to between two kickoffs
+// on the given connection, so it can run inside the transaction of an assignment.
+func (official *Official) bookings(db *gorm.DB, params param.GetBookings) *gorm.DB {
+	return db.
+		Model(&model.MatchOfficial{}).
+		Select("match_officials.official_id, match_officials.match_id, matches.kickoff_at").
+		Joins("JOIN matches ON matches.id = match_officials.match_id").
+		Where("match_officials.official_id IN ? AND match_officials.match_id <> ?", params.OfficialIDs, params.MatchID).
+		Where("matches.kickoff_at > ? AND matches.kickoff_at < ?", params.From, params.To).
+		Where("matches.status NOT IN ?", []string{model.MatchStatusPostponed, model.MatchStatusCancelled}).
+		Order("matches.kickoff_at")
+}
+
+// GetRefereeMatches is used for getting the finished matches refereed by an official or in a competition.
+// Only the official in charge is counted, whichever other role they were assigned to as well.
+// It returns getRefereeMatchesResp of []transporter.GetRefereeMatches and any errors written.
+func (official *Official) GetRefereeMatches(ctx context.Context, params param.GetRefereeMatches) (getRefereeMatchesResp []transporter.GetRefereeMatches, err error) {
+	official.ormChaining = official.ormPgSQL.
+		WithContext(ctx).
+		Model(&model.MatchOfficial{}).
+		Select("match_officials.official_id, officials.name, matches.id AS match_id, matches.home_team_id, matches.away_team_id").
+		Joins("JOIN officials ON officials.id = match_officials.official_id").
+		Joins("JOIN matches ON matches.id = match_officials.match_id").
+		Where("match_officials.role = ? AND matches.status = ? AND matches.deleted_at IS NULL", model.OfficialRoleReferee, model.MatchStatusFinished)
+
+	if params.OfficialID != nil {
+		official.ormChaining = official.ormChaining.Where("match_officials.official_id = ?", *params.OfficialID)
+	}
+
+	if params.CompetitionID != nil {
+		official.ormChaining = official.ormChaining.
+			Joins("JOIN seasons ON seasons.id = matches.season_id").
+			Where("seasons.competition_id = ?", *params.CompetitionID)
+	}
+
+	if params.SeasonID != nil {
+		official.ormChaining = official.ormChaining.Where("matches.season_id = ?", *params.SeasonID)
+	}
+
+	if err = official.ormChaining.Order("matches.kickoff_at").Scan(&getRefereeMatchesResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// GetDecisions is used for getting the cards shown and the penalties awarded in the given matches.
+// It returns getDecisionsResp of []transporter.GetDecisions and any errors written.
+func (official *Official) GetDecisions(ctx context.Context, params param.GetDecisions) (getDecisionsResp []transporter.GetDecisions, err error) {
+	official.ormChaining = official.ormPgSQL.
+		WithContext(ctx).
+		Where("match_id IN ? AND type IN ? AND deleted_at IS NULL", params.MatchIDs, []string{
+			model.MatchEventTypeYellowCard,
+			model.MatchEventTypeSecondYellow,
+			model.MatchEventTypeRedCard,
+			model.MatchEventTypePenalty,
+			model.MatchEventTypePenaltyMissed,
+		})
+
+	if err = official.ormChaining.Find(&getDecisionsResp).Error; err != nil {
+		return
+	}
+
+	return
+}
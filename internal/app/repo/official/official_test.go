@@ -0,0 +1,254 @@
+package official
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/handler/official/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/official/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	pgsqlConn *gorm.DB
+	mock      sqlmock.Sqlmock
+
+	official IOfficial
+	helper
+	response
+}
+
+type helper struct {
+	db  *sql.DB
+	err error
+}
+
+type response struct {
+	doUpdateResp    transporter.DoUpdate
+	doAssignResp    transporter.DoAssign
+	getBookingsResp []transporter.GetBookings
+
+	countAssignmentsResp transporter.CountAssignments
+
+	getRefereeMatchesResp []transporter.GetRefereeMatches
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.official = New(
+		WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+}
+
+// TestDoUpdate ...
+func (suite *Suite) TestDoUpdate() {
+	params := param.DoUpdate{
+		Official: param.Official{
+			ID:   uuid.NewV4(),
+			Name: "Michael Oliver",
+			Role: model.OfficialRoleReferee,
+		},
+	}
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "officials" SET "updated_at"=$1,"name"=$2,"role"=$3,"affiliated_team_id"=$4 WHERE id = $5`)).
+		WithArgs(sqlmock.AnyArg(), params.Name, params.Role, nil, params.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	suite.response.doUpdateResp, suite.helper.err = suite.official.DoUpdate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), params.ID, suite.response.doUpdateResp.ID)
+	require.Nil(suite.T(), suite.response.doUpdateResp.AffiliatedTeamID)
+}
+
+// TestDoAssign ...
+func (suite *Suite) TestDoAssign() {
+	kickoffAt := time.Date(2021, time.August, 14, 15, 0, 0, 0, time.UTC)
+	params := param.DoAssign{
+		MatchID: uuid.NewV4(),
+		Officials: []param.Assignment{
+			{OfficialID: uuid.NewV4(), Role: model.OfficialRoleReferee},
+			{OfficialID: uuid.NewV4(), Role: model.OfficialRoleVAR},
+		},
+		From: kickoffAt.Add(-3 * time.Hour),
+		To:   kickoffAt.Add(3 * time.Hour),
+	}
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "officials" WHERE id IN ($1,$2) ORDER BY id FOR UPDATE`)).
+		WithArgs(params.Officials[0].OfficialID, params.Officials[1].OfficialID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(params.Officials[0].OfficialID).
+			AddRow(params.Officials[1].OfficialID))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT match_officials.official_id, match_officials.match_id, matches.kickoff_at FROM "match_officials" JOIN matches ON matches.id = match_officials.match_id WHERE (match_officials.official_id IN ($1,$2) AND match_officials.match_id <> $3) AND (matches.kickoff_at > $4 AND matches.kickoff_at < $5) AND matches.status NOT IN ($6,$7) ORDER BY matches.kickoff_at`)).
+		WithArgs(params.Officials[0].OfficialID, params.Officials[1].OfficialID, params.MatchID, params.From, params.To, model.MatchStatusPostponed, model.MatchStatusCancelled).
+		WillReturnRows(sqlmock.NewRows([]string{"official_id", "match_id", "kickoff_at"}))
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`DELETE FROM "match_officials" WHERE match_id = $1`)).
+		WithArgs(params.MatchID).
+		WillReturnResult(sqlmock.NewResult(0, 4))
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`INSERT INTO "match_officials" ("match_id","official_id","role","created_at") VALUES ($1,$2,$3,$4),($5,$6,$7,$8)`)).
+		WithArgs(
+			params.MatchID, params.Officials[0].OfficialID, model.OfficialRoleReferee, sqlmock.AnyArg(),
+			params.MatchID, params.Officials[1].OfficialID, model.OfficialRoleVAR, sqlmock.AnyArg(),
+		).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	suite.mock.ExpectCommit()
+
+	suite.response.doAssignResp, suite.helper.err = suite.official.DoAssign(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), params.MatchID, suite.response.doAssignResp.MatchID)
+	require.Len(suite.T(), suite.response.doAssignResp.Officials, 2)
+	require.Equal(suite.T(), model.OfficialRoleVAR, suite.response.doAssignResp.Officials[1].Role)
+	require.Empty(suite.T(), suite.response.doAssignResp.Bookings)
+}
+
+// TestDoAssignBooked ...
+func (suite *Suite) TestDoAssignBooked() {
+	kickoffAt := time.Date(2021, time.August, 14, 15, 0, 0, 0, time.UTC)
+	params := param.DoAssign{
+		MatchID: uuid.NewV4(),
+		Officials: []param.Assignment{
+			{OfficialID: uuid.NewV4(), Role: model.OfficialRoleReferee},
+		},
+		From: kickoffAt.Add(-3 * time.Hour),
+		To:   kickoffAt.Add(3 * time.Hour),
+	}
+	bookedMatchID := uuid.NewV4()
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "officials" WHERE id IN ($1) ORDER BY id FOR UPDATE`)).
+		WithArgs(params.Officials[0].OfficialID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(params.Officials[0].OfficialID))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`FROM "match_officials" JOIN matches ON matches.id = match_officials.match_id`)).
+		WillReturnRows(sqlmock.NewRows([]string{"official_id", "match_id", "kickoff_at"}).
+			AddRow(params.Officials[0].OfficialID, bookedMatchID, kickoffAt.Add(time.Hour)))
+
+	suite.mock.ExpectRollback()
+
+	suite.response.doAssignResp, suite.helper.err = suite.official.DoAssign(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Empty(suite.T(), suite.response.doAssignResp.Officials)
+	require.Len(suite.T(), suite.response.doAssignResp.Bookings, 1)
+	require.Equal(suite.T(), bookedMatchID, suite.response.doAssignResp.Bookings[0].MatchID)
+}
+
+// TestCountAssignments ...
+func (suite *Suite) TestCountAssignments() {
+	params := param.CountAssignments{OfficialID: uuid.NewV4()}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) AS total FROM "match_officials" WHERE official_id = $1`)).
+		WithArgs(params.OfficialID).
+		WillReturnRows(sqlmock.NewRows([]string{"total"}).
+			AddRow(3))
+
+	suite.response.countAssignmentsResp, suite.helper.err = suite.official.CountAssignments(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), int64(3), suite.response.countAssignmentsResp.Total)
+}
+
+// TestGetBookings ...
+func (suite *Suite) TestGetBookings() {
+	kickoffAt := time.Date(2021, time.August, 14, 15, 0, 0, 0, time.UTC)
+	params := param.GetBookings{
+		OfficialIDs: []uuid.UUID{uuid.NewV4()},
+		MatchID:     uuid.NewV4(),
+		From:        kickoffAt.Add(-3 * time.Hour),
+		To:          kickoffAt.Add(3 * time.Hour),
+	}
+	bookedMatchID := uuid.NewV4()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT match_officials.official_id, match_officials.match_id, matches.kickoff_at FROM "match_officials" JOIN matches ON matches.id = match_officials.match_id WHERE (match_officials.official_id IN ($1) AND match_officials.match_id <> $2) AND (matches.kickoff_at > $3 AND matches.kickoff_at < $4) AND matches.status NOT IN ($5,$6) ORDER BY matches.kickoff_at`)).
+		WithArgs(params.OfficialIDs[0], params.MatchID, params.From, params.To, model.MatchStatusPostponed, model.MatchStatusCancelled).
+		WillReturnRows(sqlmock.NewRows([]string{"official_id", "match_id", "kickoff_at"}).
+			AddRow(params.OfficialIDs[0], bookedMatchID, kickoffAt.Add(time.Hour)))
+
+	suite.response.getBookingsResp, suite.helper.err = suite.official.GetBookings(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Len(suite.T(), suite.response.getBookingsResp, 1)
+	require.Equal(suite.T(), bookedMatchID, suite.response.getBookingsResp[0].MatchID)
+}
+
+// TestGetRefereeMatches ...
+func (suite *Suite) TestGetRefereeMatches() {
+	competitionID, seasonID := uuid.NewV4(), uuid.NewV4()
+	params := param.GetRefereeMatches{
+		CompetitionID: &competitionID,
+		SeasonID:      &seasonID,
+	}
+	officialID, matchID := uuid.NewV4(), uuid.NewV4()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT match_officials.official_id, officials.name, matches.id AS match_id, matches.home_team_id, matches.away_team_id FROM "match_officials" JOIN officials ON officials.id = match_officials.official_id JOIN matches ON matches.id = match_officials.match_id JOIN seasons ON seasons.id = matches.season_id WHERE (match_officials.role = $1 AND matches.status = $2 AND matches.deleted_at IS NULL) AND seasons.competition_id = $3 AND matches.season_id = $4 ORDER BY matches.kickoff_at`)).
+		WithArgs(model.OfficialRoleReferee, model.MatchStatusFinished, competitionID, seasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"official_id", "name", "match_id", "home_team_id", "away_team_id"}).
+			AddRow(officialID, "Michael Oliver", matchID, uuid.NewV4(), uuid.NewV4()))
+
+	suite.response.getRefereeMatchesResp, suite.helper.err = suite.official.GetRefereeMatches(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Len(suite.T(), suite.response.getRefereeMatchesResp, 1)
+	require.Equal(suite.T(), matchID, suite.response.getRefereeMatchesResp[0].MatchID)
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
@@ -7,9 +7,28 @@ package repo
 import (
 	"github.com/harunnryd/skeltun/config"
 	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/repo/bracket"
+	"github.com/harunnryd/skeltun/internal/app/repo/competition"
+	"github.com/harunnryd/skeltun/internal/app/repo/contract"
+	"github.com/harunnryd/skeltun/internal/app/repo/draw"
+	"github.com/harunnryd/skeltun/internal/app/repo/group"
 	"github.com/harunnryd/skeltun/internal/app/repo/hcheck"
+	"github.com/harunnryd/skeltun/internal/app/repo/injury"
+	"github.com/harunnryd/skeltun/internal/app/repo/leaderboard"
+	"github.com/harunnryd/skeltun/internal/app/repo/lineup"
+	"github.com/harunnryd/skeltun/internal/app/repo/loan"
+	"github.com/harunnryd/skeltun/internal/app/repo/match"
+	"github.com/harunnryd/skeltun/internal/app/repo/matchevent"
+	"github.com/harunnryd/skeltun/internal/app/repo/official"
 	"github.com/harunnryd/skeltun/internal/app/repo/player"
+	"github.com/harunnryd/skeltun/internal/app/repo/season"
+	"github.com/harunnryd/skeltun/internal/app/repo/shootoutkick"
+	"github.com/harunnryd/skeltun/internal/app/repo/squad"
+	"github.com/harunnryd/skeltun/internal/app/repo/stat"
+	"github.com/harunnryd/skeltun/internal/app/repo/suspension"
 	"github.com/harunnryd/skeltun/internal/app/repo/team"
+	"github.com/harunnryd/skeltun/internal/app/repo/transfer"
+	"github.com/harunnryd/skeltun/internal/app/repo/venue"
 )
 
 // Option ...
@@ -54,5 +73,119 @@ func WithDependency(config config.IConfig) Option {
 			player.WithDatabase(db.PgsqlDialectParam, pgsqlConn),
 			player.WithDatabase(db.MysqlDialectParam, mysqlConn),
 		)
+
+		repo.match = match.New(
+			match.WithConfig(config),
+			match.WithDatabase(db.PgsqlDialectParam, pgsqlConn),
+			match.WithDatabase(db.MysqlDialectParam, mysqlConn),
+		)
+
+		repo.competition = competition.New(
+			competition.WithConfig(config),
+			competition.WithDatabase(db.PgsqlDialectParam, pgsqlConn),
+			competition.WithDatabase(db.MysqlDialectParam, mysqlConn),
+		)
+
+		repo.season = season.New(
+			season.WithConfig(config),
+			season.WithDatabase(db.PgsqlDialectParam, pgsqlConn),
+			season.WithDatabase(db.MysqlDialectParam, mysqlConn),
+		)
+
+		repo.matchevent = matchevent.New(
+			matchevent.WithConfig(config),
+			matchevent.WithDatabase(db.PgsqlDialectParam, pgsqlConn),
+			matchevent.WithDatabase(db.MysqlDialectParam, mysqlConn),
+		)
+
+		repo.shootoutkick = shootoutkick.New(
+			shootoutkick.WithConfig(config),
+			shootoutkick.WithDatabase(db.PgsqlDialectParam, pgsqlConn),
+			shootoutkick.WithDatabase(db.MysqlDialectParam, mysqlConn),
+		)
+
+		repo.bracket = bracket.New(
+			bracket.WithConfig(config),
+			bracket.WithDatabase(db.PgsqlDialectParam, pgsqlConn),
+			bracket.WithDatabase(db.MysqlDialectParam, mysqlConn),
+		)
+
+		repo.draw = draw.New(
+			draw.WithConfig(config),
+			draw.WithDatabase(db.PgsqlDialectParam, pgsqlConn),
+			draw.WithDatabase(db.MysqlDialectParam, mysqlConn),
+		)
+
+		repo.group = group.New(
+			group.WithConfig(config),
+			group.WithDatabase(db.PgsqlDialectParam, pgsqlConn),
+			group.WithDatabase(db.MysqlDialectParam, mysqlConn),
+		)
+
+		repo.transfer = transfer.New(
+			transfer.WithConfig(config),
+			transfer.WithDatabase(db.PgsqlDialectParam, pgsqlConn),
+			transfer.WithDatabase(db.MysqlDialectParam, mysqlConn),
+		)
+
+		repo.loan = loan.New(
+			loan.WithConfig(config),
+			loan.WithDatabase(db.PgsqlDialectParam, pgsqlConn),
+			loan.WithDatabase(db.MysqlDialectParam, mysqlConn),
+		)
+
+		repo.contract = contract.New(
+			contract.WithConfig(config),
+			contract.WithDatabase(db.PgsqlDialectParam, pgsqlConn),
+			contract.WithDatabase(db.MysqlDialectParam, mysqlConn),
+		)
+
+		repo.squad = squad.New(
+			squad.WithConfig(config),
+			squad.WithDatabase(db.PgsqlDialectParam, pgsqlConn),
+			squad.WithDatabase(db.MysqlDialectParam, mysqlConn),
+		)
+
+		repo.lineup = lineup.New(
+			lineup.WithConfig(config),
+			lineup.WithDatabase(db.PgsqlDialectParam, pgsqlConn),
+			lineup.WithDatabase(db.MysqlDialectParam, mysqlConn),
+		)
+
+		repo.stat = stat.New(
+			stat.WithConfig(config),
+			stat.WithDatabase(db.PgsqlDialectParam, pgsqlConn),
+			stat.WithDatabase(db.MysqlDialectParam, mysqlConn),
+		)
+
+		repo.leaderboard = leaderboard.New(
+			leaderboard.WithConfig(config),
+			leaderboard.WithDatabase(db.PgsqlDialectParam, pgsqlConn),
+			leaderboard.WithDatabase(db.MysqlDialectParam, mysqlConn),
+		)
+
+		repo.injury = injury.New(
+			injury.WithConfig(config),
+			injury.WithDatabase(db.PgsqlDialectParam, pgsqlConn),
+			injury.WithDatabase(db.MysqlDialectParam, mysqlConn),
+		)
+
+		repo.suspension = suspension.New(
+			suspension.WithConfig(config),
+			suspension.WithDatabase(db.PgsqlDialectParam, pgsqlConn),
+			suspension.WithDatabase(db.MysqlDialectParam, mysqlConn),
+		)
+
+		repo.venue = venue.New(
+			venue.WithConfig(config),
+			venue.WithDatabase(db.PgsqlDialectParam, pgsqlConn),
+			venue.WithDatabase(db.MysqlDialectParam, mysqlConn),
+		)
+
+		repo.official = official.New(
+			official.WithConfig(config),
+			official.WithDatabase(db.PgsqlDialectParam, pgsqlConn),
+			official.WithDatabase(db.MysqlDialectParam, mysqlConn),
+		)
 	}
 }
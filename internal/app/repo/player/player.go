@@ -7,6 +7,7 @@ package player
 import (
 	"context"
 
+	contractTransporter "github.com/harunnryd/skeltun/internal/app/handler/contract/transporter"
 	"github.com/harunnryd/skeltun/internal/app/handler/player/transporter"
 
 	"github.com/harunnryd/skeltun/config"
@@ -37,6 +38,10 @@ type IPlayer interface {
 	// GetPlayer is used for getting an player.
 	// It returns getPlayerResp of transporter.GetPlayer and any errors written.
 	GetPlayer(ctx context.Context, params param.GetPlayer) (getPlayerResp transporter.GetPlayer, err error)
+
+	// GetShirtNumber is used for getting the player of a team wearing the given shirt number.
+	// It returns getShirtNumberResp of transporter.GetShirtNumber and any errors written.
+	GetShirtNumber(ctx context.Context, params param.GetShirtNumber) (getShirtNumberResp transporter.GetShirtNumber, err error)
 }
 
 // Player is an struct that implements IPlayer methods.
@@ -62,31 +67,85 @@ func New(opts ...Option) IPlayer {
 	return p
 }
 
-// DoCreate is used for record new player.
+// DoCreate is used for record new player along with the contract signed with the team in a single transaction.
 // It returns doCreateResp of transporter.DoCreate and any errors written.
 func (player *Player) DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error) {
 	recordPlayer := model.Player{
 		Model: model.Model{
 			ID: params.ID,
 		},
-		TeamID: params.TeamID,
-		Name:   params.Name,
+		TeamID:        params.TeamID,
+		Name:          params.Name,
+		Position:      params.Position,
+		Role:          params.Role,
+		ShirtNumber:   params.ShirtNumber,
+		DateOfBirth:   params.DateOfBirth,
+		Nationality:   params.Nationality,
+		Height:        params.Height,
+		Weight:        params.Weight,
+		PreferredFoot: params.PreferredFoot,
+		Homegrown:     params.Homegrown,
 	}
 
-	player.ormChaining = player.ormPgSQL.WithContext(ctx)
+	player.ormTX = player.ormPgSQL.WithContext(ctx).Begin()
 
-	if err != player.ormChaining.Create(&recordPlayer).Error {
+	if err = player.ormTX.Create(&recordPlayer).Error; err != nil {
+		player.ormTX.Rollback()
+		return
+	}
+
+	var recordContract *model.Contract
+	if params.Contract != nil {
+		recordContract = &model.Contract{
+			PlayerID:      recordPlayer.ID,
+			TeamID:        recordPlayer.TeamID,
+			StartsAt:      params.Date,
+			EndsAt:        params.Contract.EndsAt,
+			Wage:          params.Contract.Wage,
+			ReleaseClause: params.Contract.ReleaseClause,
+			Status:        model.ContractStatusActive,
+		}
+
+		if err = player.ormTX.Create(recordContract).Error; err != nil {
+			player.ormTX.Rollback()
+			return
+		}
+	}
+
+	if err = player.ormTX.Commit().Error; err != nil {
 		return
 	}
 
 	doCreateResp = transporter.DoCreate{
 		Player: transporter.Player{
-			ID:     recordPlayer.ID,
-			TeamID: recordPlayer.TeamID,
-			Name:   recordPlayer.Name,
+			ID:            recordPlayer.ID,
+			TeamID:        recordPlayer.TeamID,
+			Name:          recordPlayer.Name,
+			Position:      recordPlayer.Position,
+			Role:          recordPlayer.Role,
+			ShirtNumber:   recordPlayer.ShirtNumber,
+			DateOfBirth:   recordPlayer.DateOfBirth,
+			Nationality:   recordPlayer.Nationality,
+			Height:        recordPlayer.Height,
+			Weight:        recordPlayer.Weight,
+			PreferredFoot: recordPlayer.PreferredFoot,
+			Homegrown:     recordPlayer.Homegrown,
 		},
 	}
 
+	if recordContract != nil {
+		doCreateResp.Contract = &contractTransporter.Contract{
+			ID:            recordContract.ID,
+			PlayerID:      recordContract.PlayerID,
+			TeamID:        recordContract.TeamID,
+			StartsAt:      recordContract.StartsAt,
+			EndsAt:        recordContract.EndsAt,
+			Wage:          recordContract.Wage,
+			ReleaseClause: recordContract.ReleaseClause,
+			Status:        recordContract.Status,
+		}
+	}
+
 	return
 }
 
@@ -110,23 +169,41 @@ func (player *Player) GetPlayers(ctx context.Context, params param.GetPlayers) (
 // It returns doUpdateResp of transporter.DoUpdate and any errors written.
 func (player *Player) DoUpdate(ctx context.Context, params param.DoUpdate) (doUpdateResp transporter.DoUpdate, err error) {
 	recordPlayer := model.Player{
-		TeamID: params.TeamID,
-		Name:   params.Name,
+		TeamID:        params.TeamID,
+		Name:          params.Name,
+		Position:      params.Position,
+		Role:          params.Role,
+		ShirtNumber:   params.ShirtNumber,
+		DateOfBirth:   params.DateOfBirth,
+		Nationality:   params.Nationality,
+		Height:        params.Height,
+		Weight:        params.Weight,
+		PreferredFoot: params.PreferredFoot,
+		Homegrown:     params.Homegrown,
 	}
 
 	player.ormChaining = player.ormPgSQL.
 		WithContext(ctx).
 		Where("id = ?", params.ID)
 
-	if err != player.ormChaining.Updates(&recordPlayer).Error {
+	if err = player.ormChaining.Updates(&recordPlayer).Error; err != nil {
 		return
 	}
 
 	doUpdateResp = transporter.DoUpdate{
 		Player: transporter.Player{
-			ID:     params.ID,
-			TeamID: recordPlayer.TeamID,
-			Name:   recordPlayer.Name,
+			ID:            params.ID,
+			TeamID:        recordPlayer.TeamID,
+			Name:          recordPlayer.Name,
+			Position:      recordPlayer.Position,
+			Role:          recordPlayer.Role,
+			ShirtNumber:   recordPlayer.ShirtNumber,
+			DateOfBirth:   recordPlayer.DateOfBirth,
+			Nationality:   recordPlayer.Nationality,
+			Height:        recordPlayer.Height,
+			Weight:        recordPlayer.Weight,
+			PreferredFoot: recordPlayer.PreferredFoot,
+			Homegrown:     recordPlayer.Homegrown,
 		},
 	}
 
@@ -162,3 +239,19 @@ func (player *Player) GetPlayer(ctx context.Context, params param.GetPlayer) (ge
 
 	return
 }
+
+// GetShirtNumber is used for getting the player of a team wearing the given shirt number.
+// Deleted players are ignored, as their number can be given to someone else.
+// It returns getShirtNumberResp of transporter.GetShirtNumber and any errors written.
+func (player *Player) GetShirtNumber(ctx context.Context, params param.GetShirtNumber) (getShirtNumberResp transporter.GetShirtNumber, err error) {
+	player.ormChaining = player.ormPgSQL.
+		WithContext(ctx).
+		Where("team_id = ? AND shirt_number = ? AND deleted_at IS NULL", params.TeamID, params.ShirtNumber).
+		Limit(1)
+
+	if err = player.ormChaining.Find(&getShirtNumberResp).Error; err != nil {
+		return
+	}
+
+	return
+}
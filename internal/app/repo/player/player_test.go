@@ -5,9 +5,11 @@ import (
 	"database/sql"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	contractParam "github.com/harunnryd/skeltun/internal/app/handler/contract/param"
 	"github.com/harunnryd/skeltun/internal/app/handler/player/param"
 	"github.com/harunnryd/skeltun/internal/app/handler/player/transporter"
 	"github.com/harunnryd/skeltun/internal/app/model"
@@ -36,11 +38,12 @@ type helper struct {
 }
 
 type response struct {
-	doCreateResp   transporter.DoCreate
-	getPlayersResp []transporter.GetPlayers
-	getPlayerResp  transporter.GetPlayer
-	doUpdateResp   transporter.DoUpdate
-	doDeleteResp   transporter.DoDelete
+	doCreateResp       transporter.DoCreate
+	getPlayersResp     []transporter.GetPlayers
+	getPlayerResp      transporter.GetPlayer
+	doUpdateResp       transporter.DoUpdate
+	doDeleteResp       transporter.DoDelete
+	getShirtNumberResp transporter.GetShirtNumber
 }
 
 // SetupSuite ...
@@ -70,21 +73,43 @@ func (suite *Suite) SetupSuite() {
 
 // TestDoCreate ...
 func (suite *Suite) TestDoCreate() {
+	shirtNumber, dateOfBirth := 9, time.Date(1995, 3, 14, 0, 0, 0, 0, time.UTC)
 	params := param.DoCreate{
 		Player: param.Player{
-			TeamID: uuid.NewV4(),
-			Name:   "John Doe",
+			TeamID:        uuid.NewV4(),
+			Name:          "John Doe",
+			Position:      model.PlayerPositionForward,
+			Role:          "ST",
+			ShirtNumber:   &shirtNumber,
+			DateOfBirth:   &dateOfBirth,
+			Nationality:   "ENG",
+			Height:        183,
+			Weight:        78,
+			PreferredFoot: model.PreferredFootRight,
+		},
+		Contract: &contractParam.Terms{
+			EndsAt: time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC),
+			Wage:   1200000,
 		},
+		Date: time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC),
 	}
 
-	suite.mock.MatchExpectationsInOrder(false)
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "players" ("created_at","updated_at","deleted_at","team_id","name","position","role","shirt_number","date_of_birth","nationality","height","weight","preferred_foot","homegrown") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14) RETURNING "id"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.TeamID, params.Name, params.Position, params.Role, shirtNumber, dateOfBirth, params.Nationality, params.Height, params.Weight, params.PreferredFoot, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
 
 	suite.mock.
-		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "players" ("created_at","updated_at","deleted_at","team_id","name") VALUES ($1,$2,$3,$4,$5) RETURNING "id"`)).
-		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.TeamID, params.Name).
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "contracts" ("created_at","updated_at","deleted_at","player_id","team_id","starts_at","ends_at","wage","release_clause","status") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10) RETURNING "id"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.TeamID, params.Date, params.Contract.EndsAt, params.Contract.Wage, nil, model.ContractStatusActive).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).
 			AddRow(uuid.NewV4()))
 
+	suite.mock.ExpectCommit()
+
 	suite.response.doCreateResp, suite.helper.err = suite.player.DoCreate(context.Background(), params)
 
 	require.NoError(suite.T(), suite.helper.err)
@@ -159,6 +184,25 @@ func (suite *Suite) TestDoDelete() {
 	require.NoError(suite.T(), suite.helper.err)
 }
 
+// TestGetShirtNumber ...
+func (suite *Suite) TestGetShirtNumber() {
+	params := param.GetShirtNumber{
+		TeamID:      uuid.NewV4(),
+		ShirtNumber: 10,
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE team_id = $1 AND shirt_number = $2 AND deleted_at IS NULL LIMIT 1`)).
+		WithArgs(params.TeamID, params.ShirtNumber).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "team_id", "name", "shirt_number"}).
+			AddRow(uuid.NewV4(), params.TeamID, "John Doe", 10))
+
+	suite.response.getShirtNumberResp, suite.helper.err = suite.player.GetShirtNumber(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), 10, *suite.response.getShirtNumberResp.ShirtNumber)
+}
+
 // AfterTest ...
 func (suite *Suite) AfterTest(_, _ string) {
 	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
@@ -5,9 +5,28 @@
 package repo
 
 import (
+	"github.com/harunnryd/skeltun/internal/app/repo/bracket"
+	"github.com/harunnryd/skeltun/internal/app/repo/competition"
+	"github.com/harunnryd/skeltun/internal/app/repo/contract"
+	"github.com/harunnryd/skeltun/internal/app/repo/draw"
+	"github.com/harunnryd/skeltun/internal/app/repo/group"
 	"github.com/harunnryd/skeltun/internal/app/repo/hcheck"
+	"github.com/harunnryd/skeltun/internal/app/repo/injury"
+	"github.com/harunnryd/skeltun/internal/app/repo/leaderboard"
+	"github.com/harunnryd/skeltun/internal/app/repo/lineup"
+	"github.com/harunnryd/skeltun/internal/app/repo/loan"
+	"github.com/harunnryd/skeltun/internal/app/repo/match"
+	"github.com/harunnryd/skeltun/internal/app/repo/matchevent"
+	"github.com/harunnryd/skeltun/internal/app/repo/official"
 	"github.com/harunnryd/skeltun/internal/app/repo/player"
+	"github.com/harunnryd/skeltun/internal/app/repo/season"
+	"github.com/harunnryd/skeltun/internal/app/repo/shootoutkick"
+	"github.com/harunnryd/skeltun/internal/app/repo/squad"
+	"github.com/harunnryd/skeltun/internal/app/repo/stat"
+	"github.com/harunnryd/skeltun/internal/app/repo/suspension"
 	"github.com/harunnryd/skeltun/internal/app/repo/team"
+	"github.com/harunnryd/skeltun/internal/app/repo/transfer"
+	"github.com/harunnryd/skeltun/internal/app/repo/venue"
 )
 
 // IRepo ...
@@ -26,13 +45,146 @@ type IRepo interface {
 
 	// SetTeam is used for initializing team.Team repositories.
 	SetTeam(iTeam team.ITeam)
+
+	// GetMatch it returns instance of match.Match that implements match.IMatch methods.
+	GetMatch() match.IMatch
+
+	// SetMatch is used for initializing match.Match repositories.
+	SetMatch(iMatch match.IMatch)
+
+	// GetCompetition it returns instance of competition.Competition that implements competition.ICompetition methods.
+	GetCompetition() competition.ICompetition
+
+	// SetCompetition is used for initializing competition.Competition repositories.
+	SetCompetition(iCompetition competition.ICompetition)
+
+	// GetSeason it returns instance of season.Season that implements season.ISeason methods.
+	GetSeason() season.ISeason
+
+	// SetSeason is used for initializing season.Season repositories.
+	SetSeason(iSeason season.ISeason)
+
+	// GetMatchEvent it returns instance of matchevent.MatchEvent that implements matchevent.IMatchEvent methods.
+	GetMatchEvent() matchevent.IMatchEvent
+
+	// SetMatchEvent is used for initializing matchevent.MatchEvent repositories.
+	SetMatchEvent(iMatchEvent matchevent.IMatchEvent)
+
+	// GetShootoutKick it returns instance of shootoutkick.ShootoutKick that implements shootoutkick.IShootoutKick methods.
+	GetShootoutKick() shootoutkick.IShootoutKick
+
+	// SetShootoutKick is used for initializing shootoutkick.ShootoutKick repositories.
+	SetShootoutKick(iShootoutKick shootoutkick.IShootoutKick)
+
+	// GetBracket it returns instance of bracket.Bracket that implements bracket.IBracket methods.
+	GetBracket() bracket.IBracket
+
+	// SetBracket is used for initializing bracket.Bracket repositories.
+	SetBracket(iBracket bracket.IBracket)
+
+	// GetDraw it returns instance of draw.Draw that implements draw.IDraw methods.
+	GetDraw() draw.IDraw
+
+	// SetDraw is used for initializing draw.Draw repositories.
+	SetDraw(iDraw draw.IDraw)
+
+	// GetGroup it returns instance of group.Group that implements group.IGroup methods.
+	GetGroup() group.IGroup
+
+	// SetGroup is used for initializing group.Group repositories.
+	SetGroup(iGroup group.IGroup)
+
+	// GetTransfer it returns instance of transfer.Transfer that implements transfer.ITransfer methods.
+	GetTransfer() transfer.ITransfer
+
+	// SetTransfer is used for initializing transfer.Transfer repositories.
+	SetTransfer(iTransfer transfer.ITransfer)
+
+	// GetLoan it returns instance of loan.Loan that implements loan.ILoan methods.
+	GetLoan() loan.ILoan
+
+	// SetLoan is used for initializing loan.Loan repositories.
+	SetLoan(iLoan loan.ILoan)
+
+	// GetContract it returns instance of contract.Contract that implements contract.IContract methods.
+	GetContract() contract.IContract
+
+	// SetContract is used for initializing contract.Contract repositories.
+	SetContract(iContract contract.IContract)
+
+	// GetSquad it returns instance of squad.Squad that implements squad.ISquad methods.
+	GetSquad() squad.ISquad
+
+	// SetSquad is used for initializing squad.Squad repositories.
+	SetSquad(iSquad squad.ISquad)
+
+	// GetLineup it returns instance of lineup.Lineup that implements lineup.ILineup methods.
+	GetLineup() lineup.ILineup
+
+	// SetLineup is used for initializing lineup.Lineup repositories.
+	SetLineup(iLineup lineup.ILineup)
+
+	// GetStat it returns instance of stat.Stat that implements stat.IStat methods.
+	GetStat() stat.IStat
+
+	// SetStat is used for initializing stat.Stat repositories.
+	SetStat(iStat stat.IStat)
+
+	// GetLeaderboard it returns instance of leaderboard.Leaderboard that implements leaderboard.ILeaderboard methods.
+	GetLeaderboard() leaderboard.ILeaderboard
+
+	// SetLeaderboard is used for initializing leaderboard.Leaderboard repositories.
+	SetLeaderboard(iLeaderboard leaderboard.ILeaderboard)
+
+	// GetInjury it returns instance of injury.Injury that implements injury.IInjury methods.
+	GetInjury() injury.IInjury
+
+	// SetInjury is used for initializing injury.Injury repositories.
+	SetInjury(iInjury injury.IInjury)
+
+	// GetSuspension it returns instance of suspension.Suspension that implements suspension.ISuspension methods.
+	GetSuspension() suspension.ISuspension
+
+	// SetSuspension is used for initializing suspension.Suspension repositories.
+	SetSuspension(iSuspension suspension.ISuspension)
+
+	// GetVenue it returns instance of venue.Venue that implements venue.IVenue methods.
+	GetVenue() venue.IVenue
+
+	// SetVenue is used for initializing venue.Venue repositories.
+	SetVenue(iVenue venue.IVenue)
+
+	// GetOfficial it returns instance of official.Official that implements official.IOfficial methods.
+	GetOfficial() official.IOfficial
+
+	// SetOfficial is used for initializing official.Official repositories.
+	SetOfficial(iOfficial official.IOfficial)
 }
 
 // Repo ...
 type Repo struct {
-	hcheck hcheck.IHcheck
-	player player.IPlayer
-	team   team.ITeam
+	hcheck       hcheck.IHcheck
+	player       player.IPlayer
+	team         team.ITeam
+	match        match.IMatch
+	competition  competition.ICompetition
+	season       season.ISeason
+	matchevent   matchevent.IMatchEvent
+	shootoutkick shootoutkick.IShootoutKick
+	bracket      bracket.IBracket
+	draw         draw.IDraw
+	group        group.IGroup
+	transfer     transfer.ITransfer
+	loan         loan.ILoan
+	contract     contract.IContract
+	squad        squad.ISquad
+	lineup       lineup.ILineup
+	stat         stat.IStat
+	leaderboard  leaderboard.ILeaderboard
+	injury       injury.IInjury
+	suspension   suspension.ISuspension
+	venue        venue.IVenue
+	official     official.IOfficial
 }
 
 // New ...
@@ -68,3 +220,193 @@ func (repo *Repo) GetTeam() team.ITeam {
 func (repo *Repo) SetTeam(iTeam team.ITeam) {
 	repo.team = iTeam
 }
+
+// GetMatch it returns instance of match.Match that implements match.IMatch methods.
+func (repo *Repo) GetMatch() match.IMatch {
+	return repo.match
+}
+
+// SetMatch is used for initializing match.Match repositories.
+func (repo *Repo) SetMatch(iMatch match.IMatch) {
+	repo.match = iMatch
+}
+
+// GetCompetition it returns instance of competition.Competition that implements competition.ICompetition methods.
+func (repo *Repo) GetCompetition() competition.ICompetition {
+	return repo.competition
+}
+
+// SetCompetition is used for initializing competition.Competition repositories.
+func (repo *Repo) SetCompetition(iCompetition competition.ICompetition) {
+	repo.competition = iCompetition
+}
+
+// GetSeason it returns instance of season.Season that implements season.ISeason methods.
+func (repo *Repo) GetSeason() season.ISeason {
+	return repo.season
+}
+
+// SetSeason is used for initializing season.Season repositories.
+func (repo *Repo) SetSeason(iSeason season.ISeason) {
+	repo.season = iSeason
+}
+
+// GetMatchEvent it returns instance of matchevent.MatchEvent that implements matchevent.IMatchEvent methods.
+func (repo *Repo) GetMatchEvent() matchevent.IMatchEvent {
+	return repo.matchevent
+}
+
+// SetMatchEvent is used for initializing matchevent.MatchEvent repositories.
+func (repo *Repo) SetMatchEvent(iMatchEvent matchevent.IMatchEvent) {
+	repo.matchevent = iMatchEvent
+}
+
+// GetShootoutKick it returns instance of shootoutkick.ShootoutKick that implements shootoutkick.IShootoutKick methods.
+func (repo *Repo) GetShootoutKick() shootoutkick.IShootoutKick {
+	return repo.shootoutkick
+}
+
+// SetShootoutKick is used for initializing shootoutkick.ShootoutKick repositories.
+func (repo *Repo) SetShootoutKick(iShootoutKick shootoutkick.IShootoutKick) {
+	repo.shootoutkick = iShootoutKick
+}
+
+// GetBracket it returns instance of bracket.Bracket that implements bracket.IBracket methods.
+func (repo *Repo) GetBracket() bracket.IBracket {
+	return repo.bracket
+}
+
+// SetBracket is used for initializing bracket.Bracket repositories.
+func (repo *Repo) SetBracket(iBracket bracket.IBracket) {
+	repo.bracket = iBracket
+}
+
+// GetDraw it returns instance of draw.Draw that implements draw.IDraw methods.
+func (repo *Repo) GetDraw() draw.IDraw {
+	return repo.draw
+}
+
+// SetDraw is used for initializing draw.Draw repositories.
+func (repo *Repo) SetDraw(iDraw draw.IDraw) {
+	repo.draw = iDraw
+}
+
+// GetGroup it returns instance of group.Group that implements group.IGroup methods.
+func (repo *Repo) GetGroup() group.IGroup {
+	return repo.group
+}
+
+// SetGroup is used for initializing group.Group repositories.
+func (repo *Repo) SetGroup(iGroup group.IGroup) {
+	repo.group = iGroup
+}
+
+// GetTransfer it returns instance of transfer.Transfer that implements transfer.ITransfer methods.
+func (repo *Repo) GetTransfer() transfer.ITransfer {
+	return repo.transfer
+}
+
+// SetTransfer is used for initializing transfer.Transfer repositories.
+func (repo *Repo) SetTransfer(iTransfer transfer.ITransfer) {
+	repo.transfer = iTransfer
+}
+
+// GetLoan it returns instance of loan.Loan that implements loan.ILoan methods.
+func (repo *Repo) GetLoan() loan.ILoan {
+	return repo.loan
+}
+
+// SetLoan is used for initializing loan.Loan repositories.
+func (repo *Repo) SetLoan(iLoan loan.ILoan) {
+	repo.loan = iLoan
+}
+
+// GetContract it returns instance of contract.Contract that implements contract.IContract methods.
+func (repo *Repo) GetContract() contract.IContract {
+	return repo.contract
+}
+
+// SetContract is used for initializing contract.Contract repositories.
+func (repo *Repo) SetContract(iContract contract.IContract) {
+	repo.contract = iContract
+}
+
+// GetSquad it returns instance of squad.Squad that implements squad.ISquad methods.
+func (repo *Repo) GetSquad() squad.ISquad {
+	return repo.squad
+}
+
+// SetSquad is used for initializing squad.Squad repositories.
+func (repo *Repo) SetSquad(iSquad squad.ISquad) {
+	repo.squad = iSquad
+}
+
+// GetLineup it returns instance of lineup.Lineup that implements lineup.ILineup methods.
+func (repo *Repo) GetLineup() lineup.ILineup {
+	return repo.lineup
+}
+
+// SetLineup is used for initializing lineup.Lineup repositories.
+func (repo *Repo) SetLineup(iLineup lineup.ILineup) {
+	repo.lineup = iLineup
+}
+
+// GetStat it returns instance of stat.Stat that implements stat.IStat methods.
+func (repo *Repo) GetStat() stat.IStat {
+	return repo.stat
+}
+
+// SetStat is used for initializing stat.Stat repositories.
+func (repo *Repo) SetStat(iStat stat.IStat) {
+	repo.stat = iStat
+}
+
+// GetLeaderboard it returns instance of leaderboard.Leaderboard that implements leaderboard.ILeaderboard methods.
+func (repo *Repo) GetLeaderboard() leaderboard.ILeaderboard {
+	return repo.leaderboard
+}
+
+// SetLeaderboard is used for initializing leaderboard.Leaderboard repositories.
+func (repo *Repo) SetLeaderboard(iLeaderboard leaderboard.ILeaderboard) {
+	repo.leaderboard = iLeaderboard
+}
+
+// GetInjury it returns instance of injury.Injury that implements injury.IInjury methods.
+func (repo *Repo) GetInjury() injury.IInjury {
+	return repo.injury
+}
+
+// SetInjury is used for initializing injury.Injury repositories.
+func (repo *Repo) SetInjury(iInjury injury.IInjury) {
+	repo.injury = iInjury
+}
+
+// GetSuspension it returns instance of suspension.Suspension that implements suspension.ISuspension methods.
+func (repo *Repo) GetSuspension() suspension.ISuspension {
+	return repo.suspension
+}
+
+// SetSuspension is used for initializing suspension.Suspension repositories.
+func (repo *Repo) SetSuspension(iSuspension suspension.ISuspension) {
+	repo.suspension = iSuspension
+}
+
+// GetVenue it returns instance of venue.Venue that implements venue.IVenue methods.
+func (repo *Repo) GetVenue() venue.IVenue {
+	return repo.venue
+}
+
+// SetVenue is used for initializing venue.Venue repositories.
+func (repo *Repo) SetVenue(iVenue venue.IVenue) {
+	repo.venue = iVenue
+}
+
+// GetOfficial it returns instance of official.Official that implements official.IOfficial methods.
+func (repo *Repo) GetOfficial() official.IOfficial {
+	return repo.official
+}
+
+// SetOfficial is used for initializing official.Official repositories.
+func (repo *Repo) SetOfficial(iOfficial official.IOfficial) {
+	repo.official = iOfficial
+}
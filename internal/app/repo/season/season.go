@@ -0,0 +1,242 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package season
+
+import (
+	"context"
+
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/season/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/season/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ISeason is an interface that stores the methods that Season struct will use.
+type ISeason interface {
+	// DoCreate is used for record new season.
+	// It returns doCreateResp of transporter.DoCreate and any errors written.
+	DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error)
+
+	// GetSeasons is used for getting all seasons of a competition.
+	// It returns getSeasonsResp of []transporter.GetSeasons and any errors written.
+	GetSeasons(ctx context.Context, params param.GetSeasons) (getSeasonsResp []transporter.GetSeasons, err error)
+
+	// DoUpdate is used for update the record season.
+	// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+	DoUpdate(ctx context.Context, params param.DoUpdate) (doUpdateResp transporter.DoUpdate, err error)
+
+	// DoDelete is used for delete the record season.
+	// It returns doDeleteResp of transporter.DoDelete and any errors written.
+	DoDelete(ctx context.Context, params param.DoDelete) (doDeleteResp transporter.DoDelete, err error)
+
+	// GetSeason is used for getting an season.
+	// It returns getSeasonResp of transporter.GetSeason and any errors written.
+	GetSeason(ctx context.Context, params param.GetSeason) (getSeasonResp transporter.GetSeason, err error)
+
+	// DoRegisterTeam is used for registering a team into the season.
+	// It returns doRegisterTeamResp of transporter.DoRegisterTeam and any errors written.
+	DoRegisterTeam(ctx context.Context, params param.DoRegisterTeam) (doRegisterTeamResp transporter.DoRegisterTeam, err error)
+
+	// GetSeasonTeams is used for getting all teams registered in the season.
+	// It returns getSeasonTeamsResp of []transporter.GetSeasonTeams and any errors written.
+	GetSeasonTeams(ctx context.Context, params param.GetSeasonTeams) (getSeasonTeamsResp []transporter.GetSeasonTeams, err error)
+
+	// DoUnregisterTeam is used for removing a team from the season.
+	// It returns doUnregisterTeamResp of transporter.DoUnregisterTeam and any errors written.
+	DoUnregisterTeam(ctx context.Context, params param.DoUnregisterTeam) (doUnregisterTeamResp transporter.DoUnregisterTeam, err error)
+}
+
+// Season is an struct that implements ISeason methods.
+type Season struct {
+	config   config.IConfig
+	ormMySQL *gorm.DB
+	ormPgSQL *gorm.DB
+	statement
+}
+
+type statement struct {
+	ormTX       *gorm.DB
+	ormChaining *gorm.DB
+}
+
+// New it returns instance of Season that implements ISeason methods.
+func New(opts ...Option) ISeason {
+	s := new(Season)
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// DoCreate is used for record new season.
+// It returns doCreateResp of transporter.DoCreate and any errors written.
+func (season *Season) DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error) {
+	recordSeason := model.Season{
+		CompetitionID: params.CompetitionID,
+		Name:          params.Name,
+		StartDate:     params.StartDate,
+		EndDate:       params.EndDate,
+	}
+
+	season.ormChaining = season.ormPgSQL.WithContext(ctx)
+
+	if err = season.ormChaining.Create(&recordSeason).Error; err != nil {
+		return
+	}
+
+	doCreateResp = transporter.DoCreate{
+		Season: transporter.Season{
+			ID:            recordSeason.ID,
+			CompetitionID: recordSeason.CompetitionID,
+			Name:          recordSeason.Name,
+			StartDate:     recordSeason.StartDate,
+			EndDate:       recordSeason.EndDate,
+		},
+	}
+
+	return
+}
+
+// GetSeasons is used for getting all seasons of a competition.
+// It returns getSeasonsResp of []transporter.GetSeasons and any errors written.
+func (season *Season) GetSeasons(ctx context.Context, params param.GetSeasons) (getSeasonsResp []transporter.GetSeasons, err error) {
+	season.ormChaining = season.ormPgSQL.
+		WithContext(ctx).
+		Where("competition_id = ?", params.CompetitionID).
+		Order("start_date DESC").
+		Limit(params.GetLimit()).
+		Offset(params.GetOffset())
+
+	if err = season.ormChaining.Find(&getSeasonsResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// DoUpdate is used for update the record season.
+// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+func (season *Season) DoUpdate(ctx context.Context, params param.DoUpdate) (doUpdateResp transporter.DoUpdate, err error) {
+	recordSeason := model.Season{
+		Name:      params.Name,
+		StartDate: params.StartDate,
+		EndDate:   params.EndDate,
+	}
+
+	season.ormChaining = season.ormPgSQL.
+		WithContext(ctx).
+		Where("id = ?", params.ID)
+
+	if err = season.ormChaining.Updates(&recordSeason).Error; err != nil {
+		return
+	}
+
+	doUpdateResp = transporter.DoUpdate{
+		Season: transporter.Season{
+			ID:        params.ID,
+			Name:      recordSeason.Name,
+			StartDate: recordSeason.StartDate,
+			EndDate:   recordSeason.EndDate,
+		},
+	}
+
+	return
+}
+
+// DoDelete is used for delete the record season.
+// It returns doDeleteResp of transporter.DoDelete and any errors written.
+func (season *Season) DoDelete(ctx context.Context, params param.DoDelete) (doDeleteResp transporter.DoDelete, err error) {
+	season.ormChaining = season.ormPgSQL.
+		WithContext(ctx).
+		Where("id = ?", params.ID)
+
+	if err = season.ormChaining.Delete(&doDeleteResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// GetSeason is used for getting an season.
+// It returns getSeasonResp of transporter.GetSeason and any errors written.
+func (season *Season) GetSeason(ctx context.Context, params param.GetSeason) (getSeasonResp transporter.GetSeason, err error) {
+	season.ormChaining = season.ormPgSQL.
+		WithContext(ctx).
+		Where("id = ?", params.ID).
+		Limit(1)
+
+	if err = season.ormChaining.Find(&getSeasonResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// DoRegisterTeam is used for registering a team into the season.
+// Registering the same team twice is a no-op.
+// It returns doRegisterTeamResp of transporter.DoRegisterTeam and any errors written.
+func (season *Season) DoRegisterTeam(ctx context.Context, params param.DoRegisterTeam) (doRegisterTeamResp transporter.DoRegisterTeam, err error) {
+	recordSeasonTeam := model.SeasonTeam{
+		SeasonID: params.SeasonID,
+		TeamID:   params.TeamID,
+	}
+
+	season.ormChaining = season.ormPgSQL.
+		WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true})
+
+	if err = season.ormChaining.Create(&recordSeasonTeam).Error; err != nil {
+		return
+	}
+
+	doRegisterTeamResp = transporter.DoRegisterTeam{
+		SeasonTeam: transporter.SeasonTeam{
+			SeasonID: recordSeasonTeam.SeasonID,
+			TeamID:   recordSeasonTeam.TeamID,
+		},
+	}
+
+	return
+}
+
+// GetSeasonTeams is used for getting all teams registered in the season.
+// It returns getSeasonTeamsResp of []transporter.GetSeasonTeams and any errors written.
+func (season *Season) GetSeasonTeams(ctx context.Context, params param.GetSeasonTeams) (getSeasonTeamsResp []transporter.GetSeasonTeams, err error) {
+	season.ormChaining = season.ormPgSQL.
+		WithContext(ctx).
+		Joins("JOIN season_teams ON season_teams.team_id = teams.id").
+		Where("season_teams.season_id = ?", params.SeasonID).
+		Order("teams.name")
+
+	if err = season.ormChaining.Find(&getSeasonTeamsResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// DoUnregisterTeam is used for removing a team from the season.
+// It returns doUnregisterTeamResp of transporter.DoUnregisterTeam and any errors written.
+func (season *Season) DoUnregisterTeam(ctx context.Context, params param.DoUnregisterTeam) (doUnregisterTeamResp transporter.DoUnregisterTeam, err error) {
+	season.ormChaining = season.ormPgSQL.
+		WithContext(ctx).
+		Where("season_id = ? AND team_id = ?", params.SeasonID, params.TeamID)
+
+	if err = season.ormChaining.Delete(&model.SeasonTeam{}).Error; err != nil {
+		return
+	}
+
+	doUnregisterTeamResp = transporter.DoUnregisterTeam{
+		SeasonTeam: transporter.SeasonTeam{
+			SeasonID: params.SeasonID,
+			TeamID:   params.TeamID,
+		},
+	}
+
+	return
+}
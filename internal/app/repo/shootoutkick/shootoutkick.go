@@ -0,0 +1,107 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shootoutkick
+
+import (
+	"context"
+
+	"github.com/harunnryd/skeltun/config"
+	matchTransporter "github.com/harunnryd/skeltun/internal/app/handler/match/transporter"
+	"github.com/harunnryd/skeltun/internal/app/handler/shootoutkick/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/shootoutkick/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo/match"
+	"gorm.io/gorm"
+)
+
+// IShootoutKick is an interface that stores the methods that ShootoutKick struct will use.
+type IShootoutKick interface {
+	// DoCreate is used for record new shootout kick and recomputing the shootout score in a single transaction.
+	// It returns doCreateResp of transporter.DoCreate and any errors written.
+	DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error)
+
+	// GetShootoutKicks is used for getting the kicks of a penalty shootout in the order they were taken.
+	// It returns getShootoutKicksResp of []transporter.GetShootoutKicks and any errors written.
+	GetShootoutKicks(ctx context.Context, params param.GetShootoutKicks) (getShootoutKicksResp []transporter.GetShootoutKicks, err error)
+}
+
+// ShootoutKick is an struct that implements IShootoutKick methods.
+type ShootoutKick struct {
+	config   config.IConfig
+	ormMySQL *gorm.DB
+	ormPgSQL *gorm.DB
+	statement
+}
+
+type statement struct {
+	ormTX       *gorm.DB
+	ormChaining *gorm.DB
+}
+
+// New it returns instance of ShootoutKick that implements IShootoutKick methods.
+func New(opts ...Option) IShootoutKick {
+	s := new(ShootoutKick)
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// DoCreate is used for record new shootout kick and recomputing the shootout score in a single transaction.
+// It returns doCreateResp of transporter.DoCreate and any errors written.
+func (shootoutKick *ShootoutKick) DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error) {
+	recordShootoutKick := model.ShootoutKick{
+		MatchID:  params.MatchID,
+		TeamID:   params.TeamID,
+		PlayerID: params.PlayerID,
+		Sequence: params.Sequence,
+		Scored:   *params.Scored,
+	}
+
+	shootoutKick.ormTX = shootoutKick.ormPgSQL.WithContext(ctx).Begin()
+
+	if err = shootoutKick.ormTX.Create(&recordShootoutKick).Error; err != nil {
+		shootoutKick.ormTX.Rollback()
+		return
+	}
+
+	if err = match.SyncScore(shootoutKick.ormTX, params.MatchID).Scan(&matchTransporter.DoSyncScore{}).Error; err != nil {
+		shootoutKick.ormTX.Rollback()
+		return
+	}
+
+	if err = shootoutKick.ormTX.Commit().Error; err != nil {
+		return
+	}
+
+	doCreateResp = transporter.DoCreate{
+		ShootoutKick: transporter.ShootoutKick{
+			ID:       recordShootoutKick.ID,
+			MatchID:  recordShootoutKick.MatchID,
+			TeamID:   recordShootoutKick.TeamID,
+			PlayerID: recordShootoutKick.PlayerID,
+			Sequence: recordShootoutKick.Sequence,
+			Scored:   recordShootoutKick.Scored,
+		},
+	}
+
+	return
+}
+
+// GetShootoutKicks is used for getting the kicks of a penalty shootout in the order they were taken.
+// It returns getShootoutKicksResp of []transporter.GetShootoutKicks and any errors written.
+func (shootoutKick *ShootoutKick) GetShootoutKicks(ctx context.Context, params param.GetShootoutKicks) (getShootoutKicksResp []transporter.GetShootoutKicks, err error) {
+	shootoutKick.ormChaining = shootoutKick.ormPgSQL.
+		WithContext(ctx).
+		Where("match_id = ?", params.MatchID).
+		Order("sequence")
+
+	if err = shootoutKick.ormChaining.Find(&getShootoutKicksResp).Error; err != nil {
+		return
+	}
+
+	return
+}
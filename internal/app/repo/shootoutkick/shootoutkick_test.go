@@ -0,0 +1,159 @@
+package shootoutkick
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/handler/shootoutkick/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/shootoutkick/transporter"
+	"github.com/harunnryd/skeltun/internal/app/repo/match/matchtest"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	pgsqlConn *gorm.DB
+	mock      sqlmock.Sqlmock
+
+	shootoutKick IShootoutKick
+	helper
+	response
+}
+
+type helper struct {
+	db  *sql.DB
+	err error
+}
+
+type response struct {
+	doCreateResp         transporter.DoCreate
+	getShootoutKicksResp []transporter.GetShootoutKicks
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.shootoutKick = New(
+		WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+}
+
+// TestDoCreate ...
+func (suite *Suite) TestDoCreate() {
+	scored := true
+	params := param.DoCreate{
+		ShootoutKick: param.ShootoutKick{
+			MatchID:  uuid.NewV4(),
+			TeamID:   uuid.NewV4(),
+			PlayerID: uuid.NewV4(),
+			Sequence: 3,
+			Scored:   &scored,
+		},
+	}
+
+	suite.mock.ExpectBegin()
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "shootout_kicks" ("created_at","updated_at","deleted_at","match_id","team_id","player_id","sequence","scored") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING "id"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.MatchID, params.TeamID, params.PlayerID, 3, true).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+	suite.mock.
+		ExpectQuery(`UPDATE matches SET (.+) RETURNING id, home_score, away_score, home_extra_time_score, away_extra_time_score, home_shootout_score, away_shootout_score`).
+		WithArgs(matchtest.SyncScoreArgs(params.MatchID)...).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "home_shootout_score", "away_shootout_score"}).
+			AddRow(params.MatchID, 2, 1))
+	suite.mock.ExpectCommit()
+
+	suite.response.doCreateResp, suite.helper.err = suite.shootoutKick.DoCreate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.True(suite.T(), suite.response.doCreateResp.Scored)
+}
+
+// TestDoCreateRollback ...
+func (suite *Suite) TestDoCreateRollback() {
+	scored := false
+	params := param.DoCreate{
+		ShootoutKick: param.ShootoutKick{
+			MatchID:  uuid.NewV4(),
+			TeamID:   uuid.NewV4(),
+			PlayerID: uuid.NewV4(),
+			Sequence: 4,
+			Scored:   &scored,
+		},
+	}
+
+	suite.mock.ExpectBegin()
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "shootout_kicks"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+	suite.mock.
+		ExpectQuery(`UPDATE matches SET (.+)`).
+		WithArgs(matchtest.SyncScoreArgs(params.MatchID)...).
+		WillReturnError(errors.New("connection reset"))
+	suite.mock.ExpectRollback()
+
+	suite.response.doCreateResp, suite.helper.err = suite.shootoutKick.DoCreate(context.Background(), params)
+
+	require.Error(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), uuid.Nil, suite.response.doCreateResp.ID)
+}
+
+// TestGetShootoutKicks ...
+func (suite *Suite) TestGetShootoutKicks() {
+	params := param.GetShootoutKicks{
+		MatchID: uuid.NewV4(),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "shootout_kicks" WHERE match_id = $1 ORDER BY sequence`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "match_id", "sequence", "scored"}).
+			AddRow(uuid.NewV4(), params.MatchID, 1, true).
+			AddRow(uuid.NewV4(), params.MatchID, 2, false))
+
+	suite.response.getShootoutKicksResp, suite.helper.err = suite.shootoutKick.GetShootoutKicks(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Len(suite.T(), suite.response.getShootoutKicksResp, 2)
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package squad
+
+import (
+	"context"
+
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/squad/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/squad/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ISquad is an interface that stores the methods that Squad struct will use.
+type ISquad interface {
+	// DoUpdateRules is used for record the squad rules of a season, replacing the previous ones.
+	// It returns doUpdateRulesResp of transporter.DoUpdateRules and any errors written.
+	DoUpdateRules(ctx context.Context, params param.DoUpdateRules) (doUpdateRulesResp transporter.DoUpdateRules, err error)
+
+	// GetRules is used for getting the squad rules of a season.
+	// It returns getRulesResp of transporter.GetRules and any errors written.
+	GetRules(ctx context.Context, params param.GetRules) (getRulesResp transporter.GetRules, err error)
+
+	// GetTeamRules is used for getting the squad rules of every season the team is registered in that has not ended yet.
+	// It returns getTeamRulesResp of []transporter.GetTeamRules and any errors written.
+	GetTeamRules(ctx context.Context, params param.GetTeamRules) (getTeamRulesResp []transporter.GetTeamRules, err error)
+
+	// GetSquad is used for getting every player of a team.
+	// It returns getSquadResp of []transporter.GetSquad and any errors written.
+	GetSquad(ctx context.Context, params param.GetSquad) (getSquadResp []transporter.GetSquad, err error)
+}
+
+// Squad is an struct that implements ISquad methods.
+type Squad struct {
+	config   config.IConfig
+	ormMySQL *gorm.DB
+	ormPgSQL *gorm.DB
+	statement
+}
+
+type statement struct {
+	ormTX       *gorm.DB
+	ormChaining *gorm.DB
+}
+
+// New it returns instance of Squad that implements ISquad methods.
+func New(opts ...Option) ISquad {
+	s := new(Squad)
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// DoUpdateRules is used for record the squad rules of a season, replacing the previous ones.
+// It returns doUpdateRulesResp of transporter.DoUpdateRules and any errors written.
+func (squad *Squad) DoUpdateRules(ctx context.Context, params param.DoUpdateRules) (doUpdateRulesResp transporter.DoUpdateRules, err error) {
+	recordSquadRule := model.SquadRule{
+		SeasonID:     params.SeasonID,
+		MaxPlayers:   params.MaxPlayers,
+		MinHomegrown: params.MinHomegrown,
+		MinDomestic:  params.MinDomestic,
+		Nationality:  params.Nationality,
+	}
+
+	squad.ormChaining = squad.ormPgSQL.
+		WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "season_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"max_players", "min_homegrown", "min_domestic", "nationality", "updated_at"}),
+		})
+
+	if err = squad.ormChaining.Create(&recordSquadRule).Error; err != nil {
+		return
+	}
+
+	doUpdateRulesResp.Rules = transporter.Rules{
+		SeasonID:     recordSquadRule.SeasonID,
+		MaxPlayers:   recordSquadRule.MaxPlayers,
+		MinHomegrown: recordSquadRule.MinHomegrown,
+		MinDomestic:  recordSquadRule.MinDomestic,
+		Nationality:  recordSquadRule.Nationality,
+	}
+
+	return
+}
+
+// GetRules is used for getting the squad rules of a season.
+// It returns getRulesResp of transporter.GetRules and any errors written.
+func (squad *Squad) GetRules(ctx context.Context, params param.GetRules) (getRulesResp transporter.GetRules, err error) {
+	squad.ormChaining = squad.ormPgSQL.
+		WithContext(ctx).
+		Where("season_id = ?", params.SeasonID).
+		Limit(1)
+
+	if err = squad.ormChaining.Find(&getRulesResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// GetTeamRules is used for getting the squad rules of every season the team is registered in that has not ended yet.
+// It returns getTeamRulesResp of []transporter.GetTeamRules and any errors written.
+func (squad *Squad) GetTeamRules(ctx context.Context, params param.GetTeamRules) (getTeamRulesResp []transporter.GetTeamRules, err error) {
+	squad.ormChaining = squad.ormPgSQL.
+		WithContext(ctx).
+		Model(&model.SquadRule{}).
+		Select("squad_rules.*, seasons.name AS season_name").
+		Joins("JOIN seasons ON seasons.id = squad_rules.season_id").
+		Joins("JOIN season_teams ON season_teams.season_id = squad_rules.season_id").
+		Where("season_teams.team_id = ? AND seasons.end_date >= ?", params.TeamID, params.Date).
+		Order("seasons.start_date")
+
+	if err = squad.ormChaining.Scan(&getTeamRulesResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// GetSquad is used for getting every player of a team.
+// It returns getSquadResp of []transporter.GetSquad and any errors written.
+func (squad *Squad) GetSquad(ctx context.Context, params param.GetSquad) (getSquadResp []transporter.GetSquad, err error) {
+	squad.ormChaining = squad.ormPgSQL.
+		WithContext(ctx).
+		Where("team_id = ? AND deleted_at IS NULL", params.TeamID)
+
+	if err = squad.ormChaining.Find(&getSquadResp).Error; err != nil {
+		return
+	}
+
+	return
+}
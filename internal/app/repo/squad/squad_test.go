@@ -0,0 +1,161 @@
+package squad
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/handler/squad/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/squad/transporter"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	pgsqlConn *gorm.DB
+	mock      sqlmock.Sqlmock
+
+	squad ISquad
+	helper
+	response
+}
+
+type helper struct {
+	db  *sql.DB
+	err error
+}
+
+type response struct {
+	doUpdateRulesResp transporter.DoUpdateRules
+	getRulesResp      transporter.GetRules
+	getTeamRulesResp  []transporter.GetTeamRules
+	getSquadResp      []transporter.GetSquad
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.squad = New(
+		WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+}
+
+// TestDoUpdateRules ...
+func (suite *Suite) TestDoUpdateRules() {
+	params := param.DoUpdateRules{
+		Rules: param.Rules{
+			SeasonID:     uuid.NewV4(),
+			MaxPlayers:   25,
+			MinHomegrown: 8,
+			MinDomestic:  4,
+			Nationality:  "ENG",
+		},
+	}
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`INSERT INTO "squad_rules" ("season_id","max_players","min_homegrown","min_domestic","nationality","created_at","updated_at") VALUES ($1,$2,$3,$4,$5,$6,$7) ON CONFLICT ("season_id") DO UPDATE SET "max_players"="excluded"."max_players","min_homegrown"="excluded"."min_homegrown","min_domestic"="excluded"."min_domestic","nationality"="excluded"."nationality","updated_at"="excluded"."updated_at"`)).
+		WithArgs(params.SeasonID, 25, 8, 4, "ENG", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	suite.response.doUpdateRulesResp, suite.helper.err = suite.squad.DoUpdateRules(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), 25, suite.response.doUpdateRulesResp.MaxPlayers)
+}
+
+// TestGetRules ...
+func (suite *Suite) TestGetRules() {
+	params := param.GetRules{
+		SeasonID: uuid.NewV4(),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "squad_rules" WHERE season_id = $1 LIMIT 1`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"season_id", "max_players", "min_homegrown", "min_domestic", "nationality"}).
+			AddRow(params.SeasonID, 25, 8, 0, ""))
+
+	suite.response.getRulesResp, suite.helper.err = suite.squad.GetRules(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), 8, suite.response.getRulesResp.MinHomegrown)
+}
+
+// TestGetTeamRules ...
+func (suite *Suite) TestGetTeamRules() {
+	params := param.GetTeamRules{
+		TeamID: uuid.NewV4(),
+		Date:   time.Date(2021, time.August, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT squad_rules.*, seasons.name AS season_name FROM "squad_rules" JOIN seasons ON seasons.id = squad_rules.season_id JOIN season_teams ON season_teams.season_id = squad_rules.season_id WHERE season_teams.team_id = $1 AND seasons.end_date >= $2 ORDER BY seasons.start_date`)).
+		WithArgs(params.TeamID, params.Date).
+		WillReturnRows(sqlmock.NewRows([]string{"season_id", "max_players", "min_homegrown", "min_domestic", "nationality", "season_name"}).
+			AddRow(uuid.NewV4(), 25, 8, 0, "", "Premier League 2021/22").
+			AddRow(uuid.NewV4(), 0, 0, 4, "ENG", "FA Cup 2021/22"))
+
+	suite.response.getTeamRulesResp, suite.helper.err = suite.squad.GetTeamRules(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Len(suite.T(), suite.response.getTeamRulesResp, 2)
+	require.Equal(suite.T(), "FA Cup 2021/22", suite.response.getTeamRulesResp[1].SeasonName)
+}
+
+// TestGetSquad ...
+func (suite *Suite) TestGetSquad() {
+	params := param.GetSquad{
+		TeamID: uuid.NewV4(),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE team_id = $1 AND deleted_at IS NULL`)).
+		WithArgs(params.TeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "nationality", "homegrown"}).
+			AddRow(uuid.NewV4(), "ENG", true).
+			AddRow(uuid.NewV4(), "FRA", nil))
+
+	suite.response.getSquadResp, suite.helper.err = suite.squad.GetSquad(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Len(suite.T(), suite.response.getSquadResp, 2)
+	require.Nil(suite.T(), suite.response.getSquadResp[1].Homegrown)
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
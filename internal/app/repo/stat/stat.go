@@ -0,0 +1,167 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"context"
+
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/stat/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/stat/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// IStat is an interface that stores the methods that Stat struct will use.
+type IStat interface {
+	// DoRefresh is used for replacing the player stats of a season in a single transaction.
+	// It returns doRefreshResp of transporter.DoRefresh and any errors written.
+	DoRefresh(ctx context.Context, params param.DoRefresh) (doRefreshResp transporter.DoRefresh, err error)
+
+	// GetPlayerStats is used for getting the stats of a player over a season.
+	// It returns getPlayerStatsResp of transporter.GetPlayerStats and any errors written.
+	GetPlayerStats(ctx context.Context, params param.GetPlayerStats) (getPlayerStatsResp transporter.GetPlayerStats, err error)
+
+	// GetMatches is used for getting the finished matches of a season, telling the ones that went to extra time.
+	// It returns getMatchesResp of []transporter.GetMatches and any errors written.
+	GetMatches(ctx context.Context, params param.GetMatches) (getMatchesResp []transporter.GetMatches, err error)
+
+	// GetLineupPlayers is used for getting the players named on the team sheets of the given matches.
+	// It returns getLineupPlayersResp of []transporter.GetLineupPlayers and any errors written.
+	GetLineupPlayers(ctx context.Context, params param.GetLineupPlayers) (getLineupPlayersResp []transporter.GetLineupPlayers, err error)
+
+	// GetMatchEvents is used for getting the events of the given matches in the order they happened.
+	// It returns getMatchEventsResp of []transporter.GetMatchEvents and any errors written.
+	GetMatchEvents(ctx context.Context, params param.GetMatchEvents) (getMatchEventsResp []transporter.GetMatchEvents, err error)
+}
+
+// Stat is an struct that implements IStat methods.
+type Stat struct {
+	config   config.IConfig
+	ormMySQL *gorm.DB
+	ormPgSQL *gorm.DB
+	statement
+}
+
+type statement struct {
+	ormTX       *gorm.DB
+	ormChaining *gorm.DB
+}
+
+// New it returns instance of Stat that implements IStat methods.
+func New(opts ...Option) IStat {
+	s := new(Stat)
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// DoRefresh is used for replacing the player stats of a season in a single transaction.
+// It returns doRefreshResp of transporter.DoRefresh and any errors written.
+func (stat *Stat) DoRefresh(ctx context.Context, params param.DoRefresh) (doRefreshResp transporter.DoRefresh, err error) {
+	stat.ormTX = stat.ormPgSQL.WithContext(ctx).Begin()
+
+	if err = stat.ormTX.
+		Where("season_id = ?", params.SeasonID).
+		Delete(&model.PlayerStat{}).Error; err != nil {
+		stat.ormTX.Rollback()
+		return
+	}
+
+	if len(params.Stats) > 0 {
+		recordPlayerStats := make([]model.PlayerStat, 0, len(params.Stats))
+		for _, playerStat := range params.Stats {
+			recordPlayerStats = append(recordPlayerStats, model.PlayerStat{
+				SeasonID:      params.SeasonID,
+				PlayerID:      playerStat.PlayerID,
+				Appearances:   playerStat.Appearances,
+				Starts:        playerStat.Starts,
+				MinutesPlayed: playerStat.MinutesPlayed,
+				Goals:         playerStat.Goals,
+				Assists:       playerStat.Assists,
+				YellowCards:   playerStat.YellowCards,
+				RedCards:      playerStat.RedCards,
+				CleanSheets:   playerStat.CleanSheets,
+			})
+		}
+
+		if err = stat.ormTX.Create(&recordPlayerStats).Error; err != nil {
+			stat.ormTX.Rollback()
+			return
+		}
+	}
+
+	if err = stat.ormTX.Commit().Error; err != nil {
+		return
+	}
+
+	doRefreshResp.SeasonID = params.SeasonID
+	doRefreshResp.Players = len(params.Stats)
+
+	return
+}
+
+// GetPlayerStats is used for getting the stats of a player over a season.
+// It returns getPlayerStatsResp of transporter.GetPlayerStats and any errors written.
+func (stat *Stat) GetPlayerStats(ctx context.Context, params param.GetPlayerStats) (getPlayerStatsResp transporter.GetPlayerStats, err error) {
+	stat.ormChaining = stat.ormPgSQL.
+		WithContext(ctx).
+		Where("season_id = ? AND player_id = ?", params.SeasonID, params.PlayerID).
+		Limit(1)
+
+	if err = stat.ormChaining.Find(&getPlayerStatsResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// GetMatches is used for getting the finished matches of a season, telling the ones that went to extra time.
+// It returns getMatchesResp of []transporter.GetMatches and any errors written.
+func (stat *Stat) GetMatches(ctx context.Context, params param.GetMatches) (getMatchesResp []transporter.GetMatches, err error) {
+	stat.ormChaining = stat.ormPgSQL.
+		WithContext(ctx).
+		Select("*, EXISTS (SELECT 1 FROM match_transitions WHERE match_transitions.match_id = matches.id AND match_transitions.to_status = ?) AS extra_time",
+			model.MatchStatusExtraTime).
+		Where("season_id = ? AND status = ? AND deleted_at IS NULL", params.SeasonID, model.MatchStatusFinished).
+		Order("kickoff_at")
+
+	if err = stat.ormChaining.Find(&getMatchesResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// GetLineupPlayers is used for getting the players named on the team sheets of the given matches.
+// It returns getLineupPlayersResp of []transporter.GetLineupPlayers and any errors written.
+func (stat *Stat) GetLineupPlayers(ctx context.Context, params param.GetLineupPlayers) (getLineupPlayersResp []transporter.GetLineupPlayers, err error) {
+	stat.ormChaining = stat.ormPgSQL.
+		WithContext(ctx).
+		Where("match_id IN ?", params.MatchIDs)
+
+	if err = stat.ormChaining.Find(&getLineupPlayersResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// GetMatchEvents is used for getting the events of the given matches in the order they happened.
+// It returns getMatchEventsResp of []transporter.GetMatchEvents and any errors written.
+func (stat *Stat) GetMatchEvents(ctx context.Context, params param.GetMatchEvents) (getMatchEventsResp []transporter.GetMatchEvents, err error) {
+	stat.ormChaining = stat.ormPgSQL.
+		WithContext(ctx).
+		Where("match_id IN ? AND deleted_at IS NULL", params.MatchIDs).
+		Order("minute, created_at")
+
+	if err = stat.ormChaining.Find(&getMatchEventsResp).Error; err != nil {
+		return
+	}
+
+	return
+}
@@ -0,0 +1,175 @@
+package stat
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/handler/stat/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/stat/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	pgsqlConn *gorm.DB
+	mock      sqlmock.Sqlmock
+
+	stat IStat
+	helper
+	response
+}
+
+type helper struct {
+	db  *sql.DB
+	err error
+}
+
+type response struct {
+	doRefreshResp      transporter.DoRefresh
+	getPlayerStatsResp transporter.GetPlayerStats
+	getMatchesResp     []transporter.GetMatches
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.stat = New(
+		WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+}
+
+// TestDoRefresh ...
+func (suite *Suite) TestDoRefresh() {
+	params := param.DoRefresh{
+		MatchID:  uuid.NewV4(),
+		SeasonID: uuid.NewV4(),
+		Stats: []param.Stat{
+			{PlayerID: uuid.NewV4(), Appearances: 1, Starts: 1, MinutesPlayed: 90, CleanSheets: 1},
+			{PlayerID: uuid.NewV4(), Appearances: 1, MinutesPlayed: 20, Goals: 1},
+		},
+	}
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`DELETE FROM "player_stats" WHERE season_id = $1`)).
+		WithArgs(params.SeasonID).
+		WillReturnResult(sqlmock.NewResult(0, 5))
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`INSERT INTO "player_stats" ("season_id","player_id","appearances","starts","minutes_played","goals","assists","yellow_cards","red_cards","clean_sheets","updated_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11),($12,$13,$14,$15,$16,$17,$18,$19,$20,$21,$22)`)).
+		WithArgs(
+			params.SeasonID, params.Stats[0].PlayerID, 1, 1, 90, 0, 0, 0, 0, 1, sqlmock.AnyArg(),
+			params.SeasonID, params.Stats[1].PlayerID, 1, 0, 20, 1, 0, 0, 0, 0, sqlmock.AnyArg(),
+		).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	suite.mock.ExpectCommit()
+
+	suite.response.doRefreshResp, suite.helper.err = suite.stat.DoRefresh(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), params.SeasonID, suite.response.doRefreshResp.SeasonID)
+	require.Equal(suite.T(), 2, suite.response.doRefreshResp.Players)
+}
+
+// TestDoRefreshWithoutStats ...
+func (suite *Suite) TestDoRefreshWithoutStats() {
+	params := param.DoRefresh{
+		MatchID:  uuid.NewV4(),
+		SeasonID: uuid.NewV4(),
+	}
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`DELETE FROM "player_stats" WHERE season_id = $1`)).
+		WithArgs(params.SeasonID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	suite.mock.ExpectCommit()
+
+	suite.response.doRefreshResp, suite.helper.err = suite.stat.DoRefresh(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), 0, suite.response.doRefreshResp.Players)
+}
+
+// TestGetPlayerStats ...
+func (suite *Suite) TestGetPlayerStats() {
+	params := param.GetPlayerStats{
+		PlayerID: uuid.NewV4(),
+		SeasonID: uuid.NewV4(),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "player_stats" WHERE season_id = $1 AND player_id = $2 LIMIT 1`)).
+		WithArgs(params.SeasonID, params.PlayerID).
+		WillReturnRows(sqlmock.NewRows([]string{"season_id", "player_id", "appearances", "goals"}).
+			AddRow(params.SeasonID, params.PlayerID, 12, 7))
+
+	suite.response.getPlayerStatsResp, suite.helper.err = suite.stat.GetPlayerStats(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), 12, suite.response.getPlayerStatsResp.Appearances)
+	require.Equal(suite.T(), 7, suite.response.getPlayerStatsResp.Goals)
+}
+
+// TestGetMatches ...
+func (suite *Suite) TestGetMatches() {
+	params := param.GetMatches{
+		SeasonID: uuid.NewV4(),
+	}
+	matchID := uuid.NewV4()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT *, EXISTS (SELECT 1 FROM match_transitions WHERE match_transitions.match_id = matches.id AND match_transitions.to_status = $1) AS extra_time FROM "matches" WHERE season_id = $2 AND status = $3 AND deleted_at IS NULL ORDER BY kickoff_at`)).
+		WithArgs(model.MatchStatusExtraTime, params.SeasonID, model.MatchStatusFinished).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "home_score", "away_score", "extra_time"}).
+			AddRow(matchID, 1, 1, true))
+
+	suite.response.getMatchesResp, suite.helper.err = suite.stat.GetMatches(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Len(suite.T(), suite.response.getMatchesResp, 1)
+	require.True(suite.T(), suite.response.getMatchesResp[0].ExtraTime)
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
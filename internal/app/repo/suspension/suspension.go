@@ -0,0 +1,207 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package suspension
+
+import (
+	"context"
+	"time"
+
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/suspension/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/suspension/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/satori/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ISuspension is an interface that stores the methods that Suspension struct will use.
+type ISuspension interface {
+	// DoCreate is used for record new suspension, once per card.
+	// It returns doCreateResp of transporter.DoCreate and any errors written.
+	DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error)
+
+	// GetSuspensions is used for getting all suspensions of a player, the latest first.
+	// It returns getSuspensionsResp of []transporter.GetSuspensions and any errors written.
+	GetSuspensions(ctx context.Context, params param.GetSuspensions) (getSuspensionsResp []transporter.GetSuspensions, err error)
+
+	// CountYellowCards is used for counting the yellow cards a player has been shown over a season.
+	// It returns countYellowCardsResp of transporter.CountYellowCards and any errors written.
+	CountYellowCards(ctx context.Context, params param.CountYellowCards) (countYellowCardsResp transporter.CountYellowCards, err error)
+
+	// DoServe is used for taking a match off the suspensions of the players of the teams of a finished match.
+	// It returns doServeResp of transporter.DoServe and any errors written.
+	DoServe(ctx context.Context, params param.DoServe) (doServeResp transporter.DoServe, err error)
+
+	// GetTeamSuspensions is used for getting the suspensions players of a team still have to serve.
+	// It returns getTeamSuspensionsResp of []transporter.GetTeamSuspensions and any errors written.
+	GetTeamSuspensions(ctx context.Context, params param.GetTeamSuspensions) (getTeamSuspensionsResp []transporter.GetTeamSuspensions, err error)
+}
+
+// serveQuery records a finished match as served by the suspensions of the players of its teams it counts towards,
+// leaving out the suspensions it was recorded for already, and gives back the suspensions it was recorded for.
+const serveQuery = `INSERT INTO suspension_matches (suspension_id, match_id, created_at)
+SELECT suspensions.id, @match_id, @created_at FROM suspensions
+JOIN players ON players.id = suspensions.player_id
+WHERE suspensions.season_id = @season_id AND suspensions.matches_remaining > 0 AND suspensions.deleted_at IS NULL
+	AND (suspensions.match_id IS NULL OR suspensions.match_id <> @match_id)
+	AND players.team_id IN @team_ids
+ON CONFLICT (suspension_id, match_id) DO NOTHING
+RETURNING suspension_id`
+
+// Suspension is an struct that implements ISuspension methods.
+type Suspension struct {
+	config   config.IConfig
+	ormMySQL *gorm.DB
+	ormPgSQL *gorm.DB
+	statement
+}
+
+type statement struct {
+	ormTX       *gorm.DB
+	ormChaining *gorm.DB
+}
+
+// New it returns instance of Suspension that implements ISuspension methods.
+func New(opts ...Option) ISuspension {
+	s := new(Suspension)
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// DoCreate is used for record new suspension, once per card.
+// A card that already led to a suspension is left alone, giving back an empty one.
+// It returns doCreateResp of transporter.DoCreate and any errors written.
+func (suspension *Suspension) DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error) {
+	recordSuspension := model.Suspension{
+		PlayerID:         params.PlayerID,
+		SeasonID:         params.SeasonID,
+		MatchID:          params.MatchID,
+		MatchEventID:     params.MatchEventID,
+		Reason:           params.Reason,
+		MatchesRemaining: params.MatchesRemaining,
+	}
+
+	suspension.ormChaining = suspension.ormPgSQL.
+		WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "match_event_id"}},
+			DoNothing: true,
+		})
+
+	if err = suspension.ormChaining.Create(&recordSuspension).Error; err != nil {
+		return
+	}
+
+	doCreateResp.Suspension = transporter.Suspension{
+		ID:               recordSuspension.ID,
+		PlayerID:         recordSuspension.PlayerID,
+		SeasonID:         recordSuspension.SeasonID,
+		MatchID:          recordSuspension.MatchID,
+		Reason:           recordSuspension.Reason,
+		MatchesRemaining: recordSuspension.MatchesRemaining,
+	}
+
+	return
+}
+
+// GetSuspensions is used for getting all suspensions of a player, the latest first.
+// It returns getSuspensionsResp of []transporter.GetSuspensions and any errors written.
+func (suspension *Suspension) GetSuspensions(ctx context.Context, params param.GetSuspensions) (getSuspensionsResp []transporter.GetSuspensions, err error) {
+	suspension.ormChaining = suspension.ormPgSQL.
+		WithContext(ctx).
+		Where("player_id = ? AND deleted_at IS NULL", params.PlayerID).
+		Order("created_at DESC")
+
+	if err = suspension.ormChaining.Find(&getSuspensionsResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// CountYellowCards is used for counting the yellow cards a player has been shown over a season.
+// It returns countYellowCardsResp of transporter.CountYellowCards and any errors written.
+func (suspension *Suspension) CountYellowCards(ctx context.Context, params param.CountYellowCards) (countYellowCardsResp transporter.CountYellowCards, err error) {
+	suspension.ormChaining = suspension.ormPgSQL.
+		WithContext(ctx).
+		Model(&model.MatchEvent{}).
+		Select("COUNT(*) AS total").
+		Joins("JOIN matches ON matches.id = match_events.match_id").
+		Where("matches.season_id = ? AND matches.deleted_at IS NULL", params.SeasonID).
+		Where("match_events.player_id = ? AND match_events.type = ? AND match_events.deleted_at IS NULL", params.PlayerID, model.MatchEventTypeYellowCard)
+
+	if err = suspension.ormChaining.Scan(&countYellowCardsResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// DoServe is used for taking a match off the suspensions of the players of the teams of a finished match.
+// The suspension a match itself led to is not served by it, and every match a suspension is served by is recorded
+// in the same transaction, so a match is never taken off the same suspension twice and can be served again safely.
+// It returns doServeResp of transporter.DoServe and any errors written.
+func (suspension *Suspension) DoServe(ctx context.Context, params param.DoServe) (doServeResp transporter.DoServe, err error) {
+	var suspensionIDs []uuid.UUID
+
+	suspension.ormTX = suspension.ormPgSQL.WithContext(ctx).Begin()
+
+	served := suspension.ormTX.Raw(serveQuery, map[string]interface{}{
+		"match_id":   params.MatchID,
+		"season_id":  params.SeasonID,
+		"team_ids":   params.TeamIDs,
+		"created_at": time.Now(),
+	})
+	if err = served.Scan(&suspensionIDs).Error; err != nil {
+		suspension.ormTX.Rollback()
+		return
+	}
+
+	if len(suspensionIDs) > 0 {
+		updated := suspension.ormTX.
+			Model(&model.Suspension{}).
+			Where("id IN ?", suspensionIDs).
+			Updates(map[string]interface{}{
+				"matches_remaining":    gorm.Expr("matches_remaining - 1"),
+				"last_served_match_id": params.MatchID,
+				"updated_at":           time.Now(),
+			})
+		if err = updated.Error; err != nil {
+			suspension.ormTX.Rollback()
+			return
+		}
+
+		doServeResp.Served = updated.RowsAffected
+	}
+
+	if err = suspension.ormTX.Commit().Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// GetTeamSuspensions is used for getting the suspensions players of a team still have to serve.
+// It returns getTeamSuspensionsResp of []transporter.GetTeamSuspensions and any errors written.
+func (suspension *Suspension) GetTeamSuspensions(ctx context.Context, params param.GetTeamSuspensions) (getTeamSuspensionsResp []transporter.GetTeamSuspensions, err error) {
+	suspension.ormChaining = suspension.ormPgSQL.
+		WithContext(ctx).
+		Model(&model.Suspension{}).
+		Select("suspensions.*, players.name").
+		Joins("JOIN players ON players.id = suspensions.player_id").
+		Where("players.team_id = ? AND players.deleted_at IS NULL", params.TeamID).
+		Where("suspensions.matches_remaining > 0 AND suspensions.deleted_at IS NULL").
+		Order("players.name")
+
+	if err = suspension.ormChaining.Scan(&getTeamSuspensionsResp).Error; err != nil {
+		return
+	}
+
+	return
+}
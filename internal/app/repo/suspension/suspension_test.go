@@ -0,0 +1,193 @@
+package suspension
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/handler/suspension/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/suspension/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	pgsqlConn *gorm.DB
+	mock      sqlmock.Sqlmock
+
+	suspension ISuspension
+	helper
+	response
+}
+
+type helper struct {
+	db  *sql.DB
+	err error
+}
+
+type response struct {
+	doCreateResp           transporter.DoCreate
+	countYellowCardsResp   transporter.CountYellowCards
+	doServeResp            transporter.DoServe
+	getTeamSuspensionsResp []transporter.GetTeamSuspensions
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.suspension = New(
+		WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+}
+
+// TestDoCreate ...
+func (suite *Suite) TestDoCreate() {
+	matchID, matchEventID := uuid.NewV4(), uuid.NewV4()
+	params := param.DoCreate{
+		Suspension: param.Suspension{
+			PlayerID:         uuid.NewV4(),
+			SeasonID:         uuid.NewV4(),
+			MatchID:          &matchID,
+			MatchEventID:     &matchEventID,
+			Reason:           model.SuspensionReasonRedCard,
+			MatchesRemaining: 3,
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "suspensions" ("created_at","updated_at","deleted_at","player_id","season_id","match_id","match_event_id","reason","matches_remaining","last_served_match_id") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10) ON CONFLICT ("match_event_id") DO NOTHING RETURNING "id"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.PlayerID, params.SeasonID, matchID, matchEventID, params.Reason, params.MatchesRemaining, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.response.doCreateResp, suite.helper.err = suite.suspension.DoCreate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.NotEqual(suite.T(), uuid.Nil, suite.response.doCreateResp.ID)
+	require.Equal(suite.T(), 3, suite.response.doCreateResp.MatchesRemaining)
+}
+
+// TestCountYellowCards ...
+func (suite *Suite) TestCountYellowCards() {
+	params := param.CountYellowCards{
+		PlayerID: uuid.NewV4(),
+		SeasonID: uuid.NewV4(),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) AS total FROM "match_events" JOIN matches ON matches.id = match_events.match_id WHERE (matches.season_id = $1 AND matches.deleted_at IS NULL) AND (match_events.player_id = $2 AND match_events.type = $3 AND match_events.deleted_at IS NULL)`)).
+		WithArgs(params.SeasonID, params.PlayerID, model.MatchEventTypeYellowCard).
+		WillReturnRows(sqlmock.NewRows([]string{"total"}).
+			AddRow(5))
+
+	suite.response.countYellowCardsResp, suite.helper.err = suite.suspension.CountYellowCards(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), int64(5), suite.response.countYellowCardsResp.Total)
+}
+
+// TestDoServe ...
+func (suite *Suite) TestDoServe() {
+	params := param.DoServe{
+		MatchID:  uuid.NewV4(),
+		SeasonID: uuid.NewV4(),
+		TeamIDs:  []uuid.UUID{uuid.NewV4(), uuid.NewV4()},
+	}
+	suspensionIDs := []uuid.UUID{uuid.NewV4(), uuid.NewV4()}
+
+	suite.mock.ExpectBegin()
+	suite.mock.
+		ExpectQuery(`INSERT INTO suspension_matches (.+) ON CONFLICT \(suspension_id, match_id\) DO NOTHING RETURNING suspension_id`).
+		WithArgs(params.MatchID, sqlmock.AnyArg(), params.SeasonID, params.MatchID, params.TeamIDs[0], params.TeamIDs[1]).
+		WillReturnRows(sqlmock.NewRows([]string{"suspension_id"}).
+			AddRow(suspensionIDs[0]).
+			AddRow(suspensionIDs[1]))
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "suspensions" SET "last_served_match_id"=$1,"matches_remaining"=matches_remaining - 1,"updated_at"=$2 WHERE id IN ($3,$4)`)).
+		WithArgs(params.MatchID, sqlmock.AnyArg(), suspensionIDs[0], suspensionIDs[1]).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	suite.mock.ExpectCommit()
+
+	suite.response.doServeResp, suite.helper.err = suite.suspension.DoServe(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), int64(2), suite.response.doServeResp.Served)
+}
+
+// TestDoServeAlreadyServed ...
+func (suite *Suite) TestDoServeAlreadyServed() {
+	params := param.DoServe{
+		MatchID:  uuid.NewV4(),
+		SeasonID: uuid.NewV4(),
+		TeamIDs:  []uuid.UUID{uuid.NewV4(), uuid.NewV4()},
+	}
+
+	suite.mock.ExpectBegin()
+	suite.mock.
+		ExpectQuery(`INSERT INTO suspension_matches (.+) RETURNING suspension_id`).
+		WithArgs(params.MatchID, sqlmock.AnyArg(), params.SeasonID, params.MatchID, params.TeamIDs[0], params.TeamIDs[1]).
+		WillReturnRows(sqlmock.NewRows([]string{"suspension_id"}))
+	suite.mock.ExpectCommit()
+
+	suite.response.doServeResp, suite.helper.err = suite.suspension.DoServe(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), int64(0), suite.response.doServeResp.Served)
+}
+
+// TestGetTeamSuspensions ...
+func (suite *Suite) TestGetTeamSuspensions() {
+	params := param.GetTeamSuspensions{TeamID: uuid.NewV4()}
+	playerID, seasonID := uuid.NewV4(), uuid.NewV4()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT suspensions.*, players.name FROM "suspensions" JOIN players ON players.id = suspensions.player_id WHERE (players.team_id = $1 AND players.deleted_at IS NULL) AND (suspensions.matches_remaining > 0 AND suspensions.deleted_at IS NULL) ORDER BY players.name`)).
+		WithArgs(params.TeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "player_id", "season_id", "reason", "matches_remaining", "name"}).
+			AddRow(uuid.NewV4(), playerID, seasonID, model.SuspensionReasonSecondYellow, 1, "Roy Keane"))
+
+	suite.response.getTeamSuspensionsResp, suite.helper.err = suite.suspension.GetTeamSuspensions(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Len(suite.T(), suite.response.getTeamSuspensionsResp, 1)
+	require.Equal(suite.T(), seasonID, suite.response.getTeamSuspensionsResp[0].SeasonID)
+	require.Equal(suite.T(), "Roy Keane", suite.response.getTeamSuspensionsResp[0].Name)
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
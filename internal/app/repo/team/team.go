@@ -65,7 +65,9 @@ func New(opts ...Option) ITeam {
 // It returns doCreateResp of transporter.DoCreate and any errors written.
 func (team *Team) DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error) {
 	recordTeam := model.Team{
-		Name: params.Name,
+		Name:        params.Name,
+		Association: params.Association,
+		VenueID:     params.VenueID,
 	}
 
 	team.ormChaining = team.ormPgSQL.WithContext(ctx)
@@ -76,8 +78,10 @@ func (team *Team) DoCreate(ctx context.Context, params param.DoCreate) (doCreate
 
 	doCreateResp = transporter.DoCreate{
 		Team: transporter.Team{
-			ID:   recordTeam.ID,
-			Name: recordTeam.Name,
+			ID:          recordTeam.ID,
+			Name:        recordTeam.Name,
+			Association: recordTeam.Association,
+			VenueID:     recordTeam.VenueID,
 		},
 	}
 
@@ -104,7 +108,9 @@ func (team *Team) GetTeams(ctx context.Context, params param.GetTeams) (getTeams
 // It returns doUpdateResp of transporter.DoUpdate and any errors written.
 func (team *Team) DoUpdate(ctx context.Context, params param.DoUpdate) (doUpdateResp transporter.DoUpdate, err error) {
 	recordTeam := model.Team{
-		Name: params.Name,
+		Name:        params.Name,
+		Association: params.Association,
+		VenueID:     params.VenueID,
 	}
 
 	team.ormChaining = team.ormPgSQL.
@@ -117,8 +123,10 @@ func (team *Team) DoUpdate(ctx context.Context, params param.DoUpdate) (doUpdate
 
 	doUpdateResp = transporter.DoUpdate{
 		Team: transporter.Team{
-			ID:   params.ID,
-			Name: recordTeam.Name,
+			ID:          params.ID,
+			Name:        recordTeam.Name,
+			Association: recordTeam.Association,
+			VenueID:     recordTeam.VenueID,
 		},
 	}
 
@@ -69,14 +69,15 @@ func (suite *Suite) SetupSuite() {
 func (suite *Suite) TestDoCreate() {
 	params := param.DoCreate{
 		Team: param.Team{
-			Name: "Arsenal",
+			Name:        "Arsenal",
+			Association: "ENG",
 		},
 	}
 
 	suite.mock.MatchExpectationsInOrder(false)
 	suite.mock.
-		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "teams" ("created_at","updated_at","deleted_at","name") VALUES ($1,$2,$3,$4) RETURNING "id"`)).
-		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.Name).
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "teams" ("created_at","updated_at","deleted_at","name","association","venue_id") VALUES ($1,$2,$3,$4,$5,$6) RETURNING "id"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.Name, params.Association, nil).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).
 			AddRow(uuid.NewV4()))
 
@@ -85,6 +86,7 @@ func (suite *Suite) TestDoCreate() {
 	require.NoError(suite.T(), suite.helper.err)
 
 	require.Equal(suite.T(), "Arsenal", suite.response.doCreateResp.Name)
+	require.Equal(suite.T(), "ENG", suite.response.doCreateResp.Association)
 }
 
 // TestGetTeams ...
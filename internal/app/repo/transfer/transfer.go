@@ -0,0 +1,259 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transfer
+
+import (
+	"context"
+	"time"
+
+	"github.com/harunnryd/skeltun/config"
+	contractTransporter "github.com/harunnryd/skeltun/internal/app/handler/contract/transporter"
+	loanTransporter "github.com/harunnryd/skeltun/internal/app/handler/loan/transporter"
+	"github.com/harunnryd/skeltun/internal/app/handler/transfer/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/transfer/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo/contract"
+	"gorm.io/gorm"
+)
+
+// ITransfer is an interface that stores the methods that Transfer struct will use.
+type ITransfer interface {
+	// DoCreate is used for record a transfer, along with the terms of a loan or the new contract,
+	// and moving the player to the new team in a single transaction.
+	// It returns doCreateResp of transporter.DoCreate and any errors written.
+	DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error)
+
+	// GetTransfers is used for getting all transfers of a player, the oldest first.
+	// It returns getTransfersResp of []transporter.GetTransfers and any errors written.
+	GetTransfers(ctx context.Context, params param.GetTransfers) (getTransfersResp []transporter.GetTransfers, err error)
+
+	// DoCreateWindow is used for record new transfer window of a season.
+	// It returns doCreateWindowResp of transporter.DoCreateWindow and any errors written.
+	DoCreateWindow(ctx context.Context, params param.DoCreateWindow) (doCreateWindowResp transporter.DoCreateWindow, err error)
+
+	// GetWindows is used for getting all transfer windows of a season.
+	// It returns getWindowsResp of []transporter.GetWindows and any errors written.
+	GetWindows(ctx context.Context, params param.GetWindows) (getWindowsResp []transporter.GetWindows, err error)
+
+	// GetOpenWindow is used for getting a transfer window open on the given date
+	// in any season the team is registered in.
+	// It returns getOpenWindowResp of transporter.GetOpenWindow and any errors written.
+	GetOpenWindow(ctx context.Context, params param.GetOpenWindow) (getOpenWindowResp transporter.GetOpenWindow, err error)
+}
+
+// Transfer is an struct that implements ITransfer methods.
+type Transfer struct {
+	config   config.IConfig
+	ormMySQL *gorm.DB
+	ormPgSQL *gorm.DB
+	statement
+}
+
+type statement struct {
+	ormTX       *gorm.DB
+	ormChaining *gorm.DB
+}
+
+// New it returns instance of Transfer that implements ITransfer methods.
+func New(opts ...Option) ITransfer {
+	t := new(Transfer)
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
+}
+
+// DoCreate is used for record a transfer, along with the terms of a loan or the new contract,
+// and moving the player to the new team in a single transaction.
+// The player leaves the shirt number behind, it belongs to the old team,
+// and a new contract terminates the contract signed with the old team.
+// It returns doCreateResp of transporter.DoCreate and any errors written.
+func (transfer *Transfer) DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error) {
+	recordTransfer := model.Transfer{
+		PlayerID:   params.PlayerID,
+		FromTeamID: params.FromTeamID,
+		ToTeamID:   params.ToTeamID,
+		Date:       params.Date,
+		Fee:        params.Fee,
+		Type:       params.Type,
+	}
+
+	transfer.ormTX = transfer.ormPgSQL.WithContext(ctx).Begin()
+
+	if err = transfer.ormTX.Create(&recordTransfer).Error; err != nil {
+		transfer.ormTX.Rollback()
+		return
+	}
+
+	var recordLoan *model.Loan
+	if params.Loan != nil && params.FromTeamID != nil {
+		recordLoan = &model.Loan{
+			TransferID:   recordTransfer.ID,
+			PlayerID:     params.PlayerID,
+			ParentTeamID: *params.FromTeamID,
+			LoanTeamID:   params.ToTeamID,
+			StartsAt:     params.Date,
+			EndsAt:       params.Loan.EndsAt,
+			RecallClause: params.Loan.RecallClause,
+			OptionToBuy:  params.Loan.OptionToBuy,
+			OptionFee:    params.Loan.OptionFee,
+			Status:       model.LoanStatusActive,
+		}
+
+		if err = transfer.ormTX.Create(recordLoan).Error; err != nil {
+			transfer.ormTX.Rollback()
+			return
+		}
+	}
+
+	if err = transfer.ormTX.
+		Model(&model.Player{}).
+		Where("id = ?", params.PlayerID).
+		Updates(map[string]interface{}{
+			"team_id":      params.ToTeamID,
+			"shirt_number": nil,
+			"updated_at":   time.Now(),
+		}).Error; err != nil {
+		transfer.ormTX.Rollback()
+		return
+	}
+
+	var recordContract *model.Contract
+	if params.Contract != nil {
+		recordContract = &model.Contract{
+			PlayerID:      params.PlayerID,
+			TeamID:        params.ToTeamID,
+			StartsAt:      params.Date,
+			EndsAt:        params.Contract.EndsAt,
+			Wage:          params.Contract.Wage,
+			ReleaseClause: params.Contract.ReleaseClause,
+			Status:        model.ContractStatusActive,
+		}
+
+		if err = contract.Replace(transfer.ormTX, recordContract); err != nil {
+			transfer.ormTX.Rollback()
+			return
+		}
+	}
+
+	if err = transfer.ormTX.Commit().Error; err != nil {
+		return
+	}
+
+	doCreateResp.Transfer = transporter.Transfer{
+		ID:         recordTransfer.ID,
+		PlayerID:   recordTransfer.PlayerID,
+		FromTeamID: recordTransfer.FromTeamID,
+		ToTeamID:   recordTransfer.ToTeamID,
+		Date:       recordTransfer.Date,
+		Fee:        recordTransfer.Fee,
+		Type:       recordTransfer.Type,
+	}
+
+	if recordLoan != nil {
+		doCreateResp.Loan = &loanTransporter.Loan{
+			ID:           recordLoan.ID,
+			TransferID:   recordLoan.TransferID,
+			PlayerID:     recordLoan.PlayerID,
+			ParentTeamID: recordLoan.ParentTeamID,
+			LoanTeamID:   recordLoan.LoanTeamID,
+			StartsAt:     recordLoan.StartsAt,
+			EndsAt:       recordLoan.EndsAt,
+			RecallClause: recordLoan.RecallClause,
+			OptionToBuy:  recordLoan.OptionToBuy,
+			OptionFee:    recordLoan.OptionFee,
+			Status:       recordLoan.Status,
+		}
+	}
+
+	if recordContract != nil {
+		doCreateResp.Contract = &contractTransporter.Contract{
+			ID:            recordContract.ID,
+			PlayerID:      recordContract.PlayerID,
+			TeamID:        recordContract.TeamID,
+			StartsAt:      recordContract.StartsAt,
+			EndsAt:        recordContract.EndsAt,
+			Wage:          recordContract.Wage,
+			ReleaseClause: recordContract.ReleaseClause,
+			Status:        recordContract.Status,
+		}
+	}
+
+	return
+}
+
+// GetTransfers is used for getting all transfers of a player, the oldest first.
+// It returns getTransfersResp of []transporter.GetTransfers and any errors written.
+func (transfer *Transfer) GetTransfers(ctx context.Context, params param.GetTransfers) (getTransfersResp []transporter.GetTransfers, err error) {
+	transfer.ormChaining = transfer.ormPgSQL.
+		WithContext(ctx).
+		Select("transfers.*, from_teams.name AS from_team_name, to_teams.name AS to_team_name").
+		Joins("LEFT JOIN teams from_teams ON from_teams.id = transfers.from_team_id").
+		Joins("LEFT JOIN teams to_teams ON to_teams.id = transfers.to_team_id").
+		Where("transfers.player_id = ?", params.PlayerID).
+		Order("transfers.date, transfers.created_at")
+
+	if err = transfer.ormChaining.Find(&getTransfersResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// DoCreateWindow is used for record new transfer window of a season.
+// It returns doCreateWindowResp of transporter.DoCreateWindow and any errors written.
+func (transfer *Transfer) DoCreateWindow(ctx context.Context, params param.DoCreateWindow) (doCreateWindowResp transporter.DoCreateWindow, err error) {
+	recordTransferWindow := model.TransferWindow{
+		SeasonID: params.SeasonID,
+		OpensAt:  params.OpensAt,
+		ClosesAt: params.ClosesAt,
+	}
+
+	if err = transfer.ormPgSQL.WithContext(ctx).Create(&recordTransferWindow).Error; err != nil {
+		return
+	}
+
+	doCreateWindowResp.Window = transporter.Window{
+		ID:       recordTransferWindow.ID,
+		SeasonID: recordTransferWindow.SeasonID,
+		OpensAt:  recordTransferWindow.OpensAt,
+		ClosesAt: recordTransferWindow.ClosesAt,
+	}
+
+	return
+}
+
+// GetWindows is used for getting all transfer windows of a season.
+// It returns getWindowsResp of []transporter.GetWindows and any errors written.
+func (transfer *Transfer) GetWindows(ctx context.Context, params param.GetWindows) (getWindowsResp []transporter.GetWindows, err error) {
+	transfer.ormChaining = transfer.ormPgSQL.
+		WithContext(ctx).
+		Where("season_id = ?", params.SeasonID).
+		Order("opens_at")
+
+	if err = transfer.ormChaining.Find(&getWindowsResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// GetOpenWindow is used for getting a transfer window open on the given date
+// in any season the team is registered in.
+// It returns getOpenWindowResp of transporter.GetOpenWindow and any errors written.
+func (transfer *Transfer) GetOpenWindow(ctx context.Context, params param.GetOpenWindow) (getOpenWindowResp transporter.GetOpenWindow, err error) {
+	transfer.ormChaining = transfer.ormPgSQL.
+		WithContext(ctx).
+		Joins("JOIN season_teams ON season_teams.season_id = transfer_windows.season_id").
+		Where("season_teams.team_id = ? AND transfer_windows.opens_at <= ? AND transfer_windows.closes_at >= ?", params.TeamID, params.Date, params.Date).
+		Limit(1)
+
+	if err = transfer.ormChaining.Find(&getOpenWindowResp).Error; err != nil {
+		return
+	}
+
+	return
+}
@@ -0,0 +1,196 @@
+package transfer
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	contractParam "github.com/harunnryd/skeltun/internal/app/handler/contract/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/transfer/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/transfer/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	pgsqlConn *gorm.DB
+	mock      sqlmock.Sqlmock
+
+	transfer ITransfer
+	helper
+	response
+}
+
+type helper struct {
+	db  *sql.DB
+	err error
+}
+
+type response struct {
+	doCreateResp      transporter.DoCreate
+	getTransfersResp  []transporter.GetTransfers
+	getOpenWindowResp transporter.GetOpenWindow
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.transfer = New(
+		WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+}
+
+// TestDoCreate ...
+func (suite *Suite) TestDoCreate() {
+	fromTeamID, transferID := uuid.NewV4(), uuid.NewV4()
+	params := param.DoCreate{
+		PlayerID:   uuid.NewV4(),
+		FromTeamID: &fromTeamID,
+		ToTeamID:   uuid.NewV4(),
+		Date:       time.Date(2021, time.January, 15, 0, 0, 0, 0, time.UTC),
+		Fee:        5000000,
+		Type:       model.TransferTypePermanent,
+		Contract: &contractParam.Terms{
+			EndsAt: time.Date(2024, time.June, 30, 0, 0, 0, 0, time.UTC),
+			Wage:   2500000,
+		},
+	}
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "transfers" ("created_at","updated_at","deleted_at","player_id","from_team_id","to_team_id","date","fee","type") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING "id"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.PlayerID, fromTeamID, params.ToTeamID, params.Date, params.Fee, params.Type).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(transferID))
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "players" SET "shirt_number"=$1,"team_id"=$2,"updated_at"=$3 WHERE id = $4`)).
+		WithArgs(nil, params.ToTeamID, sqlmock.AnyArg(), params.PlayerID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "contracts" SET "status"=$1,"updated_at"=$2 WHERE player_id = $3 AND status = $4`)).
+		WithArgs(model.ContractStatusTerminated, sqlmock.AnyArg(), params.PlayerID, model.ContractStatusActive).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "contracts" ("created_at","updated_at","deleted_at","player_id","team_id","starts_at","ends_at","wage","release_clause","status") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10) RETURNING "id"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.PlayerID, params.ToTeamID, params.Date, params.Contract.EndsAt, params.Contract.Wage, nil, model.ContractStatusActive).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.mock.ExpectCommit()
+
+	suite.response.doCreateResp, suite.helper.err = suite.transfer.DoCreate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), transferID, suite.response.doCreateResp.ID)
+	require.NotNil(suite.T(), suite.response.doCreateResp.Contract)
+	require.Equal(suite.T(), params.ToTeamID, suite.response.doCreateResp.Contract.TeamID)
+}
+
+// TestDoCreateRollback ...
+func (suite *Suite) TestDoCreateRollback() {
+	params := param.DoCreate{
+		PlayerID: uuid.NewV4(),
+		ToTeamID: uuid.NewV4(),
+		Date:     time.Date(2021, time.January, 15, 0, 0, 0, 0, time.UTC),
+		Type:     model.TransferTypeFree,
+	}
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "transfers"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "players"`)).
+		WillReturnError(sql.ErrConnDone)
+
+	suite.mock.ExpectRollback()
+
+	suite.response.doCreateResp, suite.helper.err = suite.transfer.DoCreate(context.Background(), params)
+
+	require.Error(suite.T(), suite.helper.err)
+}
+
+// TestGetTransfers ...
+func (suite *Suite) TestGetTransfers() {
+	params := param.GetTransfers{
+		PlayerID: uuid.NewV4(),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT transfers.*, from_teams.name AS from_team_name, to_teams.name AS to_team_name FROM "transfers" LEFT JOIN teams from_teams ON from_teams.id = transfers.from_team_id LEFT JOIN teams to_teams ON to_teams.id = transfers.to_team_id WHERE transfers.player_id = $1 ORDER BY transfers.date, transfers.created_at`)).
+		WithArgs(params.PlayerID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "player_id", "to_team_id", "type", "to_team_name"}).
+			AddRow(uuid.NewV4(), params.PlayerID, uuid.NewV4(), model.TransferTypeFree, "Arsenal"))
+
+	suite.response.getTransfersResp, suite.helper.err = suite.transfer.GetTransfers(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Len(suite.T(), suite.response.getTransfersResp, 1)
+	require.Equal(suite.T(), "Arsenal", suite.response.getTransfersResp[0].ToTeamName)
+}
+
+// TestGetOpenWindow ...
+func (suite *Suite) TestGetOpenWindow() {
+	params := param.GetOpenWindow{
+		TeamID: uuid.NewV4(),
+		Date:   time.Date(2021, time.January, 15, 0, 0, 0, 0, time.UTC),
+	}
+	windowID := uuid.NewV4()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT "transfer_windows"."id","transfer_windows"."season_id","transfer_windows"."opens_at","transfer_windows"."closes_at" FROM "transfer_windows" JOIN season_teams ON season_teams.season_id = transfer_windows.season_id WHERE season_teams.team_id = $1 AND transfer_windows.opens_at <= $2 AND transfer_windows.closes_at >= $3 LIMIT 1`)).
+		WithArgs(params.TeamID, params.Date, params.Date).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(windowID))
+
+	suite.response.getOpenWindowResp, suite.helper.err = suite.transfer.GetOpenWindow(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), windowID, suite.response.getOpenWindowResp.ID)
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
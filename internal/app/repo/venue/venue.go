@@ -0,0 +1,166 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package venue
+
+import (
+	"context"
+
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/venue/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/venue/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// columns reads the location of a venue back as a plain latitude and longitude.
+const columns = "id, name, city, ST_Y(location::geometry) AS latitude, ST_X(location::geometry) AS longitude, capacity, surface"
+
+// IVenue is an interface that stores the methods that Venue struct will use.
+type IVenue interface {
+	// DoCreate is used for record new venue.
+	// It returns doCreateResp of transporter.DoCreate and any errors written.
+	DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error)
+
+	// GetVenue is used for getting a venue.
+	// It returns getVenueResp of transporter.GetVenue and any errors written.
+	GetVenue(ctx context.Context, params param.GetVenue) (getVenueResp transporter.GetVenue, err error)
+
+	// DoUpdate is used for update the record venue.
+	// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+	DoUpdate(ctx context.Context, params param.DoUpdate) (doUpdateResp transporter.DoUpdate, err error)
+
+	// GetNearby is used for getting the venues within a radius of a point, the closest first.
+	// It returns getNearbyResp of []transporter.GetNearby and any errors written.
+	GetNearby(ctx context.Context, params param.GetNearby) (getNearbyResp []transporter.GetNearby, err error)
+}
+
+// Venue is an struct that implements IVenue methods.
+type Venue struct {
+	config   config.IConfig
+	ormMySQL *gorm.DB
+	ormPgSQL *gorm.DB
+	statement
+}
+
+type statement struct {
+	ormTX       *gorm.DB
+	ormChaining *gorm.DB
+}
+
+// New it returns instance of Venue that implements IVenue methods.
+func New(opts ...Option) IVenue {
+	v := new(Venue)
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
+}
+
+// DoCreate is used for record new venue.
+// It returns doCreateResp of transporter.DoCreate and any errors written.
+func (venue *Venue) DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error) {
+	recordVenue := model.Venue{
+		Name: params.Name,
+		City: params.City,
+		Location: model.Point{
+			Latitude:  params.GetLatitude(),
+			Longitude: params.GetLongitude(),
+		},
+		Capacity: params.Capacity,
+		Surface:  params.Surface,
+	}
+
+	if err = venue.ormPgSQL.WithContext(ctx).Create(&recordVenue).Error; err != nil {
+		return
+	}
+
+	doCreateResp.Venue = transporter.Venue{
+		ID:        recordVenue.ID,
+		Name:      recordVenue.Name,
+		City:      recordVenue.City,
+		Latitude:  recordVenue.Location.Latitude,
+		Longitude: recordVenue.Location.Longitude,
+		Capacity:  recordVenue.Capacity,
+		Surface:   recordVenue.Surface,
+	}
+
+	return
+}
+
+// GetVenue is used for getting a venue.
+// It returns getVenueResp of transporter.GetVenue and any errors written.
+func (venue *Venue) GetVenue(ctx context.Context, params param.GetVenue) (getVenueResp transporter.GetVenue, err error) {
+	venue.ormChaining = venue.ormPgSQL.
+		WithContext(ctx).
+		Select(columns).
+		Where("id = ? AND deleted_at IS NULL", params.ID).
+		Limit(1)
+
+	if err = venue.ormChaining.Find(&getVenueResp).Error; err != nil {
+		return
+	}
+
+	return
+}
+
+// DoUpdate is used for update the record venue.
+// Every field is written, so the city can be cleared and the capacity brought down to zero.
+// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+func (venue *Venue) DoUpdate(ctx context.Context, params param.DoUpdate) (doUpdateResp transporter.DoUpdate, err error) {
+	recordVenue := model.Venue{
+		Name: params.Name,
+		City: params.City,
+		Location: model.Point{
+			Latitude:  params.GetLatitude(),
+			Longitude: params.GetLongitude(),
+		},
+		Capacity: params.Capacity,
+		Surface:  params.Surface,
+	}
+
+	venue.ormChaining = venue.ormPgSQL.
+		WithContext(ctx).
+		Select("updated_at", "name", "city", "location", "capacity", "surface").
+		Where("id = ?", params.ID)
+
+	if err = venue.ormChaining.Updates(&recordVenue).Error; err != nil {
+		return
+	}
+
+	doUpdateResp.Venue = transporter.Venue{
+		ID:        params.ID,
+		Name:      recordVenue.Name,
+		City:      recordVenue.City,
+		Latitude:  recordVenue.Location.Latitude,
+		Longitude: recordVenue.Location.Longitude,
+		Capacity:  recordVenue.Capacity,
+		Surface:   recordVenue.Surface,
+	}
+
+	return
+}
+
+// GetNearby is used for getting the venues within a radius of a point, the closest first.
+// Distances are measured in metres on the spheroid, and ST_DWithin keeps the search on the location index.
+// It returns getNearbyResp of []transporter.GetNearby and any errors written.
+func (venue *Venue) GetNearby(ctx context.Context, params param.GetNearby) (getNearbyResp []transporter.GetNearby, err error) {
+	point := model.Point{
+		Latitude:  params.GetLat(),
+		Longitude: params.GetLng(),
+	}
+
+	venue.ormChaining = venue.ormPgSQL.
+		WithContext(ctx).
+		Select(columns+", ST_Distance(location, ?) AS distance", point).
+		Where("deleted_at IS NULL AND ST_DWithin(location, ?, ?)", point, params.GetRadius()).
+		Order("distance, name")
+
+	if err = venue.ormChaining.Find(&getNearbyResp).Error; err != nil {
+		return
+	}
+
+	return
+}
@@ -0,0 +1,174 @@
+package venue
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/handler/venue/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/venue/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	pgsqlConn *gorm.DB
+	mock      sqlmock.Sqlmock
+
+	venue IVenue
+	helper
+	response
+}
+
+type helper struct {
+	db  *sql.DB
+	err error
+}
+
+type response struct {
+	doCreateResp  transporter.DoCreate
+	getVenueResp  transporter.GetVenue
+	doUpdateResp  transporter.DoUpdate
+	getNearbyResp []transporter.GetNearby
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.venue = New(
+		WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+}
+
+// TestDoCreate ...
+func (suite *Suite) TestDoCreate() {
+	latitude, longitude := 53.4631, -2.2913
+	params := param.DoCreate{
+		Venue: param.Venue{
+			Name:      "Old Trafford",
+			City:      "Manchester",
+			Latitude:  &latitude,
+			Longitude: &longitude,
+			Capacity:  74310,
+			Surface:   model.VenueSurfaceHybrid,
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "venues" ("created_at","updated_at","deleted_at","name","city","location","capacity","surface") VALUES ($1,$2,$3,$4,$5,ST_SetSRID(ST_MakePoint($6, $7), 4326)::geography,$8,$9) RETURNING "id"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.Name, params.City, longitude, latitude, params.Capacity, params.Surface).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.response.doCreateResp, suite.helper.err = suite.venue.DoCreate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), latitude, suite.response.doCreateResp.Latitude)
+	require.Equal(suite.T(), longitude, suite.response.doCreateResp.Longitude)
+}
+
+// TestGetVenue ...
+func (suite *Suite) TestGetVenue() {
+	params := param.GetVenue{ID: uuid.NewV4()}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT id, name, city, ST_Y(location::geometry) AS latitude, ST_X(location::geometry) AS longitude, capacity, surface FROM "venues" WHERE id = $1 AND deleted_at IS NULL LIMIT 1`)).
+		WithArgs(params.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "city", "latitude", "longitude", "capacity", "surface"}).
+			AddRow(params.ID, "Anfield", "Liverpool", 53.4308, -2.9608, 53394, model.VenueSurfaceHybrid))
+
+	suite.response.getVenueResp, suite.helper.err = suite.venue.GetVenue(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), "Anfield", suite.response.getVenueResp.Name)
+	require.Equal(suite.T(), 53.4308, suite.response.getVenueResp.Latitude)
+	require.Equal(suite.T(), -2.9608, suite.response.getVenueResp.Longitude)
+}
+
+// TestDoUpdate ...
+func (suite *Suite) TestDoUpdate() {
+	latitude, longitude := 51.5549, -0.1084
+	params := param.DoUpdate{
+		Venue: param.Venue{
+			ID:        uuid.NewV4(),
+			Name:      "Emirates Stadium",
+			City:      "London",
+			Latitude:  &latitude,
+			Longitude: &longitude,
+			Capacity:  60704,
+			Surface:   model.VenueSurfaceHybrid,
+		},
+	}
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "venues" SET "updated_at"=$1,"name"=$2,"city"=$3,"location"=ST_SetSRID(ST_MakePoint($4, $5), 4326)::geography,"capacity"=$6,"surface"=$7 WHERE id = $8`)).
+		WithArgs(sqlmock.AnyArg(), params.Name, params.City, longitude, latitude, params.Capacity, params.Surface, params.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	suite.response.doUpdateResp, suite.helper.err = suite.venue.DoUpdate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), params.ID, suite.response.doUpdateResp.ID)
+	require.Equal(suite.T(), params.Capacity, suite.response.doUpdateResp.Capacity)
+}
+
+// TestGetNearby ...
+func (suite *Suite) TestGetNearby() {
+	params := param.GetNearby{
+		Lat:    "53.4808",
+		Lng:    "-2.2426",
+		Radius: "50000",
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT id, name, city, ST_Y(location::geometry) AS latitude, ST_X(location::geometry) AS longitude, capacity, surface, ST_Distance(location, ST_SetSRID(ST_MakePoint($1, $2), 4326)::geography) AS distance FROM "venues" WHERE deleted_at IS NULL AND ST_DWithin(location, ST_SetSRID(ST_MakePoint($3, $4), 4326)::geography, $5) ORDER BY distance, name`)).
+		WithArgs(-2.2426, 53.4808, -2.2426, 53.4808, 50000.0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "city", "latitude", "longitude", "capacity", "surface", "distance"}).
+			AddRow(uuid.NewV4(), "Etihad Stadium", "Manchester", 53.4831, -2.2004, 53400, model.VenueSurfaceHybrid, 2801.5).
+			AddRow(uuid.NewV4(), "Old Trafford", "Manchester", 53.4631, -2.2913, 74310, model.VenueSurfaceHybrid, 3714.2))
+
+	suite.response.getNearbyResp, suite.helper.err = suite.venue.GetNearby(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Len(suite.T(), suite.response.getNearbyResp, 2)
+	require.Equal(suite.T(), "Etihad Stadium", suite.response.getNearbyResp[0].Name)
+	require.Equal(suite.T(), 2801.5, suite.response.getNearbyResp[0].Distance)
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
@@ -67,6 +67,125 @@ func (s *Server) Router(handler handler.IHandler) (w wrapper.IWrapper) {
 						customrest.WithHandler(handler.GetPlayer().DoDelete),
 					),
 				)
+
+				router.Route("/transfers", func(r chi.Router) {
+					router := r.(wrapper.IWrapper)
+					router.Action(
+						customrest.New(
+							customrest.WithHTTPMethod(http.MethodPost),
+							customrest.WithPattern("/"),
+							customrest.WithHandler(handler.GetTransfer().DoCreate),
+						),
+					)
+
+					router.Action(
+						customrest.New(
+							customrest.WithHTTPMethod(http.MethodGet),
+							customrest.WithPattern("/"),
+							customrest.WithHandler(handler.GetTransfer().GetTransfers),
+						),
+					)
+				})
+
+				router.Action(
+					customrest.New(
+						customrest.WithHTTPMethod(http.MethodGet),
+						customrest.WithPattern("/career"),
+						customrest.WithHandler(handler.GetTransfer().GetCareer),
+					),
+				)
+
+				router.Action(
+					customrest.New(
+						customrest.WithHTTPMethod(http.MethodGet),
+						customrest.WithPattern("/loans"),
+						customrest.WithHandler(handler.GetLoan().GetLoans),
+					),
+				)
+
+				router.Route("/contracts", func(r chi.Router) {
+					router := r.(wrapper.IWrapper)
+					router.Action(
+						customrest.New(
+							customrest.WithHTTPMethod(http.MethodPost),
+							customrest.WithPattern("/"),
+							customrest.WithHandler(handler.GetContract().DoCreate),
+						),
+					)
+
+					router.Action(
+						customrest.New(
+							customrest.WithHTTPMethod(http.MethodGet),
+							customrest.WithPattern("/"),
+							customrest.WithHandler(handler.GetContract().GetContracts),
+						),
+					)
+
+					router.Route("/{contract_id}", func(r chi.Router) {
+						router := r.(wrapper.IWrapper)
+						router.Action(
+							customrest.New(
+								customrest.WithHTTPMethod(http.MethodGet),
+								customrest.WithPattern("/"),
+								customrest.WithHandler(handler.GetContract().GetContract),
+							),
+						)
+
+						router.Action(
+							customrest.New(
+								customrest.WithHTTPMethod(http.MethodPut),
+								customrest.WithPattern("/"),
+								customrest.WithHandler(handler.GetContract().DoUpdate),
+							),
+						)
+					})
+				})
+
+				router.Action(
+					customrest.New(
+						customrest.WithHTTPMethod(http.MethodGet),
+						customrest.WithPattern("/stats"),
+						customrest.WithHandler(handler.GetStat().GetPlayerStats),
+					),
+				)
+
+				router.Route("/injuries", func(r chi.Router) {
+					router := r.(wrapper.IWrapper)
+					router.Action(
+						customrest.New(
+							customrest.WithHTTPMethod(http.MethodPost),
+							customrest.WithPattern("/"),
+							customrest.WithHandler(handler.GetInjury().DoCreate),
+						),
+					)
+
+					router.Action(
+						customrest.New(
+							customrest.WithHTTPMethod(http.MethodGet),
+							customrest.WithPattern("/"),
+							customrest.WithHandler(handler.GetInjury().GetInjuries),
+						),
+					)
+
+					router.Route("/{injury_id}", func(r chi.Router) {
+						router := r.(wrapper.IWrapper)
+						router.Action(
+							customrest.New(
+								customrest.WithHTTPMethod(http.MethodPut),
+								customrest.WithPattern("/"),
+								customrest.WithHandler(handler.GetInjury().DoUpdate),
+							),
+						)
+					})
+				})
+
+				router.Action(
+					customrest.New(
+						customrest.WithHTTPMethod(http.MethodGet),
+						customrest.WithPattern("/suspensions"),
+						customrest.WithHandler(handler.GetSuspension().GetSuspensions),
+					),
+				)
 			})
 		})
 
@@ -114,6 +233,30 @@ func (s *Server) Router(handler handler.IHandler) (w wrapper.IWrapper) {
 					),
 				)
 
+				router.Action(
+					customrest.New(
+						customrest.WithHTTPMethod(http.MethodGet),
+						customrest.WithPattern("/squad-compliance"),
+						customrest.WithHandler(handler.GetSquad().GetCompliance),
+					),
+				)
+
+				router.Action(
+					customrest.New(
+						customrest.WithHTTPMethod(http.MethodGet),
+						customrest.WithPattern("/availability"),
+						customrest.WithHandler(handler.GetAvailability().GetAvailability),
+					),
+				)
+
+				router.Action(
+					customrest.New(
+						customrest.WithHTTPMethod(http.MethodGet),
+						customrest.WithPattern("/travel"),
+						customrest.WithHandler(handler.GetTravel().GetTravel),
+					),
+				)
+
 				router.Route("/players", func(r chi.Router) {
 					router := r.(wrapper.IWrapper)
 					router.Action(
@@ -137,6 +280,567 @@ func (s *Server) Router(handler handler.IHandler) (w wrapper.IWrapper) {
 				})
 			})
 		})
+
+		router.Route("/venues", func(r chi.Router) {
+			router := r.(wrapper.IWrapper)
+			router.Action(
+				customrest.New(
+					customrest.WithHTTPMethod(http.MethodPost),
+					customrest.WithPattern("/"),
+					customrest.WithHandler(handler.GetVenue().DoCreate),
+				),
+			)
+
+			router.Action(
+				customrest.New(
+					customrest.WithHTTPMethod(http.MethodGet),
+					customrest.WithPattern("/nearby"),
+					customrest.WithHandler(handler.GetVenue().GetNearby),
+				),
+			)
+
+			router.Route("/{venue_id}", func(r chi.Router) {
+				router := r.(wrapper.IWrapper)
+				router.Action(
+					customrest.New(
+						customrest.WithHTTPMethod(http.MethodGet),
+						customrest.WithPattern("/"),
+						customrest.WithHandler(handler.GetVenue().GetVenue),
+					),
+				)
+
+				router.Action(
+					customrest.New(
+						customrest.WithHTTPMethod(http.MethodPatch),
+						customrest.WithPattern("/"),
+						customrest.WithHandler(handler.GetVenue().DoUpdate),
+					),
+				)
+			})
+		})
+
+		router.Route("/officials", func(r chi.Router) {
+			router := r.(wrapper.IWrapper)
+			router.Action(
+				customrest.New(
+					customrest.WithHTTPMethod(http.MethodPost),
+					customrest.WithPattern("/"),
+					customrest.WithHandler(handler.GetOfficial().DoCreate),
+				),
+			)
+
+			router.Action(
+				customrest.New(
+					customrest.WithHTTPMethod(http.MethodGet),
+					customrest.WithPattern("/"),
+					customrest.WithHandler(handler.GetOfficial().GetOfficials),
+				),
+			)
+
+			router.Route("/{official_id}", func(r chi.Router) {
+				router := r.(wrapper.IWrapper)
+				router.Action(
+					customrest.New(
+						customrest.WithHTTPMethod(http.MethodGet),
+						customrest.WithPattern("/"),
+						customrest.WithHandler(handler.GetOfficial().GetOfficial),
+					),
+				)
+
+				router.Action(
+					customrest.New(
+						customrest.WithHTTPMethod(http.MethodPatch),
+						customrest.WithPattern("/"),
+						customrest.WithHandler(handler.GetOfficial().DoUpdate),
+					),
+				)
+
+				router.Action(
+					customrest.New(
+						customrest.WithHTTPMethod(http.MethodDelete),
+						customrest.WithPattern("/"),
+						customrest.WithHandler(handler.GetOfficial().DoDelete),
+					),
+				)
+
+				router.Action(
+					customrest.New(
+						customrest.WithHTTPMethod(http.MethodGet),
+						customrest.WithPattern("/stats"),
+						customrest.WithHandler(handler.GetOfficial().GetStats),
+					),
+				)
+			})
+		})
+
+		router.Route("/matches", func(r chi.Router) {
+			router := r.(wrapper.IWrapper)
+			router.Action(
+				customrest.New(
+					customrest.WithHTTPMethod(http.MethodPost),
+					customrest.WithPattern("/"),
+					customrest.WithHandler(handler.GetMatch().DoCreate),
+				),
+			)
+
+			router.Action(
+				customrest.New(
+					customrest.WithHTTPMethod(http.MethodGet),
+					customrest.WithPattern("/"),
+					customrest.WithHandler(handler.GetMatch().GetMatches),
+				),
+			)
+
+			router.Route("/{match_id}", func(r chi.Router) {
+				router := r.(wrapper.IWrapper)
+				router.Action(
+					customrest.New(
+						customrest.WithHTTPMethod(http.MethodGet),
+						customrest.WithPattern("/"),
+						customrest.WithHandler(handler.GetMatch().GetMatch),
+					),
+				)
+
+				router.Action(
+					customrest.New(
+						customrest.WithHTTPMethod(http.MethodPatch),
+						customrest.WithPattern("/"),
+						customrest.WithHandler(handler.GetMatch().DoUpdate),
+					),
+				)
+
+				router.Action(
+					customrest.New(
+						customrest.WithHTTPMethod(http.MethodDelete),
+						customrest.WithPattern("/"),
+						customrest.WithHandler(handler.GetMatch().DoDelete),
+					),
+				)
+
+				router.Action(
+					customrest.New(
+						customrest.WithHTTPMethod(http.MethodPost),
+						customrest.WithPattern("/transitions"),
+						customrest.WithHandler(handler.GetMatch().DoTransition),
+					),
+				)
+
+				router.Action(
+					customrest.New(
+						customrest.WithHTTPMethod(http.MethodGet),
+						customrest.WithPattern("/clock"),
+						customrest.WithHandler(handler.GetMatch().GetClock),
+					),
+				)
+
+				router.Action(
+					customrest.New(
+						customrest.WithHTTPMethod(http.MethodGet),
+						customrest.WithPattern("/winner"),
+						customrest.WithHandler(handler.GetMatch().GetWinner),
+					),
+				)
+
+				router.Route("/events", func(r chi.Router) {
+					router := r.(wrapper.IWrapper)
+					router.Action(
+						customrest.New(
+							customrest.WithHTTPMethod(http.MethodPost),
+							customrest.WithPattern("/"),
+							customrest.WithHandler(handler.GetMatchEvent().DoCreate),
+						),
+					)
+
+					router.Action(
+						customrest.New(
+							customrest.WithHTTPMethod(http.MethodGet),
+							customrest.WithPattern("/"),
+							customrest.WithHandler(handler.GetMatchEvent().GetMatchEvents),
+						),
+					)
+
+					router.Action(
+						customrest.New(
+							customrest.WithHTTPMethod(http.MethodDelete),
+							customrest.WithPattern("/{event_id}"),
+							customrest.WithHandler(handler.GetMatchEvent().DoDelete),
+						),
+					)
+				})
+
+				router.Route("/shootout-kicks", func(r chi.Router) {
+					router := r.(wrapper.IWrapper)
+					router.Action(
+						customrest.New(
+							customrest.WithHTTPMethod(http.MethodPost),
+							customrest.WithPattern("/"),
+							customrest.WithHandler(handler.GetShootoutKick().DoCreate),
+						),
+					)
+
+					router.Action(
+						customrest.New(
+							customrest.WithHTTPMethod(http.MethodGet),
+							customrest.WithPattern("/"),
+							customrest.WithHandler(handler.GetShootoutKick().GetShootoutKicks),
+						),
+					)
+				})
+
+				router.Route("/lineups", func(r chi.Router) {
+					router := r.(wrapper.IWrapper)
+					router.Action(
+						customrest.New(
+							customrest.WithHTTPMethod(http.MethodGet),
+							customrest.WithPattern("/"),
+							customrest.WithHandler(handler.GetLineup().GetLineups),
+						),
+					)
+
+					router.Action(
+						customrest.New(
+							customrest.WithHTTPMethod(http.MethodPut),
+							customrest.WithPattern("/{team_id}"),
+							customrest.WithHandler(handler.GetLineup().DoUpdate),
+						),
+					)
+				})
+
+				router.Route("/officials", func(r chi.Router) {
+					router := r.(wrapper.IWrapper)
+					router.Action(
+						customrest.New(
+							customrest.WithHTTPMethod(http.MethodGet),
+							customrest.WithPattern("/"),
+							customrest.WithHandler(handler.GetOfficial().GetAssignments),
+						),
+					)
+
+					router.Action(
+						customrest.New(
+							customrest.WithHTTPMethod(http.MethodPut),
+							customrest.WithPattern("/"),
+							customrest.WithHandler(handler.GetOfficial().DoAssign),
+						),
+					)
+				})
+			})
+		})
+
+		router.Route("/competitions", func(r chi.Router) {
+			router := r.(wrapper.IWrapper)
+			router.Action(
+				customrest.New(
+					customrest.WithHTTPMethod(http.MethodPost),
+					customrest.WithPattern("/"),
+					customrest.WithHandler(handler.GetCompetition().DoCreate),
+				),
+			)
+
+			router.Action(
+				customrest.New(
+					customrest.WithHTTPMethod(http.MethodGet),
+					customrest.WithPattern("/"),
+					customrest.WithHandler(handler.GetCompetition().GetCompetitions),
+				),
+			)
+
+			router.Route("/{competition_id}", func(r chi.Router) {
+				router := r.(wrapper.IWrapper)
+				router.Action(
+					customrest.New(
+						customrest.WithHTTPMethod(http.MethodGet),
+						customrest.WithPattern("/"),
+						customrest.WithHandler(handler.GetCompetition().GetCompetition),
+					),
+				)
+
+				router.Action(
+					customrest.New(
+						customrest.WithHTTPMethod(http.MethodPatch),
+						customrest.WithPattern("/"),
+						customrest.WithHandler(handler.GetCompetition().DoUpdate),
+					),
+				)
+
+				router.Action(
+					customrest.New(
+						customrest.WithHTTPMethod(http.MethodDelete),
+						customrest.WithPattern("/"),
+						customrest.WithHandler(handler.GetCompetition().DoDelete),
+					),
+				)
+
+				router.Action(
+					customrest.New(
+						customrest.WithHTTPMethod(http.MethodGet),
+						customrest.WithPattern("/referees"),
+						customrest.WithHandler(handler.GetOfficial().GetRankings),
+					),
+				)
+
+				router.Route("/seasons", func(r chi.Router) {
+					router := r.(wrapper.IWrapper)
+					router.Action(
+						customrest.New(
+							customrest.WithHTTPMethod(http.MethodPost),
+							customrest.WithPattern("/"),
+							customrest.WithHandler(handler.GetSeason().DoCreate),
+						),
+					)
+
+					router.Action(
+						customrest.New(
+							customrest.WithHTTPMethod(http.MethodGet),
+							customrest.WithPattern("/"),
+							customrest.WithHandler(handler.GetSeason().GetSeasons),
+						),
+					)
+				})
+			})
+		})
+
+		router.Route("/seasons", func(r chi.Router) {
+			router := r.(wrapper.IWrapper)
+			router.Route("/{season_id}", func(r chi.Router) {
+				router := r.(wrapper.IWrapper)
+				router.Action(
+					customrest.New(
+						customrest.WithHTTPMethod(http.MethodGet),
+						customrest.WithPattern("/"),
+						customrest.WithHandler(handler.GetSeason().GetSeason),
+					),
+				)
+
+				router.Action(
+					customrest.New(
+						customrest.WithHTTPMethod(http.MethodPatch),
+						customrest.WithPattern("/"),
+						customrest.WithHandler(handler.GetSeason().DoUpdate),
+					),
+				)
+
+				router.Action(
+					customrest.New(
+						customrest.WithHTTPMethod(http.MethodDelete),
+						customrest.WithPattern("/"),
+						customrest.WithHandler(handler.GetSeason().DoDelete),
+					),
+				)
+
+				router.Route("/teams", func(r chi.Router) {
+					router := r.(wrapper.IWrapper)
+					router.Action(
+						customrest.New(
+							customrest.WithHTTPMethod(http.MethodPost),
+							customrest.WithPattern("/"),
+							customrest.WithHandler(handler.GetSeason().DoRegisterTeam),
+						),
+					)
+
+					router.Action(
+						customrest.New(
+							customrest.WithHTTPMethod(http.MethodGet),
+							customrest.WithPattern("/"),
+							customrest.WithHandler(handler.GetSeason().GetSeasonTeams),
+						),
+					)
+
+					router.Route("/{team_id}", func(r chi.Router) {
+						router := r.(wrapper.IWrapper)
+						router.Action(
+							customrest.New(
+								customrest.WithHTTPMethod(http.MethodDelete),
+								customrest.WithPattern("/"),
+								customrest.WithHandler(handler.GetSeason().DoUnregisterTeam),
+							),
+						)
+					})
+				})
+
+				router.Route("/standings", func(r chi.Router) {
+					router := r.(wrapper.IWrapper)
+					router.Action(
+						customrest.New(
+							customrest.WithHTTPMethod(http.MethodGet),
+							customrest.WithPattern("/"),
+							customrest.WithHandler(handler.GetStanding().GetStandings),
+						),
+					)
+				})
+
+				router.Route("/fixtures", func(r chi.Router) {
+					router := r.(wrapper.IWrapper)
+					router.Action(
+						customrest.New(
+							customrest.WithHTTPMethod(http.MethodPost),
+							customrest.WithPattern("/generate"),
+							customrest.WithHandler(handler.GetFixture().DoGenerate),
+						),
+					)
+				})
+
+				router.Route("/bracket", func(r chi.Router) {
+					router := r.(wrapper.IWrapper)
+					router.Action(
+						customrest.New(
+							customrest.WithHTTPMethod(http.MethodPost),
+							customrest.WithPattern("/"),
+							customrest.WithHandler(handler.GetBracket().DoGenerate),
+						),
+					)
+
+					router.Action(
+						customrest.New(
+							customrest.WithHTTPMethod(http.MethodGet),
+							customrest.WithPattern("/"),
+							customrest.WithHandler(handler.GetBracket().GetBracket),
+						),
+					)
+				})
+
+				router.Route("/draws", func(r chi.Router) {
+					router := r.(wrapper.IWrapper)
+					router.Action(
+						customrest.New(
+							customrest.WithHTTPMethod(http.MethodPost),
+							customrest.WithPattern("/"),
+							customrest.WithHandler(handler.GetDraw().DoDraw),
+						),
+					)
+
+					router.Action(
+						customrest.New(
+							customrest.WithHTTPMethod(http.MethodGet),
+							customrest.WithPattern("/{draw_id}"),
+							customrest.WithHandler(handler.GetDraw().GetDraw),
+						),
+					)
+				})
+
+				router.Route("/groups", func(r chi.Router) {
+					router := r.(wrapper.IWrapper)
+					router.Action(
+						customrest.New(
+							customrest.WithHTTPMethod(http.MethodPost),
+							customrest.WithPattern("/"),
+							customrest.WithHandler(handler.GetGroup().DoCreate),
+						),
+					)
+
+					router.Action(
+						customrest.New(
+							customrest.WithHTTPMethod(http.MethodGet),
+							customrest.WithPattern("/"),
+							customrest.WithHandler(handler.GetGroup().GetGroups),
+						),
+					)
+
+					router.Action(
+						customrest.New(
+							customrest.WithHTTPMethod(http.MethodGet),
+							customrest.WithPattern("/standings"),
+							customrest.WithHandler(handler.GetGroup().GetGroupStandings),
+						),
+					)
+
+					router.Action(
+						customrest.New(
+							customrest.WithHTTPMethod(http.MethodPut),
+							customrest.WithPattern("/qualification"),
+							customrest.WithHandler(handler.GetGroup().DoUpdateQualification),
+						),
+					)
+
+					router.Action(
+						customrest.New(
+							customrest.WithHTTPMethod(http.MethodGet),
+							customrest.WithPattern("/qualified"),
+							customrest.WithHandler(handler.GetGroup().GetQualifiedTeams),
+						),
+					)
+				})
+
+				router.Route("/transfer-windows", func(r chi.Router) {
+					router := r.(wrapper.IWrapper)
+					router.Action(
+						customrest.New(
+							customrest.WithHTTPMethod(http.MethodPost),
+							customrest.WithPattern("/"),
+							customrest.WithHandler(handler.GetTransfer().DoCreateWindow),
+						),
+					)
+
+					router.Action(
+						customrest.New(
+							customrest.WithHTTPMethod(http.MethodGet),
+							customrest.WithPattern("/"),
+							customrest.WithHandler(handler.GetTransfer().GetWindows),
+						),
+					)
+				})
+
+				router.Route("/squad-rules", func(r chi.Router) {
+					router := r.(wrapper.IWrapper)
+					router.Action(
+						customrest.New(
+							customrest.WithHTTPMethod(http.MethodPut),
+							customrest.WithPattern("/"),
+							customrest.WithHandler(handler.GetSquad().DoUpdateRules),
+						),
+					)
+
+					router.Action(
+						customrest.New(
+							customrest.WithHTTPMethod(http.MethodGet),
+							customrest.WithPattern("/"),
+							customrest.WithHandler(handler.GetSquad().GetRules),
+						),
+					)
+				})
+
+				router.Route("/leaderboards", func(r chi.Router) {
+					router := r.(wrapper.IWrapper)
+					router.Action(
+						customrest.New(
+							customrest.WithHTTPMethod(http.MethodGet),
+							customrest.WithPattern("/{metric}"),
+							customrest.WithHandler(handler.GetLeaderboard().GetLeaderboard),
+						),
+					)
+				})
+			})
+		})
+
+		router.Route("/loans", func(r chi.Router) {
+			router := r.(wrapper.IWrapper)
+			router.Route("/{loan_id}", func(r chi.Router) {
+				router := r.(wrapper.IWrapper)
+				router.Action(
+					customrest.New(
+						customrest.WithHTTPMethod(http.MethodGet),
+						customrest.WithPattern("/"),
+						customrest.WithHandler(handler.GetLoan().GetLoan),
+					),
+				)
+
+				router.Action(
+					customrest.New(
+						customrest.WithHTTPMethod(http.MethodPost),
+						customrest.WithPattern("/recall"),
+						customrest.WithHandler(handler.GetLoan().DoRecall),
+					),
+				)
+
+				router.Action(
+					customrest.New(
+						customrest.WithHTTPMethod(http.MethodPost),
+						customrest.WithPattern("/option"),
+						customrest.WithHandler(handler.GetLoan().DoExerciseOption),
+					),
+				)
+			})
+		})
 	})
 
 	return
@@ -0,0 +1,131 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package availability
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/availability/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/availability/transporter"
+	injuryParam "github.com/harunnryd/skeltun/internal/app/handler/injury/param"
+	suspensionParam "github.com/harunnryd/skeltun/internal/app/handler/suspension/param"
+	teamParam "github.com/harunnryd/skeltun/internal/app/handler/team/param"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	"github.com/harunnryd/skeltun/internal/pkg"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// IAvailability is an interface that stores the methods that Availability struct will use.
+type IAvailability interface {
+	// GetAvailability is used for getting the players of a team who cannot play today and why.
+	// It returns getAvailabilityResp of transporter.GetAvailability and any errors written.
+	GetAvailability(ctx context.Context, params param.GetAvailability) (getAvailabilityResp transporter.GetAvailability, err error)
+
+	// GetAbsences is used for getting the players of a team who cannot play on the given date and why.
+	// It returns getAbsencesResp of []transporter.GetAbsences and any errors written.
+	GetAbsences(ctx context.Context, params param.GetAbsences) (getAbsencesResp []transporter.GetAbsences, err error)
+}
+
+// Availability is an struct that implements IAvailability methods.
+type Availability struct {
+	config config.IConfig
+	repo   repo.IRepo
+	pkg    pkg.IPkg
+}
+
+// New it returns instance of Availability that implements IAvailability methods.
+func New(opts ...Option) IAvailability {
+	a := new(Availability)
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// GetAvailability is used for getting the players of a team who cannot play today and why.
+// It returns getAvailabilityResp of transporter.GetAvailability and any errors written.
+func (availability *Availability) GetAvailability(ctx context.Context, params param.GetAvailability) (getAvailabilityResp transporter.GetAvailability, err error) {
+	params.Date = time.Now()
+
+	getTeamResp, err := availability.repo.GetTeam().GetTeam(ctx, teamParam.GetTeam{ID: params.TeamID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getTeamResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("team not found")}
+		return
+	}
+
+	getAbsencesResp, err := availability.GetAbsences(ctx, param.GetAbsences{TeamID: params.TeamID, Date: params.Date})
+	if err != nil {
+		return
+	}
+
+	getAvailabilityResp.TeamID = params.TeamID
+	getAvailabilityResp.Date = params.Date
+	getAvailabilityResp.Unavailable = make([]transporter.Absence, 0, len(getAbsencesResp))
+	for _, absence := range getAbsencesResp {
+		getAvailabilityResp.Unavailable = append(getAvailabilityResp.Unavailable, absence.Absence)
+	}
+
+	return
+}
+
+// GetAbsences is used for getting the players of a team who cannot play on the given date and why.
+// A player is out while injured, or while a suspension of any season is still to be served,
+// so it is up to the caller to leave out suspensions of other seasons. A player both injured
+// and suspended is listed for each of them.
+// It returns getAbsencesResp of []transporter.GetAbsences and any errors written.
+func (availability *Availability) GetAbsences(ctx context.Context, params param.GetAbsences) (getAbsencesResp []transporter.GetAbsences, err error) {
+	getTeamInjuriesResp, err := availability.repo.GetInjury().GetTeamInjuries(ctx, injuryParam.GetTeamInjuries{
+		TeamID: params.TeamID,
+		Date:   params.Date,
+	})
+	if err != nil {
+		return
+	}
+
+	getTeamSuspensionsResp, err := availability.repo.GetSuspension().GetTeamSuspensions(ctx, suspensionParam.GetTeamSuspensions{
+		TeamID: params.TeamID,
+	})
+	if err != nil {
+		return
+	}
+
+	for _, injury := range getTeamInjuriesResp {
+		getAbsencesResp = append(getAbsencesResp, transporter.GetAbsences{Absence: transporter.Absence{
+			PlayerID:         injury.PlayerID,
+			Name:             injury.Name,
+			Reason:           model.AvailabilityInjured,
+			Detail:           injury.Type,
+			ExpectedReturnAt: injury.ExpectedReturnAt,
+		}})
+	}
+
+	for _, suspension := range getTeamSuspensionsResp {
+		seasonID := suspension.SeasonID
+		getAbsencesResp = append(getAbsencesResp, transporter.GetAbsences{Absence: transporter.Absence{
+			PlayerID:         suspension.PlayerID,
+			Name:             suspension.Name,
+			Reason:           model.AvailabilitySuspended,
+			Detail:           suspension.Reason,
+			SeasonID:         &seasonID,
+			MatchesRemaining: suspension.MatchesRemaining,
+		}})
+	}
+
+	sort.SliceStable(getAbsencesResp, func(i, j int) bool {
+		return getAbsencesResp[i].Name < getAbsencesResp[j].Name
+	})
+
+	return
+}
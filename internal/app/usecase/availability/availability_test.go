@@ -0,0 +1,147 @@
+package availability
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/handler/availability/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/availability/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	iInjuryRepo "github.com/harunnryd/skeltun/internal/app/repo/injury"
+	iSuspensionRepo "github.com/harunnryd/skeltun/internal/app/repo/suspension"
+	iTeamRepo "github.com/harunnryd/skeltun/internal/app/repo/team"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	mock      sqlmock.Sqlmock
+	pgsqlConn *gorm.DB
+
+	iInjuryRepo     iInjuryRepo.IInjury
+	iSuspensionRepo iSuspensionRepo.ISuspension
+	iTeamRepo       iTeamRepo.ITeam
+	iRepo           repo.IRepo
+	availability    IAvailability
+	helper
+	response
+}
+
+type helper struct {
+	err error
+	db  *sql.DB
+}
+
+type response struct {
+	getAvailabilityResp transporter.GetAvailability
+	getAbsencesResp     []transporter.GetAbsences
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.iInjuryRepo = iInjuryRepo.New(
+		iInjuryRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iSuspensionRepo = iSuspensionRepo.New(
+		iSuspensionRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iTeamRepo = iTeamRepo.New(
+		iTeamRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iRepo = repo.New()
+	suite.iRepo.SetInjury(suite.iInjuryRepo)
+	suite.iRepo.SetSuspension(suite.iSuspensionRepo)
+	suite.iRepo.SetTeam(suite.iTeamRepo)
+
+	suite.availability = New(WithRepo(suite.iRepo))
+}
+
+// TestGetAvailabilityTeamNotFound ...
+func (suite *Suite) TestGetAvailabilityTeamNotFound() {
+	params := param.GetAvailability{TeamID: uuid.NewV4()}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "teams" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.TeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.response.getAvailabilityResp, suite.helper.err = suite.availability.GetAvailability(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "team not found")
+}
+
+// TestGetAbsences ...
+func (suite *Suite) TestGetAbsences() {
+	params := param.GetAbsences{
+		TeamID: uuid.NewV4(),
+		Date:   time.Date(2021, time.September, 18, 15, 0, 0, 0, time.UTC),
+	}
+	injuredID, suspendedID, seasonID := uuid.NewV4(), uuid.NewV4(), uuid.NewV4()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT injuries.*, players.name FROM "injuries"`)).
+		WithArgs(params.TeamID, params.Date, params.Date).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "player_id", "type", "expected_return_at", "name"}).
+			AddRow(uuid.NewV4(), injuredID, "knee ligament", nil, "Virgil van Dijk"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT suspensions.*, players.name FROM "suspensions"`)).
+		WithArgs(params.TeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "player_id", "season_id", "reason", "matches_remaining", "name"}).
+			AddRow(uuid.NewV4(), suspendedID, seasonID, model.SuspensionReasonYellowCards, 1, "Fabinho"))
+
+	suite.response.getAbsencesResp, suite.helper.err = suite.availability.GetAbsences(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Len(suite.T(), suite.response.getAbsencesResp, 2)
+	require.Equal(suite.T(), suspendedID, suite.response.getAbsencesResp[0].PlayerID)
+	require.Equal(suite.T(), model.AvailabilitySuspended, suite.response.getAbsencesResp[0].Reason)
+	require.Equal(suite.T(), &seasonID, suite.response.getAbsencesResp[0].SeasonID)
+	require.Equal(suite.T(), injuredID, suite.response.getAbsencesResp[1].PlayerID)
+	require.Equal(suite.T(), model.AvailabilityInjured, suite.response.getAbsencesResp[1].Reason)
+	require.Equal(suite.T(), "knee ligament", suite.response.getAbsencesResp[1].Detail)
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
@@ -0,0 +1,226 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bracket
+
+import (
+	"context"
+	"errors"
+
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/bracket/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/bracket/transporter"
+	groupParam "github.com/harunnryd/skeltun/internal/app/handler/group/param"
+	matchParam "github.com/harunnryd/skeltun/internal/app/handler/match/param"
+	seasonParam "github.com/harunnryd/skeltun/internal/app/handler/season/param"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	"github.com/harunnryd/skeltun/internal/app/usecase/group"
+	"github.com/harunnryd/skeltun/internal/app/usecase/match"
+	"github.com/harunnryd/skeltun/internal/pkg"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// IBracket is an interface that stores the methods that Bracket struct will use.
+type IBracket interface {
+	// DoGenerate is used for drawing the single-elimination bracket of a season from its seeded teams.
+	// It returns doGenerateResp of transporter.DoGenerate and any errors written.
+	DoGenerate(ctx context.Context, params param.DoGenerate) (doGenerateResp transporter.DoGenerate, err error)
+
+	// GetBracket is used for getting every tie of a season bracket, round by round.
+	// It returns getBracketResp of []transporter.GetBracket and any errors written.
+	GetBracket(ctx context.Context, params param.GetBracket) (getBracketResp []transporter.GetBracket, err error)
+
+	// DoAdvance is used for moving the winner of a tie into the next round once all of its legs are finished.
+	// It returns doAdvanceResp of transporter.DoAdvance and any errors written.
+	DoAdvance(ctx context.Context, params param.DoAdvance) (doAdvanceResp transporter.DoAdvance, err error)
+}
+
+// Bracket is an struct that implements IBracket methods.
+type Bracket struct {
+	config config.IConfig
+	repo   repo.IRepo
+	pkg    pkg.IPkg
+	group  group.IGroup
+	match  match.IMatch
+}
+
+// New it returns instance of Bracket that implements IBracket methods.
+func New(opts ...Option) IBracket {
+	b := new(Bracket)
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// DoGenerate is used for drawing the single-elimination bracket of a season from its seeded teams.
+// The registered teams of the season are seeded in the order they are listed when no seeds are given,
+// or the teams qualified out of the groups when FromGroups is set.
+// The first round is played on the start date, or on the season start date when none is given.
+// It returns doGenerateResp of transporter.DoGenerate and any errors written.
+func (bracket *Bracket) DoGenerate(ctx context.Context, params param.DoGenerate) (doGenerateResp transporter.DoGenerate, err error) {
+	getSeasonResp, err := bracket.repo.GetSeason().GetSeason(ctx, seasonParam.GetSeason{ID: params.SeasonID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getSeasonResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("season not found")}
+		return
+	}
+
+	if params.StartDate.IsZero() {
+		params.StartDate = getSeasonResp.StartDate
+	}
+
+	if params.DaysBetweenRounds == 0 {
+		params.DaysBetweenRounds = param.DefaultDaysBetweenRounds
+	}
+
+	getBracketResp, err := bracket.repo.GetBracket().GetBracket(ctx, param.GetBracket{SeasonID: params.SeasonID})
+	if err != nil {
+		return
+	}
+
+	if len(getBracketResp) > 0 {
+		err = &iPkgError.ValidationError{Err: errors.New("season already has a bracket")}
+		return
+	}
+
+	getSeasonTeamsResp, err := bracket.repo.GetSeason().GetSeasonTeams(ctx, seasonParam.GetSeasonTeams{SeasonID: params.SeasonID})
+	if err != nil {
+		return
+	}
+
+	registered := make(map[uuid.UUID]bool, len(getSeasonTeamsResp))
+	for _, team := range getSeasonTeamsResp {
+		registered[team.ID] = true
+	}
+
+	if len(params.Seeds) == 0 && params.FromGroups {
+		getQualifiedTeamsResp, err := bracket.group.GetQualifiedTeams(ctx, groupParam.GetQualifiedTeams{SeasonID: params.SeasonID})
+		if err != nil {
+			return doGenerateResp, err
+		}
+
+		for _, team := range getQualifiedTeamsResp {
+			params.Seeds = append(params.Seeds, team.TeamID)
+		}
+	}
+
+	if len(params.Seeds) == 0 {
+		for _, team := range getSeasonTeamsResp {
+			params.Seeds = append(params.Seeds, team.ID)
+		}
+	}
+
+	if len(params.Seeds) < 2 {
+		err = &iPkgError.ValidationError{Err: errors.New("bracket needs at least two teams")}
+		return
+	}
+
+	seeded := make(map[uuid.UUID]bool, len(params.Seeds))
+	for _, teamID := range params.Seeds {
+		if !registered[teamID] {
+			err = &iPkgError.ValidationError{Err: errors.New("seed " + teamID.String() + " is not registered in the season")}
+			return
+		}
+
+		if seeded[teamID] {
+			err = &iPkgError.ValidationError{Err: errors.New("seed " + teamID.String() + " is listed more than once")}
+			return
+		}
+		seeded[teamID] = true
+	}
+
+	doCreateResp, err := bracket.repo.GetBracket().DoCreate(ctx, param.DoCreate{
+		SeasonID: params.SeasonID,
+		Ties:     draw(params),
+	})
+	if err != nil {
+		return
+	}
+
+	for _, tie := range doCreateResp {
+		doGenerateResp.Ties = append(doGenerateResp.Ties, tie.Tie)
+	}
+
+	return
+}
+
+// GetBracket is used for getting every tie of a season bracket, round by round.
+// It returns getBracketResp of []transporter.GetBracket and any errors written.
+func (bracket *Bracket) GetBracket(ctx context.Context, params param.GetBracket) (getBracketResp []transporter.GetBracket, err error) {
+	getBracketResp, err = bracket.repo.GetBracket().GetBracket(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// DoAdvance is used for moving the winner of a tie into the next round once all of its legs are finished.
+// A single leg tie goes to the winner of its match, a two-legged one is decided on aggregate.
+// Nothing happens while a leg is still to be played or when the tie has no winner yet.
+// It returns doAdvanceResp of transporter.DoAdvance and any errors written.
+func (bracket *Bracket) DoAdvance(ctx context.Context, params param.DoAdvance) (doAdvanceResp transporter.DoAdvance, err error) {
+	getTieResp, err := bracket.repo.GetBracket().GetTie(ctx, param.GetTie{ID: params.TieID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getTieResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("tie not found")}
+		return
+	}
+
+	if getTieResp.WinnerTeamID != nil {
+		return
+	}
+
+	getTieMatchesResp, err := bracket.repo.GetMatch().GetTieMatches(ctx, matchParam.GetTieMatches{TieID: params.TieID})
+	if err != nil {
+		return
+	}
+
+	if len(getTieMatchesResp) < getTieResp.Legs {
+		return
+	}
+
+	for _, match := range getTieMatchesResp {
+		if match.Status != model.MatchStatusFinished {
+			return
+		}
+	}
+
+	var winnerTeamID *uuid.UUID
+	if len(getTieMatchesResp) == 1 {
+		getWinnerResp, err := bracket.match.GetWinner(ctx, matchParam.GetWinner{MatchID: getTieMatchesResp[0].ID})
+		if err != nil {
+			return doAdvanceResp, err
+		}
+
+		winnerTeamID = getWinnerResp.WinnerTeamID
+	} else {
+		winnerTeamID = aggregate(getTieResp.Tie, getTieMatchesResp)
+	}
+
+	if winnerTeamID == nil {
+		return
+	}
+
+	params.SeasonID = getTieResp.SeasonID
+	params.Round = getTieResp.Round
+	params.Position = getTieResp.Position
+	params.WinnerTeamID = *winnerTeamID
+
+	doAdvanceResp, err = bracket.repo.GetBracket().DoAdvance(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
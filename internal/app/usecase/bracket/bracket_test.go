@@ -0,0 +1,527 @@
+package bracket
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/handler/bracket/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/bracket/transporter"
+	groupParam "github.com/harunnryd/skeltun/internal/app/handler/group/param"
+	groupTransporter "github.com/harunnryd/skeltun/internal/app/handler/group/transporter"
+	matchTransporter "github.com/harunnryd/skeltun/internal/app/handler/match/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	iBracketRepo "github.com/harunnryd/skeltun/internal/app/repo/bracket"
+	iMatchRepo "github.com/harunnryd/skeltun/internal/app/repo/match"
+	iSeasonRepo "github.com/harunnryd/skeltun/internal/app/repo/season"
+	"github.com/harunnryd/skeltun/internal/app/usecase/group"
+	"github.com/harunnryd/skeltun/internal/app/usecase/match"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	mock      sqlmock.Sqlmock
+	pgsqlConn *gorm.DB
+
+	iBracketRepo iBracketRepo.IBracket
+	iMatchRepo   iMatchRepo.IMatch
+	iSeasonRepo  iSeasonRepo.ISeason
+	iRepo        repo.IRepo
+	group        *fakeGroup
+	bracket      IBracket
+	helper
+	response
+}
+
+type helper struct {
+	err error
+	db  *sql.DB
+}
+
+type response struct {
+	doGenerateResp transporter.DoGenerate
+	doAdvanceResp  transporter.DoAdvance
+}
+
+// fakeGroup returns the qualified teams it is given instead of computing them from the group tables.
+type fakeGroup struct {
+	group.IGroup
+	qualified []uuid.UUID
+}
+
+func (group *fakeGroup) GetQualifiedTeams(ctx context.Context, params groupParam.GetQualifiedTeams) (getQualifiedTeamsResp []groupTransporter.GetQualifiedTeams, err error) {
+	for i, teamID := range group.qualified {
+		getQualifiedTeamsResp = append(getQualifiedTeamsResp, groupTransporter.GetQualifiedTeams{
+			QualifiedTeam: groupTransporter.QualifiedTeam{Seed: i + 1, TeamID: teamID},
+		})
+	}
+	return
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.iBracketRepo = iBracketRepo.New(
+		iBracketRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iMatchRepo = iMatchRepo.New(
+		iMatchRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iSeasonRepo = iSeasonRepo.New(
+		iSeasonRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iRepo = repo.New()
+	suite.iRepo.SetBracket(suite.iBracketRepo)
+	suite.iRepo.SetMatch(suite.iMatchRepo)
+	suite.iRepo.SetSeason(suite.iSeasonRepo)
+
+	suite.group = new(fakeGroup)
+
+	suite.bracket = New(WithRepo(suite.iRepo), WithGroup(suite.group), WithMatch(match.New(match.WithRepo(suite.iRepo))))
+}
+
+// TestDoGenerate ...
+func (suite *Suite) TestDoGenerate() {
+	arsenalID, chelseaID, evertonID := uuid.NewV4(), uuid.NewV4(), uuid.NewV4()
+	params := param.DoGenerate{
+		SeasonID:  uuid.NewV4(),
+		Seeds:     []uuid.UUID{chelseaID, arsenalID, evertonID},
+		Legs:      param.LegsSingle,
+		StartDate: time.Date(2021, 1, 9, 15, 0, 0, 0, time.UTC),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seasons" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(params.SeasonID, "2020/21"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "bracket_ties" WHERE season_id = $1 ORDER BY round, position`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT "teams"."id","teams"."name","teams"."association" FROM "teams" JOIN season_teams ON season_teams.team_id = teams.id WHERE season_teams.season_id = $1 ORDER BY teams.name`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(arsenalID, "Arsenal").
+			AddRow(chelseaID, "Chelsea").
+			AddRow(evertonID, "Everton"))
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "bracket_ties"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()).
+			AddRow(uuid.NewV4()).
+			AddRow(uuid.NewV4()))
+
+	// Chelsea gets the bye, only Arsenal against Everton can be played straight away.
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "matches"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.SeasonID, 1, arsenalID, evertonID, params.StartDate, "", nil, model.MatchStatusScheduled, 0, 0, 0, 0, 0, 0, sqlmock.AnyArg(), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.mock.ExpectCommit()
+
+	suite.response.doGenerateResp, suite.helper.err = suite.bracket.DoGenerate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Len(suite.T(), suite.response.doGenerateResp.Ties, 3)
+	require.Equal(suite.T(), chelseaID, *suite.response.doGenerateResp.Ties[0].WinnerTeamID)
+	require.Equal(suite.T(), chelseaID, *suite.response.doGenerateResp.Ties[2].HomeTeamID)
+}
+
+// TestDoGenerateFromGroups ...
+func (suite *Suite) TestDoGenerateFromGroups() {
+	arsenalID, chelseaID, evertonID := uuid.NewV4(), uuid.NewV4(), uuid.NewV4()
+	params := param.DoGenerate{
+		SeasonID:   uuid.NewV4(),
+		FromGroups: true,
+		Legs:       param.LegsSingle,
+		StartDate:  time.Date(2021, 5, 29, 20, 0, 0, 0, time.UTC),
+	}
+
+	// Chelsea did not make it out of the groups.
+	suite.group.qualified = []uuid.UUID{evertonID, arsenalID}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seasons" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(params.SeasonID, "2020/21"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "bracket_ties" WHERE season_id = $1 ORDER BY round, position`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT "teams"."id","teams"."name","teams"."association" FROM "teams" JOIN season_teams ON season_teams.team_id = teams.id WHERE season_teams.season_id = $1 ORDER BY teams.name`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(arsenalID, "Arsenal").
+			AddRow(chelseaID, "Chelsea").
+			AddRow(evertonID, "Everton"))
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "bracket_ties"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "matches"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.SeasonID, 1, evertonID, arsenalID, params.StartDate, "", nil, model.MatchStatusScheduled, 0, 0, 0, 0, 0, 0, sqlmock.AnyArg(), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.mock.ExpectCommit()
+
+	suite.response.doGenerateResp, suite.helper.err = suite.bracket.DoGenerate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Len(suite.T(), suite.response.doGenerateResp.Ties, 1)
+}
+
+// TestDoGenerateUnregisteredSeed ...
+func (suite *Suite) TestDoGenerateUnregisteredSeed() {
+	arsenalID, outsiderID := uuid.NewV4(), uuid.NewV4()
+	params := param.DoGenerate{
+		SeasonID: uuid.NewV4(),
+		Seeds:    []uuid.UUID{arsenalID, outsiderID},
+		Legs:     param.LegsSingle,
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seasons" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(params.SeasonID, "2020/21"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "bracket_ties" WHERE season_id = $1 ORDER BY round, position`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT "teams"."id","teams"."name","teams"."association" FROM "teams" JOIN season_teams ON season_teams.team_id = teams.id WHERE season_teams.season_id = $1 ORDER BY teams.name`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(arsenalID, "Arsenal").
+			AddRow(uuid.NewV4(), "Chelsea"))
+
+	suite.response.doGenerateResp, suite.helper.err = suite.bracket.DoGenerate(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "seed "+outsiderID.String()+" is not registered in the season")
+}
+
+// TestDoAdvance ...
+func (suite *Suite) TestDoAdvance() {
+	homeTeamID, awayTeamID := uuid.NewV4(), uuid.NewV4()
+	params := param.DoAdvance{
+		TieID: uuid.NewV4(),
+	}
+	seasonID := uuid.NewV4()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "bracket_ties" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.TieID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "season_id", "round", "position", "legs", "home_team_id", "away_team_id"}).
+			AddRow(params.TieID, seasonID, 2, 0, 2, homeTeamID, awayTeamID))
+
+	// 1-2 away and 1-0 at home: level on aggregate, the home side wins the shootout of the second leg.
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE tie_id = $1 ORDER BY leg`)).
+		WithArgs(params.TieID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "home_team_id", "away_team_id", "status", "home_score", "away_score", "home_shootout_score", "away_shootout_score", "leg"}).
+			AddRow(uuid.NewV4(), homeTeamID, awayTeamID, model.MatchStatusFinished, 1, 2, 0, 0, 1).
+			AddRow(uuid.NewV4(), awayTeamID, homeTeamID, model.MatchStatusFinished, 0, 1, 3, 4, 2))
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "bracket_ties" SET "updated_at"=$1,"winner_team_id"=$2 WHERE id = $3 AND winner_team_id IS NULL`)).
+		WithArgs(sqlmock.AnyArg(), homeTeamID, params.TieID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "bracket_ties" WHERE season_id = $1 AND round = $2 AND position = $3 LIMIT 1 FOR UPDATE`)).
+		WithArgs(seasonID, 3, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.mock.ExpectCommit()
+
+	suite.response.doAdvanceResp, suite.helper.err = suite.bracket.DoAdvance(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), homeTeamID, suite.response.doAdvanceResp.WinnerTeamID)
+}
+
+// TestDoAdvanceSingleLeg ...
+func (suite *Suite) TestDoAdvanceSingleLeg() {
+	homeTeamID, awayTeamID := uuid.NewV4(), uuid.NewV4()
+	params := param.DoAdvance{
+		TieID: uuid.NewV4(),
+	}
+	seasonID, matchID := uuid.NewV4(), uuid.NewV4()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "bracket_ties" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.TieID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "season_id", "round", "position", "legs", "home_team_id", "away_team_id"}).
+			AddRow(params.TieID, seasonID, 1, 1, 1, homeTeamID, awayTeamID))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE tie_id = $1 ORDER BY leg`)).
+		WithArgs(params.TieID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "home_team_id", "away_team_id", "status", "leg"}).
+			AddRow(matchID, homeTeamID, awayTeamID, model.MatchStatusFinished, 1))
+
+	// 1-1 after regular time, the away side scores the only goal of extra time.
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE id = $1 LIMIT 1`)).
+		WithArgs(matchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "home_team_id", "away_team_id", "status", "home_score", "away_score", "home_extra_time_score", "away_extra_time_score"}).
+			AddRow(matchID, homeTeamID, awayTeamID, model.MatchStatusFinished, 1, 1, 0, 1))
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "bracket_ties" SET "updated_at"=$1,"winner_team_id"=$2 WHERE id = $3 AND winner_team_id IS NULL`)).
+		WithArgs(sqlmock.AnyArg(), awayTeamID, params.TieID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "bracket_ties" WHERE season_id = $1 AND round = $2 AND position = $3 LIMIT 1 FOR UPDATE`)).
+		WithArgs(seasonID, 2, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.mock.ExpectCommit()
+
+	suite.response.doAdvanceResp, suite.helper.err = suite.bracket.DoAdvance(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), awayTeamID, suite.response.doAdvanceResp.WinnerTeamID)
+}
+
+// TestDoAdvanceLegToPlay ...
+func (suite *Suite) TestDoAdvanceLegToPlay() {
+	homeTeamID, awayTeamID := uuid.NewV4(), uuid.NewV4()
+	params := param.DoAdvance{
+		TieID: uuid.NewV4(),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "bracket_ties" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.TieID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "round", "position", "legs", "home_team_id", "away_team_id"}).
+			AddRow(params.TieID, 1, 0, 2, homeTeamID, awayTeamID))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE tie_id = $1 ORDER BY leg`)).
+		WithArgs(params.TieID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "home_team_id", "away_team_id", "status", "home_score", "away_score", "leg"}).
+			AddRow(uuid.NewV4(), homeTeamID, awayTeamID, model.MatchStatusFinished, 3, 0, 1).
+			AddRow(uuid.NewV4(), awayTeamID, homeTeamID, model.MatchStatusScheduled, 0, 0, 2))
+
+	suite.response.doAdvanceResp, suite.helper.err = suite.bracket.DoAdvance(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.True(suite.T(), uuid.Equal(uuid.Nil, suite.response.doAdvanceResp.TieID))
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+// TestSeedOrder ...
+func TestSeedOrder(t *testing.T) {
+	require.Equal(t, []int{1}, seedOrder(1))
+	require.Equal(t, []int{1, 2}, seedOrder(2))
+	require.Equal(t, []int{1, 4, 2, 3}, seedOrder(4))
+	require.Equal(t, []int{1, 8, 4, 5, 2, 7, 3, 6}, seedOrder(8))
+}
+
+// TestDraw ...
+func TestDraw(t *testing.T) {
+	tests := []struct {
+		name   string
+		teams  int
+		legs   int
+		ties   int
+		byes   int
+		rounds int
+	}{
+		{name: "two teams", teams: 2, legs: param.LegsDouble, ties: 1, byes: 0, rounds: 1},
+		{name: "power of two", teams: 8, legs: param.LegsSingle, ties: 7, byes: 0, rounds: 3},
+		{name: "byes for the best seeds", teams: 5, legs: param.LegsSingle, ties: 7, byes: 3, rounds: 3},
+		{name: "two legs with byes", teams: 12, legs: param.LegsDouble, ties: 15, byes: 4, rounds: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := param.DoGenerate{
+				Legs:              tt.legs,
+				StartDate:         time.Date(2021, 1, 9, 15, 0, 0, 0, time.UTC),
+				DaysBetweenRounds: 7,
+			}
+			for i := 0; i < tt.teams; i++ {
+				params.Seeds = append(params.Seeds, uuid.NewV4())
+			}
+
+			ties := draw(params)
+			require.Len(t, ties, tt.ties)
+
+			byes := 0
+			drawn := make(map[uuid.UUID]bool)
+			for _, tie := range ties {
+				require.True(t, tie.Round >= 1 && tie.Round <= tt.rounds)
+
+				if tie.Round == tt.rounds {
+					require.Equal(t, param.LegsSingle, tie.Legs, "the final is a single match")
+					require.Nil(t, tie.SecondLegAt)
+				} else {
+					require.Equal(t, tt.legs, tie.Legs)
+					require.Equal(t, tt.legs == param.LegsDouble, tie.SecondLegAt != nil)
+				}
+
+				if tie.Round != 1 {
+					continue
+				}
+
+				if tie.AwayTeamID == nil {
+					byes++
+					require.Equal(t, tie.HomeTeamID, tie.WinnerTeamID)
+				}
+
+				for _, teamID := range []*uuid.UUID{tie.HomeTeamID, tie.AwayTeamID} {
+					if teamID != nil {
+						require.False(t, drawn[*teamID], "team drawn twice")
+						drawn[*teamID] = true
+					}
+				}
+			}
+
+			require.Equal(t, tt.byes, byes)
+			require.Len(t, drawn, tt.teams)
+
+			// The best seeds get the byes.
+			for i := 0; i < tt.byes; i++ {
+				found := false
+				for _, tie := range ties[:len(ties)/2+1] {
+					if tie.WinnerTeamID != nil && uuid.Equal(*tie.WinnerTeamID, params.Seeds[i]) {
+						found = true
+					}
+				}
+				require.True(t, found, "seed %d should get a bye", i+1)
+			}
+		})
+	}
+}
+
+// TestAggregate ...
+func TestAggregate(t *testing.T) {
+	homeTeamID, awayTeamID := uuid.NewV4(), uuid.NewV4()
+	tie := transporter.Tie{HomeTeamID: &homeTeamID, AwayTeamID: &awayTeamID}
+	leg := func(home, away uuid.UUID, homeScore, awayScore, homeExtraTimeScore, awayExtraTimeScore, homeShootoutScore, awayShootoutScore int) matchTransporter.GetTieMatches {
+		return matchTransporter.GetTieMatches{Match: matchTransporter.Match{
+			HomeTeamID:         home,
+			AwayTeamID:         away,
+			HomeScore:          homeScore,
+			AwayScore:          awayScore,
+			HomeExtraTimeScore: homeExtraTimeScore,
+			AwayExtraTimeScore: awayExtraTimeScore,
+			HomeShootoutScore:  homeShootoutScore,
+			AwayShootoutScore:  awayShootoutScore,
+		}}
+	}
+
+	tests := []struct {
+		name    string
+		matches []matchTransporter.GetTieMatches
+		winner  *uuid.UUID
+	}{
+		{
+			name: "two legs on aggregate",
+			matches: []matchTransporter.GetTieMatches{
+				leg(homeTeamID, awayTeamID, 2, 0, 0, 0, 0, 0),
+				leg(awayTeamID, homeTeamID, 2, 1, 0, 0, 0, 0),
+			},
+			winner: &homeTeamID,
+		},
+		{
+			name: "two legs with extra time in the second leg",
+			matches: []matchTransporter.GetTieMatches{
+				leg(homeTeamID, awayTeamID, 1, 0, 0, 0, 0, 0),
+				leg(awayTeamID, homeTeamID, 1, 0, 1, 0, 0, 0),
+			},
+			winner: &awayTeamID,
+		},
+		{
+			name: "two legs on penalties",
+			matches: []matchTransporter.GetTieMatches{
+				leg(homeTeamID, awayTeamID, 1, 1, 0, 0, 0, 0),
+				leg(awayTeamID, homeTeamID, 2, 2, 0, 0, 5, 4),
+			},
+			winner: &awayTeamID,
+		},
+		{
+			name: "still level",
+			matches: []matchTransporter.GetTieMatches{
+				leg(homeTeamID, awayTeamID, 1, 0, 0, 0, 0, 0),
+				leg(awayTeamID, homeTeamID, 1, 0, 0, 0, 0, 0),
+			},
+			winner: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.winner, aggregate(tie, tt.matches))
+		})
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bracket
+
+import (
+	"github.com/harunnryd/skeltun/internal/app/handler/bracket/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/bracket/transporter"
+	matchTransporter "github.com/harunnryd/skeltun/internal/app/handler/match/transporter"
+	"github.com/satori/uuid"
+)
+
+// seedOrder is used for listing the seeds of a bracket of the given size in the order they are drawn,
+// two by two for every tie of the first round. Seeds are placed so that the best two can only meet
+// in the final, the best four in the semi-finals and so on.
+func seedOrder(size int) (order []int) {
+	order = []int{1}
+	for len(order) < size {
+		next := make([]int, 0, 2*len(order))
+		for _, seed := range order {
+			next = append(next, seed, 2*len(order)+1-seed)
+		}
+		order = next
+	}
+	return
+}
+
+// draw is used for building every tie of a single-elimination bracket out of the seeded teams.
+// The bracket is grown to the next power of two, the missing teams are byes given to the best seeds,
+// whose winners are moved into the second round straight away. With two legs every tie but the final
+// is played over two matches, the second one DaysBetweenRounds after the first.
+func draw(params param.DoGenerate) (ties []param.Tie) {
+	size, rounds := 1, 0
+	for size < len(params.Seeds) {
+		size *= 2
+		rounds++
+	}
+
+	kickoffAt := params.StartDate
+	for round := 1; round <= rounds; round++ {
+		legs := params.Legs
+		if round == rounds {
+			legs = param.LegsSingle
+		}
+
+		for position := 0; position < size>>round; position++ {
+			tie := param.Tie{
+				SeasonID:   params.SeasonID,
+				Round:      round,
+				Position:   position,
+				Legs:       legs,
+				FirstLegAt: kickoffAt,
+			}
+
+			if legs == param.LegsDouble {
+				secondLegAt := kickoffAt.AddDate(0, 0, params.DaysBetweenRounds)
+				tie.SecondLegAt = &secondLegAt
+			}
+
+			ties = append(ties, tie)
+		}
+
+		kickoffAt = kickoffAt.AddDate(0, 0, legs*params.DaysBetweenRounds)
+	}
+
+	order := seedOrder(size)
+	for position := 0; position < size/2; position++ {
+		tie := &ties[position]
+		tie.HomeTeamID = seed(params.Seeds, order[2*position])
+		tie.AwayTeamID = seed(params.Seeds, order[2*position+1])
+
+		if tie.AwayTeamID == nil {
+			tie.WinnerTeamID = tie.HomeTeamID
+
+			next := &ties[size/2+position/2]
+			if position%2 == 0 {
+				next.HomeTeamID = tie.WinnerTeamID
+			} else {
+				next.AwayTeamID = tie.WinnerTeamID
+			}
+		}
+	}
+
+	return
+}
+
+// seed is used for getting the team drawn with the given seed, or nil when the seed is a bye.
+func seed(seeds []uuid.UUID, number int) *uuid.UUID {
+	if number > len(seeds) {
+		return nil
+	}
+
+	teamID := seeds[number-1]
+	return &teamID
+}
+
+// aggregate is used for deciding the winner of a two-legged tie once both legs are finished,
+// a single leg is decided by the match itself. Goals of both legs are added up, extra time
+// included, and a level tie goes to the winner of the shootout played at the end of the
+// second leg. It returns nil when the tie is still level.
+func aggregate(tie transporter.Tie, matches []matchTransporter.GetTieMatches) *uuid.UUID {
+	if tie.HomeTeamID == nil || tie.AwayTeamID == nil || len(matches) == 0 {
+		return nil
+	}
+
+	goals := make(map[uuid.UUID]int, 2)
+	for _, match := range matches {
+		goals[match.HomeTeamID] += match.HomeScore + match.HomeExtraTimeScore
+		goals[match.AwayTeamID] += match.AwayScore + match.AwayExtraTimeScore
+	}
+
+	last := matches[len(matches)-1]
+	shootout := map[uuid.UUID]int{
+		last.HomeTeamID: last.HomeShootoutScore,
+		last.AwayTeamID: last.AwayShootoutScore,
+	}
+
+	home, away := *tie.HomeTeamID, *tie.AwayTeamID
+	switch {
+	case goals[home] > goals[away]:
+		return &home
+	case goals[home] < goals[away]:
+		return &away
+	case shootout[home] > shootout[away]:
+		return &home
+	case shootout[home] < shootout[away]:
+		return &away
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bracket
+
+import (
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	"github.com/harunnryd/skeltun/internal/app/usecase/group"
+	"github.com/harunnryd/skeltun/internal/app/usecase/match"
+	"github.com/harunnryd/skeltun/internal/pkg"
+)
+
+// Option is a closure that is used for accessing the local variables.
+type Option func(bracket *Bracket)
+
+// WithConfig ...
+func WithConfig(config config.IConfig) Option {
+	return func(bracket *Bracket) {
+		bracket.config = config
+	}
+}
+
+// WithRepo ...
+func WithRepo(repo repo.IRepo) Option {
+	return func(bracket *Bracket) {
+		bracket.repo = repo
+	}
+}
+
+// WithPkg ...
+func WithPkg(pkg pkg.IPkg) Option {
+	return func(bracket *Bracket) {
+		bracket.pkg = pkg
+	}
+}
+
+// WithGroup ...
+func WithGroup(group group.IGroup) Option {
+	return func(bracket *Bracket) {
+		bracket.group = group
+	}
+}
+
+// WithMatch ...
+func WithMatch(match match.IMatch) Option {
+	return func(bracket *Bracket) {
+		bracket.match = match
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package competition
+
+import (
+	"context"
+
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/competition/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/competition/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	"github.com/harunnryd/skeltun/internal/pkg"
+)
+
+// ICompetition is an interface that stores the methods that Competition struct will use.
+// ICompetition is an interface that stores the methods that Competition struct will use.
+type ICompetition interface {
+	// DoCreate is used for record new competition.
+	// It returns doCreateResp of transporter.DoCreate and any errors written.
+	DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error)
+
+	// GetCompetitions is used for getting all competitions with seasons.
+	// It returns getCompetitionsResp of []transporter.GetCompetitions and any errors written.
+	GetCompetitions(ctx context.Context, params param.GetCompetitions) (getCompetitionsResp []transporter.GetCompetitions, err error)
+
+	// DoUpdate is used for update the record competition.
+	// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+	DoUpdate(ctx context.Context, params param.DoUpdate) (doUpdateResp transporter.DoUpdate, err error)
+
+	// DoDelete is used for delete the record competition.
+	// It returns doDeleteResp of transporter.DoDelete and any errors written.
+	DoDelete(ctx context.Context, params param.DoDelete) (doDeleteResp transporter.DoDelete, err error)
+
+	// GetCompetition is used for getting an competition with seasons.
+	// It returns getCompetitionResp of transporter.GetCompetition and any errors written.
+	GetCompetition(ctx context.Context, params param.GetCompetition) (getCompetitionResp transporter.GetCompetition, err error)
+}
+
+// Competition is an struct that implements ICompetition methods.
+type Competition struct {
+	config config.IConfig
+	repo   repo.IRepo
+	pkg    pkg.IPkg
+}
+
+// New it returns instance of Competition that implements ICompetition methods.
+func New(opts ...Option) ICompetition {
+	c := new(Competition)
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// DoCreate is used for record new competition.
+// It returns doCreateResp of transporter.DoCreate and any errors written.
+func (competition *Competition) DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error) {
+	if len(params.Tiebreakers) == 0 {
+		params.Tiebreakers = model.DefaultTiebreakers
+	}
+
+	doCreateResp, err = competition.repo.GetCompetition().DoCreate(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// GetCompetitions is used for getting all competitions with seasons.
+// It returns getCompetitionsResp of []transporter.GetCompetitions and any errors written.
+func (competition *Competition) GetCompetitions(ctx context.Context, params param.GetCompetitions) (getCompetitionsResp []transporter.GetCompetitions, err error) {
+	getCompetitionsResp, err = competition.repo.GetCompetition().GetCompetitions(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// DoUpdate is used for update the record competition.
+// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+func (competition *Competition) DoUpdate(ctx context.Context, params param.DoUpdate) (doUpdateResp transporter.DoUpdate, err error) {
+	doUpdateResp, err = competition.repo.GetCompetition().DoUpdate(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// DoDelete is used for delete the record competition.
+// It returns doDeleteResp of transporter.DoDelete and any errors written.
+func (competition *Competition) DoDelete(ctx context.Context, params param.DoDelete) (doDeleteResp transporter.DoDelete, err error) {
+	doDeleteResp, err = competition.repo.GetCompetition().DoDelete(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// GetCompetition is used for getting an competition with seasons.
+// It returns getCompetitionResp of transporter.GetCompetition and any errors written.
+func (competition *Competition) GetCompetition(ctx context.Context, params param.GetCompetition) (getCompetitionResp transporter.GetCompetition, err error) {
+	getCompetitionResp, err = competition.repo.GetCompetition().GetCompetition(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
@@ -0,0 +1,270 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package contract
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/contract/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/contract/transporter"
+	playerParam "github.com/harunnryd/skeltun/internal/app/handler/player/param"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	"github.com/harunnryd/skeltun/internal/pkg"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// IContract is an interface that stores the methods that Contract struct will use.
+type IContract interface {
+	// DoCreate is used for record new contract of a player with the current team.
+	// It returns doCreateResp of transporter.DoCreate and any errors written.
+	DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error)
+
+	// GetContracts is used for getting all contracts of a player, the oldest first.
+	// It returns getContractsResp of []transporter.GetContracts and any errors written.
+	GetContracts(ctx context.Context, params param.GetContracts) (getContractsResp []transporter.GetContracts, err error)
+
+	// GetContract is used for getting a contract of a player.
+	// It returns getContractResp of transporter.GetContract and any errors written.
+	GetContract(ctx context.Context, params param.GetContract) (getContractResp transporter.GetContract, err error)
+
+	// DoUpdate is used for update the record contract.
+	// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+	DoUpdate(ctx context.Context, params param.DoUpdate) (doUpdateResp transporter.DoUpdate, err error)
+
+	// CheckSalaryCap is used for making sure a team stays under its salary caps with an extra wage to pay.
+	// It returns any errors written.
+	CheckSalaryCap(ctx context.Context, params param.CheckSalaryCap) (err error)
+
+	// CheckContract is used for making sure a player holds a valid contract with a team.
+	// It returns any errors written.
+	CheckContract(ctx context.Context, params param.CheckContract) (err error)
+}
+
+// Contract is an struct that implements IContract methods.
+type Contract struct {
+	config config.IConfig
+	repo   repo.IRepo
+	pkg    pkg.IPkg
+}
+
+// New it returns instance of Contract that implements IContract methods.
+func New(opts ...Option) IContract {
+	c := new(Contract)
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// DoCreate is used for record new contract of a player with the current team.
+// A contract overlapping another active contract of the player, or pushing
+// the team over a salary cap, is rejected.
+// It returns doCreateResp of transporter.DoCreate and any errors written.
+func (contract *Contract) DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error) {
+	getPlayerResp, err := contract.repo.GetPlayer().GetPlayer(ctx, playerParam.GetPlayer{ID: params.PlayerID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getPlayerResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("player not found")}
+		return
+	}
+
+	if uuid.Equal(getPlayerResp.TeamID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("player without a team cannot sign a contract")}
+		return
+	}
+
+	params.TeamID = getPlayerResp.TeamID
+
+	if err = contract.checkOverlap(ctx, params.Contract); err != nil {
+		return
+	}
+
+	if err = contract.CheckSalaryCap(ctx, param.CheckSalaryCap{
+		TeamID: params.TeamID,
+		Date:   params.StartsAt,
+		Wage:   params.Wage,
+	}); err != nil {
+		return
+	}
+
+	doCreateResp, err = contract.repo.GetContract().DoCreate(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// GetContracts is used for getting all contracts of a player, the oldest first.
+// It returns getContractsResp of []transporter.GetContracts and any errors written.
+func (contract *Contract) GetContracts(ctx context.Context, params param.GetContracts) (getContractsResp []transporter.GetContracts, err error) {
+	getContractsResp, err = contract.repo.GetContract().GetContracts(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// GetContract is used for getting a contract of a player.
+// It returns getContractResp of transporter.GetContract and any errors written.
+func (contract *Contract) GetContract(ctx context.Context, params param.GetContract) (getContractResp transporter.GetContract, err error) {
+	getContractResp, err = contract.repo.GetContract().GetContract(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// DoUpdate is used for update the record contract.
+// A contract binding the player today cannot stop doing so, the player would be left without a valid contract.
+// An active contract is checked against the other contracts of the player and the salary caps
+// the same way a new one is, counting its new wage instead of the old one.
+// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+func (contract *Contract) DoUpdate(ctx context.Context, params param.DoUpdate) (doUpdateResp transporter.DoUpdate, err error) {
+	getContractResp, err := contract.repo.GetContract().GetContract(ctx, param.GetContract{ID: params.ID, PlayerID: params.PlayerID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getContractResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("contract not found")}
+		return
+	}
+
+	params.TeamID = getContractResp.TeamID
+
+	now := time.Now()
+	if validOn(getContractResp.Status, getContractResp.StartsAt, getContractResp.EndsAt, now) &&
+		!validOn(params.Status, params.StartsAt, params.EndsAt, now) {
+		err = &iPkgError.ValidationError{Err: errors.New("player cannot be left without a valid contract")}
+		return
+	}
+
+	if params.Status == model.ContractStatusActive {
+		if err = contract.checkOverlap(ctx, params.Contract); err != nil {
+			return
+		}
+
+		if err = contract.CheckSalaryCap(ctx, param.CheckSalaryCap{
+			TeamID:    params.TeamID,
+			Date:      params.StartsAt,
+			Wage:      params.Wage,
+			ExcludeID: params.ID,
+		}); err != nil {
+			return
+		}
+	}
+
+	doUpdateResp, err = contract.repo.GetContract().DoUpdate(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// CheckSalaryCap is used for making sure a team stays under its salary caps with an extra wage to pay.
+// The wages of the contracts running on the given date, but the one with ExcludeID, are added
+// to Wage and compared with the cap of every competition the team plays in.
+// It returns any errors written.
+func (contract *Contract) CheckSalaryCap(ctx context.Context, params param.CheckSalaryCap) (err error) {
+	getSalaryCapsResp, err := contract.repo.GetContract().GetSalaryCaps(ctx, param.GetSalaryCaps{
+		TeamID:   params.TeamID,
+		Date:     params.Date,
+		SeasonID: params.SeasonID,
+	})
+	if err != nil {
+		return
+	}
+
+	if len(getSalaryCapsResp) == 0 {
+		return
+	}
+
+	getPayrollResp, err := contract.repo.GetContract().GetPayroll(ctx, param.GetPayroll{
+		TeamID:    params.TeamID,
+		Date:      params.Date,
+		ExcludeID: params.ExcludeID,
+	})
+	if err != nil {
+		return
+	}
+
+	wages := getPayrollResp.Total + params.Wage
+	for _, salaryCap := range getSalaryCapsResp {
+		if wages > salaryCap.SalaryCap {
+			err = &iPkgError.ValidationError{Err: errors.New("team wages of " + strconv.FormatInt(wages, 10) + " would exceed the salary cap of " + strconv.FormatInt(salaryCap.SalaryCap, 10) + " in " + salaryCap.CompetitionName)}
+			return
+		}
+	}
+
+	return
+}
+
+// CheckContract is used for making sure a player holds a valid contract with a team.
+// It returns any errors written.
+func (contract *Contract) CheckContract(ctx context.Context, params param.CheckContract) (err error) {
+	getValidContractResp, err := contract.repo.GetContract().GetValidContract(ctx, param.GetValidContract{
+		PlayerID: params.PlayerID,
+		TeamID:   params.TeamID,
+		Date:     params.Date,
+	})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getValidContractResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("player has no valid contract with the team")}
+		return
+	}
+
+	return
+}
+
+// checkOverlap is used for making sure a contract does not run at the same time as another active contract of the player.
+// It returns any errors written.
+func (contract *Contract) checkOverlap(ctx context.Context, params param.Contract) (err error) {
+	getContractsResp, err := contract.repo.GetContract().GetContracts(ctx, param.GetContracts{PlayerID: params.PlayerID})
+	if err != nil {
+		return
+	}
+
+	if overlaps(getContractsResp, params) {
+		err = &iPkgError.ValidationError{Err: errors.New("contract overlaps another active contract of the player")}
+		return
+	}
+
+	return
+}
+
+// validOn is used for checking whether a contract with the given status and dates binds the player on date.
+func validOn(status string, startsAt, endsAt, date time.Time) bool {
+	return status == model.ContractStatusActive && !startsAt.After(date) && !endsAt.Before(date)
+}
+
+// overlaps is used for checking whether any other active contract runs at some point of the given one.
+func overlaps(contracts []transporter.GetContracts, c param.Contract) bool {
+	for _, other := range contracts {
+		if uuid.Equal(other.ID, c.ID) || other.Status != model.ContractStatusActive {
+			continue
+		}
+
+		if !other.StartsAt.After(c.EndsAt) && !c.StartsAt.After(other.EndsAt) {
+			return true
+		}
+	}
+	return false
+}
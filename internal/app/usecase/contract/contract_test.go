@@ -0,0 +1,294 @@
+package contract
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/handler/contract/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/contract/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	iContractRepo "github.com/harunnryd/skeltun/internal/app/repo/contract"
+	iPlayerRepo "github.com/harunnryd/skeltun/internal/app/repo/player"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	mock      sqlmock.Sqlmock
+	pgsqlConn *gorm.DB
+
+	iContractRepo iContractRepo.IContract
+	iPlayerRepo   iPlayerRepo.IPlayer
+	iRepo         repo.IRepo
+	contract      IContract
+	helper
+	response
+}
+
+type helper struct {
+	err error
+	db  *sql.DB
+}
+
+type response struct {
+	doCreateResp transporter.DoCreate
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.iContractRepo = iContractRepo.New(
+		iContractRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iPlayerRepo = iPlayerRepo.New(
+		iPlayerRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iRepo = repo.New()
+	suite.iRepo.SetContract(suite.iContractRepo)
+	suite.iRepo.SetPlayer(suite.iPlayerRepo)
+
+	suite.contract = New(WithRepo(suite.iRepo))
+}
+
+// TestDoCreate ...
+func (suite *Suite) TestDoCreate() {
+	teamID := uuid.NewV4()
+	params := param.DoCreate{
+		Contract: param.Contract{
+			PlayerID: uuid.NewV4(),
+			StartsAt: time.Date(2021, time.July, 1, 0, 0, 0, 0, time.UTC),
+			Terms: param.Terms{
+				EndsAt: time.Date(2024, time.June, 30, 0, 0, 0, 0, time.UTC),
+				Wage:   2500000,
+			},
+		},
+	}
+
+	suite.mock.MatchExpectationsInOrder(false)
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.PlayerID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "team_id", "name"}).
+			AddRow(params.PlayerID, teamID, "John Doe"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "contracts" WHERE player_id = $1 ORDER BY starts_at`)).
+		WithArgs(params.PlayerID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "starts_at", "ends_at", "status"}).
+			AddRow(uuid.NewV4(), time.Date(2018, time.July, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, time.June, 30, 0, 0, 0, 0, time.UTC), model.ContractStatusActive))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`FROM "competitions"`)).
+		WithArgs(uuid.Nil, params.StartsAt, teamID).
+		WillReturnRows(sqlmock.NewRows([]string{"competition_id", "competition_name", "salary_cap"}).
+			AddRow(uuid.NewV4(), "Premier League", 90000000))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(wage), 0) AS total FROM "contracts"`)).
+		WithArgs(teamID, model.ContractStatusActive, params.StartsAt, params.StartsAt, uuid.Nil).
+		WillReturnRows(sqlmock.NewRows([]string{"total"}).
+			AddRow(60000000))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "contracts"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.PlayerID, teamID, params.StartsAt, params.EndsAt, params.Wage, nil, model.ContractStatusActive).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.response.doCreateResp, suite.helper.err = suite.contract.DoCreate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), teamID, suite.response.doCreateResp.TeamID)
+}
+
+// TestDoCreateSalaryCapExceeded ...
+func (suite *Suite) TestDoCreateSalaryCapExceeded() {
+	teamID := uuid.NewV4()
+	params := param.DoCreate{
+		Contract: param.Contract{
+			PlayerID: uuid.NewV4(),
+			StartsAt: time.Date(2021, time.July, 1, 0, 0, 0, 0, time.UTC),
+			Terms: param.Terms{
+				EndsAt: time.Date(2024, time.June, 30, 0, 0, 0, 0, time.UTC),
+				Wage:   35000000,
+			},
+		},
+	}
+
+	suite.mock.MatchExpectationsInOrder(false)
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.PlayerID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "team_id", "name"}).
+			AddRow(params.PlayerID, teamID, "John Doe"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "contracts" WHERE player_id = $1 ORDER BY starts_at`)).
+		WithArgs(params.PlayerID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`FROM "competitions"`)).
+		WithArgs(uuid.Nil, params.StartsAt, teamID).
+		WillReturnRows(sqlmock.NewRows([]string{"competition_id", "competition_name", "salary_cap"}).
+			AddRow(uuid.NewV4(), "Premier League", 90000000))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(wage), 0) AS total FROM "contracts"`)).
+		WithArgs(teamID, model.ContractStatusActive, params.StartsAt, params.StartsAt, uuid.Nil).
+		WillReturnRows(sqlmock.NewRows([]string{"total"}).
+			AddRow(60000000))
+
+	suite.response.doCreateResp, suite.helper.err = suite.contract.DoCreate(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "team wages of 95000000 would exceed the salary cap of 90000000 in Premier League")
+}
+
+// TestDoCreatePlayerWithoutTeam ...
+func (suite *Suite) TestDoCreatePlayerWithoutTeam() {
+	params := param.DoCreate{
+		Contract: param.Contract{
+			PlayerID: uuid.NewV4(),
+			StartsAt: time.Date(2021, time.July, 1, 0, 0, 0, 0, time.UTC),
+			Terms: param.Terms{
+				EndsAt: time.Date(2024, time.June, 30, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.PlayerID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(params.PlayerID, "John Doe"))
+
+	suite.response.doCreateResp, suite.helper.err = suite.contract.DoCreate(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "player without a team cannot sign a contract")
+}
+
+// TestDoUpdateOnlyValidContract ...
+func (suite *Suite) TestDoUpdateOnlyValidContract() {
+	startsAt := time.Now().AddDate(-1, 0, 0)
+	params := param.DoUpdate{
+		Contract: param.Contract{
+			ID:       uuid.NewV4(),
+			PlayerID: uuid.NewV4(),
+			StartsAt: startsAt,
+			Terms: param.Terms{
+				EndsAt: startsAt.AddDate(3, 0, 0),
+			},
+			Status: model.ContractStatusTerminated,
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "contracts" WHERE id = $1 AND player_id = $2 LIMIT 1`)).
+		WithArgs(params.ID, params.PlayerID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "player_id", "team_id", "starts_at", "ends_at", "status"}).
+			AddRow(params.ID, params.PlayerID, uuid.NewV4(), params.StartsAt, params.EndsAt, model.ContractStatusActive))
+
+	_, suite.helper.err = suite.contract.DoUpdate(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "player cannot be left without a valid contract")
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+// TestOverlaps ...
+func TestOverlaps(t *testing.T) {
+	id := uuid.NewV4()
+	july2018, june2021 := time.Date(2018, time.July, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, time.June, 30, 0, 0, 0, 0, time.UTC)
+	july2021, june2024 := time.Date(2021, time.July, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, time.June, 30, 0, 0, 0, 0, time.UTC)
+
+	contract := param.Contract{StartsAt: july2021, Terms: param.Terms{EndsAt: june2024}}
+
+	tests := []struct {
+		name      string
+		contracts []transporter.GetContracts
+		contract  param.Contract
+		want      bool
+	}{
+		{
+			name:     "no contracts",
+			contract: contract,
+		},
+		{
+			name: "previous contract ended the day before",
+			contracts: []transporter.GetContracts{
+				{Contract: transporter.Contract{ID: uuid.NewV4(), StartsAt: july2018, EndsAt: june2021, Status: model.ContractStatusActive}},
+			},
+			contract: contract,
+		},
+		{
+			name: "running contract",
+			contracts: []transporter.GetContracts{
+				{Contract: transporter.Contract{ID: uuid.NewV4(), StartsAt: july2018, EndsAt: july2021, Status: model.ContractStatusActive}},
+			},
+			contract: contract,
+			want:     true,
+		},
+		{
+			name: "terminated contract",
+			contracts: []transporter.GetContracts{
+				{Contract: transporter.Contract{ID: uuid.NewV4(), StartsAt: july2018, EndsAt: june2024, Status: model.ContractStatusTerminated}},
+			},
+			contract: contract,
+		},
+		{
+			name: "same contract",
+			contracts: []transporter.GetContracts{
+				{Contract: transporter.Contract{ID: id, StartsAt: july2021, EndsAt: june2024, Status: model.ContractStatusActive}},
+			},
+			contract: param.Contract{ID: id, StartsAt: july2021, Terms: param.Terms{EndsAt: june2024}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, overlaps(tt.contracts, tt.contract))
+		})
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package draw
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/draw/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/draw/transporter"
+	seasonParam "github.com/harunnryd/skeltun/internal/app/handler/season/param"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	"github.com/harunnryd/skeltun/internal/pkg"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// IDraw is an interface that stores the methods that Draw struct will use.
+type IDraw interface {
+	// DoDraw is used for drawing the teams of a season from seeded pots into groups or ties.
+	// It returns doDrawResp of transporter.DoDraw and any errors written.
+	DoDraw(ctx context.Context, params param.DoDraw) (doDrawResp transporter.DoDraw, err error)
+
+	// GetDraw is used for getting a draw of a season and checking that it can be reproduced from its seed.
+	// It returns getDrawResp of transporter.GetDraw and any errors written.
+	GetDraw(ctx context.Context, params param.GetDraw) (getDrawResp transporter.GetDraw, err error)
+}
+
+// Draw is an struct that implements IDraw methods.
+type Draw struct {
+	config config.IConfig
+	repo   repo.IRepo
+	pkg    pkg.IPkg
+}
+
+// New it returns instance of Draw that implements IDraw methods.
+func New(opts ...Option) IDraw {
+	d := new(Draw)
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// DoDraw is used for drawing the teams of a season from seeded pots into groups or ties.
+// A groups draw puts at most one team of every pot into each group. A ties draw pairs the teams
+// of a single pot, or every team of the second pot with a team of the first one.
+// A random seed is picked when none is given, and it is stored so the draw can be replayed.
+// It returns doDrawResp of transporter.DoDraw and any errors written.
+func (draw *Draw) DoDraw(ctx context.Context, params param.DoDraw) (doDrawResp transporter.DoDraw, err error) {
+	getSeasonResp, err := draw.repo.GetSeason().GetSeason(ctx, seasonParam.GetSeason{ID: params.SeasonID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getSeasonResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("season not found")}
+		return
+	}
+
+	switch params.Kind {
+	case model.DrawKindGroups:
+		for i, pot := range params.Pots {
+			if len(pot) > params.Groups {
+				err = &iPkgError.ValidationError{Err: errors.New("pot " + strconv.Itoa(i+1) + " has more teams than groups")}
+				return
+			}
+		}
+	case model.DrawKindTies:
+		if len(params.Pots) > 2 {
+			err = &iPkgError.ValidationError{Err: errors.New("ties are drawn from one or two pots")}
+			return
+		}
+
+		if len(params.Pots) == 2 && len(params.Pots[0]) != len(params.Pots[1]) {
+			err = &iPkgError.ValidationError{Err: errors.New("both pots should have the same number of teams")}
+			return
+		}
+
+		if len(params.Pots) == 1 && len(params.Pots[0])%2 != 0 {
+			err = &iPkgError.ValidationError{Err: errors.New("pot should have an even number of teams")}
+			return
+		}
+	}
+
+	getSeasonTeamsResp, err := draw.repo.GetSeason().GetSeasonTeams(ctx, seasonParam.GetSeasonTeams{SeasonID: params.SeasonID})
+	if err != nil {
+		return
+	}
+
+	associations := make(map[uuid.UUID]string, len(getSeasonTeamsResp))
+	for _, team := range getSeasonTeamsResp {
+		associations[team.ID] = team.Association
+	}
+
+	var entrants []param.Placement
+	drawn := make(map[uuid.UUID]bool)
+	for i, pot := range params.Pots {
+		for j, teamID := range pot {
+			association, registered := associations[teamID]
+			if !registered {
+				err = &iPkgError.ValidationError{Err: errors.New("team " + teamID.String() + " is not registered in the season")}
+				return
+			}
+
+			if drawn[teamID] {
+				err = &iPkgError.ValidationError{Err: errors.New("team " + teamID.String() + " is listed more than once")}
+				return
+			}
+			drawn[teamID] = true
+
+			entrants = append(entrants, param.Placement{
+				TeamID:        teamID,
+				Pot:           i + 1,
+				PotPosition:   j + 1,
+				Association:   association,
+				PreviousGroup: params.PreviousGroups[teamID],
+			})
+		}
+	}
+
+	seed := time.Now().UnixNano()
+	if params.Seed != nil {
+		seed = *params.Seed
+	}
+
+	placements, err := run(params.Kind, entrants, params.Groups, params.Constraints, seed)
+	if err != nil {
+		err = &iPkgError.ValidationError{Err: err}
+		return
+	}
+
+	doCreateResp, err := draw.repo.GetDraw().DoCreate(ctx, param.DoCreate{
+		SeasonID:    params.SeasonID,
+		Kind:        params.Kind,
+		Seed:        seed,
+		Groups:      params.Groups,
+		Constraints: params.Constraints,
+		Placements:  placements,
+	})
+	if err != nil {
+		return
+	}
+
+	doDrawResp.Draw = doCreateResp.Draw
+	doDrawResp.Placements = doCreateResp.Placements
+
+	return
+}
+
+// GetDraw is used for getting a draw of a season and checking that it can be reproduced from its seed.
+// The stored pots are drawn again with the stored seed and constraints, and the draw is
+// reported as reproduced when every team ends up in the same place in the same order.
+// It returns getDrawResp of transporter.GetDraw and any errors written.
+func (draw *Draw) GetDraw(ctx context.Context, params param.GetDraw) (getDrawResp transporter.GetDraw, err error) {
+	getDrawResp, err = draw.repo.GetDraw().GetDraw(ctx, params)
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getDrawResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("draw not found")}
+		return
+	}
+
+	entrants := make([]param.Placement, 0, len(getDrawResp.Placements))
+	for _, placement := range getDrawResp.Placements {
+		entrants = append(entrants, param.Placement{
+			TeamID:        placement.TeamID,
+			Pot:           placement.Pot,
+			PotPosition:   placement.PotPosition,
+			Association:   placement.Association,
+			PreviousGroup: placement.PreviousGroup,
+		})
+	}
+
+	placements, err := run(getDrawResp.Kind, entrants, getDrawResp.Groups, param.Constraints{
+		SeparateAssociations: getDrawResp.SeparateAssociations,
+		SeparateGroups:       getDrawResp.SeparateGroups,
+	}, getDrawResp.Seed)
+	if err != nil {
+		// A draw that cannot be replayed is still returned, only flagged as not reproduced.
+		err = nil
+		return
+	}
+
+	getDrawResp.Reproduced = len(placements) == len(getDrawResp.Placements)
+	for i := 0; getDrawResp.Reproduced && i < len(placements); i++ {
+		stored := getDrawResp.Placements[i]
+		getDrawResp.Reproduced = uuid.Equal(placements[i].TeamID, stored.TeamID) &&
+			placements[i].Sequence == stored.Sequence &&
+			placements[i].GroupName == stored.GroupName &&
+			placements[i].Tie == stored.Tie &&
+			placements[i].Slot == stored.Slot
+	}
+
+	return
+}
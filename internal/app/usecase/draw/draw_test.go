@@ -0,0 +1,327 @@
+package draw
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/handler/draw/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/draw/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	iDrawRepo "github.com/harunnryd/skeltun/internal/app/repo/draw"
+	iSeasonRepo "github.com/harunnryd/skeltun/internal/app/repo/season"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	mock      sqlmock.Sqlmock
+	pgsqlConn *gorm.DB
+
+	iDrawRepo   iDrawRepo.IDraw
+	iSeasonRepo iSeasonRepo.ISeason
+	iRepo       repo.IRepo
+	draw        IDraw
+	helper
+	response
+}
+
+type helper struct {
+	err error
+	db  *sql.DB
+}
+
+type response struct {
+	doDrawResp  transporter.DoDraw
+	getDrawResp transporter.GetDraw
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.iDrawRepo = iDrawRepo.New(
+		iDrawRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iSeasonRepo = iSeasonRepo.New(
+		iSeasonRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iRepo = repo.New()
+	suite.iRepo.SetDraw(suite.iDrawRepo)
+	suite.iRepo.SetSeason(suite.iSeasonRepo)
+
+	suite.draw = New(WithRepo(suite.iRepo))
+}
+
+// TestDoDraw ...
+func (suite *Suite) TestDoDraw() {
+	arsenalID, chelseaID, barcelonaID, sevillaID := uuid.NewV4(), uuid.NewV4(), uuid.NewV4(), uuid.NewV4()
+	seed := int64(2021)
+	params := param.DoDraw{
+		SeasonID: uuid.NewV4(),
+		Kind:     model.DrawKindGroups,
+		Pots: [][]uuid.UUID{
+			{arsenalID, barcelonaID},
+			{chelseaID, sevillaID},
+		},
+		Groups: 2,
+		Seed:   &seed,
+		Constraints: param.Constraints{
+			SeparateAssociations: true,
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seasons" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(params.SeasonID, "2020/21"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT "teams"."id","teams"."name","teams"."association" FROM "teams" JOIN season_teams ON season_teams.team_id = teams.id WHERE season_teams.season_id = $1 ORDER BY teams.name`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "association"}).
+			AddRow(arsenalID, "Arsenal", "ENG").
+			AddRow(barcelonaID, "Barcelona", "ESP").
+			AddRow(chelseaID, "Chelsea", "ENG").
+			AddRow(sevillaID, "Sevilla", "ESP"))
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "draws"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.SeasonID, model.DrawKindGroups, seed, 2, true, false).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "draw_placements"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()).
+			AddRow(uuid.NewV4()).
+			AddRow(uuid.NewV4()).
+			AddRow(uuid.NewV4()))
+
+	suite.mock.ExpectCommit()
+
+	suite.response.doDrawResp, suite.helper.err = suite.draw.DoDraw(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), seed, suite.response.doDrawResp.Seed)
+	require.Len(suite.T(), suite.response.doDrawResp.Placements, 4)
+
+	// English teams and Spanish teams are kept apart, so each group gets one of both.
+	groups := make(map[string]string)
+	for _, placement := range suite.response.doDrawResp.Placements {
+		groups[placement.Association+placement.GroupName] = placement.TeamID.String()
+	}
+	require.Len(suite.T(), groups, 4)
+}
+
+// TestDoDrawUnregisteredTeam ...
+func (suite *Suite) TestDoDrawUnregisteredTeam() {
+	arsenalID, outsiderID := uuid.NewV4(), uuid.NewV4()
+	params := param.DoDraw{
+		SeasonID: uuid.NewV4(),
+		Kind:     model.DrawKindTies,
+		Pots:     [][]uuid.UUID{{arsenalID, outsiderID}},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seasons" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(params.SeasonID, "2020/21"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT "teams"."id","teams"."name","teams"."association" FROM "teams" JOIN season_teams ON season_teams.team_id = teams.id WHERE season_teams.season_id = $1 ORDER BY teams.name`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "association"}).
+			AddRow(arsenalID, "Arsenal", "ENG"))
+
+	suite.response.doDrawResp, suite.helper.err = suite.draw.DoDraw(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "team "+outsiderID.String()+" is not registered in the season")
+}
+
+// TestGetDraw ...
+func (suite *Suite) TestGetDraw() {
+	params := param.GetDraw{
+		ID:       uuid.NewV4(),
+		SeasonID: uuid.NewV4(),
+	}
+
+	entrants := []param.Placement{
+		{TeamID: uuid.NewV4(), Pot: 1, PotPosition: 1, PreviousGroup: "A"},
+		{TeamID: uuid.NewV4(), Pot: 1, PotPosition: 2, PreviousGroup: "B"},
+		{TeamID: uuid.NewV4(), Pot: 2, PotPosition: 1, PreviousGroup: "A"},
+		{TeamID: uuid.NewV4(), Pot: 2, PotPosition: 2, PreviousGroup: "B"},
+	}
+	constraints := param.Constraints{SeparateGroups: true}
+
+	placements, err := run(model.DrawKindTies, entrants, 0, constraints, 99)
+	require.NoError(suite.T(), err)
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "draws" WHERE id = $1 AND season_id = $2 LIMIT 1`)).
+		WithArgs(params.ID, params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "season_id", "kind", "seed", "separate_groups"}).
+			AddRow(params.ID, params.SeasonID, model.DrawKindTies, 99, true))
+
+	rows := sqlmock.NewRows([]string{"id", "draw_id", "team_id", "pot", "pot_position", "previous_group", "sequence", "tie", "slot"})
+	for _, placement := range placements {
+		rows.AddRow(uuid.NewV4(), params.ID, placement.TeamID, placement.Pot, placement.PotPosition, placement.PreviousGroup, placement.Sequence, placement.Tie, placement.Slot)
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "draw_placements" WHERE "draw_placements"."draw_id" = $1 ORDER BY sequence`)).
+		WithArgs(params.ID).
+		WillReturnRows(rows)
+
+	suite.response.getDrawResp, suite.helper.err = suite.draw.GetDraw(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.True(suite.T(), suite.response.getDrawResp.Reproduced)
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+// TestRun ...
+func TestRun(t *testing.T) {
+	entrant := func(pot, potPosition int, association, previousGroup string) param.Placement {
+		return param.Placement{
+			TeamID:        uuid.NewV4(),
+			Pot:           pot,
+			PotPosition:   potPosition,
+			Association:   association,
+			PreviousGroup: previousGroup,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		kind        string
+		entrants    []param.Placement
+		groups      int
+		constraints param.Constraints
+		err         error
+	}{
+		{
+			name: "groups with associations kept apart",
+			kind: model.DrawKindGroups,
+			entrants: []param.Placement{
+				entrant(1, 1, "ENG", ""), entrant(1, 2, "ESP", ""), entrant(1, 3, "ITA", ""),
+				entrant(2, 1, "ENG", ""), entrant(2, 2, "ESP", ""), entrant(2, 3, "GER", ""),
+				entrant(3, 1, "ITA", ""), entrant(3, 2, "GER", ""), entrant(3, 3, "ENG", ""),
+			},
+			groups:      3,
+			constraints: param.Constraints{SeparateAssociations: true},
+		},
+		{
+			name: "group winners against runners-up from other groups",
+			kind: model.DrawKindTies,
+			entrants: []param.Placement{
+				entrant(1, 1, "ENG", "A"), entrant(1, 2, "ESP", "B"), entrant(1, 3, "ITA", "C"),
+				entrant(2, 1, "GER", "A"), entrant(2, 2, "FRA", "B"), entrant(2, 3, "POR", "C"),
+			},
+			constraints: param.Constraints{SeparateGroups: true},
+		},
+		{
+			name: "single pot with associations kept apart",
+			kind: model.DrawKindTies,
+			entrants: []param.Placement{
+				entrant(1, 1, "ENG", ""), entrant(1, 2, "ENG", ""), entrant(1, 3, "ESP", ""), entrant(1, 4, "ESP", ""),
+			},
+			constraints: param.Constraints{SeparateAssociations: true},
+		},
+		{
+			name: "impossible draw",
+			kind: model.DrawKindTies,
+			entrants: []param.Placement{
+				entrant(1, 1, "ENG", ""), entrant(2, 1, "ENG", ""),
+			},
+			constraints: param.Constraints{SeparateAssociations: true},
+			err:         errDeadEnd,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Every seed shuffles the pots differently, some of them only complete after backtracking.
+			for seed := int64(0); seed < 50; seed++ {
+				placements, err := run(tt.kind, tt.entrants, tt.groups, tt.constraints, seed)
+				if tt.err != nil {
+					require.Equal(t, tt.err, err)
+					return
+				}
+
+				require.NoError(t, err)
+				require.Len(t, placements, len(tt.entrants))
+
+				together := make(map[string][]param.Placement)
+				for i, placement := range placements {
+					require.Equal(t, i+1, placement.Sequence)
+
+					key := placement.GroupName
+					if tt.kind == model.DrawKindTies {
+						key = string(rune('0' + placement.Tie))
+					}
+					together[key] = append(together[key], placement)
+				}
+
+				e := &engine{constraints: tt.constraints}
+				for _, members := range together {
+					for i := 1; i < len(members); i++ {
+						if tt.kind == model.DrawKindGroups {
+							require.True(t, e.fitsGroup(members[i], members[:i]), "seed %d", seed)
+						} else {
+							require.Len(t, members, 2)
+							require.True(t, e.fitsTie(members[0], members[1]), "seed %d", seed)
+						}
+					}
+				}
+
+				again, err := run(tt.kind, tt.entrants, tt.groups, tt.constraints, seed)
+				require.NoError(t, err)
+				require.Equal(t, placements, again, "the same seed gives the same draw")
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package draw
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+
+	"github.com/harunnryd/skeltun/internal/app/handler/draw/param"
+	"github.com/harunnryd/skeltun/internal/app/model"
+)
+
+// maxSteps bounds the backtracking, so a draw that cannot be completed fails instead of running forever.
+const maxSteps = 1000000
+
+// errDeadEnd is returned when no placement satisfies every constraint.
+var errDeadEnd = errors.New("draw cannot be completed with the given constraints")
+
+// engine keeps the state of a draw while teams are being placed.
+type engine struct {
+	constraints param.Constraints
+	steps       int
+}
+
+// run is used for drawing the given entrants from a random source seeded with seed.
+// Entrants are shuffled pot by pot, so running it again with the same seed and entrants
+// gives the same outcome. It returns the entrants in the order they were drawn.
+func run(kind string, entrants []param.Placement, groups int, constraints param.Constraints, seed int64) ([]param.Placement, error) {
+	entrants = append([]param.Placement(nil), entrants...)
+	sort.SliceStable(entrants, func(i, j int) bool {
+		if entrants[i].Pot != entrants[j].Pot {
+			return entrants[i].Pot < entrants[j].Pot
+		}
+		return entrants[i].PotPosition < entrants[j].PotPosition
+	})
+
+	var pots [][]param.Placement
+	for _, entrant := range entrants {
+		if len(pots) == 0 || pots[len(pots)-1][0].Pot != entrant.Pot {
+			pots = append(pots, nil)
+		}
+		pots[len(pots)-1] = append(pots[len(pots)-1], entrant)
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	for _, pot := range pots {
+		rng.Shuffle(len(pot), func(i, j int) {
+			pot[i], pot[j] = pot[j], pot[i]
+		})
+	}
+
+	e := &engine{constraints: constraints}
+	if kind == model.DrawKindGroups {
+		return e.groups(pots, groups)
+	}
+	return e.ties(pots)
+}
+
+// groups is used for placing the teams of every pot into the groups, one team of a pot per group.
+// Teams are drawn pot by pot and each one goes into the first group it can join without making
+// the rest of the draw impossible, trying the next group whenever a placement leads to a dead end.
+func (e *engine) groups(pots [][]param.Placement, groups int) ([]param.Placement, error) {
+	var drawn []param.Placement
+	for _, pot := range pots {
+		drawn = append(drawn, pot...)
+	}
+
+	members := make([][]param.Placement, groups)
+
+	var place func(i int) bool
+	place = func(i int) bool {
+		if i == len(drawn) {
+			return true
+		}
+
+		if e.steps++; e.steps > maxSteps {
+			return false
+		}
+
+		for g := range members {
+			if !e.fitsGroup(drawn[i], members[g]) {
+				continue
+			}
+
+			members[g] = append(members[g], drawn[i])
+			if place(i + 1) {
+				drawn[i].GroupName = string(rune('A' + g))
+				return true
+			}
+			members[g] = members[g][:len(members[g])-1]
+		}
+
+		return false
+	}
+
+	if !place(0) {
+		return nil, errDeadEnd
+	}
+
+	for i := range drawn {
+		drawn[i].Sequence = i + 1
+	}
+
+	return drawn, nil
+}
+
+// ties is used for pairing the teams into ties. With two pots a team of the second pot is drawn
+// and paired with the first team of the other pot it may meet, so teams of the same pot, like
+// the group winners, never meet each other. With a single pot teams are paired in the order
+// they are drawn. An opponent leading to a dead end is replaced with the next eligible one.
+func (e *engine) ties(pots [][]param.Placement) ([]param.Placement, error) {
+	drawFrom, pairWith := pots[0], pots[0]
+	if len(pots) == 2 {
+		drawFrom, pairWith = pots[1], pots[0]
+	}
+
+	var drawn []param.Placement
+	used := make(map[int]bool)
+	key := func(p param.Placement) int {
+		return p.Pot<<16 | p.PotPosition
+	}
+
+	var pair func(tie int) bool
+	pair = func(tie int) bool {
+		i := 0
+		for i < len(drawFrom) && used[key(drawFrom[i])] {
+			i++
+		}
+
+		if i == len(drawFrom) {
+			return true
+		}
+
+		if e.steps++; e.steps > maxSteps {
+			return false
+		}
+
+		team := drawFrom[i]
+		used[key(team)] = true
+		for _, opponent := range pairWith {
+			if used[key(opponent)] || !e.fitsTie(team, opponent) {
+				continue
+			}
+
+			used[key(opponent)] = true
+			team.Tie, team.Slot = tie, model.BracketSlotHome
+			opponent.Tie, opponent.Slot = tie, model.BracketSlotAway
+			drawn = append(drawn, team, opponent)
+			if pair(tie + 1) {
+				return true
+			}
+			drawn = drawn[:len(drawn)-2]
+			used[key(opponent)] = false
+		}
+		used[key(team)] = false
+
+		return false
+	}
+
+	if !pair(1) {
+		return nil, errDeadEnd
+	}
+
+	for i := range drawn {
+		drawn[i].Sequence = i + 1
+	}
+
+	return drawn, nil
+}
+
+// fitsGroup is used for checking whether a team can join a group given the teams already in it.
+func (e *engine) fitsGroup(team param.Placement, members []param.Placement) bool {
+	for _, member := range members {
+		if member.Pot == team.Pot {
+			return false
+		}
+
+		if e.constraints.SeparateAssociations && team.Association != "" && member.Association == team.Association {
+			return false
+		}
+	}
+	return true
+}
+
+// fitsTie is used for checking whether two teams can be drawn against each other.
+func (e *engine) fitsTie(team, opponent param.Placement) bool {
+	if e.constraints.SeparateAssociations && team.Association != "" && opponent.Association == team.Association {
+		return false
+	}
+
+	if e.constraints.SeparateGroups && team.PreviousGroup != "" && opponent.PreviousGroup == team.PreviousGroup {
+		return false
+	}
+
+	return true
+}
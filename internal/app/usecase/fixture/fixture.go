@@ -0,0 +1,193 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fixture
+
+import (
+	"context"
+	"errors"
+
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/fixture/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/fixture/transporter"
+	matchParam "github.com/harunnryd/skeltun/internal/app/handler/match/param"
+	seasonParam "github.com/harunnryd/skeltun/internal/app/handler/season/param"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	"github.com/harunnryd/skeltun/internal/pkg"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// IFixture is an interface that stores the methods that Fixture struct will use.
+type IFixture interface {
+	// DoGenerate is used for generating the round-robin fixtures of a season.
+	// It returns doGenerateResp of transporter.DoGenerate and any errors written.
+	DoGenerate(ctx context.Context, params param.DoGenerate) (doGenerateResp transporter.DoGenerate, err error)
+}
+
+// Fixture is an struct that implements IFixture methods.
+type Fixture struct {
+	config config.IConfig
+	repo   repo.IRepo
+	pkg    pkg.IPkg
+}
+
+// pairing is a single match of a round, before it gets a date.
+type pairing struct {
+	home uuid.UUID
+	away uuid.UUID
+}
+
+// New it returns instance of Fixture that implements IFixture methods.
+func New(opts ...Option) IFixture {
+	f := new(Fixture)
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+// DoGenerate is used for generating the round-robin fixtures of a season.
+// Kickoff times are picked by the scheduler, constraints it could not satisfy are reported back.
+// The first round is played on the start date, or on the season start date when none is given.
+// The scheduled matches of the season are replaced, it refuses to run once any match has kicked off
+// or has a lineup or officials assigned, as those would go with the match.
+// It returns doGenerateResp of transporter.DoGenerate and any errors written.
+func (fixture *Fixture) DoGenerate(ctx context.Context, params param.DoGenerate) (doGenerateResp transporter.DoGenerate, err error) {
+	getSeasonResp, err := fixture.repo.GetSeason().GetSeason(ctx, seasonParam.GetSeason{ID: params.SeasonID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getSeasonResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("season not found")}
+		return
+	}
+
+	if params.StartDate.IsZero() {
+		params.StartDate = getSeasonResp.StartDate
+	}
+
+	if params.DaysBetweenRounds == 0 {
+		params.DaysBetweenRounds = param.DefaultDaysBetweenRounds
+	}
+
+	getSeasonMatchesResp, err := fixture.repo.GetMatch().GetSeasonMatches(ctx, matchParam.GetSeasonMatches{SeasonID: params.SeasonID})
+	if err != nil {
+		return
+	}
+
+	for _, match := range getSeasonMatchesResp {
+		if match.Status != model.MatchStatusScheduled {
+			err = &iPkgError.ValidationError{Err: errors.New("season already has fixtures with results")}
+			return
+		}
+	}
+
+	countPreparedFixturesResp, err := fixture.repo.GetMatch().CountPreparedFixtures(ctx, matchParam.CountPreparedFixtures{SeasonID: params.SeasonID})
+	if err != nil {
+		return
+	}
+
+	if countPreparedFixturesResp.Total > 0 {
+		err = &iPkgError.ValidationError{Err: errors.New("season already has fixtures with lineups or officials assigned")}
+		return
+	}
+
+	getSeasonTeamsResp, err := fixture.repo.GetSeason().GetSeasonTeams(ctx, seasonParam.GetSeasonTeams{SeasonID: params.SeasonID})
+	if err != nil {
+		return
+	}
+
+	if len(getSeasonTeamsResp) < 2 {
+		err = &iPkgError.ValidationError{Err: errors.New("season needs at least two registered teams")}
+		return
+	}
+
+	teamIDs := make([]uuid.UUID, 0, len(getSeasonTeamsResp))
+	for _, team := range getSeasonTeamsResp {
+		teamIDs = append(teamIDs, team.ID)
+	}
+
+	fixtures, unsatisfied := schedule(roundRobin(teamIDs, params.Legs), params)
+
+	doReplaceFixturesParam := matchParam.DoReplaceFixtures{SeasonID: params.SeasonID}
+	for _, f := range fixtures {
+		doReplaceFixturesParam.Matches = append(doReplaceFixturesParam.Matches, matchParam.Match{
+			Round:      f.round,
+			HomeTeamID: f.home,
+			AwayTeamID: f.away,
+			KickoffAt:  f.kickoffAt,
+			Status:     model.MatchStatusScheduled,
+		})
+	}
+
+	doReplaceFixturesResp, err := fixture.repo.GetMatch().DoReplaceFixtures(ctx, doReplaceFixturesParam)
+	if err != nil {
+		return
+	}
+
+	for _, match := range doReplaceFixturesResp {
+		doGenerateResp.Fixtures = append(doGenerateResp.Fixtures, transporter.Fixture{
+			ID:         match.ID,
+			Round:      match.Round,
+			HomeTeamID: match.HomeTeamID,
+			AwayTeamID: match.AwayTeamID,
+			KickoffAt:  match.KickoffAt,
+		})
+	}
+
+	doGenerateResp.Unsatisfied = unsatisfied
+
+	return
+}
+
+// roundRobin is used for pairing every team against each other with the circle method.
+// The first team stays in place while the others rotate around it. When the number of teams
+// is odd, a bye takes that first place instead, so every team sits out right where its
+// venue would otherwise repeat. The home side alternates so that no team plays more than
+// two home or away matches in a row, and a single leg with a bye alternates strictly.
+// The second leg mirrors the first one, shifted by a round so that a team never meets the
+// same opponent in consecutive rounds.
+func roundRobin(teamIDs []uuid.UUID, legs int) (rounds [][]pairing) {
+	circle := append([]uuid.UUID(nil), teamIDs...)
+	if len(circle)%2 == 1 {
+		circle = append([]uuid.UUID{uuid.Nil}, circle...)
+	}
+
+	n := len(circle)
+	for r := 0; r < n-1; r++ {
+		var round []pairing
+		for i := 0; i < n/2; i++ {
+			p := pairing{home: circle[i], away: circle[n-1-i]}
+			if (i == 0 && r%2 == 1) || (i > 0 && i%2 == 1) {
+				p.home, p.away = p.away, p.home
+			}
+
+			if uuid.Equal(p.home, uuid.Nil) || uuid.Equal(p.away, uuid.Nil) {
+				continue
+			}
+			round = append(round, p)
+		}
+		rounds = append(rounds, round)
+
+		last := circle[n-1]
+		copy(circle[2:], circle[1:n-1])
+		circle[1] = last
+	}
+
+	if legs == param.LegsDouble {
+		firstLeg := len(rounds)
+		for r := 0; r < firstLeg; r++ {
+			var round []pairing
+			for _, p := range rounds[(r+1)%firstLeg] {
+				round = append(round, pairing{home: p.away, away: p.home})
+			}
+			rounds = append(rounds, round)
+		}
+	}
+
+	return
+}
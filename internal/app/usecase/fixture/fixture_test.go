@@ -0,0 +1,419 @@
+package fixture
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/handler/fixture/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/fixture/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	iMatchRepo "github.com/harunnryd/skeltun/internal/app/repo/match"
+	iSeasonRepo "github.com/harunnryd/skeltun/internal/app/repo/season"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	mock      sqlmock.Sqlmock
+	pgsqlConn *gorm.DB
+
+	iMatchRepo  iMatchRepo.IMatch
+	iSeasonRepo iSeasonRepo.ISeason
+	iRepo       repo.IRepo
+	fixture     IFixture
+	helper
+	response
+}
+
+type helper struct {
+	err error
+	db  *sql.DB
+}
+
+type response struct {
+	doGenerateResp transporter.DoGenerate
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.iMatchRepo = iMatchRepo.New(
+		iMatchRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iSeasonRepo = iSeasonRepo.New(
+		iSeasonRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iRepo = repo.New()
+	suite.iRepo.SetMatch(suite.iMatchRepo)
+	suite.iRepo.SetSeason(suite.iSeasonRepo)
+
+	suite.fixture = New(WithRepo(suite.iRepo))
+}
+
+// TestDoGenerate ...
+func (suite *Suite) TestDoGenerate() {
+	params := param.DoGenerate{
+		SeasonID:          uuid.NewV4(),
+		Legs:              param.LegsDouble,
+		StartDate:         time.Date(2020, 8, 1, 15, 0, 0, 0, time.UTC),
+		DaysBetweenRounds: 7,
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seasons" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(params.SeasonID, "2020/21"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE season_id = $1 AND tie_id IS NULL ORDER BY kickoff_at`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).
+			AddRow(uuid.NewV4(), model.MatchStatusScheduled))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) AS total FROM "matches" WHERE (season_id = $1 AND status = $2 AND tie_id IS NULL) AND (EXISTS (SELECT 1 FROM lineups WHERE lineups.match_id = matches.id) OR EXISTS (SELECT 1 FROM match_officials WHERE match_officials.match_id = matches.id))`)).
+		WithArgs(params.SeasonID, model.MatchStatusScheduled).
+		WillReturnRows(sqlmock.NewRows([]string{"total"}).
+			AddRow(0))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT "teams"."id","teams"."name","teams"."association" FROM "teams" JOIN season_teams ON season_teams.team_id = teams.id WHERE season_teams.season_id = $1 ORDER BY teams.name`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(uuid.NewV4(), "Arsenal").
+			AddRow(uuid.NewV4(), "Chelsea"))
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`DELETE FROM "matches" WHERE season_id = $1 AND status = $2 AND tie_id IS NULL`)).
+		WithArgs(params.SeasonID, model.MatchStatusScheduled).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "matches"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()).
+			AddRow(uuid.NewV4()))
+
+	suite.mock.ExpectCommit()
+
+	suite.response.doGenerateResp, suite.helper.err = suite.fixture.DoGenerate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.Len(suite.T(), suite.response.doGenerateResp.Fixtures, 2)
+	require.Empty(suite.T(), suite.response.doGenerateResp.Unsatisfied)
+	require.Equal(suite.T(), suite.response.doGenerateResp.Fixtures[0].HomeTeamID, suite.response.doGenerateResp.Fixtures[1].AwayTeamID)
+	require.Equal(suite.T(), params.StartDate.AddDate(0, 0, 7), suite.response.doGenerateResp.Fixtures[1].KickoffAt)
+}
+
+// TestDoGenerateWithResults ...
+func (suite *Suite) TestDoGenerateWithResults() {
+	params := param.DoGenerate{
+		SeasonID: uuid.NewV4(),
+		Legs:     param.LegsSingle,
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seasons" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(params.SeasonID, "2020/21"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE season_id = $1 AND tie_id IS NULL ORDER BY kickoff_at`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).
+			AddRow(uuid.NewV4(), model.MatchStatusFinished))
+
+	suite.response.doGenerateResp, suite.helper.err = suite.fixture.DoGenerate(context.Background(), params)
+
+	require.Error(suite.T(), suite.helper.err)
+}
+
+// TestDoGenerateWithPreparedFixtures ...
+func (suite *Suite) TestDoGenerateWithPreparedFixtures() {
+	params := param.DoGenerate{
+		SeasonID: uuid.NewV4(),
+		Legs:     param.LegsSingle,
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seasons" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(params.SeasonID, "2020/21"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE season_id = $1 AND tie_id IS NULL ORDER BY kickoff_at`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).
+			AddRow(uuid.NewV4(), model.MatchStatusScheduled))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) AS total FROM "matches"`)).
+		WithArgs(params.SeasonID, model.MatchStatusScheduled).
+		WillReturnRows(sqlmock.NewRows([]string{"total"}).
+			AddRow(1))
+
+	suite.response.doGenerateResp, suite.helper.err = suite.fixture.DoGenerate(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "season already has fixtures with lineups or officials assigned")
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+// TestRoundRobin ...
+func TestRoundRobin(t *testing.T) {
+	tests := []struct {
+		name    string
+		teams   int
+		legs    int
+		rounds  int
+		maxRun  int
+		perTeam int
+	}{
+		{name: "single round-robin with even teams", teams: 20, legs: param.LegsSingle, rounds: 19, maxRun: 2, perTeam: 19},
+		{name: "double round-robin with even teams", teams: 20, legs: param.LegsDouble, rounds: 38, maxRun: 2, perTeam: 38},
+		{name: "single round-robin with a bye", teams: 5, legs: param.LegsSingle, rounds: 5, maxRun: 1, perTeam: 4},
+		{name: "single round-robin with a bye and more teams", teams: 9, legs: param.LegsSingle, rounds: 9, maxRun: 1, perTeam: 8},
+		{name: "double round-robin with a bye", teams: 7, legs: param.LegsDouble, rounds: 14, maxRun: 2, perTeam: 12},
+		{name: "double round-robin with a bye and fewer teams", teams: 5, legs: param.LegsDouble, rounds: 10, maxRun: 2, perTeam: 8},
+		{name: "two teams", teams: 2, legs: param.LegsDouble, rounds: 2, maxRun: 1, perTeam: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			teamIDs := make([]uuid.UUID, tt.teams)
+			for i := range teamIDs {
+				teamIDs[i] = uuid.NewV4()
+			}
+
+			rounds := roundRobin(teamIDs, tt.legs)
+			require.Len(t, rounds, tt.rounds)
+
+			meetings := make(map[[2]uuid.UUID]int)
+			venues := make(map[uuid.UUID][]bool)
+			for _, round := range rounds {
+				playing := make(map[uuid.UUID]bool)
+				for _, p := range round {
+					require.False(t, playing[p.home], "team plays twice in a round")
+					require.False(t, playing[p.away], "team plays twice in a round")
+					playing[p.home], playing[p.away] = true, true
+
+					meetings[[2]uuid.UUID{p.home, p.away}]++
+					venues[p.home] = append(venues[p.home], true)
+					venues[p.away] = append(venues[p.away], false)
+				}
+			}
+
+			for _, home := range teamIDs {
+				require.Len(t, venues[home], tt.perTeam)
+				for _, away := range teamIDs {
+					if uuid.Equal(home, away) {
+						continue
+					}
+
+					if tt.legs == param.LegsDouble {
+						require.Equal(t, 1, meetings[[2]uuid.UUID{home, away}])
+					} else {
+						require.Equal(t, 1, meetings[[2]uuid.UUID{home, away}]+meetings[[2]uuid.UUID{away, home}])
+					}
+				}
+
+				run := 1
+				for i := 1; i < len(venues[home]); i++ {
+					if venues[home][i] == venues[home][i-1] {
+						run++
+					} else {
+						run = 1
+					}
+					require.LessOrEqual(t, run, tt.maxRun)
+				}
+			}
+		})
+	}
+}
+
+// TestSchedule ...
+func TestSchedule(t *testing.T) {
+	a, b, c, d := uuid.NewV4(), uuid.NewV4(), uuid.NewV4(), uuid.NewV4()
+	saturday := time.Date(2020, 8, 1, 15, 0, 0, 0, time.UTC)
+	rounds := [][]pairing{
+		{{home: a, away: b}, {home: c, away: d}},
+		{{home: a, away: c}, {home: d, away: b}},
+	}
+
+	tests := []struct {
+		name        string
+		constraints param.Constraints
+		days        int
+		kickoffs    []time.Time
+		homes       []uuid.UUID
+		unsatisfied []string
+	}{
+		{
+			name:     "no constraints",
+			days:     7,
+			kickoffs: []time.Time{saturday, saturday, saturday.AddDate(0, 0, 7), saturday.AddDate(0, 0, 7)},
+		},
+		{
+			name:        "blackout date moves the round to the next playable day",
+			constraints: param.Constraints{BlackoutDates: []time.Time{saturday.AddDate(0, 0, 7)}},
+			days:        7,
+			kickoffs:    []time.Time{saturday, saturday, saturday.AddDate(0, 0, 8), saturday.AddDate(0, 0, 8)},
+		},
+		{
+			name:        "shared venue spreads home matches over the round days",
+			constraints: param.Constraints{SharedVenues: [][]uuid.UUID{{a, c}}, RoundDays: 2},
+			days:        7,
+			kickoffs:    []time.Time{saturday, saturday.AddDate(0, 0, 1), saturday.AddDate(0, 0, 7), saturday.AddDate(0, 0, 7)},
+		},
+		{
+			name:        "shared venue without spare days swaps home and away",
+			constraints: param.Constraints{SharedVenues: [][]uuid.UUID{{a, c}}},
+			days:        7,
+			kickoffs:    []time.Time{saturday, saturday, saturday.AddDate(0, 0, 7), saturday.AddDate(0, 0, 7)},
+			homes:       []uuid.UUID{a, d, a, d},
+		},
+		{
+			name:        "shared venue without spare days or swap is reported",
+			constraints: param.Constraints{SharedVenues: [][]uuid.UUID{{a, b, c, d}}},
+			days:        7,
+			kickoffs:    []time.Time{saturday, saturday, saturday.AddDate(0, 0, 7), saturday.AddDate(0, 0, 7)},
+			unsatisfied: []string{constraintSharedVenues, constraintSharedVenues},
+		},
+		{
+			name:        "rest days pick the latest day of the round",
+			constraints: param.Constraints{MinRestDays: 3, RoundDays: 2},
+			days:        2,
+			kickoffs:    []time.Time{saturday, saturday, saturday.AddDate(0, 0, 3), saturday.AddDate(0, 0, 3)},
+		},
+		{
+			name:        "rest days that cannot be met are reported",
+			constraints: param.Constraints{MinRestDays: 3},
+			days:        2,
+			kickoffs:    []time.Time{saturday, saturday, saturday.AddDate(0, 0, 2), saturday.AddDate(0, 0, 2)},
+			unsatisfied: []string{constraintMinRestDays, constraintMinRestDays, constraintMinRestDays, constraintMinRestDays},
+		},
+		{
+			name:        "kickoff slots are handed out in turn",
+			constraints: param.Constraints{KickoffSlots: []string{"12:30", "17:30"}},
+			days:        7,
+			kickoffs: []time.Time{
+				time.Date(2020, 8, 1, 12, 30, 0, 0, time.UTC),
+				time.Date(2020, 8, 1, 17, 30, 0, 0, time.UTC),
+				time.Date(2020, 8, 8, 12, 30, 0, 0, time.UTC),
+				time.Date(2020, 8, 8, 17, 30, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixtures, unsatisfied := schedule(rounds, param.DoGenerate{
+				StartDate:         saturday,
+				DaysBetweenRounds: tt.days,
+				Constraints:       tt.constraints,
+			})
+
+			require.Len(t, fixtures, len(tt.kickoffs))
+			for i, f := range fixtures {
+				require.Equal(t, tt.kickoffs[i], f.kickoffAt, "fixture %d", i)
+			}
+
+			for i, home := range tt.homes {
+				require.Equal(t, home, fixtures[i].home, "fixture %d", i)
+			}
+
+			require.Len(t, unsatisfied, len(tt.unsatisfied))
+			for i, u := range unsatisfied {
+				require.Equal(t, tt.unsatisfied[i], u.Constraint)
+			}
+		})
+	}
+}
+
+// TestScheduleDoubleLegSharedVenue ...
+func TestScheduleDoubleLegSharedVenue(t *testing.T) {
+	teamIDs := make([]uuid.UUID, 8)
+	for i := range teamIDs {
+		teamIDs[i] = uuid.NewV4()
+	}
+	rounds := roundRobin(teamIDs, param.LegsDouble)
+
+	swapped := false
+	for i := range teamIDs {
+		for j := i + 1; j < len(teamIDs); j++ {
+			fixtures, _ := schedule(rounds, param.DoGenerate{
+				Legs:              param.LegsDouble,
+				StartDate:         time.Date(2020, 8, 1, 15, 0, 0, 0, time.UTC),
+				DaysBetweenRounds: 7,
+				Constraints: param.Constraints{
+					SharedVenues: [][]uuid.UUID{{teamIDs[i], teamIDs[j]}},
+					RoundDays:    1,
+				},
+			})
+
+			meetings := make(map[pairing]int)
+			for k, f := range fixtures {
+				meetings[f.pairing]++
+				if f.round <= len(rounds)/2 && f.pairing != rounds[f.round-1][k%len(rounds[0])] {
+					swapped = true
+				}
+			}
+
+			for _, home := range teamIDs {
+				for _, away := range teamIDs {
+					if !uuid.Equal(home, away) {
+						require.Equal(t, 1, meetings[pairing{home: home, away: away}], "pair meets once at each ground")
+					}
+				}
+			}
+		}
+	}
+
+	require.True(t, swapped, "some pairing is swapped to free a shared venue")
+}
@@ -0,0 +1,288 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fixture
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/harunnryd/skeltun/internal/app/handler/fixture/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/fixture/transporter"
+	"github.com/satori/uuid"
+)
+
+const (
+	// constraintBlackoutDates is reported when a round could only be played on a blackout date.
+	constraintBlackoutDates = "blackout_dates"
+	// constraintSharedVenues is reported when two teams sharing a venue play at home on the same day.
+	constraintSharedVenues = "shared_venues"
+	// constraintMinRestDays is reported when a team plays again too soon.
+	constraintMinRestDays = "min_rest_days"
+
+	// maxSearchSteps bounds the backtracking search of a single round.
+	maxSearchSteps = 100000
+	// maxShiftDays bounds how far a round is moved forward looking for playable days.
+	maxShiftDays = 366
+)
+
+// scheduled is a pairing placed on a round and a kickoff time.
+type scheduled struct {
+	pairing
+	round     int
+	kickoffAt time.Time
+}
+
+// scheduler is used for placing the rounds of a round-robin on the calendar.
+type scheduler struct {
+	constraints param.Constraints
+	blackouts   map[string]bool
+	venues      map[uuid.UUID]int
+	lastPlayed  map[uuid.UUID]time.Time
+	unsatisfied []transporter.Unsatisfied
+}
+
+// schedule is used for giving every pairing a kickoff time that satisfies the constraints.
+// Each round starts on its regular date, or later when the previous round ran over or the
+// date is blacked out, and may spread over constraints.RoundDays playable days. Within a
+// round the days are assigned by backtracking search, swapping home and away of a pairing
+// when that avoids a broken constraint; when no assignment satisfies every constraint the
+// one breaking the fewest is kept and the broken constraints are reported. With two legs
+// only first leg pairings are swapped, and their return match in the second leg with them,
+// so every pair still meets once at each ground.
+func schedule(rounds [][]pairing, params param.DoGenerate) (fixtures []scheduled, unsatisfied []transporter.Unsatisfied) {
+	s := &scheduler{
+		constraints: params.Constraints,
+		blackouts:   make(map[string]bool),
+		venues:      make(map[uuid.UUID]int),
+		lastPlayed:  make(map[uuid.UUID]time.Time),
+	}
+
+	for _, date := range params.Constraints.BlackoutDates {
+		s.blackouts[dateOf(date)] = true
+	}
+
+	for i, teamIDs := range params.Constraints.SharedVenues {
+		for _, teamID := range teamIDs {
+			s.venues[teamID] = i + 1
+		}
+	}
+
+	roundDays := params.Constraints.RoundDays
+	if roundDays == 0 {
+		roundDays = 1
+	}
+
+	rounds = append([][]pairing(nil), rounds...)
+	secondLeg := len(rounds)
+	if params.Legs == param.LegsDouble {
+		secondLeg = len(rounds) / 2
+	}
+
+	next := params.StartDate
+	for i, round := range rounds {
+		start := params.StartDate.AddDate(0, 0, i*params.DaysBetweenRounds)
+		if start.Before(next) {
+			start = next
+		}
+
+		days := s.window(start, roundDays)
+		if len(days) == 0 {
+			days = []time.Time{start}
+			for _, p := range round {
+				s.report(constraintBlackoutDates, i+1, p, fmt.Sprintf("no playable day found after %s", dateOf(start)))
+			}
+		}
+
+		placed, assigned := s.place(i+1, round, days, i < secondLeg)
+		perDay := make(map[int]int)
+		for j, p := range placed {
+			if p != round[j] {
+				swapReturn(rounds[secondLeg:], round[j])
+			}
+
+			day := days[assigned[j]]
+			s.lastPlayed[p.home], s.lastPlayed[p.away] = day, day
+
+			fixtures = append(fixtures, scheduled{
+				pairing:   p,
+				round:     i + 1,
+				kickoffAt: s.kickoff(day, perDay[assigned[j]]),
+			})
+			perDay[assigned[j]]++
+		}
+
+		next = days[len(days)-1].AddDate(0, 0, 1)
+	}
+
+	return fixtures, s.unsatisfied
+}
+
+// window is used for collecting the playable days of a round, starting from the given day.
+func (s *scheduler) window(start time.Time, size int) (days []time.Time) {
+	for shift := 0; shift < maxShiftDays && len(days) < size; shift++ {
+		if day := start.AddDate(0, 0, shift); !s.blackouts[dateOf(day)] {
+			days = append(days, day)
+		}
+	}
+	return
+}
+
+// place is used for picking a day out of days for every pairing of the round, swapping
+// home and away of a pairing when swappable and that avoids a broken constraint. It returns the pairings
+// as they are played and the index of the picked day for every pairing. The first placement
+// breaking no constraint is kept; otherwise the one breaking the fewest found within
+// maxSearchSteps is kept and its broken constraints are reported.
+func (s *scheduler) place(round int, pairings []pairing, days []time.Time, swappable bool) (placed []pairing, assigned []int) {
+	placed, assigned, best := s.greedy(round, pairings, days, swappable)
+
+	current, currentDays := make([]pairing, len(pairings)), make([]int, len(pairings))
+	steps := 0
+	var search func(j, cost int)
+	search = func(j, cost int) {
+		if j == len(pairings) {
+			best = cost
+			copy(placed, current)
+			copy(assigned, currentDays)
+			return
+		}
+
+		for _, p := range sides(pairings[j], swappable) {
+			for d := range days {
+				if best == 0 {
+					return
+				}
+
+				if steps++; steps > maxSearchSteps {
+					return
+				}
+
+				c := cost + len(s.violations(round, current, currentDays[:j], p, d, days))
+				if c >= best {
+					continue
+				}
+
+				current[j], currentDays[j] = p, d
+				search(j+1, c)
+			}
+		}
+	}
+
+	if best > 0 {
+		search(0, 0)
+	}
+
+	for j, p := range placed {
+		s.unsatisfied = append(s.unsatisfied, s.violations(round, placed, assigned[:j], p, assigned[j], days)...)
+	}
+
+	return
+}
+
+// greedy is used for placing the pairings one after another on the day and sides breaking
+// the fewest constraints. It returns the placement and the number of constraints it breaks.
+func (s *scheduler) greedy(round int, pairings []pairing, days []time.Time, swappable bool) (placed []pairing, assigned []int, cost int) {
+	placed, assigned = make([]pairing, len(pairings)), make([]int, len(pairings))
+
+	for j := range pairings {
+		least := -1
+		for _, p := range sides(pairings[j], swappable) {
+			for d := range days {
+				if n := len(s.violations(round, placed, assigned[:j], p, d, days)); least < 0 || n < least {
+					placed[j], assigned[j], least = p, d, n
+				}
+			}
+		}
+		cost += least
+	}
+
+	return
+}
+
+// violations is used for listing the constraints broken by playing p on days[d],
+// given the pairings of the round already assigned.
+func (s *scheduler) violations(round int, pairings []pairing, assigned []int, p pairing, d int, days []time.Time) (violations []transporter.Unsatisfied) {
+	day := days[d]
+
+	if venue := s.venues[p.home]; venue != 0 {
+		for k, other := range assigned {
+			if other == d && s.venues[pairings[k].home] == venue {
+				violations = append(violations, unsatisfied(constraintSharedVenues, round, p,
+					fmt.Sprintf("venue already used by %s on %s", pairings[k].home, dateOf(day))))
+			}
+		}
+	}
+
+	for _, teamID := range []uuid.UUID{p.home, p.away} {
+		last, ok := s.lastPlayed[teamID]
+		if !ok {
+			continue
+		}
+
+		if rest := daysBetween(last, day); rest < s.constraints.MinRestDays {
+			violations = append(violations, unsatisfied(constraintMinRestDays, round, p,
+				fmt.Sprintf("%s plays again after %d days", teamID, rest)))
+		}
+	}
+
+	return
+}
+
+// kickoff is used for setting the kickoff time of the n-th match played on the day.
+// The preferred slots are handed out in turn, the day keeps its time when there are none.
+func (s *scheduler) kickoff(day time.Time, n int) time.Time {
+	if len(s.constraints.KickoffSlots) == 0 {
+		return day
+	}
+
+	slot, _ := time.Parse(param.KickoffSlotLayout, s.constraints.KickoffSlots[n%len(s.constraints.KickoffSlots)])
+	return time.Date(day.Year(), day.Month(), day.Day(), slot.Hour(), slot.Minute(), 0, 0, day.Location())
+}
+
+// report is used for recording a constraint the scheduler could not satisfy.
+func (s *scheduler) report(constraint string, round int, p pairing, detail string) {
+	s.unsatisfied = append(s.unsatisfied, unsatisfied(constraint, round, p, detail))
+}
+
+func unsatisfied(constraint string, round int, p pairing, detail string) transporter.Unsatisfied {
+	return transporter.Unsatisfied{
+		Constraint: constraint,
+		Round:      round,
+		HomeTeamID: p.home,
+		AwayTeamID: p.away,
+		Detail:     detail,
+	}
+}
+
+// sides is used for listing the ways a pairing can be played, as drawn first.
+func sides(p pairing, swappable bool) []pairing {
+	if !swappable {
+		return []pairing{p}
+	}
+	return []pairing{p, {home: p.away, away: p.home}}
+}
+
+// swapReturn is used for swapping home and away of the return match of drawn, once drawn is
+// played the other way round, so the pair still meets once at each ground.
+func swapReturn(rounds [][]pairing, drawn pairing) {
+	for i, round := range rounds {
+		for j, p := range round {
+			if p.home == drawn.away && p.away == drawn.home {
+				rounds[i] = append([]pairing(nil), round...)
+				rounds[i][j] = drawn
+				return
+			}
+		}
+	}
+}
+
+func dateOf(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+func daysBetween(from, to time.Time) int {
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(to.Sub(from).Hours() / 24)
+}
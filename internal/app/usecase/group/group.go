@@ -0,0 +1,283 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package group
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/group/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/group/transporter"
+	seasonParam "github.com/harunnryd/skeltun/internal/app/handler/season/param"
+	standingParam "github.com/harunnryd/skeltun/internal/app/handler/standing/param"
+	standingTransporter "github.com/harunnryd/skeltun/internal/app/handler/standing/transporter"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	"github.com/harunnryd/skeltun/internal/app/usecase/standing"
+	"github.com/harunnryd/skeltun/internal/pkg"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// IGroup is an interface that stores the methods that Group struct will use.
+type IGroup interface {
+	// DoCreate is used for creating a group of a season out of its registered teams.
+	// It returns doCreateResp of transporter.DoCreate and any errors written.
+	DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error)
+
+	// GetGroups is used for getting all groups of a season with their teams.
+	// It returns getGroupsResp of []transporter.GetGroups and any errors written.
+	GetGroups(ctx context.Context, params param.GetGroups) (getGroupsResp []transporter.GetGroups, err error)
+
+	// GetGroupStandings is used for getting the table of every group of a season.
+	// It returns getGroupStandingsResp of []transporter.GetGroupStandings and any errors written.
+	GetGroupStandings(ctx context.Context, params param.GetGroupStandings) (getGroupStandingsResp []transporter.GetGroupStandings, err error)
+
+	// DoUpdateQualification is used for setting how many teams of the groups qualify for the next phase.
+	// It returns doUpdateQualificationResp of transporter.DoUpdateQualification and any errors written.
+	DoUpdateQualification(ctx context.Context, params param.DoUpdateQualification) (doUpdateQualificationResp transporter.DoUpdateQualification, err error)
+
+	// GetQualifiedTeams is used for getting the teams qualified out of the groups, best seed first.
+	// It returns getQualifiedTeamsResp of []transporter.GetQualifiedTeams and any errors written.
+	GetQualifiedTeams(ctx context.Context, params param.GetQualifiedTeams) (getQualifiedTeamsResp []transporter.GetQualifiedTeams, err error)
+}
+
+// Group is an struct that implements IGroup methods.
+type Group struct {
+	config   config.IConfig
+	repo     repo.IRepo
+	pkg      pkg.IPkg
+	standing standing.IStanding
+}
+
+// New it returns instance of Group that implements IGroup methods.
+func New(opts ...Option) IGroup {
+	g := new(Group)
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// DoCreate is used for creating a group of a season out of its registered teams.
+// A team can only be drawn into one group of the season.
+// It returns doCreateResp of transporter.DoCreate and any errors written.
+func (group *Group) DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error) {
+	getSeasonResp, err := group.repo.GetSeason().GetSeason(ctx, seasonParam.GetSeason{ID: params.SeasonID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getSeasonResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("season not found")}
+		return
+	}
+
+	getSeasonTeamsResp, err := group.repo.GetSeason().GetSeasonTeams(ctx, seasonParam.GetSeasonTeams{SeasonID: params.SeasonID})
+	if err != nil {
+		return
+	}
+
+	registered := make(map[uuid.UUID]bool, len(getSeasonTeamsResp))
+	for _, team := range getSeasonTeamsResp {
+		registered[team.ID] = true
+	}
+
+	getGroupsResp, err := group.repo.GetGroup().GetGroups(ctx, param.GetGroups{SeasonID: params.SeasonID})
+	if err != nil {
+		return
+	}
+
+	grouped := make(map[uuid.UUID]string)
+	for _, existing := range getGroupsResp {
+		if existing.Name == params.Name {
+			err = &iPkgError.ValidationError{Err: errors.New("group " + params.Name + " already exists")}
+			return
+		}
+
+		for _, team := range existing.Teams {
+			grouped[team.TeamID] = existing.Name
+		}
+	}
+
+	listed := make(map[uuid.UUID]bool, len(params.TeamIDs))
+	for _, teamID := range params.TeamIDs {
+		if !registered[teamID] {
+			err = &iPkgError.ValidationError{Err: errors.New("team " + teamID.String() + " is not registered in the season")}
+			return
+		}
+
+		if name, ok := grouped[teamID]; ok {
+			err = &iPkgError.ValidationError{Err: errors.New("team " + teamID.String() + " is already in group " + name)}
+			return
+		}
+
+		if listed[teamID] {
+			err = &iPkgError.ValidationError{Err: errors.New("team " + teamID.String() + " is listed more than once")}
+			return
+		}
+		listed[teamID] = true
+	}
+
+	doCreateResp, err = group.repo.GetGroup().DoCreate(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// GetGroups is used for getting all groups of a season with their teams.
+// It returns getGroupsResp of []transporter.GetGroups and any errors written.
+func (group *Group) GetGroups(ctx context.Context, params param.GetGroups) (getGroupsResp []transporter.GetGroups, err error) {
+	getGroupsResp, err = group.repo.GetGroup().GetGroups(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// GetGroupStandings is used for getting the table of every group of a season.
+// Every group is ranked with the same points and tiebreakers as the league table,
+// counting only the matches played between the teams of the group.
+// It returns getGroupStandingsResp of []transporter.GetGroupStandings and any errors written.
+func (group *Group) GetGroupStandings(ctx context.Context, params param.GetGroupStandings) (getGroupStandingsResp []transporter.GetGroupStandings, err error) {
+	getGroupsResp, err := group.repo.GetGroup().GetGroups(ctx, param.GetGroups{SeasonID: params.SeasonID})
+	if err != nil {
+		return
+	}
+
+	for _, g := range getGroupsResp {
+		getStandingsParam := standingParam.GetStandings{SeasonID: params.SeasonID}
+		for _, team := range g.Teams {
+			getStandingsParam.TeamIDs = append(getStandingsParam.TeamIDs, team.TeamID)
+		}
+
+		getStandingsResp, err := group.standing.GetStandings(ctx, getStandingsParam)
+		if err != nil {
+			return nil, err
+		}
+
+		groupStandings := transporter.GetGroupStandings{Group: g.Group}
+		for _, row := range getStandingsResp {
+			groupStandings.Standings = append(groupStandings.Standings, row.Standing)
+		}
+
+		getGroupStandingsResp = append(getGroupStandingsResp, groupStandings)
+	}
+
+	return
+}
+
+// DoUpdateQualification is used for setting how many teams of the groups qualify for the next phase.
+// It returns doUpdateQualificationResp of transporter.DoUpdateQualification and any errors written.
+func (group *Group) DoUpdateQualification(ctx context.Context, params param.DoUpdateQualification) (doUpdateQualificationResp transporter.DoUpdateQualification, err error) {
+	getSeasonResp, err := group.repo.GetSeason().GetSeason(ctx, seasonParam.GetSeason{ID: params.SeasonID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getSeasonResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("season not found")}
+		return
+	}
+
+	doUpdateQualificationResp, err = group.repo.GetGroup().DoUpdateQualification(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// GetQualifiedTeams is used for getting the teams qualified out of the groups, best seed first.
+// The best TopPerGroup teams of every group qualify, then the BestPlaced best teams finishing right
+// below them across all groups, like the best third-placed teams. Teams are seeded by their finishing
+// position first, then by points, goal difference and goals scored, so group winners come before runners-up.
+// It returns getQualifiedTeamsResp of []transporter.GetQualifiedTeams and any errors written.
+func (group *Group) GetQualifiedTeams(ctx context.Context, params param.GetQualifiedTeams) (getQualifiedTeamsResp []transporter.GetQualifiedTeams, err error) {
+	getQualificationResp, err := group.repo.GetGroup().GetQualification(ctx, param.GetQualification{SeasonID: params.SeasonID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getQualificationResp.SeasonID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("season has no qualification rules")}
+		return
+	}
+
+	getGroupStandingsResp, err := group.GetGroupStandings(ctx, param.GetGroupStandings{SeasonID: params.SeasonID})
+	if err != nil {
+		return
+	}
+
+	if len(getGroupStandingsResp) == 0 {
+		err = &iPkgError.ValidationError{Err: errors.New("season has no groups")}
+		return
+	}
+
+	for i, team := range qualify(getGroupStandingsResp, getQualificationResp.Qualification) {
+		team.Seed = i + 1
+		getQualifiedTeamsResp = append(getQualifiedTeamsResp, transporter.GetQualifiedTeams{QualifiedTeam: team})
+	}
+
+	return
+}
+
+// qualify is used for picking the qualified teams out of the group tables, best seed first.
+func qualify(groups []transporter.GetGroupStandings, rules transporter.Qualification) (qualified []transporter.QualifiedTeam) {
+	for position := 1; position <= rules.TopPerGroup; position++ {
+		qualified = append(qualified, placed(groups, position)...)
+	}
+
+	best := placed(groups, rules.TopPerGroup+1)
+	if len(best) > rules.BestPlaced {
+		best = best[:rules.BestPlaced]
+	}
+
+	return append(qualified, best...)
+}
+
+// placed is used for listing the teams finishing at the given position of every group,
+// the best of them first. Groups too small to have that position are skipped.
+func placed(groups []transporter.GetGroupStandings, position int) (teams []transporter.QualifiedTeam) {
+	type candidate struct {
+		row   standingTransporter.Standing
+		group transporter.Group
+	}
+
+	var candidates []candidate
+	for _, g := range groups {
+		if position <= len(g.Standings) {
+			candidates = append(candidates, candidate{row: g.Standings[position-1], group: g.Group})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i].row, candidates[j].row
+		if a.Points != b.Points {
+			return a.Points > b.Points
+		}
+		if a.GoalDifference != b.GoalDifference {
+			return a.GoalDifference > b.GoalDifference
+		}
+		return a.GoalsFor > b.GoalsFor
+	})
+
+	for _, c := range candidates {
+		teams = append(teams, transporter.QualifiedTeam{
+			TeamID:    c.row.TeamID,
+			TeamName:  c.row.TeamName,
+			GroupID:   c.group.ID,
+			GroupName: c.group.Name,
+			Position:  position,
+			Points:    c.row.Points,
+		})
+	}
+
+	return
+}
@@ -0,0 +1,255 @@
+package group
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/handler/group/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/group/transporter"
+	standingTransporter "github.com/harunnryd/skeltun/internal/app/handler/standing/transporter"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	iGroupRepo "github.com/harunnryd/skeltun/internal/app/repo/group"
+	iSeasonRepo "github.com/harunnryd/skeltun/internal/app/repo/season"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	mock      sqlmock.Sqlmock
+	pgsqlConn *gorm.DB
+
+	iGroupRepo  iGroupRepo.IGroup
+	iSeasonRepo iSeasonRepo.ISeason
+	iRepo       repo.IRepo
+	group       IGroup
+	helper
+	response
+}
+
+type helper struct {
+	err error
+	db  *sql.DB
+}
+
+type response struct {
+	doCreateResp          transporter.DoCreate
+	getQualifiedTeamsResp []transporter.GetQualifiedTeams
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.iGroupRepo = iGroupRepo.New(
+		iGroupRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iSeasonRepo = iSeasonRepo.New(
+		iSeasonRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iRepo = repo.New()
+	suite.iRepo.SetGroup(suite.iGroupRepo)
+	suite.iRepo.SetSeason(suite.iSeasonRepo)
+
+	suite.group = New(WithRepo(suite.iRepo))
+}
+
+// TestDoCreate ...
+func (suite *Suite) TestDoCreate() {
+	arsenalID, chelseaID, groupID := uuid.NewV4(), uuid.NewV4(), uuid.NewV4()
+	params := param.DoCreate{
+		SeasonID: uuid.NewV4(),
+		Name:     "B",
+		TeamIDs:  []uuid.UUID{arsenalID, chelseaID},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seasons" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(params.SeasonID, "2020/21"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT "teams"."id","teams"."name","teams"."association" FROM "teams" JOIN season_teams ON season_teams.team_id = teams.id WHERE season_teams.season_id = $1 ORDER BY teams.name`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(arsenalID, "Arsenal").
+			AddRow(chelseaID, "Chelsea"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "groups" WHERE season_id = $1 ORDER BY name`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "groups"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.SeasonID, params.Name).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(groupID))
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`INSERT INTO "group_teams"`)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	suite.mock.ExpectCommit()
+
+	suite.response.doCreateResp, suite.helper.err = suite.group.DoCreate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), groupID, suite.response.doCreateResp.ID)
+}
+
+// TestDoCreateTeamAlreadyGrouped ...
+func (suite *Suite) TestDoCreateTeamAlreadyGrouped() {
+	arsenalID, chelseaID, groupID := uuid.NewV4(), uuid.NewV4(), uuid.NewV4()
+	params := param.DoCreate{
+		SeasonID: uuid.NewV4(),
+		Name:     "B",
+		TeamIDs:  []uuid.UUID{arsenalID, chelseaID},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seasons" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(params.SeasonID, "2020/21"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT "teams"."id","teams"."name","teams"."association" FROM "teams" JOIN season_teams ON season_teams.team_id = teams.id WHERE season_teams.season_id = $1 ORDER BY teams.name`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(arsenalID, "Arsenal").
+			AddRow(chelseaID, "Chelsea"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "groups" WHERE season_id = $1 ORDER BY name`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "season_id", "name"}).
+			AddRow(groupID, params.SeasonID, "A"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "group_teams" WHERE "group_teams"."group_id" = $1`)).
+		WithArgs(groupID).
+		WillReturnRows(sqlmock.NewRows([]string{"group_id", "team_id"}).
+			AddRow(groupID, chelseaID))
+
+	suite.response.doCreateResp, suite.helper.err = suite.group.DoCreate(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "team "+chelseaID.String()+" is already in group A")
+}
+
+// TestGetQualifiedTeamsWithoutRules ...
+func (suite *Suite) TestGetQualifiedTeamsWithoutRules() {
+	params := param.GetQualifiedTeams{
+		SeasonID: uuid.NewV4(),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "qualification_rules" WHERE season_id = $1 LIMIT 1`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"season_id"}))
+
+	suite.response.getQualifiedTeamsResp, suite.helper.err = suite.group.GetQualifiedTeams(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "season has no qualification rules")
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+// TestQualify ...
+func TestQualify(t *testing.T) {
+	row := func(name string, points, goalDifference, goalsFor int) standingTransporter.Standing {
+		return standingTransporter.Standing{
+			TeamID:         uuid.NewV4(),
+			TeamName:       name,
+			Points:         points,
+			GoalDifference: goalDifference,
+			GoalsFor:       goalsFor,
+		}
+	}
+
+	groups := []transporter.GetGroupStandings{
+		{
+			Group:     transporter.Group{ID: uuid.NewV4(), Name: "A"},
+			Standings: []standingTransporter.Standing{row("Arsenal", 7, 4, 6), row("Benfica", 6, 2, 5), row("Celtic", 4, 0, 3), row("Dynamo", 0, -6, 1)},
+		},
+		{
+			Group:     transporter.Group{ID: uuid.NewV4(), Name: "B"},
+			Standings: []standingTransporter.Standing{row("Everton", 9, 7, 8), row("Fenerbahce", 4, 1, 4), row("Galatasaray", 4, -1, 4), row("Hajduk", 0, -7, 0)},
+		},
+		{
+			Group:     transporter.Group{ID: uuid.NewV4(), Name: "C"},
+			Standings: []standingTransporter.Standing{row("Inter", 5, 2, 4), row("Juventus", 5, 1, 3), row("Kaiserslautern", 4, 0, 5)},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		rules transporter.Qualification
+		teams []string
+	}{
+		{
+			name:  "group winners",
+			rules: transporter.Qualification{TopPerGroup: 1},
+			teams: []string{"Everton", "Arsenal", "Inter"},
+		},
+		{
+			name:  "top two with the best two third-placed teams",
+			rules: transporter.Qualification{TopPerGroup: 2, BestPlaced: 2},
+			teams: []string{"Everton", "Arsenal", "Inter", "Benfica", "Juventus", "Fenerbahce", "Kaiserslautern", "Celtic"},
+		},
+		{
+			name:  "more best-placed teams than groups",
+			rules: transporter.Qualification{TopPerGroup: 3, BestPlaced: 4},
+			teams: []string{"Everton", "Arsenal", "Inter", "Benfica", "Juventus", "Fenerbahce", "Kaiserslautern", "Celtic", "Galatasaray", "Dynamo", "Hajduk"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var teams []string
+			for _, team := range qualify(groups, tt.rules) {
+				teams = append(teams, team.TeamName)
+			}
+			require.Equal(t, tt.teams, teams)
+		})
+	}
+}
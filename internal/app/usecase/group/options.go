@@ -0,0 +1,43 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package group
+
+import (
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	"github.com/harunnryd/skeltun/internal/app/usecase/standing"
+	"github.com/harunnryd/skeltun/internal/pkg"
+)
+
+// Option is a closure that is used for accessing the local variables.
+type Option func(group *Group)
+
+// WithConfig ...
+func WithConfig(config config.IConfig) Option {
+	return func(group *Group) {
+		group.config = config
+	}
+}
+
+// WithRepo ...
+func WithRepo(repo repo.IRepo) Option {
+	return func(group *Group) {
+		group.repo = repo
+	}
+}
+
+// WithPkg ...
+func WithPkg(pkg pkg.IPkg) Option {
+	return func(group *Group) {
+		group.pkg = pkg
+	}
+}
+
+// WithStanding ...
+func WithStanding(standing standing.IStanding) Option {
+	return func(group *Group) {
+		group.standing = standing
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package injury
+
+import (
+	"context"
+	"errors"
+
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/injury/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/injury/transporter"
+	playerParam "github.com/harunnryd/skeltun/internal/app/handler/player/param"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	"github.com/harunnryd/skeltun/internal/pkg"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// IInjury is an interface that stores the methods that Injury struct will use.
+type IInjury interface {
+	// DoCreate is used for record new injury of a player.
+	// It returns doCreateResp of transporter.DoCreate and any errors written.
+	DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error)
+
+	// GetInjuries is used for getting all injuries of a player, the latest first.
+	// It returns getInjuriesResp of []transporter.GetInjuries and any errors written.
+	GetInjuries(ctx context.Context, params param.GetInjuries) (getInjuriesResp []transporter.GetInjuries, err error)
+
+	// DoUpdate is used for update the record injury, such as pushing back or setting the expected return.
+	// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+	DoUpdate(ctx context.Context, params param.DoUpdate) (doUpdateResp transporter.DoUpdate, err error)
+}
+
+// Injury is an struct that implements IInjury methods.
+type Injury struct {
+	config config.IConfig
+	repo   repo.IRepo
+	pkg    pkg.IPkg
+}
+
+// New it returns instance of Injury that implements IInjury methods.
+func New(opts ...Option) IInjury {
+	i := new(Injury)
+	for _, opt := range opts {
+		opt(i)
+	}
+	return i
+}
+
+// DoCreate is used for record new injury of a player.
+// It returns doCreateResp of transporter.DoCreate and any errors written.
+func (injury *Injury) DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error) {
+	getPlayerResp, err := injury.repo.GetPlayer().GetPlayer(ctx, playerParam.GetPlayer{ID: params.PlayerID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getPlayerResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("player not found")}
+		return
+	}
+
+	doCreateResp, err = injury.repo.GetInjury().DoCreate(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// GetInjuries is used for getting all injuries of a player, the latest first.
+// It returns getInjuriesResp of []transporter.GetInjuries and any errors written.
+func (injury *Injury) GetInjuries(ctx context.Context, params param.GetInjuries) (getInjuriesResp []transporter.GetInjuries, err error) {
+	getInjuriesResp, err = injury.repo.GetInjury().GetInjuries(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// DoUpdate is used for update the record injury, such as pushing back or setting the expected return.
+// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+func (injury *Injury) DoUpdate(ctx context.Context, params param.DoUpdate) (doUpdateResp transporter.DoUpdate, err error) {
+	getInjuryResp, err := injury.repo.GetInjury().GetInjury(ctx, param.GetInjury{ID: params.ID, PlayerID: params.PlayerID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getInjuryResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("injury not found")}
+		return
+	}
+
+	doUpdateResp, err = injury.repo.GetInjury().DoUpdate(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
@@ -0,0 +1,165 @@
+package injury
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/handler/injury/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/injury/transporter"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	iInjuryRepo "github.com/harunnryd/skeltun/internal/app/repo/injury"
+	iPlayerRepo "github.com/harunnryd/skeltun/internal/app/repo/player"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	mock      sqlmock.Sqlmock
+	pgsqlConn *gorm.DB
+
+	iInjuryRepo iInjuryRepo.IInjury
+	iPlayerRepo iPlayerRepo.IPlayer
+	iRepo       repo.IRepo
+	injury      IInjury
+	helper
+	response
+}
+
+type helper struct {
+	err error
+	db  *sql.DB
+}
+
+type response struct {
+	doCreateResp transporter.DoCreate
+	doUpdateResp transporter.DoUpdate
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.iInjuryRepo = iInjuryRepo.New(
+		iInjuryRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iPlayerRepo = iPlayerRepo.New(
+		iPlayerRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iRepo = repo.New()
+	suite.iRepo.SetInjury(suite.iInjuryRepo)
+	suite.iRepo.SetPlayer(suite.iPlayerRepo)
+
+	suite.injury = New(WithRepo(suite.iRepo))
+}
+
+// TestDoCreate ...
+func (suite *Suite) TestDoCreate() {
+	expectedReturnAt := time.Date(2021, time.October, 2, 0, 0, 0, 0, time.UTC)
+	params := param.DoCreate{
+		Injury: param.Injury{
+			PlayerID:         uuid.NewV4(),
+			Type:             "ankle sprain",
+			StartedAt:        time.Date(2021, time.September, 11, 0, 0, 0, 0, time.UTC),
+			ExpectedReturnAt: &expectedReturnAt,
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.PlayerID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "team_id", "name"}).
+			AddRow(params.PlayerID, uuid.NewV4(), "John Doe"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "injuries"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.PlayerID, params.Type, params.StartedAt, expectedReturnAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.response.doCreateResp, suite.helper.err = suite.injury.DoCreate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), params.PlayerID, suite.response.doCreateResp.PlayerID)
+	require.Equal(suite.T(), &expectedReturnAt, suite.response.doCreateResp.ExpectedReturnAt)
+}
+
+// TestDoCreatePlayerNotFound ...
+func (suite *Suite) TestDoCreatePlayerNotFound() {
+	params := param.DoCreate{
+		Injury: param.Injury{
+			PlayerID:  uuid.NewV4(),
+			Type:      "ankle sprain",
+			StartedAt: time.Date(2021, time.September, 11, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.PlayerID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.response.doCreateResp, suite.helper.err = suite.injury.DoCreate(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "player not found")
+}
+
+// TestDoUpdateNotFound ...
+func (suite *Suite) TestDoUpdateNotFound() {
+	params := param.DoUpdate{
+		Injury: param.Injury{
+			ID:        uuid.NewV4(),
+			PlayerID:  uuid.NewV4(),
+			Type:      "ankle sprain",
+			StartedAt: time.Date(2021, time.September, 11, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "injuries" WHERE id = $1 AND player_id = $2 AND deleted_at IS NULL LIMIT 1`)).
+		WithArgs(params.ID, params.PlayerID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.response.doUpdateResp, suite.helper.err = suite.injury.DoUpdate(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "injury not found")
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
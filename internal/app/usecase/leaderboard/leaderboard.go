@@ -0,0 +1,173 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package leaderboard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/leaderboard/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/leaderboard/transporter"
+	seasonParam "github.com/harunnryd/skeltun/internal/app/handler/season/param"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	"github.com/harunnryd/skeltun/internal/pkg"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// cacheTTL is the number of seconds a leaderboard page is cached for when "leaderboards.cache.ttl" is not set.
+const cacheTTL = 300
+
+// ILeaderboard is an interface that stores the methods that Leaderboard struct will use.
+type ILeaderboard interface {
+	// GetLeaderboard is used for getting a page of the players of a season ranked by a metric.
+	// It returns getLeaderboardResp of transporter.GetLeaderboard and any errors written.
+	GetLeaderboard(ctx context.Context, params param.GetLeaderboard) (getLeaderboardResp transporter.GetLeaderboard, err error)
+
+	// DoInvalidate is used for dropping every cached leaderboard page of a season.
+	// It returns doInvalidateResp of transporter.DoInvalidate and any errors written.
+	DoInvalidate(ctx context.Context, params param.DoInvalidate) (doInvalidateResp transporter.DoInvalidate, err error)
+}
+
+// Leaderboard is an struct that implements ILeaderboard methods.
+type Leaderboard struct {
+	config config.IConfig
+	repo   repo.IRepo
+	pkg    pkg.IPkg
+	redis  *redis.Pool
+}
+
+// New it returns instance of Leaderboard that implements ILeaderboard methods.
+func New(opts ...Option) ILeaderboard {
+	l := new(Leaderboard)
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// GetLeaderboard is used for getting a page of the players of a season ranked by a metric.
+// Pages are served from Redis when they are cached, the database is only read on a miss.
+// The cache is left out when Redis cannot be reached.
+// It returns getLeaderboardResp of transporter.GetLeaderboard and any errors written.
+func (leaderboard *Leaderboard) GetLeaderboard(ctx context.Context, params param.GetLeaderboard) (getLeaderboardResp transporter.GetLeaderboard, err error) {
+	key, cached := leaderboard.cacheKey(params)
+	if cached {
+		if reply, err := redis.Bytes(leaderboard.do("GET", key)); err == nil && json.Unmarshal(reply, &getLeaderboardResp) == nil {
+			return getLeaderboardResp, nil
+		}
+	}
+
+	getSeasonResp, err := leaderboard.repo.GetSeason().GetSeason(ctx, seasonParam.GetSeason{ID: params.SeasonID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getSeasonResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("season not found")}
+		return
+	}
+
+	getEntriesResp, err := leaderboard.repo.GetLeaderboard().GetEntries(ctx, params)
+	if err != nil {
+		return
+	}
+
+	getLeaderboardResp = page(params, getEntriesResp)
+
+	if cached {
+		if reply, err := json.Marshal(getLeaderboardResp); err == nil {
+			_, _ = leaderboard.do("SET", key, reply, "EX", leaderboard.cacheTTL())
+		}
+	}
+
+	return
+}
+
+// DoInvalidate is used for dropping every cached leaderboard page of a season.
+// The pages are not deleted one by one, moving the season to a new version makes every one of them miss
+// and the old ones expire on their own.
+// It returns doInvalidateResp of transporter.DoInvalidate and any errors written.
+func (leaderboard *Leaderboard) DoInvalidate(ctx context.Context, params param.DoInvalidate) (doInvalidateResp transporter.DoInvalidate, err error) {
+	doInvalidateResp.SeasonID = params.SeasonID
+	if leaderboard.redis == nil {
+		return
+	}
+
+	doInvalidateResp.Version, err = redis.Int(leaderboard.do("INCR", leaderboard.versionKey(params.SeasonID)))
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// do is used for sending a single command to Redis.
+func (leaderboard *Leaderboard) do(command string, args ...interface{}) (reply interface{}, err error) {
+	conn := leaderboard.redis.Get()
+	defer conn.Close()
+
+	return conn.Do(command, args...)
+}
+
+// cacheKey is used for naming the cached page of a leaderboard after the current version of its season.
+// It gives back false when pages cannot be cached.
+func (leaderboard *Leaderboard) cacheKey(params param.GetLeaderboard) (key string, ok bool) {
+	if leaderboard.redis == nil {
+		return
+	}
+
+	version, err := redis.Int(leaderboard.do("GET", leaderboard.versionKey(params.SeasonID)))
+	if err != nil && err != redis.ErrNil {
+		return
+	}
+
+	return fmt.Sprintf("%s:leaderboards:%s:%d:%s:%d:%d:%s",
+		leaderboard.namespace(), params.SeasonID, version, params.Metric, params.GetMinAppearances(), params.GetLimit(), params.Cursor), true
+}
+
+// versionKey is used for naming the counter every cached page of a season is named after.
+func (leaderboard *Leaderboard) versionKey(seasonID uuid.UUID) string {
+	return fmt.Sprintf("%s:leaderboards:%s:version", leaderboard.namespace(), seasonID)
+}
+
+// namespace is used for keeping the keys apart from the ones of other apps sharing Redis.
+func (leaderboard *Leaderboard) namespace() string {
+	if leaderboard.config == nil {
+		return ""
+	}
+	return leaderboard.config.GetString("app.name")
+}
+
+// cacheTTL is used for getting how many seconds a page stays cached.
+func (leaderboard *Leaderboard) cacheTTL() int {
+	if leaderboard.config == nil || leaderboard.config.GetInt("leaderboards.cache.ttl") <= 0 {
+		return cacheTTL
+	}
+	return leaderboard.config.GetInt("leaderboards.cache.ttl")
+}
+
+// page is used for cutting the entries down to the limit, pointing the next page at the last entry kept.
+func page(params param.GetLeaderboard, entries []transporter.GetEntries) (getLeaderboardResp transporter.GetLeaderboard) {
+	getLeaderboardResp.SeasonID = params.SeasonID
+	getLeaderboardResp.Metric = params.Metric
+	getLeaderboardResp.Entries = make([]transporter.Entry, 0, len(entries))
+
+	for i, entry := range entries {
+		if i == params.GetLimit() {
+			last := getLeaderboardResp.Entries[i-1]
+			getLeaderboardResp.NextCursor = param.NewCursor(last.Value, last.PlayerID)
+			break
+		}
+		getLeaderboardResp.Entries = append(getLeaderboardResp.Entries, entry.Entry)
+	}
+
+	return
+}
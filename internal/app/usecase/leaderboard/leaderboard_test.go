@@ -0,0 +1,261 @@
+package leaderboard
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/handler/leaderboard/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/leaderboard/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	iLeaderboardRepo "github.com/harunnryd/skeltun/internal/app/repo/leaderboard"
+	iSeasonRepo "github.com/harunnryd/skeltun/internal/app/repo/season"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	mock      sqlmock.Sqlmock
+	pgsqlConn *gorm.DB
+
+	iLeaderboardRepo iLeaderboardRepo.ILeaderboard
+	iSeasonRepo      iSeasonRepo.ISeason
+	iRepo            repo.IRepo
+	redis            *fakeRedis
+	leaderboard      ILeaderboard
+	helper
+	response
+}
+
+type helper struct {
+	err error
+	db  *sql.DB
+}
+
+type response struct {
+	getLeaderboardResp transporter.GetLeaderboard
+	doInvalidateResp   transporter.DoInvalidate
+}
+
+// fakeRedis keeps the values written by the usecase in memory instead of sending them to redis.
+type fakeRedis struct {
+	values map[string][]byte
+}
+
+// Close ...
+func (conn *fakeRedis) Close() error { return nil }
+
+// Err ...
+func (conn *fakeRedis) Err() error { return nil }
+
+// Send ...
+func (conn *fakeRedis) Send(commandName string, args ...interface{}) error { return nil }
+
+// Flush ...
+func (conn *fakeRedis) Flush() error { return nil }
+
+// Receive ...
+func (conn *fakeRedis) Receive() (reply interface{}, err error) { return }
+
+// Do ...
+func (conn *fakeRedis) Do(commandName string, args ...interface{}) (reply interface{}, err error) {
+	switch commandName {
+	case "GET":
+		if value, ok := conn.values[args[0].(string)]; ok {
+			return value, nil
+		}
+	case "SET":
+		conn.values[args[0].(string)] = args[1].([]byte)
+		return "OK", nil
+	case "INCR":
+		version, _ := strconv.Atoi(string(conn.values[args[0].(string)]))
+		conn.values[args[0].(string)] = []byte(strconv.Itoa(version + 1))
+		return int64(version + 1), nil
+	}
+	return
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.iLeaderboardRepo = iLeaderboardRepo.New(
+		iLeaderboardRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iSeasonRepo = iSeasonRepo.New(
+		iSeasonRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iRepo = repo.New()
+	suite.iRepo.SetLeaderboard(suite.iLeaderboardRepo)
+	suite.iRepo.SetSeason(suite.iSeasonRepo)
+
+	suite.redis = &fakeRedis{values: make(map[string][]byte)}
+
+	suite.leaderboard = New(
+		WithRepo(suite.iRepo),
+		WithRedis(&redis.Pool{
+			Dial: func() (redis.Conn, error) {
+				return suite.redis, nil
+			},
+		}),
+	)
+}
+
+// expectLeaderboard expects the season and one page of its leaderboard to be read from the database.
+func (suite *Suite) expectLeaderboard(params param.GetLeaderboard, playerID uuid.UUID) {
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seasons" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(params.SeasonID, "2021/22"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`AS leaderboard ORDER BY value DESC, player_id LIMIT 2`)).
+		WithArgs(params.SeasonID, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"player_id", "name", "rank", "value"}).
+			AddRow(playerID, "John Doe", 1, 12))
+}
+
+// TestGetLeaderboard ...
+func (suite *Suite) TestGetLeaderboard() {
+	params := param.GetLeaderboard{
+		SeasonID: uuid.NewV4(),
+		Metric:   model.LeaderboardMetricGoals,
+		Limit:    "1",
+	}
+	playerID := uuid.NewV4()
+
+	suite.expectLeaderboard(params, playerID)
+
+	suite.response.getLeaderboardResp, suite.helper.err = suite.leaderboard.GetLeaderboard(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Len(suite.T(), suite.response.getLeaderboardResp.Entries, 1)
+
+	// The second read is served from the cache, without any query.
+	suite.response.getLeaderboardResp, suite.helper.err = suite.leaderboard.GetLeaderboard(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), playerID, suite.response.getLeaderboardResp.Entries[0].PlayerID)
+	require.Equal(suite.T(), 12, suite.response.getLeaderboardResp.Entries[0].Value)
+}
+
+// TestGetLeaderboardInvalidated ...
+func (suite *Suite) TestGetLeaderboardInvalidated() {
+	params := param.GetLeaderboard{
+		SeasonID: uuid.NewV4(),
+		Metric:   model.LeaderboardMetricAssists,
+		Limit:    "1",
+	}
+
+	suite.expectLeaderboard(params, uuid.NewV4())
+
+	suite.response.getLeaderboardResp, suite.helper.err = suite.leaderboard.GetLeaderboard(context.Background(), params)
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.response.doInvalidateResp, suite.helper.err = suite.leaderboard.DoInvalidate(context.Background(), param.DoInvalidate{SeasonID: params.SeasonID})
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), 1, suite.response.doInvalidateResp.Version)
+
+	suite.expectLeaderboard(params, uuid.NewV4())
+
+	suite.response.getLeaderboardResp, suite.helper.err = suite.leaderboard.GetLeaderboard(context.Background(), params)
+	require.NoError(suite.T(), suite.helper.err)
+}
+
+// TestGetLeaderboardSeasonNotFound ...
+func (suite *Suite) TestGetLeaderboardSeasonNotFound() {
+	params := param.GetLeaderboard{
+		SeasonID: uuid.NewV4(),
+		Metric:   model.LeaderboardMetricCleanSheets,
+		Limit:    "10",
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seasons" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.response.getLeaderboardResp, suite.helper.err = suite.leaderboard.GetLeaderboard(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "season not found")
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+// TestPage ...
+func TestPage(t *testing.T) {
+	firstPlayerID, secondPlayerID, thirdPlayerID := uuid.NewV4(), uuid.NewV4(), uuid.NewV4()
+	entries := []transporter.GetEntries{
+		{Entry: transporter.Entry{Rank: 1, PlayerID: firstPlayerID, Value: 9}},
+		{Entry: transporter.Entry{Rank: 1, PlayerID: secondPlayerID, Value: 9}},
+		{Entry: transporter.Entry{Rank: 3, PlayerID: thirdPlayerID, Value: 7}},
+	}
+
+	tests := []struct {
+		name           string
+		limit          string
+		wantEntries    int
+		wantNextCursor string
+	}{
+		{
+			name:           "more entries than the limit",
+			limit:          "2",
+			wantEntries:    2,
+			wantNextCursor: param.NewCursor(9, secondPlayerID),
+		},
+		{
+			name:        "last page",
+			limit:       "3",
+			wantEntries: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getLeaderboardResp := page(param.GetLeaderboard{Limit: tt.limit}, entries)
+			require.Len(t, getLeaderboardResp.Entries, tt.wantEntries)
+			require.Equal(t, tt.wantNextCursor, getLeaderboardResp.NextCursor)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package leaderboard
+
+import (
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	"github.com/harunnryd/skeltun/internal/pkg"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// Option is a closure that is used for accessing the local variables.
+type Option func(leaderboard *Leaderboard)
+
+// WithConfig ...
+func WithConfig(config config.IConfig) Option {
+	return func(leaderboard *Leaderboard) {
+		leaderboard.config = config
+	}
+}
+
+// WithRepo ...
+func WithRepo(repo repo.IRepo) Option {
+	return func(leaderboard *Leaderboard) {
+		leaderboard.repo = repo
+	}
+}
+
+// WithPkg ...
+func WithPkg(pkg pkg.IPkg) Option {
+	return func(leaderboard *Leaderboard) {
+		leaderboard.pkg = pkg
+	}
+}
+
+// WithRedis ...
+func WithRedis(redis *redis.Pool) Option {
+	return func(leaderboard *Leaderboard) {
+		leaderboard.redis = redis
+	}
+}
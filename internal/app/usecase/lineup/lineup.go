@@ -0,0 +1,254 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lineup
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/harunnryd/skeltun/config"
+	availabilityParam "github.com/harunnryd/skeltun/internal/app/handler/availability/param"
+	competitionParam "github.com/harunnryd/skeltun/internal/app/handler/competition/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/lineup/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/lineup/transporter"
+	matchParam "github.com/harunnryd/skeltun/internal/app/handler/match/param"
+	matchEventParam "github.com/harunnryd/skeltun/internal/app/handler/matchevent/param"
+	matchEventTransporter "github.com/harunnryd/skeltun/internal/app/handler/matchevent/transporter"
+	seasonParam "github.com/harunnryd/skeltun/internal/app/handler/season/param"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	"github.com/harunnryd/skeltun/internal/app/usecase/availability"
+	"github.com/harunnryd/skeltun/internal/pkg"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// ILineup is an interface that stores the methods that Lineup struct will use.
+type ILineup interface {
+	// DoUpdate is used for record the lineup of a team for a match, replacing the previous one.
+	// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+	DoUpdate(ctx context.Context, params param.DoUpdate) (doUpdateResp transporter.DoUpdate, err error)
+
+	// GetLineups is used for getting the lineups of both teams of a match.
+	// It returns getLineupsResp of []transporter.GetLineups and any errors written.
+	GetLineups(ctx context.Context, params param.GetLineups) (getLineupsResp []transporter.GetLineups, err error)
+
+	// CheckSubstitution is used for making sure a substitution takes a player off the pitch for one on the bench.
+	// It returns any errors written.
+	CheckSubstitution(ctx context.Context, params param.CheckSubstitution) (err error)
+}
+
+// Lineup is an struct that implements ILineup methods.
+type Lineup struct {
+	config       config.IConfig
+	repo         repo.IRepo
+	pkg          pkg.IPkg
+	availability availability.IAvailability
+}
+
+// New it returns instance of Lineup that implements ILineup methods.
+func New(opts ...Option) ILineup {
+	l := new(Lineup)
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// DoUpdate is used for record the lineup of a team for a match, replacing the previous one.
+// Every player should belong to the team and be available on the day, neither injured nor suspended
+// for the season, with a single goalkeeper among the starters and no more substitutes than the competition allows.
+// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+func (lineup *Lineup) DoUpdate(ctx context.Context, params param.DoUpdate) (doUpdateResp transporter.DoUpdate, err error) {
+	getMatchResp, err := lineup.repo.GetMatch().GetMatch(ctx, matchParam.GetMatch{ID: params.MatchID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getMatchResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("match not found")}
+		return
+	}
+
+	if !uuid.Equal(params.TeamID, getMatchResp.HomeTeamID) && !uuid.Equal(params.TeamID, getMatchResp.AwayTeamID) {
+		err = &iPkgError.ValidationError{Err: errors.New("team does not play the match")}
+		return
+	}
+
+	if getMatchResp.Status != model.MatchStatusScheduled {
+		err = &iPkgError.ValidationError{Err: errors.New("lineup cannot be changed once the match has kicked off")}
+		return
+	}
+
+	if getMatchResp.SeasonID != nil {
+		if err = lineup.checkBench(ctx, *getMatchResp.SeasonID, len(params.Bench)); err != nil {
+			return
+		}
+	}
+
+	named := append(append([]uuid.UUID{}, params.Starters...), params.Bench...)
+
+	getPlayersResp, err := lineup.repo.GetLineup().GetPlayers(ctx, param.GetPlayers{IDs: named})
+	if err != nil {
+		return
+	}
+
+	players := make(map[uuid.UUID]transporter.GetPlayers, len(getPlayersResp))
+	for _, player := range getPlayersResp {
+		players[player.ID] = player
+	}
+
+	goalkeepers := 0
+	for i, playerID := range named {
+		player, ok := players[playerID]
+		if !ok || !uuid.Equal(player.TeamID, params.TeamID) {
+			err = &iPkgError.ValidationError{Err: errors.New("player " + playerID.String() + " does not belong to the team")}
+			return
+		}
+
+		if i < len(params.Starters) && player.Position == model.PlayerPositionGoalkeeper {
+			goalkeepers++
+		}
+	}
+
+	if goalkeepers != 1 {
+		err = &iPkgError.ValidationError{Err: errors.New("starters should have exactly one goalkeeper")}
+		return
+	}
+
+	getAbsencesResp, err := lineup.availability.GetAbsences(ctx, availabilityParam.GetAbsences{
+		TeamID: params.TeamID,
+		Date:   getMatchResp.KickoffAt,
+	})
+	if err != nil {
+		return
+	}
+
+	for _, absence := range getAbsencesResp {
+		player, ok := players[absence.PlayerID]
+		if !ok {
+			continue
+		}
+
+		// Suspensions are only served in the season they were picked up in, so they do not rule out friendlies.
+		if absence.Reason == model.AvailabilitySuspended && (getMatchResp.SeasonID == nil || !uuid.Equal(*absence.SeasonID, *getMatchResp.SeasonID)) {
+			continue
+		}
+
+		err = &iPkgError.ValidationError{Err: errors.New(player.Name + " is " + absence.Reason + " (" + absence.Detail + ")")}
+		return
+	}
+
+	doUpdateResp, err = lineup.repo.GetLineup().DoUpdate(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// GetLineups is used for getting the lineups of both teams of a match.
+// It returns getLineupsResp of []transporter.GetLineups and any errors written.
+func (lineup *Lineup) GetLineups(ctx context.Context, params param.GetLineups) (getLineupsResp []transporter.GetLineups, err error) {
+	getLineupsResp, err = lineup.repo.GetLineup().GetLineups(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// CheckSubstitution is used for making sure a substitution takes a player off the pitch for one on the bench.
+// Matches without a lineup for the team are not checked.
+// It returns any errors written.
+func (lineup *Lineup) CheckSubstitution(ctx context.Context, params param.CheckSubstitution) (err error) {
+	getLineupResp, err := lineup.repo.GetLineup().GetLineup(ctx, param.GetLineup{
+		MatchID: params.MatchID,
+		TeamID:  params.TeamID,
+	})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getLineupResp.MatchID, uuid.Nil) {
+		return
+	}
+
+	getMatchEventsResp, err := lineup.repo.GetMatchEvent().GetMatchEvents(ctx, matchEventParam.GetMatchEvents{MatchID: params.MatchID})
+	if err != nil {
+		return
+	}
+
+	onPitch, onBench := pitch(getLineupResp.Lineup, getMatchEventsResp)
+
+	if !onPitch[params.PlayerID] {
+		err = &iPkgError.ValidationError{Err: errors.New("player is not on the pitch")}
+		return
+	}
+
+	if !onBench[params.RelatedPlayerID] {
+		err = &iPkgError.ValidationError{Err: errors.New("substitute is not on the bench")}
+		return
+	}
+
+	return
+}
+
+// checkBench is used for making sure the bench stays within the limit of the competition the season belongs to.
+// It returns any errors written.
+func (lineup *Lineup) checkBench(ctx context.Context, seasonID uuid.UUID, bench int) (err error) {
+	getSeasonResp, err := lineup.repo.GetSeason().GetSeason(ctx, seasonParam.GetSeason{ID: seasonID})
+	if err != nil {
+		return
+	}
+
+	getCompetitionResp, err := lineup.repo.GetCompetition().GetCompetition(ctx, competitionParam.GetCompetition{ID: getSeasonResp.CompetitionID})
+	if err != nil {
+		return
+	}
+
+	if getCompetitionResp.BenchSize != nil && bench > *getCompetitionResp.BenchSize {
+		err = &iPkgError.ValidationError{Err: errors.New(
+			"bench of " + strconv.Itoa(bench) + " players goes over the limit of " + strconv.Itoa(*getCompetitionResp.BenchSize) + " in " + getCompetitionResp.Name,
+		)}
+		return
+	}
+
+	return
+}
+
+// pitch is used for following the players of a lineup through the substitutions and sendings off of a match,
+// giving back who is on the pitch and who is still available on the bench.
+func pitch(lineup transporter.Lineup, events []matchEventTransporter.GetMatchEvents) (onPitch, onBench map[uuid.UUID]bool) {
+	onPitch = make(map[uuid.UUID]bool, len(lineup.Starters))
+	for _, playerID := range lineup.Starters {
+		onPitch[playerID] = true
+	}
+
+	onBench = make(map[uuid.UUID]bool, len(lineup.Bench))
+	for _, playerID := range lineup.Bench {
+		onBench[playerID] = true
+	}
+
+	for _, event := range events {
+		if !uuid.Equal(event.TeamID, lineup.TeamID) {
+			continue
+		}
+
+		switch event.Type {
+		case model.MatchEventTypeSubstitution:
+			delete(onPitch, event.PlayerID)
+			if event.RelatedPlayerID != nil {
+				delete(onBench, *event.RelatedPlayerID)
+				onPitch[*event.RelatedPlayerID] = true
+			}
+		case model.MatchEventTypeSecondYellow, model.MatchEventTypeRedCard:
+			delete(onPitch, event.PlayerID)
+		}
+	}
+
+	return
+}
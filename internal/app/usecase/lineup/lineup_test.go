@@ -0,0 +1,458 @@
+package lineup
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/handler/lineup/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/lineup/transporter"
+	matchEventTransporter "github.com/harunnryd/skeltun/internal/app/handler/matchevent/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	iCompetitionRepo "github.com/harunnryd/skeltun/internal/app/repo/competition"
+	iInjuryRepo "github.com/harunnryd/skeltun/internal/app/repo/injury"
+	iLineupRepo "github.com/harunnryd/skeltun/internal/app/repo/lineup"
+	iMatchRepo "github.com/harunnryd/skeltun/internal/app/repo/match"
+	iSeasonRepo "github.com/harunnryd/skeltun/internal/app/repo/season"
+	iSuspensionRepo "github.com/harunnryd/skeltun/internal/app/repo/suspension"
+	"github.com/harunnryd/skeltun/internal/app/usecase/availability"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	mock      sqlmock.Sqlmock
+	pgsqlConn *gorm.DB
+
+	iLineupRepo      iLineupRepo.ILineup
+	iMatchRepo       iMatchRepo.IMatch
+	iSeasonRepo      iSeasonRepo.ISeason
+	iCompetitionRepo iCompetitionRepo.ICompetition
+	iInjuryRepo      iInjuryRepo.IInjury
+	iSuspensionRepo  iSuspensionRepo.ISuspension
+	iRepo            repo.IRepo
+	lineup           ILineup
+	helper
+	response
+}
+
+type helper struct {
+	err error
+	db  *sql.DB
+}
+
+type response struct {
+	doUpdateResp transporter.DoUpdate
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.iLineupRepo = iLineupRepo.New(
+		iLineupRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iMatchRepo = iMatchRepo.New(
+		iMatchRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iSeasonRepo = iSeasonRepo.New(
+		iSeasonRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iCompetitionRepo = iCompetitionRepo.New(
+		iCompetitionRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iInjuryRepo = iInjuryRepo.New(
+		iInjuryRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iSuspensionRepo = iSuspensionRepo.New(
+		iSuspensionRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iRepo = repo.New()
+	suite.iRepo.SetLineup(suite.iLineupRepo)
+	suite.iRepo.SetMatch(suite.iMatchRepo)
+	suite.iRepo.SetSeason(suite.iSeasonRepo)
+	suite.iRepo.SetCompetition(suite.iCompetitionRepo)
+	suite.iRepo.SetInjury(suite.iInjuryRepo)
+	suite.iRepo.SetSuspension(suite.iSuspensionRepo)
+
+	suite.lineup = New(
+		WithRepo(suite.iRepo),
+		WithAvailability(availability.New(availability.WithRepo(suite.iRepo))),
+	)
+}
+
+// lineupParams returns a lineup of eleven starters, the first one of them in goal, and two substitutes.
+func lineupParams(matchID, teamID uuid.UUID) param.DoUpdate {
+	params := param.DoUpdate{
+		Lineup: param.Lineup{
+			MatchID:   matchID,
+			TeamID:    teamID,
+			Formation: "4-3-3",
+			Bench:     []uuid.UUID{uuid.NewV4(), uuid.NewV4()},
+		},
+	}
+
+	for i := 0; i < model.LineupStarters; i++ {
+		params.Starters = append(params.Starters, uuid.NewV4())
+	}
+
+	return params
+}
+
+// playerRows returns the players of a lineup, all of them belonging to the team.
+func playerRows(params param.DoUpdate, goalkeeper string) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"id", "team_id", "name", "position"})
+	for i, playerID := range append(append([]uuid.UUID{}, params.Starters...), params.Bench...) {
+		position := model.PlayerPositionMidfielder
+		if i == 0 {
+			position = goalkeeper
+		}
+		rows.AddRow(playerID, params.TeamID, "Player "+playerID.String()[:8], position)
+	}
+	return rows
+}
+
+// TestDoUpdate ...
+func (suite *Suite) TestDoUpdate() {
+	seasonID, competitionID, awayTeamID := uuid.NewV4(), uuid.NewV4(), uuid.NewV4()
+	kickoffAt := time.Date(2021, time.August, 21, 15, 0, 0, 0, time.UTC)
+	params := lineupParams(uuid.NewV4(), uuid.NewV4())
+
+	suite.mock.MatchExpectationsInOrder(false)
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "season_id", "home_team_id", "away_team_id", "kickoff_at", "status"}).
+			AddRow(params.MatchID, seasonID, params.TeamID, awayTeamID, kickoffAt, model.MatchStatusScheduled))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seasons" WHERE id = $1 LIMIT 1`)).
+		WithArgs(seasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "competition_id", "name"}).
+			AddRow(seasonID, competitionID, "2021/22"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "competitions" WHERE id = $1 LIMIT 1`)).
+		WithArgs(competitionID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "bench_size"}).
+			AddRow(competitionID, "Premier League", 7))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seasons" WHERE "seasons"."competition_id" = $1`)).
+		WithArgs(competitionID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "competition_id"}).
+			AddRow(seasonID, competitionID))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE id IN (`)).
+		WillReturnRows(playerRows(params, model.PlayerPositionGoalkeeper))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT injuries.*, players.name FROM "injuries" JOIN players`)).
+		WithArgs(params.TeamID, kickoffAt, kickoffAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "player_id", "type", "name"}))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT suspensions.*, players.name FROM "suspensions" JOIN players`)).
+		WithArgs(params.TeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "player_id", "season_id", "reason", "matches_remaining", "name"}).
+			AddRow(uuid.NewV4(), params.Bench[0], uuid.NewV4(), model.SuspensionReasonRedCard, 1, "Player "+params.Bench[0].String()[:8]))
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`INSERT INTO "lineups"`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`DELETE FROM "lineup_players"`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`INSERT INTO "lineup_players"`)).
+		WillReturnResult(sqlmock.NewResult(0, 13))
+
+	suite.mock.ExpectCommit()
+
+	suite.response.doUpdateResp, suite.helper.err = suite.lineup.DoUpdate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), params.Starters, suite.response.doUpdateResp.Starters)
+}
+
+// TestDoUpdateBenchTooLong ...
+func (suite *Suite) TestDoUpdateBenchTooLong() {
+	seasonID, competitionID := uuid.NewV4(), uuid.NewV4()
+	params := lineupParams(uuid.NewV4(), uuid.NewV4())
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "season_id", "home_team_id", "away_team_id", "status"}).
+			AddRow(params.MatchID, seasonID, uuid.NewV4(), params.TeamID, model.MatchStatusScheduled))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seasons" WHERE id = $1 LIMIT 1`)).
+		WithArgs(seasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "competition_id", "name"}).
+			AddRow(seasonID, competitionID, "2021/22"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "competitions" WHERE id = $1 LIMIT 1`)).
+		WithArgs(competitionID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "bench_size"}).
+			AddRow(competitionID, "FA Cup", 1))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seasons" WHERE "seasons"."competition_id" = $1`)).
+		WithArgs(competitionID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.response.doUpdateResp, suite.helper.err = suite.lineup.DoUpdate(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "bench of 2 players goes over the limit of 1 in FA Cup")
+}
+
+// TestDoUpdateWithoutGoalkeeper ...
+func (suite *Suite) TestDoUpdateWithoutGoalkeeper() {
+	params := lineupParams(uuid.NewV4(), uuid.NewV4())
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "home_team_id", "away_team_id", "status"}).
+			AddRow(params.MatchID, params.TeamID, uuid.NewV4(), model.MatchStatusScheduled))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE id IN (`)).
+		WillReturnRows(playerRows(params, model.PlayerPositionDefender))
+
+	suite.response.doUpdateResp, suite.helper.err = suite.lineup.DoUpdate(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "starters should have exactly one goalkeeper")
+}
+
+// TestDoUpdatePlayerFromOtherTeam ...
+func (suite *Suite) TestDoUpdatePlayerFromOtherTeam() {
+	params := lineupParams(uuid.NewV4(), uuid.NewV4())
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "home_team_id", "away_team_id", "status"}).
+			AddRow(params.MatchID, params.TeamID, uuid.NewV4(), model.MatchStatusScheduled))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE id IN (`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "team_id", "name", "position"}).
+			AddRow(params.Starters[0], params.TeamID, "Alisson", model.PlayerPositionGoalkeeper))
+
+	suite.response.doUpdateResp, suite.helper.err = suite.lineup.DoUpdate(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "player "+params.Starters[1].String()+" does not belong to the team")
+}
+
+// TestDoUpdateSuspended ...
+func (suite *Suite) TestDoUpdateSuspended() {
+	seasonID, competitionID := uuid.NewV4(), uuid.NewV4()
+	params := lineupParams(uuid.NewV4(), uuid.NewV4())
+
+	suite.mock.MatchExpectationsInOrder(false)
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "season_id", "home_team_id", "away_team_id", "status"}).
+			AddRow(params.MatchID, seasonID, params.TeamID, uuid.NewV4(), model.MatchStatusScheduled))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seasons" WHERE id = $1 LIMIT 1`)).
+		WithArgs(seasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "competition_id"}).
+			AddRow(seasonID, competitionID))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "competitions" WHERE id = $1 LIMIT 1`)).
+		WithArgs(competitionID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(competitionID, "Premier League"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seasons" WHERE "seasons"."competition_id" = $1`)).
+		WithArgs(competitionID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE id IN (`)).
+		WillReturnRows(playerRows(params, model.PlayerPositionGoalkeeper))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT injuries.*, players.name FROM "injuries" JOIN players`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "player_id", "type", "name"}))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT suspensions.*, players.name FROM "suspensions" JOIN players`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "player_id", "season_id", "reason", "matches_remaining", "name"}).
+			AddRow(uuid.NewV4(), params.Bench[1], seasonID, model.SuspensionReasonYellowCards, 1, "Player "+params.Bench[1].String()[:8]))
+
+	suite.response.doUpdateResp, suite.helper.err = suite.lineup.DoUpdate(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "Player "+params.Bench[1].String()[:8]+" is suspended (yellow_cards)")
+}
+
+// TestDoUpdateInjured ...
+func (suite *Suite) TestDoUpdateInjured() {
+	params := lineupParams(uuid.NewV4(), uuid.NewV4())
+
+	suite.mock.MatchExpectationsInOrder(false)
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "home_team_id", "away_team_id", "status"}).
+			AddRow(params.MatchID, params.TeamID, uuid.NewV4(), model.MatchStatusScheduled))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE id IN (`)).
+		WillReturnRows(playerRows(params, model.PlayerPositionGoalkeeper))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT injuries.*, players.name FROM "injuries" JOIN players`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "player_id", "type", "name"}).
+			AddRow(uuid.NewV4(), params.Starters[3], "hamstring strain", "Player "+params.Starters[3].String()[:8]))
+
+	// A suspension is not served in a friendly, so it leaves the player available.
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT suspensions.*, players.name FROM "suspensions" JOIN players`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "player_id", "season_id", "reason", "matches_remaining", "name"}).
+			AddRow(uuid.NewV4(), params.Starters[1], uuid.NewV4(), model.SuspensionReasonRedCard, 2, "Player "+params.Starters[1].String()[:8]))
+
+	suite.response.doUpdateResp, suite.helper.err = suite.lineup.DoUpdate(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "Player "+params.Starters[3].String()[:8]+" is injured (hamstring strain)")
+}
+
+// TestDoUpdateMatchKickedOff ...
+func (suite *Suite) TestDoUpdateMatchKickedOff() {
+	params := lineupParams(uuid.NewV4(), uuid.NewV4())
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "home_team_id", "away_team_id", "status"}).
+			AddRow(params.MatchID, params.TeamID, uuid.NewV4(), model.MatchStatusFirstHalf))
+
+	suite.response.doUpdateResp, suite.helper.err = suite.lineup.DoUpdate(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "lineup cannot be changed once the match has kicked off")
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+// TestPitch ...
+func TestPitch(t *testing.T) {
+	teamID, otherTeamID := uuid.NewV4(), uuid.NewV4()
+	starterID, sentOffID, substituteID, unusedID := uuid.NewV4(), uuid.NewV4(), uuid.NewV4(), uuid.NewV4()
+
+	lineup := transporter.Lineup{
+		TeamID:   teamID,
+		Starters: []uuid.UUID{starterID, sentOffID},
+		Bench:    []uuid.UUID{substituteID, unusedID},
+	}
+
+	event := func(teamID, playerID uuid.UUID, eventType string, relatedPlayerID *uuid.UUID) matchEventTransporter.GetMatchEvents {
+		return matchEventTransporter.GetMatchEvents{MatchEvent: matchEventTransporter.MatchEvent{
+			TeamID:          teamID,
+			PlayerID:        playerID,
+			RelatedPlayerID: relatedPlayerID,
+			Type:            eventType,
+		}}
+	}
+
+	tests := []struct {
+		name        string
+		events      []matchEventTransporter.GetMatchEvents
+		wantOnPitch []uuid.UUID
+		wantOnBench []uuid.UUID
+	}{
+		{
+			name:        "kick off",
+			wantOnPitch: []uuid.UUID{starterID, sentOffID},
+			wantOnBench: []uuid.UUID{substituteID, unusedID},
+		},
+		{
+			name: "substitution and sending off",
+			events: []matchEventTransporter.GetMatchEvents{
+				event(teamID, starterID, model.MatchEventTypeSubstitution, &substituteID),
+				event(teamID, sentOffID, model.MatchEventTypeRedCard, nil),
+			},
+			wantOnPitch: []uuid.UUID{substituteID},
+			wantOnBench: []uuid.UUID{unusedID},
+		},
+		{
+			name: "events of the other team",
+			events: []matchEventTransporter.GetMatchEvents{
+				event(otherTeamID, sentOffID, model.MatchEventTypeSecondYellow, nil),
+			},
+			wantOnPitch: []uuid.UUID{starterID, sentOffID},
+			wantOnBench: []uuid.UUID{substituteID, unusedID},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			onPitch, onBench := pitch(lineup, tt.events)
+			require.Len(t, onPitch, len(tt.wantOnPitch))
+			for _, playerID := range tt.wantOnPitch {
+				require.True(t, onPitch[playerID])
+			}
+			require.Len(t, onBench, len(tt.wantOnBench))
+			for _, playerID := range tt.wantOnBench {
+				require.True(t, onBench[playerID])
+			}
+		})
+	}
+}
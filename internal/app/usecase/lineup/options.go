@@ -0,0 +1,43 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lineup
+
+import (
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	"github.com/harunnryd/skeltun/internal/app/usecase/availability"
+	"github.com/harunnryd/skeltun/internal/pkg"
+)
+
+// Option is a closure that is used for accessing the local variables.
+type Option func(lineup *Lineup)
+
+// WithConfig ...
+func WithConfig(config config.IConfig) Option {
+	return func(lineup *Lineup) {
+		lineup.config = config
+	}
+}
+
+// WithRepo ...
+func WithRepo(repo repo.IRepo) Option {
+	return func(lineup *Lineup) {
+		lineup.repo = repo
+	}
+}
+
+// WithPkg ...
+func WithPkg(pkg pkg.IPkg) Option {
+	return func(lineup *Lineup) {
+		lineup.pkg = pkg
+	}
+}
+
+// WithAvailability ...
+func WithAvailability(availability availability.IAvailability) Option {
+	return func(lineup *Lineup) {
+		lineup.availability = availability
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package loan
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/harunnryd/skeltun/config"
+	contractParam "github.com/harunnryd/skeltun/internal/app/handler/contract/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/loan/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/loan/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	"github.com/harunnryd/skeltun/internal/app/usecase/contract"
+	"github.com/harunnryd/skeltun/internal/pkg"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// errNoLongerActive is reported when a loan was ended in the meantime, like by a recall while the expiry job runs.
+var errNoLongerActive = &iPkgError.ValidationError{Err: errors.New("loan is no longer " + model.LoanStatusActive)}
+
+// ILoan is an interface that stores the methods that Loan struct will use.
+type ILoan interface {
+	// GetLoans is used for getting all loans of a player, the oldest first.
+	// It returns getLoansResp of []transporter.GetLoans and any errors written.
+	GetLoans(ctx context.Context, params param.GetLoans) (getLoansResp []transporter.GetLoans, err error)
+
+	// GetLoan is used for getting a loan.
+	// It returns getLoanResp of transporter.GetLoan and any errors written.
+	GetLoan(ctx context.Context, params param.GetLoan) (getLoanResp transporter.GetLoan, err error)
+
+	// DoRecall is used for sending a player on loan back to the parent club before the loan ends.
+	// It returns doRecallResp of transporter.DoRecall and any errors written.
+	DoRecall(ctx context.Context, params param.DoRecall) (doRecallResp transporter.DoRecall, err error)
+
+	// DoExerciseOption is used for signing a player on loan for good at the agreed option fee.
+	// It returns doExerciseOptionResp of transporter.DoExerciseOption and any errors written.
+	DoExerciseOption(ctx context.Context, params param.DoExerciseOption) (doExerciseOptionResp transporter.DoExerciseOption, err error)
+
+	// DoReturnExpired is used for sending every player whose loan ended by the given date back to the parent club.
+	// It returns doReturnExpiredResp of []transporter.DoReturnExpired and any errors written.
+	DoReturnExpired(ctx context.Context, params param.DoReturnExpired) (doReturnExpiredResp []transporter.DoReturnExpired, err error)
+}
+
+// Loan is an struct that implements ILoan methods.
+type Loan struct {
+	config   config.IConfig
+	repo     repo.IRepo
+	pkg      pkg.IPkg
+	contract contract.IContract
+}
+
+// New it returns instance of Loan that implements ILoan methods.
+func New(opts ...Option) ILoan {
+	l := new(Loan)
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// GetLoans is used for getting all loans of a player, the oldest first.
+// It returns getLoansResp of []transporter.GetLoans and any errors written.
+func (loan *Loan) GetLoans(ctx context.Context, params param.GetLoans) (getLoansResp []transporter.GetLoans, err error) {
+	getLoansResp, err = loan.repo.GetLoan().GetLoans(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// GetLoan is used for getting a loan.
+// It returns getLoanResp of transporter.GetLoan and any errors written.
+func (loan *Loan) GetLoan(ctx context.Context, params param.GetLoan) (getLoanResp transporter.GetLoan, err error) {
+	getLoanResp, err = loan.repo.GetLoan().GetLoan(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// DoRecall is used for sending a player on loan back to the parent club before the loan ends.
+// Only loans agreed with a recall clause can be cut short.
+// It returns doRecallResp of transporter.DoRecall and any errors written.
+func (loan *Loan) DoRecall(ctx context.Context, params param.DoRecall) (doRecallResp transporter.DoRecall, err error) {
+	getLoanResp, err := loan.getActive(ctx, params.ID)
+	if err != nil {
+		return
+	}
+
+	if !getLoanResp.RecallClause {
+		err = &iPkgError.ValidationError{Err: errors.New("loan has no recall clause")}
+		return
+	}
+
+	doRecallResp.Loan, err = loan.end(ctx, getLoanResp.Loan, model.LoanStatusRecalled, params.Date, nil)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// DoExerciseOption is used for signing a player on loan for good at the agreed option fee.
+// The player stays at the loan club, a permanent transfer from the parent club is recorded
+// and the contract with the parent club gives way to a new one, as long as its wage keeps
+// the loan club under its salary caps.
+// It returns doExerciseOptionResp of transporter.DoExerciseOption and any errors written.
+func (loan *Loan) DoExerciseOption(ctx context.Context, params param.DoExerciseOption) (doExerciseOptionResp transporter.DoExerciseOption, err error) {
+	getLoanResp, err := loan.getActive(ctx, params.ID)
+	if err != nil {
+		return
+	}
+
+	if !getLoanResp.OptionToBuy {
+		err = &iPkgError.ValidationError{Err: errors.New("loan has no option to buy")}
+		return
+	}
+
+	if err = loan.contract.CheckSalaryCap(ctx, contractParam.CheckSalaryCap{
+		TeamID: getLoanResp.LoanTeamID,
+		Date:   params.Date,
+		Wage:   params.Contract.Wage,
+	}); err != nil {
+		return
+	}
+
+	doExerciseOptionResp.Loan, err = loan.end(ctx, getLoanResp.Loan, model.LoanStatusBought, params.Date, params.Contract)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// DoReturnExpired is used for sending every player whose loan ended by the given date back to the parent club.
+// The return is dated on the day the loan ended, not the day it was noticed.
+// A loan that cannot be ended does not hold the others back: every returned player is given back
+// along with an error naming the loans left behind, which the next run picks up again.
+// A loan ended in the meantime, by a recall for instance, is skipped.
+// It returns doReturnExpiredResp of []transporter.DoReturnExpired and any errors written.
+func (loan *Loan) DoReturnExpired(ctx context.Context, params param.DoReturnExpired) (doReturnExpiredResp []transporter.DoReturnExpired, err error) {
+	getExpiredLoansResp, err := loan.repo.GetLoan().GetExpiredLoans(ctx, param.GetExpiredLoans{Date: params.Date})
+	if err != nil {
+		return
+	}
+
+	var failures []string
+	for _, expired := range getExpiredLoansResp {
+		var endErr error
+		expired.Loan, endErr = loan.end(ctx, expired.Loan, model.LoanStatusReturned, expired.EndsAt, nil)
+		if endErr == errNoLongerActive {
+			continue
+		}
+
+		if endErr != nil {
+			failures = append(failures, expired.PlayerName+" ("+expired.ID.String()+"): "+endErr.Error())
+			continue
+		}
+
+		doReturnExpiredResp = append(doReturnExpiredResp, transporter.DoReturnExpired{GetExpiredLoans: expired})
+	}
+
+	if len(failures) > 0 {
+		err = errors.New("could not return " + strings.Join(failures, "; "))
+		return
+	}
+
+	return
+}
+
+// getActive is used for getting a loan that has not ended yet.
+// It returns getLoanResp of transporter.GetLoan and any errors written.
+func (loan *Loan) getActive(ctx context.Context, id uuid.UUID) (getLoanResp transporter.GetLoan, err error) {
+	getLoanResp, err = loan.repo.GetLoan().GetLoan(ctx, param.GetLoan{ID: id})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getLoanResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("loan not found")}
+		return
+	}
+
+	if getLoanResp.Status != model.LoanStatusActive {
+		err = &iPkgError.ValidationError{Err: errors.New("loan is not active")}
+		return
+	}
+
+	return
+}
+
+// end is used for ending a loan with the given status on the given date.
+// A bought player moves from the parent club to the loan club for the option fee
+// and signs the given contract, any other ending sends the player back to the parent club.
+// It returns the ended loan and any errors written.
+func (loan *Loan) end(ctx context.Context, ended transporter.Loan, status string, date time.Time, terms *contractParam.Terms) (transporter.Loan, error) {
+	doEndParam := param.DoEnd{
+		ID:         ended.ID,
+		PlayerID:   ended.PlayerID,
+		FromTeamID: ended.LoanTeamID,
+		ToTeamID:   ended.ParentTeamID,
+		Date:       date,
+		Type:       model.TransferTypeLoanReturn,
+		Status:     status,
+	}
+
+	if status == model.LoanStatusBought {
+		doEndParam.FromTeamID, doEndParam.ToTeamID = ended.ParentTeamID, ended.LoanTeamID
+		doEndParam.Fee, doEndParam.Type = ended.OptionFee, model.TransferTypePermanent
+		doEndParam.Contract = terms
+	}
+
+	doEndResp, err := loan.repo.GetLoan().DoEnd(ctx, doEndParam)
+	if err != nil {
+		return ended, err
+	}
+
+	if doEndResp.NoLongerActive {
+		return ended, errNoLongerActive
+	}
+
+	ended.Status, ended.EndedAt = status, &date
+
+	return ended, nil
+}
@@ -0,0 +1,359 @@
+package loan
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	contractParam "github.com/harunnryd/skeltun/internal/app/handler/contract/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/loan/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/loan/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	iContractRepo "github.com/harunnryd/skeltun/internal/app/repo/contract"
+	iLoanRepo "github.com/harunnryd/skeltun/internal/app/repo/loan"
+	"github.com/harunnryd/skeltun/internal/app/usecase/contract"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	mock      sqlmock.Sqlmock
+	pgsqlConn *gorm.DB
+
+	iLoanRepo     iLoanRepo.ILoan
+	iContractRepo iContractRepo.IContract
+	iRepo         repo.IRepo
+	loan          ILoan
+	helper
+	response
+}
+
+type helper struct {
+	err error
+	db  *sql.DB
+}
+
+type response struct {
+	doRecallResp         transporter.DoRecall
+	doExerciseOptionResp transporter.DoExerciseOption
+	doReturnExpiredResp  []transporter.DoReturnExpired
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.iLoanRepo = iLoanRepo.New(
+		iLoanRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iContractRepo = iContractRepo.New(
+		iContractRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iRepo = repo.New()
+	suite.iRepo.SetLoan(suite.iLoanRepo)
+	suite.iRepo.SetContract(suite.iContractRepo)
+
+	suite.loan = New(
+		WithRepo(suite.iRepo),
+		WithContract(contract.New(contract.WithRepo(suite.iRepo))),
+	)
+}
+
+// TestDoRecall ...
+func (suite *Suite) TestDoRecall() {
+	playerID, parentTeamID, loanTeamID := uuid.NewV4(), uuid.NewV4(), uuid.NewV4()
+	params := param.DoRecall{
+		ID:   uuid.NewV4(),
+		Date: time.Date(2021, time.January, 15, 0, 0, 0, 0, time.UTC),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "loans" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "player_id", "parent_team_id", "loan_team_id", "recall_clause", "status"}).
+			AddRow(params.ID, playerID, parentTeamID, loanTeamID, true, model.LoanStatusActive))
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "loans"`)).
+		WithArgs(params.Date, model.LoanStatusRecalled, sqlmock.AnyArg(), params.ID, model.LoanStatusActive).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "transfers"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), playerID, loanTeamID, parentTeamID, params.Date, int64(0), model.TransferTypeLoanReturn).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "players"`)).
+		WithArgs(nil, parentTeamID, sqlmock.AnyArg(), playerID, parentTeamID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	suite.mock.ExpectCommit()
+
+	suite.response.doRecallResp, suite.helper.err = suite.loan.DoRecall(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), model.LoanStatusRecalled, suite.response.doRecallResp.Status)
+	require.Equal(suite.T(), params.Date, *suite.response.doRecallResp.EndedAt)
+}
+
+// TestDoRecallWithoutClause ...
+func (suite *Suite) TestDoRecallWithoutClause() {
+	params := param.DoRecall{
+		ID:   uuid.NewV4(),
+		Date: time.Date(2021, time.January, 15, 0, 0, 0, 0, time.UTC),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "loans" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "recall_clause", "status"}).
+			AddRow(params.ID, false, model.LoanStatusActive))
+
+	suite.response.doRecallResp, suite.helper.err = suite.loan.DoRecall(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "loan has no recall clause")
+}
+
+// TestDoExerciseOption ...
+func (suite *Suite) TestDoExerciseOption() {
+	playerID, parentTeamID, loanTeamID := uuid.NewV4(), uuid.NewV4(), uuid.NewV4()
+	params := param.DoExerciseOption{
+		ID:   uuid.NewV4(),
+		Date: time.Date(2021, time.May, 1, 0, 0, 0, 0, time.UTC),
+		Contract: &contractParam.Terms{
+			EndsAt: time.Date(2024, time.June, 30, 0, 0, 0, 0, time.UTC),
+			Wage:   1500000,
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "loans" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "player_id", "parent_team_id", "loan_team_id", "option_to_buy", "option_fee", "status"}).
+			AddRow(params.ID, playerID, parentTeamID, loanTeamID, true, 2000000, model.LoanStatusActive))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`FROM "competitions"`)).
+		WithArgs(uuid.Nil, params.Date, loanTeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"competition_id", "competition_name", "salary_cap"}))
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "loans"`)).
+		WithArgs(params.Date, model.LoanStatusBought, sqlmock.AnyArg(), params.ID, model.LoanStatusActive).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "transfers"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), playerID, parentTeamID, loanTeamID, params.Date, int64(2000000), model.TransferTypePermanent).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "players"`)).
+		WithArgs(nil, loanTeamID, sqlmock.AnyArg(), playerID, loanTeamID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "contracts"`)).
+		WithArgs(model.ContractStatusTerminated, sqlmock.AnyArg(), playerID, model.ContractStatusActive).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "contracts"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), playerID, loanTeamID, params.Date, params.Contract.EndsAt, params.Contract.Wage, nil, model.ContractStatusActive).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.mock.ExpectCommit()
+
+	suite.response.doExerciseOptionResp, suite.helper.err = suite.loan.DoExerciseOption(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), model.LoanStatusBought, suite.response.doExerciseOptionResp.Status)
+}
+
+// TestDoExerciseOptionEnded ...
+func (suite *Suite) TestDoExerciseOptionEnded() {
+	params := param.DoExerciseOption{
+		ID:   uuid.NewV4(),
+		Date: time.Date(2021, time.May, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "loans" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "option_to_buy", "status"}).
+			AddRow(params.ID, true, model.LoanStatusReturned))
+
+	suite.response.doExerciseOptionResp, suite.helper.err = suite.loan.DoExerciseOption(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "loan is not active")
+}
+
+// TestDoReturnExpired ...
+func (suite *Suite) TestDoReturnExpired() {
+	loanID, playerID, parentTeamID, loanTeamID := uuid.NewV4(), uuid.NewV4(), uuid.NewV4(), uuid.NewV4()
+	endsAt := time.Date(2021, time.June, 30, 0, 0, 0, 0, time.UTC)
+	params := param.DoReturnExpired{
+		Date: time.Date(2021, time.July, 1, 6, 0, 0, 0, time.UTC),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`FROM "loans"`)).
+		WithArgs(model.LoanStatusActive, params.Date).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "player_id", "parent_team_id", "loan_team_id", "ends_at", "status", "player_name", "parent_team_name", "loan_team_name"}).
+			AddRow(loanID, playerID, parentTeamID, loanTeamID, endsAt, model.LoanStatusActive, "John Doe", "Arsenal", "Fulham"))
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "loans"`)).
+		WithArgs(endsAt, model.LoanStatusReturned, sqlmock.AnyArg(), loanID, model.LoanStatusActive).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "transfers"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), playerID, loanTeamID, parentTeamID, endsAt, int64(0), model.TransferTypeLoanReturn).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "players"`)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	suite.mock.ExpectCommit()
+
+	suite.response.doReturnExpiredResp, suite.helper.err = suite.loan.DoReturnExpired(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Len(suite.T(), suite.response.doReturnExpiredResp, 1)
+	require.Equal(suite.T(), model.LoanStatusReturned, suite.response.doReturnExpiredResp[0].Status)
+	require.Equal(suite.T(), "Arsenal", suite.response.doReturnExpiredResp[0].ParentTeamName)
+}
+
+// TestDoReturnExpiredEndedMeanwhile ...
+func (suite *Suite) TestDoReturnExpiredEndedMeanwhile() {
+	loanID := uuid.NewV4()
+	endsAt := time.Date(2021, time.June, 30, 0, 0, 0, 0, time.UTC)
+	params := param.DoReturnExpired{
+		Date: time.Date(2021, time.July, 1, 6, 0, 0, 0, time.UTC),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`FROM "loans"`)).
+		WithArgs(model.LoanStatusActive, params.Date).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "player_id", "parent_team_id", "loan_team_id", "ends_at", "status", "player_name", "parent_team_name", "loan_team_name"}).
+			AddRow(loanID, uuid.NewV4(), uuid.NewV4(), uuid.NewV4(), endsAt, model.LoanStatusActive, "John Doe", "Arsenal", "Fulham"))
+
+	// The loan was recalled after it was read, so there is nothing left to end and nothing has failed.
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "loans"`)).
+		WithArgs(endsAt, model.LoanStatusReturned, sqlmock.AnyArg(), loanID, model.LoanStatusActive).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	suite.mock.ExpectRollback()
+
+	suite.response.doReturnExpiredResp, suite.helper.err = suite.loan.DoReturnExpired(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Empty(suite.T(), suite.response.doReturnExpiredResp)
+}
+
+// TestDoReturnExpiredPartially ...
+func (suite *Suite) TestDoReturnExpiredPartially() {
+	failedLoanID, loanID, playerID, parentTeamID, loanTeamID := uuid.NewV4(), uuid.NewV4(), uuid.NewV4(), uuid.NewV4(), uuid.NewV4()
+	endsAt := time.Date(2021, time.June, 30, 0, 0, 0, 0, time.UTC)
+	params := param.DoReturnExpired{
+		Date: time.Date(2021, time.July, 1, 6, 0, 0, 0, time.UTC),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`FROM "loans"`)).
+		WithArgs(model.LoanStatusActive, params.Date).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "player_id", "parent_team_id", "loan_team_id", "ends_at", "status", "player_name", "parent_team_name", "loan_team_name"}).
+			AddRow(failedLoanID, uuid.NewV4(), parentTeamID, loanTeamID, endsAt, model.LoanStatusActive, "Richard Roe", "Arsenal", "Fulham").
+			AddRow(loanID, playerID, parentTeamID, loanTeamID, endsAt, model.LoanStatusActive, "John Doe", "Arsenal", "Fulham"))
+
+	// The first loan cannot be ended, which should not keep the second player from going back.
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "loans"`)).
+		WithArgs(endsAt, model.LoanStatusReturned, sqlmock.AnyArg(), failedLoanID, model.LoanStatusActive).
+		WillReturnError(sql.ErrConnDone)
+
+	suite.mock.ExpectRollback()
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "loans"`)).
+		WithArgs(endsAt, model.LoanStatusReturned, sqlmock.AnyArg(), loanID, model.LoanStatusActive).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "transfers"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "players"`)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	suite.mock.ExpectCommit()
+
+	suite.response.doReturnExpiredResp, suite.helper.err = suite.loan.DoReturnExpired(context.Background(), params)
+
+	require.Error(suite.T(), suite.helper.err)
+	require.Contains(suite.T(), suite.helper.err.Error(), failedLoanID.String())
+	require.Len(suite.T(), suite.response.doReturnExpiredResp, 1)
+	require.Equal(suite.T(), loanID, suite.response.doReturnExpiredResp[0].ID)
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
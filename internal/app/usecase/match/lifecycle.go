@@ -0,0 +1,80 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package match
+
+import (
+	"time"
+
+	"github.com/harunnryd/skeltun/internal/app/handler/match/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+)
+
+const (
+	// halfLength is the number of minutes in each half of regular time.
+	halfLength = 45
+	// extraTimeLength is the number of minutes in both periods of extra time together.
+	extraTimeLength = 30
+)
+
+// lifecycle maps every match status to the statuses it can move into.
+// Finished, abandoned and cancelled matches cannot move anymore.
+var lifecycle = map[string][]string{
+	model.MatchStatusScheduled:  {model.MatchStatusFirstHalf, model.MatchStatusPostponed, model.MatchStatusCancelled},
+	model.MatchStatusPostponed:  {model.MatchStatusScheduled, model.MatchStatusCancelled},
+	model.MatchStatusFirstHalf:  {model.MatchStatusHalfTime, model.MatchStatusAbandoned},
+	model.MatchStatusHalfTime:   {model.MatchStatusSecondHalf, model.MatchStatusAbandoned},
+	model.MatchStatusSecondHalf: {model.MatchStatusExtraTime, model.MatchStatusFinished, model.MatchStatusAbandoned},
+	model.MatchStatusExtraTime:  {model.MatchStatusPenalties, model.MatchStatusFinished, model.MatchStatusAbandoned},
+	model.MatchStatusPenalties:  {model.MatchStatusFinished, model.MatchStatusAbandoned},
+}
+
+// periodOffsets maps every status where the ball is in play to the minute its period starts from.
+var periodOffsets = map[string]int{
+	model.MatchStatusFirstHalf:  0,
+	model.MatchStatusSecondHalf: halfLength,
+	model.MatchStatusExtraTime:  2 * halfLength,
+}
+
+// canTransition is used for checking whether a match can move from one status into another.
+func canTransition(from, to string) bool {
+	for _, status := range lifecycle[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// clock is used for deriving the match minute at the given time out of the match transitions.
+// While the ball is in play the minute keeps counting from the start of the current period,
+// stoppage time included, and periodStartedAt is set so clients can keep the clock running.
+func clock(transitions []transporter.GetTransitions, now time.Time) (minute int, periodStartedAt *time.Time) {
+	if len(transitions) == 0 {
+		return
+	}
+
+	last := transitions[len(transitions)-1]
+	if offset, ok := periodOffsets[last.ToStatus]; ok {
+		startedAt := last.OccurredAt
+		return offset + int(now.Sub(startedAt)/time.Minute) + 1, &startedAt
+	}
+
+	switch last.ToStatus {
+	case model.MatchStatusHalfTime:
+		minute = halfLength
+	case model.MatchStatusPenalties:
+		minute = 2*halfLength + extraTimeLength
+	case model.MatchStatusFinished:
+		minute = 2 * halfLength
+		if last.FromStatus != model.MatchStatusSecondHalf {
+			minute += extraTimeLength
+		}
+	case model.MatchStatusAbandoned:
+		// The clock stops at the minute the match was abandoned.
+		minute, _ = clock(transitions[:len(transitions)-1], last.OccurredAt)
+	}
+
+	return
+}
@@ -0,0 +1,322 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package match
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/match/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/match/transporter"
+	matchEventParam "github.com/harunnryd/skeltun/internal/app/handler/matchevent/param"
+	teamParam "github.com/harunnryd/skeltun/internal/app/handler/team/param"
+	venueParam "github.com/harunnryd/skeltun/internal/app/handler/venue/param"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	"github.com/harunnryd/skeltun/internal/pkg"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/harunnryd/skeltun/job"
+	"github.com/satori/uuid"
+
+	"github.com/gocraft/work"
+)
+
+// IMatch is an interface that stores the methods that Match struct will use.
+type IMatch interface {
+	// DoCreate is used for record new match.
+	// It returns doCreateResp of transporter.DoCreate and any errors written.
+	DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error)
+
+	// GetMatches is used for getting all matches.
+	// It returns getMatchesResp of []transporter.GetMatches and any errors written.
+	GetMatches(ctx context.Context, params param.GetMatches) (getMatchesResp []transporter.GetMatches, err error)
+
+	// DoUpdate is used for update the record match.
+	// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+	DoUpdate(ctx context.Context, params param.DoUpdate) (doUpdateResp transporter.DoUpdate, err error)
+
+	// DoDelete is used for delete the record match.
+	// It returns doDeleteResp of transporter.DoDelete and any errors written.
+	DoDelete(ctx context.Context, params param.DoDelete) (doDeleteResp transporter.DoDelete, err error)
+
+	// GetMatch is used for getting an match.
+	// It returns getMatchResp of transporter.GetMatch and any errors written.
+	GetMatch(ctx context.Context, params param.GetMatch) (getMatchResp transporter.GetMatch, err error)
+
+	// DoTransition is used for moving a match through its lifecycle.
+	// It returns doTransitionResp of transporter.DoTransition and any errors written.
+	DoTransition(ctx context.Context, params param.DoTransition) (doTransitionResp transporter.DoTransition, err error)
+
+	// GetClock is used for getting the live clock of a match.
+	// It returns getClockResp of transporter.GetClock and any errors written.
+	GetClock(ctx context.Context, params param.GetClock) (getClockResp transporter.GetClock, err error)
+
+	// GetWinner is used for getting the team that won a finished match and how it was decided.
+	// It returns getWinnerResp of transporter.GetWinner and any errors written.
+	GetWinner(ctx context.Context, params param.GetWinner) (getWinnerResp transporter.GetWinner, err error)
+}
+
+// Match is an struct that implements IMatch methods.
+type Match struct {
+	config config.IConfig
+	repo   repo.IRepo
+	pkg    pkg.IPkg
+	job    job.IJob
+}
+
+// New it returns instance of Match that implements IMatch methods.
+func New(opts ...Option) IMatch {
+	m := new(Match)
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// DoCreate is used for record new match.
+// A match is always created scheduled and is played at the home ground of the home team
+// when it has no venue, if the team has one.
+// It returns doCreateResp of transporter.DoCreate and any errors written.
+func (match *Match) DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error) {
+	params.Status = model.MatchStatusScheduled
+
+	if params.VenueID == nil {
+		getTeamResp, err := match.repo.GetTeam().GetTeam(ctx, teamParam.GetTeam{ID: params.HomeTeamID})
+		if err != nil {
+			return doCreateResp, err
+		}
+
+		params.VenueID = getTeamResp.VenueID
+	}
+
+	if err = match.checkVenue(ctx, &params.Match); err != nil {
+		return
+	}
+
+	doCreateResp, err = match.repo.GetMatch().DoCreate(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// GetMatches is used for getting all matches.
+// It returns getMatchesResp of []transporter.GetMatches and any errors written.
+func (match *Match) GetMatches(ctx context.Context, params param.GetMatches) (getMatchesResp []transporter.GetMatches, err error) {
+	getMatchesResp, err = match.repo.GetMatch().GetMatches(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// DoUpdate is used for update the record match.
+// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+func (match *Match) DoUpdate(ctx context.Context, params param.DoUpdate) (doUpdateResp transporter.DoUpdate, err error) {
+	getMatchResp, err := match.repo.GetMatch().GetMatch(ctx, param.GetMatch{ID: params.ID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getMatchResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("match not found")}
+		return
+	}
+
+	// The status has to go through DoTransition so the live clock can be derived from it.
+	if params.Status != getMatchResp.Status {
+		err = &iPkgError.InvalidTransitionError{Err: errors.New("match status can only be changed through a transition")}
+		return
+	}
+
+	if err = match.checkVenue(ctx, &params.Match); err != nil {
+		return
+	}
+
+	doUpdateResp, err = match.repo.GetMatch().DoUpdate(ctx, params)
+	if err != nil {
+		return
+	}
+
+	getMatchEventsResp, err := match.repo.GetMatchEvent().GetMatchEvents(ctx, matchEventParam.GetMatchEvents{MatchID: params.ID})
+	if err != nil {
+		return
+	}
+
+	// Once a match has a timeline the score always follows its goal events.
+	if len(getMatchEventsResp) > 0 {
+		doSyncScoreResp, err := match.repo.GetMatch().DoSyncScore(ctx, param.DoSyncScore{ID: params.ID})
+		if err != nil {
+			return doUpdateResp, err
+		}
+
+		doUpdateResp.HomeScore = doSyncScoreResp.HomeScore
+		doUpdateResp.AwayScore = doSyncScoreResp.AwayScore
+		doUpdateResp.HomeExtraTimeScore = doSyncScoreResp.HomeExtraTimeScore
+		doUpdateResp.AwayExtraTimeScore = doSyncScoreResp.AwayExtraTimeScore
+		doUpdateResp.HomeShootoutScore = doSyncScoreResp.HomeShootoutScore
+		doUpdateResp.AwayShootoutScore = doSyncScoreResp.AwayShootoutScore
+	}
+
+	return
+}
+
+// DoDelete is used for delete the record match.
+// It returns doDeleteResp of transporter.DoDelete and any errors written.
+func (match *Match) DoDelete(ctx context.Context, params param.DoDelete) (doDeleteResp transporter.DoDelete, err error) {
+	doDeleteResp, err = match.repo.GetMatch().DoDelete(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// GetMatch is used for getting an match.
+// It returns getMatchResp of transporter.GetMatch and any errors written.
+func (match *Match) GetMatch(ctx context.Context, params param.GetMatch) (getMatchResp transporter.GetMatch, err error) {
+	getMatchResp, err = match.repo.GetMatch().GetMatch(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// DoTransition is used for moving a match through its lifecycle.
+// It returns doTransitionResp of transporter.DoTransition and any errors written.
+func (match *Match) DoTransition(ctx context.Context, params param.DoTransition) (doTransitionResp transporter.DoTransition, err error) {
+	getMatchResp, err := match.repo.GetMatch().GetMatch(ctx, param.GetMatch{ID: params.MatchID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getMatchResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("match not found")}
+		return
+	}
+
+	if !canTransition(getMatchResp.Status, params.Status) {
+		err = &iPkgError.InvalidTransitionError{Err: fmt.Errorf("match cannot move from %s to %s", getMatchResp.Status, params.Status)}
+		return
+	}
+
+	getTransitionsResp, err := match.repo.GetMatch().GetTransitions(ctx, param.GetTransitions{MatchID: params.MatchID})
+	if err != nil {
+		return
+	}
+
+	now := time.Now()
+	if params.OccurredAt == nil {
+		params.OccurredAt = &now
+	}
+
+	if len(getTransitionsResp) > 0 && params.OccurredAt.Before(getTransitionsResp[len(getTransitionsResp)-1].OccurredAt) {
+		err = &iPkgError.ValidationError{Err: errors.New("occurred_at cannot be before the previous transition")}
+		return
+	}
+
+	params.FromStatus = getMatchResp.Status
+
+	doTransitionResp, err = match.repo.GetMatch().DoTransition(ctx, params)
+	if err != nil {
+		return
+	}
+
+	doTransitionResp.Minute, _ = clock(append(getTransitionsResp, transporter.GetTransitions{
+		Transition: doTransitionResp.Transition,
+	}), now)
+
+	// A finished knockout match may decide its tie, the winner is moved into the next round in the background.
+	if params.Status == model.MatchStatusFinished && getMatchResp.TieID != nil {
+		match.job.Queue("do_advance_bracket", work.Q{"tie_id": getMatchResp.TieID.String()})
+	}
+
+	// The player stats of the season are rebuilt in the background, so reading them stays cheap.
+	if params.Status == model.MatchStatusFinished && getMatchResp.SeasonID != nil {
+		match.job.Queue("do_refresh_stats", work.Q{"match_id": getMatchResp.ID.String()})
+	}
+
+	// Both teams have played another match of the season, which counts down the suspensions of their players.
+	if params.Status == model.MatchStatusFinished && getMatchResp.SeasonID != nil {
+		match.job.Queue("do_serve_suspensions", work.Q{"match_id": getMatchResp.ID.String()})
+	}
+
+	return
+}
+
+// GetClock is used for getting the live clock of a match.
+// It returns getClockResp of transporter.GetClock and any errors written.
+func (match *Match) GetClock(ctx context.Context, params param.GetClock) (getClockResp transporter.GetClock, err error) {
+	getMatchResp, err := match.repo.GetMatch().GetMatch(ctx, param.GetMatch{ID: params.MatchID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getMatchResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("match not found")}
+		return
+	}
+
+	getTransitionsResp, err := match.repo.GetMatch().GetTransitions(ctx, param.GetTransitions{MatchID: params.MatchID})
+	if err != nil {
+		return
+	}
+
+	getClockResp = transporter.GetClock{
+		MatchID: getMatchResp.ID,
+		Status:  getMatchResp.Status,
+	}
+	getClockResp.Minute, getClockResp.PeriodStartedAt = clock(getTransitionsResp, time.Now())
+
+	return
+}
+
+// GetWinner is used for getting the team that won a finished match and how it was decided.
+// A match that is not finished yet or ended in a draw has no winner.
+// It returns getWinnerResp of transporter.GetWinner and any errors written.
+func (match *Match) GetWinner(ctx context.Context, params param.GetWinner) (getWinnerResp transporter.GetWinner, err error) {
+	getMatchResp, err := match.repo.GetMatch().GetMatch(ctx, param.GetMatch{ID: params.MatchID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getMatchResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("match not found")}
+		return
+	}
+
+	getWinnerResp = decide(getMatchResp.Match)
+
+	return
+}
+
+// checkVenue is used for making sure the venue of a match exists, naming the match venue after it.
+// Matches without a venue are not checked and keep the name given.
+// It returns any errors written.
+func (match *Match) checkVenue(ctx context.Context, params *param.Match) (err error) {
+	if params.VenueID == nil {
+		return
+	}
+
+	getVenueResp, err := match.repo.GetVenue().GetVenue(ctx, venueParam.GetVenue{ID: *params.VenueID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getVenueResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("venue not found")}
+		return
+	}
+
+	params.Venue = getVenueResp.Name
+
+	return
+}
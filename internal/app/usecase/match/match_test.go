@@ -0,0 +1,806 @@
+package match
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gocraft/work"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/handler/match/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/match/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	iMatchRepo "github.com/harunnryd/skeltun/internal/app/repo/match"
+	"github.com/harunnryd/skeltun/internal/app/repo/match/matchtest"
+	iMatchEventRepo "github.com/harunnryd/skeltun/internal/app/repo/matchevent"
+	iTeamRepo "github.com/harunnryd/skeltun/internal/app/repo/team"
+	iVenueRepo "github.com/harunnryd/skeltun/internal/app/repo/venue"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/harunnryd/skeltun/job/jobtest"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	mock      sqlmock.Sqlmock
+	pgsqlConn *gorm.DB
+
+	iMatchRepo      iMatchRepo.IMatch
+	iMatchEventRepo iMatchEventRepo.IMatchEvent
+	iTeamRepo       iTeamRepo.ITeam
+	iVenueRepo      iVenueRepo.IVenue
+	iRepo           repo.IRepo
+	job             *jobtest.Fake
+	match           IMatch
+	helper
+	response
+}
+
+type helper struct {
+	err error
+	db  *sql.DB
+}
+
+type response struct {
+	doCreateResp   transporter.DoCreate
+	getMatchesResp []transporter.GetMatches
+	getMatchResp   transporter.GetMatch
+	doDeleteResp   transporter.DoDelete
+	doUpdateResp   transporter.DoUpdate
+
+	doTransitionResp transporter.DoTransition
+	getClockResp     transporter.GetClock
+	getWinnerResp    transporter.GetWinner
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.iMatchRepo = iMatchRepo.New(
+		iMatchRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iMatchEventRepo = iMatchEventRepo.New(
+		iMatchEventRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iTeamRepo = iTeamRepo.New(
+		iTeamRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iVenueRepo = iVenueRepo.New(
+		iVenueRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iRepo = repo.New()
+	suite.iRepo.SetMatch(suite.iMatchRepo)
+	suite.iRepo.SetMatchEvent(suite.iMatchEventRepo)
+	suite.iRepo.SetTeam(suite.iTeamRepo)
+	suite.iRepo.SetVenue(suite.iVenueRepo)
+
+	suite.job = new(jobtest.Fake)
+
+	suite.match = New(WithRepo(suite.iRepo), WithJob(suite.job))
+}
+
+// TestDoCreate ...
+func (suite *Suite) TestDoCreate() {
+	params := param.DoCreate{
+		Match: param.Match{
+			HomeTeamID: uuid.NewV4(),
+			AwayTeamID: uuid.NewV4(),
+			KickoffAt:  time.Date(2021, 1, 16, 15, 0, 0, 0, time.UTC),
+			Venue:      "Anfield",
+			Status:     model.MatchStatusFinished,
+		},
+	}
+
+	suite.mock.MatchExpectationsInOrder(false)
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "teams" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.HomeTeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "venue_id"}).
+			AddRow(params.HomeTeamID, "Liverpool", nil))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE "players"."team_id" = $1`)).
+		WithArgs(params.HomeTeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "team_id", "name"}))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "matches" ("created_at","updated_at","deleted_at","season_id","round","home_team_id","away_team_id","kickoff_at","venue","venue_id","status","home_score","away_score","home_extra_time_score","away_extra_time_score","home_shootout_score","away_shootout_score","tie_id","leg") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19) RETURNING "id"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.SeasonID, params.Round, params.HomeTeamID, params.AwayTeamID, params.KickoffAt, params.Venue, nil, model.MatchStatusScheduled, 0, 0, 0, 0, 0, 0, nil, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.response.doCreateResp, suite.helper.err = suite.match.DoCreate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.Equal(suite.T(), model.MatchStatusScheduled, suite.response.doCreateResp.Status)
+}
+
+// TestDoCreateHomeGround ...
+func (suite *Suite) TestDoCreateHomeGround() {
+	venueID := uuid.NewV4()
+	params := param.DoCreate{
+		Match: param.Match{
+			HomeTeamID: uuid.NewV4(),
+			AwayTeamID: uuid.NewV4(),
+			KickoffAt:  time.Date(2021, 1, 16, 15, 0, 0, 0, time.UTC),
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "teams" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.HomeTeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "venue_id"}).
+			AddRow(params.HomeTeamID, "Liverpool", venueID))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE "players"."team_id" = $1`)).
+		WithArgs(params.HomeTeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "team_id", "name"}))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT id, name, city, ST_Y(location::geometry) AS latitude, ST_X(location::geometry) AS longitude, capacity, surface FROM "venues" WHERE id = $1 AND deleted_at IS NULL LIMIT 1`)).
+		WithArgs(venueID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "city", "latitude", "longitude", "capacity", "surface"}).
+			AddRow(venueID, "Anfield", "Liverpool", 53.4308, -2.9608, 53394, model.VenueSurfaceHybrid))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "matches"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), nil, 0, params.HomeTeamID, params.AwayTeamID, params.KickoffAt, "Anfield", venueID, model.MatchStatusScheduled, 0, 0, 0, 0, 0, 0, nil, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.response.doCreateResp, suite.helper.err = suite.match.DoCreate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), &venueID, suite.response.doCreateResp.VenueID)
+	require.Equal(suite.T(), "Anfield", suite.response.doCreateResp.Venue)
+}
+
+// TestDoCreateVenueName ...
+func (suite *Suite) TestDoCreateVenueName() {
+	venueID := uuid.NewV4()
+	params := param.DoCreate{
+		Match: param.Match{
+			HomeTeamID: uuid.NewV4(),
+			AwayTeamID: uuid.NewV4(),
+			KickoffAt:  time.Date(2021, 1, 16, 15, 0, 0, 0, time.UTC),
+			Venue:      "Old Trafford",
+			VenueID:    &venueID,
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT id, name, city, ST_Y(location::geometry) AS latitude, ST_X(location::geometry) AS longitude, capacity, surface FROM "venues" WHERE id = $1 AND deleted_at IS NULL LIMIT 1`)).
+		WithArgs(venueID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "city", "latitude", "longitude", "capacity", "surface"}).
+			AddRow(venueID, "Anfield", "Liverpool", 53.4308, -2.9608, 53394, model.VenueSurfaceHybrid))
+
+	// The venue wins over the name given, so the two can not disagree.
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "matches"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), nil, 0, params.HomeTeamID, params.AwayTeamID, params.KickoffAt, "Anfield", venueID, model.MatchStatusScheduled, 0, 0, 0, 0, 0, 0, nil, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.response.doCreateResp, suite.helper.err = suite.match.DoCreate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), "Anfield", suite.response.doCreateResp.Venue)
+}
+
+// TestGetMatches ...
+func (suite *Suite) TestGetMatches() {
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" ORDER BY kickoff_at LIMIT 10 OFFSET 1`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "home_team_id", "away_team_id"}).
+			AddRow(uuid.NewV4(), uuid.NewV4(), uuid.NewV4()))
+
+	suite.response.getMatchesResp, suite.helper.err = suite.match.GetMatches(context.Background(), param.GetMatches{Pagination: param.Pagination{
+		Limit:  "10",
+		Offset: "1",
+	}})
+
+	require.NoError(suite.T(), suite.helper.err)
+}
+
+// TestGetMatch ...
+func (suite *Suite) TestGetMatch() {
+	params := param.GetMatch{ID: uuid.NewV4()}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "venue"}).
+			AddRow(params.ID, "Anfield"))
+
+	suite.response.getMatchResp, suite.helper.err = suite.match.GetMatch(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+}
+
+// TestDoUpdate ...
+func (suite *Suite) TestDoUpdate() {
+	params := param.DoUpdate{
+		Match: param.Match{
+			ID:         uuid.NewV4(),
+			HomeTeamID: uuid.NewV4(),
+			AwayTeamID: uuid.NewV4(),
+			KickoffAt:  time.Date(2021, 1, 16, 15, 0, 0, 0, time.UTC),
+			Venue:      "Old Trafford",
+			Status:     model.MatchStatusFinished,
+			HomeScore:  2,
+			AwayScore:  2,
+		},
+	}
+
+	suite.mock.MatchExpectationsInOrder(false)
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).
+			AddRow(params.ID, model.MatchStatusFinished))
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "matches" SET "updated_at"=$1,"season_id"=$2,"round"=$3,"home_team_id"=$4,"away_team_id"=$5,"kickoff_at"=$6,"venue"=$7,"venue_id"=$8,"status"=$9,"home_score"=$10,"away_score"=$11,"home_extra_time_score"=$12,"away_extra_time_score"=$13,"home_shootout_score"=$14,"away_shootout_score"=$15 WHERE id = $16`)).
+		WithArgs(sqlmock.AnyArg(), params.SeasonID, params.Round, params.HomeTeamID, params.AwayTeamID, params.KickoffAt, params.Venue, nil, params.Status, 2, 2, 0, 0, 0, 0, params.ID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "match_events" WHERE match_id = $1 ORDER BY minute, created_at`)).
+		WithArgs(params.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "match_id"}))
+
+	suite.response.doUpdateResp, suite.helper.err = suite.match.DoUpdate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), 2, suite.response.doUpdateResp.HomeScore)
+}
+
+// TestDoUpdateWithEvents ...
+func (suite *Suite) TestDoUpdateWithEvents() {
+	params := param.DoUpdate{
+		Match: param.Match{
+			ID:         uuid.NewV4(),
+			HomeTeamID: uuid.NewV4(),
+			AwayTeamID: uuid.NewV4(),
+			KickoffAt:  time.Date(2021, 1, 16, 15, 0, 0, 0, time.UTC),
+			Venue:      "Old Trafford",
+			Status:     model.MatchStatusFinished,
+			HomeScore:  5,
+			AwayScore:  0,
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).
+			AddRow(params.ID, model.MatchStatusFinished))
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "matches" SET "updated_at"=$1,"season_id"=$2,"round"=$3,"home_team_id"=$4,"away_team_id"=$5,"kickoff_at"=$6,"venue"=$7,"venue_id"=$8,"status"=$9,"home_score"=$10,"away_score"=$11,"home_extra_time_score"=$12,"away_extra_time_score"=$13,"home_shootout_score"=$14,"away_shootout_score"=$15 WHERE id = $16`)).
+		WithArgs(sqlmock.AnyArg(), params.SeasonID, params.Round, params.HomeTeamID, params.AwayTeamID, params.KickoffAt, params.Venue, nil, params.Status, 5, 0, 0, 0, 0, 0, params.ID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "match_events" WHERE match_id = $1 ORDER BY minute, created_at`)).
+		WithArgs(params.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "match_id", "team_id", "type", "minute"}).
+			AddRow(uuid.NewV4(), params.ID, params.HomeTeamID, model.MatchEventTypeGoal, 12))
+
+	suite.mock.
+		ExpectQuery(`UPDATE matches SET (.+) RETURNING id, home_score, away_score, home_extra_time_score, away_extra_time_score, home_shootout_score, away_shootout_score`).
+		WithArgs(matchtest.SyncScoreArgs(params.ID)...).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "home_score", "away_score"}).
+			AddRow(params.ID, 1, 0))
+
+	suite.response.doUpdateResp, suite.helper.err = suite.match.DoUpdate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), 1, suite.response.doUpdateResp.HomeScore)
+	require.Equal(suite.T(), 0, suite.response.doUpdateResp.AwayScore)
+}
+
+// TestDoUpdateStatusChange ...
+func (suite *Suite) TestDoUpdateStatusChange() {
+	params := param.DoUpdate{
+		Match: param.Match{
+			ID:         uuid.NewV4(),
+			HomeTeamID: uuid.NewV4(),
+			AwayTeamID: uuid.NewV4(),
+			KickoffAt:  time.Date(2021, 1, 16, 15, 0, 0, 0, time.UTC),
+			Status:     model.MatchStatusFinished,
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).
+			AddRow(params.ID, model.MatchStatusScheduled))
+
+	suite.response.doUpdateResp, suite.helper.err = suite.match.DoUpdate(context.Background(), params)
+
+	require.IsType(suite.T(), &iPkgError.InvalidTransitionError{}, suite.helper.err)
+}
+
+func (suite *Suite) TestDoDelete() {
+	params := param.DoDelete{
+		ID: uuid.NewV4(),
+	}
+
+	suite.mock.MatchExpectationsInOrder(false)
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`DELETE FROM "matches" WHERE id = $1`)).
+		WithArgs(params.ID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	suite.response.doDeleteResp, suite.helper.err = suite.match.DoDelete(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+}
+
+// TestDoTransition ...
+func (suite *Suite) TestDoTransition() {
+	occurredAt := time.Now().Add(-10 * time.Minute)
+	params := param.DoTransition{
+		MatchID:    uuid.NewV4(),
+		Status:     model.MatchStatusSecondHalf,
+		OccurredAt: &occurredAt,
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).
+			AddRow(params.MatchID, model.MatchStatusHalfTime))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "match_transitions" WHERE match_id = $1 ORDER BY occurred_at, created_at`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "match_id", "from_status", "to_status", "occurred_at"}).
+			AddRow(uuid.NewV4(), params.MatchID, model.MatchStatusScheduled, model.MatchStatusFirstHalf, occurredAt.Add(-65*time.Minute)).
+			AddRow(uuid.NewV4(), params.MatchID, model.MatchStatusFirstHalf, model.MatchStatusHalfTime, occurredAt.Add(-17*time.Minute)))
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "matches" SET "status"=$1,"updated_at"=$2 WHERE id = $3 AND status = $4`)).
+		WithArgs(params.Status, sqlmock.AnyArg(), params.MatchID, model.MatchStatusHalfTime).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "match_transitions" ("created_at","updated_at","deleted_at","match_id","from_status","to_status","occurred_at") VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING "id"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.MatchID, model.MatchStatusHalfTime, params.Status, occurredAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.mock.ExpectCommit()
+
+	suite.response.doTransitionResp, suite.helper.err = suite.match.DoTransition(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), 56, suite.response.doTransitionResp.Minute)
+}
+
+// TestDoTransitionFinishedTie ...
+func (suite *Suite) TestDoTransitionFinishedTie() {
+	occurredAt := time.Now()
+	tieID := uuid.NewV4()
+	params := param.DoTransition{
+		MatchID:    uuid.NewV4(),
+		Status:     model.MatchStatusFinished,
+		OccurredAt: &occurredAt,
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status", "tie_id", "leg"}).
+			AddRow(params.MatchID, model.MatchStatusSecondHalf, tieID, 2))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "match_transitions" WHERE match_id = $1 ORDER BY occurred_at, created_at`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "match_id", "from_status", "to_status", "occurred_at"}).
+			AddRow(uuid.NewV4(), params.MatchID, model.MatchStatusHalfTime, model.MatchStatusSecondHalf, occurredAt.Add(-48*time.Minute)))
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "matches" SET "status"=$1,"updated_at"=$2 WHERE id = $3 AND status = $4`)).
+		WithArgs(params.Status, sqlmock.AnyArg(), params.MatchID, model.MatchStatusSecondHalf).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "match_transitions"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.mock.ExpectCommit()
+
+	suite.job.Queued = nil
+	suite.response.doTransitionResp, suite.helper.err = suite.match.DoTransition(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), []work.Q{
+		{"name": "do_advance_bracket", "args": work.Q{"tie_id": tieID.String()}},
+	}, suite.job.Queued)
+}
+
+// TestDoTransitionFinishedSeason ...
+func (suite *Suite) TestDoTransitionFinishedSeason() {
+	occurredAt := time.Now()
+	seasonID := uuid.NewV4()
+	params := param.DoTransition{
+		MatchID:    uuid.NewV4(),
+		Status:     model.MatchStatusFinished,
+		OccurredAt: &occurredAt,
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "season_id", "status"}).
+			AddRow(params.MatchID, seasonID, model.MatchStatusSecondHalf))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "match_transitions" WHERE match_id = $1 ORDER BY occurred_at, created_at`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "match_id", "from_status", "to_status", "occurred_at"}).
+			AddRow(uuid.NewV4(), params.MatchID, model.MatchStatusHalfTime, model.MatchStatusSecondHalf, occurredAt.Add(-48*time.Minute)))
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "matches" SET "status"=$1,"updated_at"=$2 WHERE id = $3 AND status = $4`)).
+		WithArgs(params.Status, sqlmock.AnyArg(), params.MatchID, model.MatchStatusSecondHalf).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "match_transitions"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.mock.ExpectCommit()
+
+	suite.job.Queued = nil
+	suite.response.doTransitionResp, suite.helper.err = suite.match.DoTransition(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), []work.Q{
+		{"name": "do_refresh_stats", "args": work.Q{"match_id": params.MatchID.String()}},
+		{"name": "do_serve_suspensions", "args": work.Q{"match_id": params.MatchID.String()}},
+	}, suite.job.Queued)
+}
+
+// TestDoTransitionIllegal ...
+func (suite *Suite) TestDoTransitionIllegal() {
+	params := param.DoTransition{
+		MatchID: uuid.NewV4(),
+		Status:  model.MatchStatusFinished,
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).
+			AddRow(params.MatchID, model.MatchStatusScheduled))
+
+	suite.response.doTransitionResp, suite.helper.err = suite.match.DoTransition(context.Background(), params)
+
+	require.IsType(suite.T(), &iPkgError.InvalidTransitionError{}, suite.helper.err)
+	require.EqualError(suite.T(), suite.helper.err, "match cannot move from scheduled to finished")
+}
+
+// TestDoTransitionOutOfOrder ...
+func (suite *Suite) TestDoTransitionOutOfOrder() {
+	occurredAt := time.Date(2021, 1, 16, 15, 0, 0, 0, time.UTC)
+	params := param.DoTransition{
+		MatchID:    uuid.NewV4(),
+		Status:     model.MatchStatusHalfTime,
+		OccurredAt: &occurredAt,
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).
+			AddRow(params.MatchID, model.MatchStatusFirstHalf))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "match_transitions" WHERE match_id = $1 ORDER BY occurred_at, created_at`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "match_id", "from_status", "to_status", "occurred_at"}).
+			AddRow(uuid.NewV4(), params.MatchID, model.MatchStatusScheduled, model.MatchStatusFirstHalf, occurredAt.Add(time.Minute)))
+
+	suite.response.doTransitionResp, suite.helper.err = suite.match.DoTransition(context.Background(), params)
+
+	require.IsType(suite.T(), &iPkgError.ValidationError{}, suite.helper.err)
+}
+
+// TestGetClock ...
+func (suite *Suite) TestGetClock() {
+	params := param.GetClock{
+		MatchID: uuid.NewV4(),
+	}
+	kickoff := time.Now().Add(-30 * time.Minute)
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).
+			AddRow(params.MatchID, model.MatchStatusFirstHalf))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "match_transitions" WHERE match_id = $1 ORDER BY occurred_at, created_at`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "match_id", "from_status", "to_status", "occurred_at"}).
+			AddRow(uuid.NewV4(), params.MatchID, model.MatchStatusScheduled, model.MatchStatusFirstHalf, kickoff))
+
+	suite.response.getClockResp, suite.helper.err = suite.match.GetClock(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), model.MatchStatusFirstHalf, suite.response.getClockResp.Status)
+	require.Equal(suite.T(), 31, suite.response.getClockResp.Minute)
+	require.NotNil(suite.T(), suite.response.getClockResp.PeriodStartedAt)
+}
+
+// TestGetWinner ...
+func (suite *Suite) TestGetWinner() {
+	params := param.GetWinner{
+		MatchID: uuid.NewV4(),
+	}
+	homeTeamID, awayTeamID := uuid.NewV4(), uuid.NewV4()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "home_team_id", "away_team_id", "status", "home_score", "away_score", "home_extra_time_score", "away_extra_time_score", "home_shootout_score", "away_shootout_score"}).
+			AddRow(params.MatchID, homeTeamID, awayTeamID, model.MatchStatusFinished, 1, 1, 0, 0, 3, 4))
+
+	suite.response.getWinnerResp, suite.helper.err = suite.match.GetWinner(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), awayTeamID, *suite.response.getWinnerResp.WinnerTeamID)
+	require.Equal(suite.T(), model.MatchDecidedInPenalties, suite.response.getWinnerResp.DecidedIn)
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+// TestClock ...
+func TestClock(t *testing.T) {
+	kickoff := time.Date(2021, 1, 16, 15, 0, 0, 0, time.UTC)
+	transition := func(from, to string, after time.Duration) transporter.GetTransitions {
+		return transporter.GetTransitions{Transition: transporter.Transition{
+			FromStatus: from,
+			ToStatus:   to,
+			OccurredAt: kickoff.Add(after),
+		}}
+	}
+	firstHalf := transition(model.MatchStatusScheduled, model.MatchStatusFirstHalf, 0)
+	halfTime := transition(model.MatchStatusFirstHalf, model.MatchStatusHalfTime, 47*time.Minute)
+	secondHalf := transition(model.MatchStatusHalfTime, model.MatchStatusSecondHalf, 62*time.Minute)
+	extraTime := transition(model.MatchStatusSecondHalf, model.MatchStatusExtraTime, 115*time.Minute)
+
+	tests := []struct {
+		name        string
+		transitions []transporter.GetTransitions
+		now         time.Duration
+		minute      int
+		running     bool
+	}{
+		{
+			name:   "not kicked off",
+			now:    -time.Hour,
+			minute: 0,
+		},
+		{
+			name:        "first minute",
+			transitions: []transporter.GetTransitions{firstHalf},
+			now:         30 * time.Second,
+			minute:      1,
+			running:     true,
+		},
+		{
+			name:        "first half stoppage time",
+			transitions: []transporter.GetTransitions{firstHalf},
+			now:         46*time.Minute + 10*time.Second,
+			minute:      47,
+			running:     true,
+		},
+		{
+			name:        "half time",
+			transitions: []transporter.GetTransitions{firstHalf, halfTime},
+			now:         55 * time.Minute,
+			minute:      45,
+		},
+		{
+			name:        "second half counts on from 45",
+			transitions: []transporter.GetTransitions{firstHalf, halfTime, secondHalf},
+			now:         72 * time.Minute,
+			minute:      56,
+			running:     true,
+		},
+		{
+			name:        "extra time counts on from 90",
+			transitions: []transporter.GetTransitions{firstHalf, halfTime, secondHalf, extraTime},
+			now:         120 * time.Minute,
+			minute:      96,
+			running:     true,
+		},
+		{
+			name: "penalties",
+			transitions: []transporter.GetTransitions{firstHalf, halfTime, secondHalf, extraTime,
+				transition(model.MatchStatusExtraTime, model.MatchStatusPenalties, 150*time.Minute)},
+			now:    155 * time.Minute,
+			minute: 120,
+		},
+		{
+			name: "finished in regular time",
+			transitions: []transporter.GetTransitions{firstHalf, halfTime, secondHalf,
+				transition(model.MatchStatusSecondHalf, model.MatchStatusFinished, 110*time.Minute)},
+			now:    3 * time.Hour,
+			minute: 90,
+		},
+		{
+			name: "finished after extra time",
+			transitions: []transporter.GetTransitions{firstHalf, halfTime, secondHalf, extraTime,
+				transition(model.MatchStatusExtraTime, model.MatchStatusFinished, 150*time.Minute)},
+			now:    3 * time.Hour,
+			minute: 120,
+		},
+		{
+			name: "abandoned stops the clock",
+			transitions: []transporter.GetTransitions{firstHalf, halfTime, secondHalf,
+				transition(model.MatchStatusSecondHalf, model.MatchStatusAbandoned, 80*time.Minute)},
+			now:    3 * time.Hour,
+			minute: 64,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			minute, periodStartedAt := clock(tt.transitions, kickoff.Add(tt.now))
+
+			require.Equal(t, tt.minute, minute)
+			require.Equal(t, tt.running, periodStartedAt != nil)
+		})
+	}
+}
+
+// TestCanTransition ...
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		allowed  bool
+	}{
+		{model.MatchStatusScheduled, model.MatchStatusFirstHalf, true},
+		{model.MatchStatusScheduled, model.MatchStatusFinished, false},
+		{model.MatchStatusFirstHalf, model.MatchStatusSecondHalf, false},
+		{model.MatchStatusSecondHalf, model.MatchStatusFinished, true},
+		{model.MatchStatusSecondHalf, model.MatchStatusPenalties, false},
+		{model.MatchStatusExtraTime, model.MatchStatusPenalties, true},
+		{model.MatchStatusPostponed, model.MatchStatusScheduled, true},
+		{model.MatchStatusHalfTime, model.MatchStatusAbandoned, true},
+		{model.MatchStatusFinished, model.MatchStatusFirstHalf, false},
+		{model.MatchStatusCancelled, model.MatchStatusScheduled, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+"->"+tt.to, func(t *testing.T) {
+			require.Equal(t, tt.allowed, canTransition(tt.from, tt.to))
+		})
+	}
+}
+
+// TestDecide ...
+func TestDecide(t *testing.T) {
+	home, away := uuid.NewV4(), uuid.NewV4()
+	result := func(status string, scores ...int) transporter.Match {
+		return transporter.Match{
+			HomeTeamID:         home,
+			AwayTeamID:         away,
+			Status:             status,
+			HomeScore:          scores[0],
+			AwayScore:          scores[1],
+			HomeExtraTimeScore: scores[2],
+			AwayExtraTimeScore: scores[3],
+			HomeShootoutScore:  scores[4],
+			AwayShootoutScore:  scores[5],
+		}
+	}
+
+	tests := []struct {
+		name      string
+		match     transporter.Match
+		winner    *uuid.UUID
+		decidedIn string
+	}{
+		{
+			name:  "not finished",
+			match: result(model.MatchStatusSecondHalf, 2, 0, 0, 0, 0, 0),
+		},
+		{
+			name:      "home win in regular time",
+			match:     result(model.MatchStatusFinished, 2, 1, 0, 0, 0, 0),
+			winner:    &home,
+			decidedIn: model.MatchDecidedInRegularTime,
+		},
+		{
+			name:      "away win in regular time",
+			match:     result(model.MatchStatusFinished, 0, 3, 0, 0, 0, 0),
+			winner:    &away,
+			decidedIn: model.MatchDecidedInRegularTime,
+		},
+		{
+			name:  "draw",
+			match: result(model.MatchStatusFinished, 1, 1, 0, 0, 0, 0),
+		},
+		{
+			name:      "extra time",
+			match:     result(model.MatchStatusFinished, 1, 1, 0, 1, 0, 0),
+			winner:    &away,
+			decidedIn: model.MatchDecidedInExtraTime,
+		},
+		{
+			name:      "penalties",
+			match:     result(model.MatchStatusFinished, 2, 2, 1, 1, 5, 4),
+			winner:    &home,
+			decidedIn: model.MatchDecidedInPenalties,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getWinnerResp := decide(tt.match)
+
+			require.Equal(t, tt.winner, getWinnerResp.WinnerTeamID)
+			require.Equal(t, tt.decidedIn, getWinnerResp.DecidedIn)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package match
+
+import (
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	"github.com/harunnryd/skeltun/internal/pkg"
+	"github.com/harunnryd/skeltun/job"
+)
+
+// Option is a closure that is used for accessing the local variables.
+type Option func(match *Match)
+
+// WithConfig ...
+func WithConfig(config config.IConfig) Option {
+	return func(match *Match) {
+		match.config = config
+	}
+}
+
+// WithRepo ...
+func WithRepo(repo repo.IRepo) Option {
+	return func(match *Match) {
+		match.repo = repo
+	}
+}
+
+// WithPkg ...
+func WithPkg(pkg pkg.IPkg) Option {
+	return func(match *Match) {
+		match.pkg = pkg
+	}
+}
+
+// WithJob ...
+func WithJob(job job.IJob) Option {
+	return func(match *Match) {
+		match.job = job
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package match
+
+import (
+	"github.com/harunnryd/skeltun/internal/app/handler/match/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+)
+
+// decide is used for working out who won a match.
+// Extra time goals are added to the regular time score, the shootout only counts
+// once both of them are level.
+func decide(match transporter.Match) (getWinnerResp transporter.GetWinner) {
+	getWinnerResp.MatchID = match.ID
+	if match.Status != model.MatchStatusFinished {
+		return
+	}
+
+	home, away := match.HomeScore, match.AwayScore
+	decidedIn := model.MatchDecidedInRegularTime
+
+	if home == away {
+		home, away = home+match.HomeExtraTimeScore, away+match.AwayExtraTimeScore
+		decidedIn = model.MatchDecidedInExtraTime
+	}
+
+	if home == away {
+		home, away = match.HomeShootoutScore, match.AwayShootoutScore
+		decidedIn = model.MatchDecidedInPenalties
+	}
+
+	if home == away {
+		return
+	}
+
+	winnerTeamID, loserTeamID := match.HomeTeamID, match.AwayTeamID
+	if away > home {
+		winnerTeamID, loserTeamID = loserTeamID, winnerTeamID
+	}
+
+	getWinnerResp.WinnerTeamID = &winnerTeamID
+	getWinnerResp.LoserTeamID = &loserTeamID
+	getWinnerResp.DecidedIn = decidedIn
+
+	return
+}
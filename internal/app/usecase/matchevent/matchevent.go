@@ -0,0 +1,187 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matchevent
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gocraft/work"
+	"github.com/harunnryd/skeltun/config"
+	lineupParam "github.com/harunnryd/skeltun/internal/app/handler/lineup/param"
+	matchParam "github.com/harunnryd/skeltun/internal/app/handler/match/param"
+	matchTransporter "github.com/harunnryd/skeltun/internal/app/handler/match/transporter"
+	"github.com/harunnryd/skeltun/internal/app/handler/matchevent/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/matchevent/transporter"
+	playerParam "github.com/harunnryd/skeltun/internal/app/handler/player/param"
+	suspensionParam "github.com/harunnryd/skeltun/internal/app/handler/suspension/param"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	"github.com/harunnryd/skeltun/internal/app/usecase/lineup"
+	"github.com/harunnryd/skeltun/internal/app/usecase/suspension"
+	"github.com/harunnryd/skeltun/internal/pkg"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/harunnryd/skeltun/job"
+	"github.com/satori/uuid"
+)
+
+// IMatchEvent is an interface that stores the methods that MatchEvent struct will use.
+type IMatchEvent interface {
+	// DoCreate is used for record new match event and recomputing the match score.
+	// It returns doCreateResp of transporter.DoCreate and any errors written.
+	DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error)
+
+	// GetMatchEvents is used for getting the timeline of a match.
+	// It returns getMatchEventsResp of []transporter.GetMatchEvents and any errors written.
+	GetMatchEvents(ctx context.Context, params param.GetMatchEvents) (getMatchEventsResp []transporter.GetMatchEvents, err error)
+
+	// DoDelete is used for delete the record match event and recomputing the match score.
+	// It returns doDeleteResp of transporter.DoDelete and any errors written.
+	DoDelete(ctx context.Context, params param.DoDelete) (doDeleteResp transporter.DoDelete, err error)
+}
+
+// MatchEvent is an struct that implements IMatchEvent methods.
+type MatchEvent struct {
+	config     config.IConfig
+	repo       repo.IRepo
+	pkg        pkg.IPkg
+	lineup     lineup.ILineup
+	suspension suspension.ISuspension
+	job        job.IJob
+}
+
+// New it returns instance of MatchEvent that implements IMatchEvent methods.
+func New(opts ...Option) IMatchEvent {
+	m := new(MatchEvent)
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// DoCreate is used for record new match event and recomputing the match score.
+// A substitution should take a player off the pitch for one on the bench of the team lineup.
+// A card that calls for a suspension gets the player suspended, recorded along with the card and the new score.
+// An event of a finished match has the player stats of the season rebuilt.
+// It returns doCreateResp of transporter.DoCreate and any errors written.
+func (matchEvent *MatchEvent) DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error) {
+	getMatchResp, err := matchEvent.repo.GetMatch().GetMatch(ctx, matchParam.GetMatch{ID: params.MatchID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getMatchResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("match not found")}
+		return
+	}
+
+	getPlayerResp, err := matchEvent.repo.GetPlayer().GetPlayer(ctx, playerParam.GetPlayer{ID: params.PlayerID})
+	if err != nil {
+		return
+	}
+
+	if !uuid.Equal(getPlayerResp.TeamID, getMatchResp.HomeTeamID) && !uuid.Equal(getPlayerResp.TeamID, getMatchResp.AwayTeamID) {
+		err = &iPkgError.ValidationError{Err: errors.New("player does not belong to either team")}
+		return
+	}
+
+	// The event is always credited to the team the player plays for, own goals included.
+	params.TeamID = getPlayerResp.TeamID
+
+	if params.Period == "" {
+		params.Period = model.MatchPeriodRegularTime
+		if getMatchResp.Status == model.MatchStatusExtraTime {
+			params.Period = model.MatchPeriodExtraTime
+		}
+	}
+
+	if params.RelatedPlayerID != nil {
+		getRelatedPlayerResp, err := matchEvent.repo.GetPlayer().GetPlayer(ctx, playerParam.GetPlayer{ID: *params.RelatedPlayerID})
+		if err != nil {
+			return doCreateResp, err
+		}
+
+		if !uuid.Equal(getRelatedPlayerResp.TeamID, params.TeamID) {
+			err = &iPkgError.ValidationError{Err: errors.New("related player does not belong to the same team")}
+			return doCreateResp, err
+		}
+	}
+
+	if params.Type == model.MatchEventTypeSubstitution {
+		if err = matchEvent.lineup.CheckSubstitution(ctx, lineupParam.CheckSubstitution{
+			MatchID:         params.MatchID,
+			TeamID:          params.TeamID,
+			PlayerID:        params.PlayerID,
+			RelatedPlayerID: *params.RelatedPlayerID,
+		}); err != nil {
+			return
+		}
+	}
+
+	getPenaltyResp, err := matchEvent.suspension.GetPenalty(ctx, suspensionParam.GetPenalty{
+		SeasonID: getMatchResp.SeasonID,
+		PlayerID: params.PlayerID,
+		Type:     params.Type,
+	})
+	if err != nil {
+		return
+	}
+
+	if getPenaltyResp.Reason != "" {
+		params.Suspension = &suspensionParam.Suspension{
+			PlayerID:         params.PlayerID,
+			SeasonID:         *getMatchResp.SeasonID,
+			Reason:           getPenaltyResp.Reason,
+			MatchesRemaining: getPenaltyResp.MatchesRemaining,
+		}
+	}
+
+	doCreateResp, err = matchEvent.repo.GetMatchEvent().DoCreate(ctx, params)
+	if err != nil {
+		return
+	}
+
+	matchEvent.refreshStats(getMatchResp)
+
+	return
+}
+
+// GetMatchEvents is used for getting the timeline of a match.
+// It returns getMatchEventsResp of []transporter.GetMatchEvents and any errors written.
+func (matchEvent *MatchEvent) GetMatchEvents(ctx context.Context, params param.GetMatchEvents) (getMatchEventsResp []transporter.GetMatchEvents, err error) {
+	getMatchEventsResp, err = matchEvent.repo.GetMatchEvent().GetMatchEvents(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// DoDelete is used for delete the record match event and recomputing the match score.
+// A suspension the event led to goes with it, and an event of a finished match has the player stats of the season rebuilt.
+// It returns doDeleteResp of transporter.DoDelete and any errors written.
+func (matchEvent *MatchEvent) DoDelete(ctx context.Context, params param.DoDelete) (doDeleteResp transporter.DoDelete, err error) {
+	getMatchResp, err := matchEvent.repo.GetMatch().GetMatch(ctx, matchParam.GetMatch{ID: params.MatchID})
+	if err != nil {
+		return
+	}
+
+	doDeleteResp, err = matchEvent.repo.GetMatchEvent().DoDelete(ctx, params)
+	if err != nil {
+		return
+	}
+
+	matchEvent.refreshStats(getMatchResp)
+
+	return
+}
+
+// refreshStats is used for rebuilding the player stats of a finished match of a season in the background,
+// so a corrected scorer or a cancelled card reaches the stats and the leaderboards.
+func (matchEvent *MatchEvent) refreshStats(getMatchResp matchTransporter.GetMatch) {
+	if getMatchResp.Status == model.MatchStatusFinished && getMatchResp.SeasonID != nil {
+		matchEvent.job.Queue("do_refresh_stats", work.Q{"match_id": getMatchResp.ID.String()})
+	}
+}
@@ -0,0 +1,410 @@
+package matchevent
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gocraft/work"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/handler/matchevent/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/matchevent/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	iLineupRepo "github.com/harunnryd/skeltun/internal/app/repo/lineup"
+	iMatchRepo "github.com/harunnryd/skeltun/internal/app/repo/match"
+	"github.com/harunnryd/skeltun/internal/app/repo/match/matchtest"
+	iMatchEventRepo "github.com/harunnryd/skeltun/internal/app/repo/matchevent"
+	iPlayerRepo "github.com/harunnryd/skeltun/internal/app/repo/player"
+	"github.com/harunnryd/skeltun/internal/app/usecase/lineup"
+	"github.com/harunnryd/skeltun/internal/app/usecase/suspension"
+	"github.com/harunnryd/skeltun/job/jobtest"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	mock      sqlmock.Sqlmock
+	pgsqlConn *gorm.DB
+
+	iMatchRepo      iMatchRepo.IMatch
+	iMatchEventRepo iMatchEventRepo.IMatchEvent
+	iPlayerRepo     iPlayerRepo.IPlayer
+	iLineupRepo     iLineupRepo.ILineup
+	iRepo           repo.IRepo
+	job             *jobtest.Fake
+	matchEvent      IMatchEvent
+	helper
+	response
+}
+
+type helper struct {
+	err error
+	db  *sql.DB
+}
+
+type response struct {
+	doCreateResp       transporter.DoCreate
+	getMatchEventsResp []transporter.GetMatchEvents
+	doDeleteResp       transporter.DoDelete
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.iMatchRepo = iMatchRepo.New(
+		iMatchRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iMatchEventRepo = iMatchEventRepo.New(
+		iMatchEventRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iPlayerRepo = iPlayerRepo.New(
+		iPlayerRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iLineupRepo = iLineupRepo.New(
+		iLineupRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iRepo = repo.New()
+	suite.iRepo.SetMatch(suite.iMatchRepo)
+	suite.iRepo.SetMatchEvent(suite.iMatchEventRepo)
+	suite.iRepo.SetPlayer(suite.iPlayerRepo)
+	suite.iRepo.SetLineup(suite.iLineupRepo)
+
+	suite.job = new(jobtest.Fake)
+
+	suite.matchEvent = New(
+		WithRepo(suite.iRepo),
+		WithLineup(lineup.New(lineup.WithRepo(suite.iRepo))),
+		WithSuspension(suspension.New(suspension.WithRepo(suite.iRepo))),
+		WithJob(suite.job),
+	)
+}
+
+// TestDoCreate ...
+func (suite *Suite) TestDoCreate() {
+	homeTeamID, awayTeamID := uuid.NewV4(), uuid.NewV4()
+	params := param.DoCreate{
+		MatchEvent: param.MatchEvent{
+			MatchID:  uuid.NewV4(),
+			PlayerID: uuid.NewV4(),
+			Type:     model.MatchEventTypeOwnGoal,
+			Minute:   27,
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "home_team_id", "away_team_id"}).
+			AddRow(params.MatchID, homeTeamID, awayTeamID))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.PlayerID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "team_id", "name"}).
+			AddRow(params.PlayerID, awayTeamID, "Jamie Carragher"))
+
+	suite.mock.ExpectBegin()
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "match_events" ("created_at","updated_at","deleted_at","match_id","team_id","player_id","related_player_id","type","period","minute") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10) RETURNING "id"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.MatchID, awayTeamID, params.PlayerID, nil, params.Type, model.MatchPeriodRegularTime, params.Minute).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.mock.
+		ExpectQuery(`UPDATE matches SET (.+) RETURNING id, home_score, away_score, home_extra_time_score, away_extra_time_score, home_shootout_score, away_shootout_score`).
+		WithArgs(matchtest.SyncScoreArgs(params.MatchID)...).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "home_score", "away_score"}).
+			AddRow(params.MatchID, 1, 0))
+	suite.mock.ExpectCommit()
+
+	suite.job.Queued = nil
+	suite.response.doCreateResp, suite.helper.err = suite.matchEvent.DoCreate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), awayTeamID, suite.response.doCreateResp.TeamID)
+	require.Empty(suite.T(), suite.job.Queued)
+}
+
+// TestDoCreateFinishedMatch ...
+func (suite *Suite) TestDoCreateFinishedMatch() {
+	homeTeamID, awayTeamID := uuid.NewV4(), uuid.NewV4()
+	params := param.DoCreate{
+		MatchEvent: param.MatchEvent{
+			MatchID:  uuid.NewV4(),
+			PlayerID: uuid.NewV4(),
+			Type:     model.MatchEventTypeOwnGoal,
+			Minute:   27,
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "season_id", "home_team_id", "away_team_id", "status"}).
+			AddRow(params.MatchID, uuid.NewV4(), homeTeamID, awayTeamID, model.MatchStatusFinished))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.PlayerID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "team_id", "name"}).
+			AddRow(params.PlayerID, awayTeamID, "Jamie Carragher"))
+
+	suite.mock.ExpectBegin()
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "match_events" ("created_at","updated_at","deleted_at","match_id","team_id","player_id","related_player_id","type","period","minute") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10) RETURNING "id"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.MatchID, awayTeamID, params.PlayerID, nil, params.Type, model.MatchPeriodRegularTime, params.Minute).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.mock.
+		ExpectQuery(`UPDATE matches SET (.+) RETURNING id, home_score, away_score, home_extra_time_score, away_extra_time_score, home_shootout_score, away_shootout_score`).
+		WithArgs(matchtest.SyncScoreArgs(params.MatchID)...).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "home_score", "away_score"}).
+			AddRow(params.MatchID, 1, 0))
+	suite.mock.ExpectCommit()
+
+	suite.job.Queued = nil
+	suite.response.doCreateResp, suite.helper.err = suite.matchEvent.DoCreate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), []work.Q{
+		{"name": "do_refresh_stats", "args": work.Q{"match_id": params.MatchID.String()}},
+	}, suite.job.Queued)
+}
+
+// TestDoCreateMatchNotFound ...
+func (suite *Suite) TestDoCreateMatchNotFound() {
+	params := param.DoCreate{
+		MatchEvent: param.MatchEvent{
+			MatchID:  uuid.NewV4(),
+			PlayerID: uuid.NewV4(),
+			Type:     model.MatchEventTypeGoal,
+			Minute:   10,
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.response.doCreateResp, suite.helper.err = suite.matchEvent.DoCreate(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "match not found")
+}
+
+// TestDoCreatePlayerNotInMatch ...
+func (suite *Suite) TestDoCreatePlayerNotInMatch() {
+	params := param.DoCreate{
+		MatchEvent: param.MatchEvent{
+			MatchID:  uuid.NewV4(),
+			PlayerID: uuid.NewV4(),
+			Type:     model.MatchEventTypeGoal,
+			Minute:   10,
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "home_team_id", "away_team_id"}).
+			AddRow(params.MatchID, uuid.NewV4(), uuid.NewV4()))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.PlayerID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "team_id", "name"}).
+			AddRow(params.PlayerID, uuid.NewV4(), "Thierry Henry"))
+
+	suite.response.doCreateResp, suite.helper.err = suite.matchEvent.DoCreate(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "player does not belong to either team")
+}
+
+// TestDoCreateSubstituteFromOtherTeam ...
+func (suite *Suite) TestDoCreateSubstituteFromOtherTeam() {
+	homeTeamID, awayTeamID := uuid.NewV4(), uuid.NewV4()
+	relatedPlayerID := uuid.NewV4()
+	params := param.DoCreate{
+		MatchEvent: param.MatchEvent{
+			MatchID:         uuid.NewV4(),
+			PlayerID:        uuid.NewV4(),
+			RelatedPlayerID: &relatedPlayerID,
+			Type:            model.MatchEventTypeSubstitution,
+			Minute:          70,
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "home_team_id", "away_team_id"}).
+			AddRow(params.MatchID, homeTeamID, awayTeamID))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.PlayerID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "team_id", "name"}).
+			AddRow(params.PlayerID, homeTeamID, "Paul Scholes"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE id = $1 LIMIT 1`)).
+		WithArgs(relatedPlayerID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "team_id", "name"}).
+			AddRow(relatedPlayerID, awayTeamID, "Frank Lampard"))
+
+	suite.response.doCreateResp, suite.helper.err = suite.matchEvent.DoCreate(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "related player does not belong to the same team")
+}
+
+// TestDoCreateSubstituteNotOnBench ...
+func (suite *Suite) TestDoCreateSubstituteNotOnBench() {
+	homeTeamID, awayTeamID := uuid.NewV4(), uuid.NewV4()
+	relatedPlayerID, benchPlayerID := uuid.NewV4(), uuid.NewV4()
+	params := param.DoCreate{
+		MatchEvent: param.MatchEvent{
+			MatchID:         uuid.NewV4(),
+			PlayerID:        uuid.NewV4(),
+			RelatedPlayerID: &relatedPlayerID,
+			Type:            model.MatchEventTypeSubstitution,
+			Minute:          70,
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "home_team_id", "away_team_id"}).
+			AddRow(params.MatchID, homeTeamID, awayTeamID))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.PlayerID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "team_id", "name"}).
+			AddRow(params.PlayerID, homeTeamID, "Paul Scholes"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE id = $1 LIMIT 1`)).
+		WithArgs(relatedPlayerID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "team_id", "name"}).
+			AddRow(relatedPlayerID, homeTeamID, "Darren Fletcher"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "lineups" WHERE match_id = $1 AND team_id = $2 LIMIT 1`)).
+		WithArgs(params.MatchID, homeTeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"match_id", "team_id", "formation"}).
+			AddRow(params.MatchID, homeTeamID, "4-4-2"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "lineup_players" WHERE match_id = $1 AND team_id = $2 ORDER BY sequence`)).
+		WithArgs(params.MatchID, homeTeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"match_id", "team_id", "player_id", "starter", "sequence"}).
+			AddRow(params.MatchID, homeTeamID, params.PlayerID, true, 8).
+			AddRow(params.MatchID, homeTeamID, benchPlayerID, false, 12))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "match_events" WHERE match_id = $1 ORDER BY minute, created_at`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.response.doCreateResp, suite.helper.err = suite.matchEvent.DoCreate(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "substitute is not on the bench")
+}
+
+// TestGetMatchEvents ...
+func (suite *Suite) TestGetMatchEvents() {
+	params := param.GetMatchEvents{
+		MatchID: uuid.NewV4(),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "match_events" WHERE match_id = $1 ORDER BY minute, created_at`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "match_id", "type", "minute"}).
+			AddRow(uuid.NewV4(), params.MatchID, model.MatchEventTypeGoal, 9))
+
+	suite.response.getMatchEventsResp, suite.helper.err = suite.matchEvent.GetMatchEvents(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Len(suite.T(), suite.response.getMatchEventsResp, 1)
+}
+
+// TestDoDelete ...
+func (suite *Suite) TestDoDelete() {
+	params := param.DoDelete{
+		ID:      uuid.NewV4(),
+		MatchID: uuid.NewV4(),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "season_id", "status"}).
+			AddRow(params.MatchID, uuid.NewV4(), model.MatchStatusFinished))
+
+	suite.mock.ExpectBegin()
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`DELETE FROM "match_events" WHERE id = $1 AND match_id = $2`)).
+		WithArgs(params.ID, params.MatchID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	suite.mock.
+		ExpectQuery(`UPDATE matches SET (.+) RETURNING id, home_score, away_score, home_extra_time_score, away_extra_time_score, home_shootout_score, away_shootout_score`).
+		WithArgs(matchtest.SyncScoreArgs(params.MatchID)...).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "home_score", "away_score"}).
+			AddRow(params.MatchID, 0, 0))
+	suite.mock.ExpectCommit()
+
+	suite.job.Queued = nil
+	suite.response.doDeleteResp, suite.helper.err = suite.matchEvent.DoDelete(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	// A cancelled card or goal of a finished match has to reach the player stats and the leaderboards.
+	require.Equal(suite.T(), []work.Q{
+		{"name": "do_refresh_stats", "args": work.Q{"match_id": params.MatchID.String()}},
+	}, suite.job.Queued)
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
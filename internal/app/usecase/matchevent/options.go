@@ -0,0 +1,59 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matchevent
+
+import (
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	"github.com/harunnryd/skeltun/internal/app/usecase/lineup"
+	"github.com/harunnryd/skeltun/internal/app/usecase/suspension"
+	"github.com/harunnryd/skeltun/internal/pkg"
+	"github.com/harunnryd/skeltun/job"
+)
+
+// Option is a closure that is used for accessing the local variables.
+type Option func(matchevent *MatchEvent)
+
+// WithConfig ...
+func WithConfig(config config.IConfig) Option {
+	return func(matchevent *MatchEvent) {
+		matchevent.config = config
+	}
+}
+
+// WithRepo ...
+func WithRepo(repo repo.IRepo) Option {
+	return func(matchevent *MatchEvent) {
+		matchevent.repo = repo
+	}
+}
+
+// WithPkg ...
+func WithPkg(pkg pkg.IPkg) Option {
+	return func(matchevent *MatchEvent) {
+		matchevent.pkg = pkg
+	}
+}
+
+// WithLineup ...
+func WithLineup(lineup lineup.ILineup) Option {
+	return func(matchevent *MatchEvent) {
+		matchevent.lineup = lineup
+	}
+}
+
+// WithSuspension ...
+func WithSuspension(suspension suspension.ISuspension) Option {
+	return func(matchevent *MatchEvent) {
+		matchevent.suspension = suspension
+	}
+}
+
+// WithJob ...
+func WithJob(job job.IJob) Option {
+	return func(matchevent *MatchEvent) {
+		matchevent.job = job
+	}
+}
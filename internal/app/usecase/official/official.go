@@ -0,0 +1,512 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package official
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/harunnryd/skeltun/config"
+	competitionParam "github.com/harunnryd/skeltun/internal/app/handler/competition/param"
+	matchParam "github.com/harunnryd/skeltun/internal/app/handler/match/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/official/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/official/transporter"
+	seasonParam "github.com/harunnryd/skeltun/internal/app/handler/season/param"
+	teamParam "github.com/harunnryd/skeltun/internal/app/handler/team/param"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	"github.com/harunnryd/skeltun/internal/pkg"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// bookingMinutes is how long an official stays booked either side of a kickoff when it is not configured.
+const bookingMinutes = 180
+
+// IOfficial is an interface that stores the methods that Official struct will use.
+type IOfficial interface {
+	// DoCreate is used for record new official.
+	// It returns doCreateResp of transporter.DoCreate and any errors written.
+	DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error)
+
+	// GetOfficials is used for getting all officials.
+	// It returns getOfficialsResp of []transporter.GetOfficials and any errors written.
+	GetOfficials(ctx context.Context, params param.GetOfficials) (getOfficialsResp []transporter.GetOfficials, err error)
+
+	// DoUpdate is used for update the record official.
+	// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+	DoUpdate(ctx context.Context, params param.DoUpdate) (doUpdateResp transporter.DoUpdate, err error)
+
+	// DoDelete is used for delete the record official.
+	// It returns doDeleteResp of transporter.DoDelete and any errors written.
+	DoDelete(ctx context.Context, params param.DoDelete) (doDeleteResp transporter.DoDelete, err error)
+
+	// GetOfficial is used for getting an official.
+	// It returns getOfficialResp of transporter.GetOfficial and any errors written.
+	GetOfficial(ctx context.Context, params param.GetOfficial) (getOfficialResp transporter.GetOfficial, err error)
+
+	// DoAssign is used for assign the officials of a match, replacing the previous ones.
+	// It returns doAssignResp of transporter.DoAssign and any errors written.
+	DoAssign(ctx context.Context, params param.DoAssign) (doAssignResp transporter.DoAssign, err error)
+
+	// GetAssignments is used for getting the officials assigned to a match.
+	// It returns getAssignmentsResp of []transporter.GetAssignments and any errors written.
+	GetAssignments(ctx context.Context, params param.GetAssignments) (getAssignmentsResp []transporter.GetAssignments, err error)
+
+	// GetStats is used for getting the decisions of a referee over the matches they took charge of.
+	// It returns getStatsResp of transporter.GetStats and any errors written.
+	GetStats(ctx context.Context, params param.GetStats) (getStatsResp transporter.GetStats, err error)
+
+	// GetRankings is used for getting the referees of a competition ranked by a metric.
+	// It returns getRankingsResp of transporter.GetRankings and any errors written.
+	GetRankings(ctx context.Context, params param.GetRankings) (getRankingsResp transporter.GetRankings, err error)
+}
+
+// Official is an struct that implements IOfficial methods.
+type Official struct {
+	config config.IConfig
+	repo   repo.IRepo
+	pkg    pkg.IPkg
+}
+
+// New it returns instance of Official that implements IOfficial methods.
+func New(opts ...Option) IOfficial {
+	o := new(Official)
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// DoCreate is used for record new official.
+// It returns doCreateResp of transporter.DoCreate and any errors written.
+func (official *Official) DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error) {
+	if err = official.checkTeam(ctx, params.AffiliatedTeamID); err != nil {
+		return
+	}
+
+	doCreateResp, err = official.repo.GetOfficial().DoCreate(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// GetOfficials is used for getting all officials.
+// It returns getOfficialsResp of []transporter.GetOfficials and any errors written.
+func (official *Official) GetOfficials(ctx context.Context, params param.GetOfficials) (getOfficialsResp []transporter.GetOfficials, err error) {
+	getOfficialsResp, err = official.repo.GetOfficial().GetOfficials(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// DoUpdate is used for update the record official.
+// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+func (official *Official) DoUpdate(ctx context.Context, params param.DoUpdate) (doUpdateResp transporter.DoUpdate, err error) {
+	getOfficialResp, err := official.repo.GetOfficial().GetOfficial(ctx, param.GetOfficial{ID: params.ID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getOfficialResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("official not found")}
+		return
+	}
+
+	if err = official.checkTeam(ctx, params.AffiliatedTeamID); err != nil {
+		return
+	}
+
+	doUpdateResp, err = official.repo.GetOfficial().DoUpdate(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// DoDelete is used for delete the record official.
+// An official assigned to a match cannot be deleted, the assignments of the match should be changed first.
+// It returns doDeleteResp of transporter.DoDelete and any errors written.
+func (official *Official) DoDelete(ctx context.Context, params param.DoDelete) (doDeleteResp transporter.DoDelete, err error) {
+	getOfficialResp, err := official.repo.GetOfficial().GetOfficial(ctx, param.GetOfficial{ID: params.ID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getOfficialResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("official not found")}
+		return
+	}
+
+	countAssignmentsResp, err := official.repo.GetOfficial().CountAssignments(ctx, param.CountAssignments{OfficialID: params.ID})
+	if err != nil {
+		return
+	}
+
+	if countAssignmentsResp.Total > 0 {
+		err = &iPkgError.ValidationError{Err: errors.New("official is assigned to matches and cannot be deleted")}
+		return
+	}
+
+	doDeleteResp, err = official.repo.GetOfficial().DoDelete(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// GetOfficial is used for getting an official.
+// It returns getOfficialResp of transporter.GetOfficial and any errors written.
+func (official *Official) GetOfficial(ctx context.Context, params param.GetOfficial) (getOfficialResp transporter.GetOfficial, err error) {
+	getOfficialResp, err = official.repo.GetOfficial().GetOfficial(ctx, params)
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getOfficialResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("official not found")}
+		return
+	}
+
+	return
+}
+
+// DoAssign is used for assign the officials of a match, replacing the previous ones.
+// A referee can be assigned in any role, any other official only in the role they are registered for.
+// No official may be affiliated with either team, nor be booked for another match
+// kicking off within the booking window of this one.
+// It returns doAssignResp of transporter.DoAssign and any errors written.
+func (official *Official) DoAssign(ctx context.Context, params param.DoAssign) (doAssignResp transporter.DoAssign, err error) {
+	getMatchResp, err := official.repo.GetMatch().GetMatch(ctx, matchParam.GetMatch{ID: params.MatchID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getMatchResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("match not found")}
+		return
+	}
+
+	if getMatchResp.Status != model.MatchStatusScheduled {
+		err = &iPkgError.ValidationError{Err: errors.New("officials cannot be changed once the match has kicked off")}
+		return
+	}
+
+	officialIDs := make([]uuid.UUID, 0, len(params.Officials))
+	for _, assignment := range params.Officials {
+		officialIDs = append(officialIDs, assignment.OfficialID)
+	}
+
+	getOfficialsByIDsResp, err := official.repo.GetOfficial().GetOfficialsByIDs(ctx, param.GetOfficialsByIDs{IDs: officialIDs})
+	if err != nil {
+		return
+	}
+
+	officials := make(map[uuid.UUID]transporter.GetOfficialsByIDs, len(getOfficialsByIDsResp))
+	for _, officialByID := range getOfficialsByIDsResp {
+		officials[officialByID.ID] = officialByID
+	}
+
+	for _, assignment := range params.Officials {
+		officialByID, ok := officials[assignment.OfficialID]
+		if !ok {
+			err = &iPkgError.ValidationError{Err: errors.New("official " + assignment.OfficialID.String() + " not found")}
+			return
+		}
+
+		if officialByID.Role != model.OfficialRoleReferee && officialByID.Role != assignment.Role {
+			err = &iPkgError.ValidationError{Err: errors.New(officialByID.Name + " is registered as " + officialByID.Role + " and cannot be assigned as " + assignment.Role)}
+			return
+		}
+
+		if officialByID.AffiliatedTeamID != nil &&
+			(uuid.Equal(*officialByID.AffiliatedTeamID, getMatchResp.HomeTeamID) || uuid.Equal(*officialByID.AffiliatedTeamID, getMatchResp.AwayTeamID)) {
+			err = &iPkgError.ValidationError{Err: errors.New(officialByID.Name + " is affiliated with a team playing the match")}
+			return
+		}
+	}
+
+	window := time.Duration(official.bookingMinutes()) * time.Minute
+	params.From = getMatchResp.KickoffAt.Add(-window)
+	params.To = getMatchResp.KickoffAt.Add(window)
+
+	doAssignResp, err = official.repo.GetOfficial().DoAssign(ctx, params)
+	if err != nil {
+		return
+	}
+
+	if len(doAssignResp.Bookings) > 0 {
+		booking := doAssignResp.Bookings[0]
+		doAssignResp = transporter.DoAssign{}
+		err = &iPkgError.ValidationError{Err: errors.New(
+			officials[booking.OfficialID].Name + " is already booked for a match kicking off at " + booking.KickoffAt.UTC().Format(time.RFC3339),
+		)}
+		return
+	}
+
+	for i := range doAssignResp.Officials {
+		doAssignResp.Officials[i].Name = officials[doAssignResp.Officials[i].OfficialID].Name
+	}
+
+	return
+}
+
+// GetAssignments is used for getting the officials assigned to a match.
+// It returns getAssignmentsResp of []transporter.GetAssignments and any errors written.
+func (official *Official) GetAssignments(ctx context.Context, params param.GetAssignments) (getAssignmentsResp []transporter.GetAssignments, err error) {
+	getAssignmentsResp, err = official.repo.GetOfficial().GetAssignments(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// GetStats is used for getting the decisions of a referee over the matches they took charge of.
+// Only finished matches count, over every season unless one is given.
+// It returns getStatsResp of transporter.GetStats and any errors written.
+func (official *Official) GetStats(ctx context.Context, params param.GetStats) (getStatsResp transporter.GetStats, err error) {
+	getOfficialResp, err := official.repo.GetOfficial().GetOfficial(ctx, param.GetOfficial{ID: params.OfficialID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getOfficialResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("official not found")}
+		return
+	}
+
+	seasonID := params.GetSeasonID()
+	if seasonID != nil {
+		if err = official.checkSeason(ctx, *seasonID); err != nil {
+			return
+		}
+	}
+
+	referees, err := official.referees(ctx, param.GetRefereeMatches{
+		OfficialID: &params.OfficialID,
+		SeasonID:   seasonID,
+	})
+	if err != nil {
+		return
+	}
+
+	getStatsResp.SeasonID = seasonID
+	getStatsResp.RefereeStats = transporter.RefereeStats{
+		OfficialID: getOfficialResp.ID,
+		Name:       getOfficialResp.Name,
+	}
+	if len(referees) > 0 {
+		getStatsResp.RefereeStats = referees[0]
+	}
+
+	return
+}
+
+// GetRankings is used for getting the referees of a competition ranked by a metric.
+// Referees level on the metric share a rank and are listed by name.
+// It returns getRankingsResp of transporter.GetRankings and any errors written.
+func (official *Official) GetRankings(ctx context.Context, params param.GetRankings) (getRankingsResp transporter.GetRankings, err error) {
+	getCompetitionResp, err := official.repo.GetCompetition().GetCompetition(ctx, competitionParam.GetCompetition{ID: params.CompetitionID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getCompetitionResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("competition not found")}
+		return
+	}
+
+	seasonID := params.GetSeasonID()
+	if seasonID != nil {
+		found := false
+		for _, season := range getCompetitionResp.Seasons {
+			found = found || uuid.Equal(season.ID, *seasonID)
+		}
+
+		if !found {
+			err = &iPkgError.ValidationError{Err: errors.New("season does not belong to the competition")}
+			return
+		}
+	}
+
+	referees, err := official.referees(ctx, param.GetRefereeMatches{
+		CompetitionID: &params.CompetitionID,
+		SeasonID:      seasonID,
+	})
+	if err != nil {
+		return
+	}
+
+	getRankingsResp = transporter.GetRankings{
+		CompetitionID: params.CompetitionID,
+		SeasonID:      seasonID,
+		Metric:        params.Metric,
+		Referees:      rank(referees, params.Metric),
+	}
+
+	return
+}
+
+// referees is used for tallying the decisions of every referee over the matches they took charge of.
+// Penalties awarded are the penalties scored along with the ones saved or missed.
+// It returns the stats of every referee by name and any errors written.
+func (official *Official) referees(ctx context.Context, params param.GetRefereeMatches) (referees []transporter.RefereeStats, err error) {
+	getRefereeMatchesResp, err := official.repo.GetOfficial().GetRefereeMatches(ctx, params)
+	if err != nil {
+		return
+	}
+
+	if len(getRefereeMatchesResp) == 0 {
+		return
+	}
+
+	matches := make(map[uuid.UUID]transporter.GetRefereeMatches, len(getRefereeMatchesResp))
+	stats := make(map[uuid.UUID]*transporter.RefereeStats)
+	for _, match := range getRefereeMatchesResp {
+		matches[match.MatchID] = match
+
+		if _, ok := stats[match.OfficialID]; !ok {
+			stats[match.OfficialID] = &transporter.RefereeStats{OfficialID: match.OfficialID, Name: match.Name}
+		}
+		stats[match.OfficialID].MatchesOfficiated++
+	}
+
+	matchIDs := make([]uuid.UUID, 0, len(matches))
+	for matchID := range matches {
+		matchIDs = append(matchIDs, matchID)
+	}
+
+	getDecisionsResp, err := official.repo.GetOfficial().GetDecisions(ctx, param.GetDecisions{MatchIDs: matchIDs})
+	if err != nil {
+		return
+	}
+
+	for _, decision := range getDecisionsResp {
+		match, ok := matches[decision.MatchID]
+		if !ok {
+			continue
+		}
+
+		referee := stats[match.OfficialID]
+		switch decision.Type {
+		case model.MatchEventTypeYellowCard:
+			referee.YellowCards++
+			side(&referee.Cards, decision.TeamID, match.HomeTeamID)
+		case model.MatchEventTypeSecondYellow, model.MatchEventTypeRedCard:
+			referee.RedCards++
+			side(&referee.Cards, decision.TeamID, match.HomeTeamID)
+		case model.MatchEventTypePenalty, model.MatchEventTypePenaltyMissed:
+			referee.PenaltiesAwarded++
+			side(&referee.Penalties, decision.TeamID, match.HomeTeamID)
+		}
+	}
+
+	for _, referee := range stats {
+		referee.CardsPerGame = math.Round(float64(referee.YellowCards+referee.RedCards)/float64(referee.MatchesOfficiated)*100) / 100
+		referees = append(referees, *referee)
+	}
+
+	sort.SliceStable(referees, func(i, j int) bool {
+		if referees[i].Name != referees[j].Name {
+			return referees[i].Name < referees[j].Name
+		}
+		return referees[i].OfficialID.String() < referees[j].OfficialID.String()
+	})
+
+	return
+}
+
+// side is used for counting a decision towards the home or the away team.
+func side(split *transporter.Split, teamID, homeTeamID uuid.UUID) {
+	if uuid.Equal(teamID, homeTeamID) {
+		split.Home++
+		return
+	}
+	split.Away++
+}
+
+// rank is used for ordering the referees by a metric, the highest first, giving referees level on it the same rank.
+func rank(referees []transporter.RefereeStats, metric string) (rankings []transporter.Ranking) {
+	value := func(referee transporter.RefereeStats) float64 {
+		switch metric {
+		case model.RefereeMetricMatches:
+			return float64(referee.MatchesOfficiated)
+		case model.RefereeMetricPenalties:
+			return float64(referee.PenaltiesAwarded)
+		default:
+			return referee.CardsPerGame
+		}
+	}
+
+	sort.SliceStable(referees, func(i, j int) bool {
+		return value(referees[i]) > value(referees[j])
+	})
+
+	rankings = make([]transporter.Ranking, 0, len(referees))
+	for i, referee := range referees {
+		ranking := transporter.Ranking{Rank: i + 1, RefereeStats: referee}
+		if i > 0 && value(referee) == value(referees[i-1]) {
+			ranking.Rank = rankings[i-1].Rank
+		}
+		rankings = append(rankings, ranking)
+	}
+
+	return
+}
+
+// checkSeason is used for making sure a season exists.
+// It returns any errors written.
+func (official *Official) checkSeason(ctx context.Context, seasonID uuid.UUID) (err error) {
+	getSeasonResp, err := official.repo.GetSeason().GetSeason(ctx, seasonParam.GetSeason{ID: seasonID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getSeasonResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("season not found")}
+		return
+	}
+
+	return
+}
+
+// checkTeam is used for making sure the team an official is affiliated with exists.
+// Officials without an affiliation are not checked.
+// It returns any errors written.
+func (official *Official) checkTeam(ctx context.Context, teamID *uuid.UUID) (err error) {
+	if teamID == nil {
+		return
+	}
+
+	getTeamResp, err := official.repo.GetTeam().GetTeam(ctx, teamParam.GetTeam{ID: *teamID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getTeamResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("team not found")}
+		return
+	}
+
+	return
+}
+
+// bookingMinutes is used for getting how long an official stays booked either side of a kickoff.
+func (official *Official) bookingMinutes() int {
+	if official.config == nil || official.config.GetInt("officials.booking.minutes") <= 0 {
+		return bookingMinutes
+	}
+	return official.config.GetInt("officials.booking.minutes")
+}
@@ -0,0 +1,520 @@
+package official
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/config/configtest"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/handler/official/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/official/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	iCompetitionRepo "github.com/harunnryd/skeltun/internal/app/repo/competition"
+	iMatchRepo "github.com/harunnryd/skeltun/internal/app/repo/match"
+	iOfficialRepo "github.com/harunnryd/skeltun/internal/app/repo/official"
+	iSeasonRepo "github.com/harunnryd/skeltun/internal/app/repo/season"
+	iTeamRepo "github.com/harunnryd/skeltun/internal/app/repo/team"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	mock      sqlmock.Sqlmock
+	pgsqlConn *gorm.DB
+
+	iCompetitionRepo iCompetitionRepo.ICompetition
+	iMatchRepo       iMatchRepo.IMatch
+	iOfficialRepo    iOfficialRepo.IOfficial
+	iSeasonRepo      iSeasonRepo.ISeason
+	iTeamRepo        iTeamRepo.ITeam
+	iRepo            repo.IRepo
+	official         IOfficial
+	helper
+	response
+}
+
+type helper struct {
+	err error
+	db  *sql.DB
+}
+
+type response struct {
+	doCreateResp transporter.DoCreate
+	doDeleteResp transporter.DoDelete
+	doAssignResp transporter.DoAssign
+
+	getStatsResp    transporter.GetStats
+	getRankingsResp transporter.GetRankings
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.iCompetitionRepo = iCompetitionRepo.New(
+		iCompetitionRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iMatchRepo = iMatchRepo.New(
+		iMatchRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iOfficialRepo = iOfficialRepo.New(
+		iOfficialRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iSeasonRepo = iSeasonRepo.New(
+		iSeasonRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iTeamRepo = iTeamRepo.New(
+		iTeamRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iRepo = repo.New()
+	suite.iRepo.SetCompetition(suite.iCompetitionRepo)
+	suite.iRepo.SetMatch(suite.iMatchRepo)
+	suite.iRepo.SetOfficial(suite.iOfficialRepo)
+	suite.iRepo.SetSeason(suite.iSeasonRepo)
+	suite.iRepo.SetTeam(suite.iTeamRepo)
+
+	suite.official = New(WithConfig(configtest.Fake{
+		"officials.booking.minutes": 180,
+	}), WithRepo(suite.iRepo))
+}
+
+// assignParams is used for building a referee and a VAR for a match.
+func assignParams() param.DoAssign {
+	return param.DoAssign{
+		MatchID: uuid.NewV4(),
+		Officials: []param.Assignment{
+			{OfficialID: uuid.NewV4(), Role: model.OfficialRoleReferee},
+			{OfficialID: uuid.NewV4(), Role: model.OfficialRoleVAR},
+		},
+	}
+}
+
+// expectMatch is used for serving a scheduled match between the given teams.
+func (suite *Suite) expectMatch(matchID, homeTeamID, awayTeamID uuid.UUID, kickoffAt time.Time) {
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE id = $1 LIMIT 1`)).
+		WithArgs(matchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "home_team_id", "away_team_id", "kickoff_at", "status"}).
+			AddRow(matchID, homeTeamID, awayTeamID, kickoffAt, model.MatchStatusScheduled))
+}
+
+// TestDoAssign ...
+func (suite *Suite) TestDoAssign() {
+	kickoffAt := time.Date(2021, time.August, 14, 15, 0, 0, 0, time.UTC)
+	params := assignParams()
+
+	suite.expectMatch(params.MatchID, uuid.NewV4(), uuid.NewV4(), kickoffAt)
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "officials" WHERE id IN ($1,$2) AND deleted_at IS NULL`)).
+		WithArgs(params.Officials[0].OfficialID, params.Officials[1].OfficialID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role", "affiliated_team_id"}).
+			AddRow(params.Officials[0].OfficialID, "Michael Oliver", model.OfficialRoleReferee, nil).
+			AddRow(params.Officials[1].OfficialID, "Stuart Attwell", model.OfficialRoleVAR, uuid.NewV4()))
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "officials" WHERE id IN ($1,$2) ORDER BY id FOR UPDATE`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`FROM "match_officials" JOIN matches ON matches.id = match_officials.match_id`)).
+		WithArgs(
+			params.Officials[0].OfficialID, params.Officials[1].OfficialID, params.MatchID,
+			kickoffAt.Add(-3*time.Hour), kickoffAt.Add(3*time.Hour),
+			model.MatchStatusPostponed, model.MatchStatusCancelled,
+		).
+		WillReturnRows(sqlmock.NewRows([]string{"official_id", "match_id", "kickoff_at"}))
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`DELETE FROM "match_officials" WHERE match_id = $1`)).
+		WithArgs(params.MatchID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`INSERT INTO "match_officials"`)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	suite.mock.ExpectCommit()
+
+	suite.response.doAssignResp, suite.helper.err = suite.official.DoAssign(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Len(suite.T(), suite.response.doAssignResp.Officials, 2)
+	require.Equal(suite.T(), "Michael Oliver", suite.response.doAssignResp.Officials[0].Name)
+}
+
+// TestDoAssignAffiliated ...
+func (suite *Suite) TestDoAssignAffiliated() {
+	homeTeamID := uuid.NewV4()
+	params := assignParams()
+
+	suite.expectMatch(params.MatchID, homeTeamID, uuid.NewV4(), time.Date(2021, time.August, 14, 15, 0, 0, 0, time.UTC))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "officials" WHERE id IN ($1,$2) AND deleted_at IS NULL`)).
+		WithArgs(params.Officials[0].OfficialID, params.Officials[1].OfficialID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role", "affiliated_team_id"}).
+			AddRow(params.Officials[0].OfficialID, "Michael Oliver", model.OfficialRoleReferee, nil).
+			AddRow(params.Officials[1].OfficialID, "Stuart Attwell", model.OfficialRoleVAR, homeTeamID))
+
+	suite.response.doAssignResp, suite.helper.err = suite.official.DoAssign(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "Stuart Attwell is affiliated with a team playing the match")
+}
+
+// TestDoAssignBooked ...
+func (suite *Suite) TestDoAssignBooked() {
+	kickoffAt := time.Date(2021, time.August, 14, 15, 0, 0, 0, time.UTC)
+	params := assignParams()
+
+	suite.expectMatch(params.MatchID, uuid.NewV4(), uuid.NewV4(), kickoffAt)
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "officials" WHERE id IN ($1,$2) AND deleted_at IS NULL`)).
+		WithArgs(params.Officials[0].OfficialID, params.Officials[1].OfficialID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role", "affiliated_team_id"}).
+			AddRow(params.Officials[0].OfficialID, "Michael Oliver", model.OfficialRoleReferee, nil).
+			AddRow(params.Officials[1].OfficialID, "Stuart Attwell", model.OfficialRoleVAR, nil))
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "officials" WHERE id IN ($1,$2) ORDER BY id FOR UPDATE`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`FROM "match_officials" JOIN matches ON matches.id = match_officials.match_id`)).
+		WillReturnRows(sqlmock.NewRows([]string{"official_id", "match_id", "kickoff_at"}).
+			AddRow(params.Officials[0].OfficialID, uuid.NewV4(), kickoffAt.Add(150*time.Minute)))
+
+	suite.mock.ExpectRollback()
+
+	suite.response.doAssignResp, suite.helper.err = suite.official.DoAssign(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "Michael Oliver is already booked for a match kicking off at 2021-08-14T17:30:00Z")
+	require.Empty(suite.T(), suite.response.doAssignResp.Officials)
+}
+
+// TestDoAssignWrongRole ...
+func (suite *Suite) TestDoAssignWrongRole() {
+	params := assignParams()
+
+	suite.expectMatch(params.MatchID, uuid.NewV4(), uuid.NewV4(), time.Date(2021, time.August, 14, 15, 0, 0, 0, time.UTC))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "officials" WHERE id IN ($1,$2) AND deleted_at IS NULL`)).
+		WithArgs(params.Officials[0].OfficialID, params.Officials[1].OfficialID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role", "affiliated_team_id"}).
+			AddRow(params.Officials[0].OfficialID, "Simon Bennett", model.OfficialRoleAssistant, nil).
+			AddRow(params.Officials[1].OfficialID, "Stuart Attwell", model.OfficialRoleVAR, nil))
+
+	suite.response.doAssignResp, suite.helper.err = suite.official.DoAssign(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "Simon Bennett is registered as assistant and cannot be assigned as referee")
+}
+
+// TestDoAssignOfficialNotFound ...
+func (suite *Suite) TestDoAssignOfficialNotFound() {
+	params := assignParams()
+
+	suite.expectMatch(params.MatchID, uuid.NewV4(), uuid.NewV4(), time.Date(2021, time.August, 14, 15, 0, 0, 0, time.UTC))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "officials" WHERE id IN ($1,$2) AND deleted_at IS NULL`)).
+		WithArgs(params.Officials[0].OfficialID, params.Officials[1].OfficialID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role", "affiliated_team_id"}).
+			AddRow(params.Officials[0].OfficialID, "Michael Oliver", model.OfficialRoleReferee, nil))
+
+	suite.response.doAssignResp, suite.helper.err = suite.official.DoAssign(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "official "+params.Officials[1].OfficialID.String()+" not found")
+}
+
+// TestDoDelete ...
+func (suite *Suite) TestDoDelete() {
+	params := param.DoDelete{ID: uuid.NewV4()}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "officials" WHERE id = $1 AND deleted_at IS NULL LIMIT 1`)).
+		WithArgs(params.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}).
+			AddRow(params.ID, "Michael Oliver", model.OfficialRoleReferee))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) AS total FROM "match_officials" WHERE official_id = $1`)).
+		WithArgs(params.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"total"}).
+			AddRow(0))
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`DELETE FROM "officials" WHERE id = $1`)).
+		WithArgs(params.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	suite.response.doDeleteResp, suite.helper.err = suite.official.DoDelete(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+}
+
+// TestDoDeleteNotFound ...
+func (suite *Suite) TestDoDeleteNotFound() {
+	params := param.DoDelete{ID: uuid.NewV4()}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "officials" WHERE id = $1 AND deleted_at IS NULL LIMIT 1`)).
+		WithArgs(params.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.response.doDeleteResp, suite.helper.err = suite.official.DoDelete(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "official not found")
+}
+
+// TestDoDeleteAssigned ...
+func (suite *Suite) TestDoDeleteAssigned() {
+	params := param.DoDelete{ID: uuid.NewV4()}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "officials" WHERE id = $1 AND deleted_at IS NULL LIMIT 1`)).
+		WithArgs(params.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}).
+			AddRow(params.ID, "Michael Oliver", model.OfficialRoleReferee))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) AS total FROM "match_officials" WHERE official_id = $1`)).
+		WithArgs(params.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"total"}).
+			AddRow(2))
+
+	suite.response.doDeleteResp, suite.helper.err = suite.official.DoDelete(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "official is assigned to matches and cannot be deleted")
+}
+
+// TestDoCreateTeamNotFound ...
+func (suite *Suite) TestDoCreateTeamNotFound() {
+	teamID := uuid.NewV4()
+	params := param.DoCreate{
+		Official: param.Official{
+			Name:             "Michael Oliver",
+			Role:             model.OfficialRoleReferee,
+			AffiliatedTeamID: &teamID,
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "teams" WHERE id = $1 LIMIT 1`)).
+		WithArgs(teamID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.response.doCreateResp, suite.helper.err = suite.official.DoCreate(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "team not found")
+}
+
+// TestGetStats ...
+func (suite *Suite) TestGetStats() {
+	seasonID, homeTeamID, awayTeamID := uuid.NewV4(), uuid.NewV4(), uuid.NewV4()
+	matchIDs := []uuid.UUID{uuid.NewV4(), uuid.NewV4(), uuid.NewV4()}
+	params := param.GetStats{
+		OfficialID: uuid.NewV4(),
+		SeasonID:   seasonID.String(),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "officials" WHERE id = $1 AND deleted_at IS NULL LIMIT 1`)).
+		WithArgs(params.OfficialID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}).
+			AddRow(params.OfficialID, "Michael Oliver", model.OfficialRoleReferee))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seasons" WHERE id = $1 LIMIT 1`)).
+		WithArgs(seasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(seasonID, "2021/22"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`FROM "match_officials" JOIN officials ON officials.id = match_officials.official_id JOIN matches ON matches.id = match_officials.match_id`)).
+		WithArgs(model.OfficialRoleReferee, model.MatchStatusFinished, params.OfficialID, seasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"official_id", "name", "match_id", "home_team_id", "away_team_id"}).
+			AddRow(params.OfficialID, "Michael Oliver", matchIDs[0], homeTeamID, awayTeamID).
+			AddRow(params.OfficialID, "Michael Oliver", matchIDs[1], awayTeamID, homeTeamID).
+			AddRow(params.OfficialID, "Michael Oliver", matchIDs[2], homeTeamID, uuid.NewV4()))
+
+	// Two cards and two penalties, one of them missed, for the home side, three cards and a penalty for the away side
+	// over three matches.
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "match_events" WHERE match_id IN ($1,$2,$3) AND type IN ($4,$5,$6,$7,$8) AND deleted_at IS NULL`)).
+		WillReturnRows(sqlmock.NewRows([]string{"match_id", "team_id", "type"}).
+			AddRow(matchIDs[0], homeTeamID, model.MatchEventTypeYellowCard).
+			AddRow(matchIDs[0], awayTeamID, model.MatchEventTypeYellowCard).
+			AddRow(matchIDs[0], awayTeamID, model.MatchEventTypeSecondYellow).
+			AddRow(matchIDs[0], homeTeamID, model.MatchEventTypePenalty).
+			AddRow(matchIDs[1], homeTeamID, model.MatchEventTypeRedCard).
+			AddRow(matchIDs[1], homeTeamID, model.MatchEventTypePenalty).
+			AddRow(matchIDs[2], homeTeamID, model.MatchEventTypeYellowCard).
+			AddRow(matchIDs[2], homeTeamID, model.MatchEventTypePenaltyMissed))
+
+	suite.response.getStatsResp, suite.helper.err = suite.official.GetStats(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), 3, suite.response.getStatsResp.MatchesOfficiated)
+	require.Equal(suite.T(), 3, suite.response.getStatsResp.YellowCards)
+	require.Equal(suite.T(), 2, suite.response.getStatsResp.RedCards)
+	require.Equal(suite.T(), 1.67, suite.response.getStatsResp.CardsPerGame)
+	require.Equal(suite.T(), 3, suite.response.getStatsResp.PenaltiesAwarded)
+	require.Equal(suite.T(), transporter.Split{Home: 2, Away: 3}, suite.response.getStatsResp.Cards)
+	require.Equal(suite.T(), transporter.Split{Home: 2, Away: 1}, suite.response.getStatsResp.Penalties)
+	require.Equal(suite.T(), &seasonID, suite.response.getStatsResp.SeasonID)
+}
+
+// TestGetStatsWithoutMatches ...
+func (suite *Suite) TestGetStatsWithoutMatches() {
+	params := param.GetStats{
+		OfficialID: uuid.NewV4(),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "officials" WHERE id = $1 AND deleted_at IS NULL LIMIT 1`)).
+		WithArgs(params.OfficialID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}).
+			AddRow(params.OfficialID, "Michael Oliver", model.OfficialRoleReferee))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`FROM "match_officials" JOIN officials ON officials.id = match_officials.official_id JOIN matches ON matches.id = match_officials.match_id`)).
+		WithArgs(model.OfficialRoleReferee, model.MatchStatusFinished, params.OfficialID).
+		WillReturnRows(sqlmock.NewRows([]string{"official_id", "name", "match_id", "home_team_id", "away_team_id"}))
+
+	suite.response.getStatsResp, suite.helper.err = suite.official.GetStats(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), "Michael Oliver", suite.response.getStatsResp.Name)
+	require.Zero(suite.T(), suite.response.getStatsResp.MatchesOfficiated)
+	require.Nil(suite.T(), suite.response.getStatsResp.SeasonID)
+}
+
+// TestGetRankings ...
+func (suite *Suite) TestGetRankings() {
+	homeTeamID, awayTeamID := uuid.NewV4(), uuid.NewV4()
+	oliverID, taylorID, atwellID := uuid.NewV4(), uuid.NewV4(), uuid.NewV4()
+	matchIDs := []uuid.UUID{uuid.NewV4(), uuid.NewV4(), uuid.NewV4(), uuid.NewV4()}
+	params := param.GetRankings{
+		CompetitionID: uuid.NewV4(),
+		Metric:        model.RefereeMetricCardsPerGame,
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "competitions" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.CompetitionID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(params.CompetitionID, "Premier League"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seasons" WHERE "seasons"."competition_id" = $1`)).
+		WithArgs(params.CompetitionID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "competition_id"}))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`JOIN seasons ON seasons.id = matches.season_id`)).
+		WithArgs(model.OfficialRoleReferee, model.MatchStatusFinished, params.CompetitionID).
+		WillReturnRows(sqlmock.NewRows([]string{"official_id", "name", "match_id", "home_team_id", "away_team_id"}).
+			AddRow(oliverID, "Michael Oliver", matchIDs[0], homeTeamID, awayTeamID).
+			AddRow(taylorID, "Anthony Taylor", matchIDs[1], homeTeamID, awayTeamID).
+			AddRow(atwellID, "Stuart Attwell", matchIDs[2], homeTeamID, awayTeamID).
+			AddRow(oliverID, "Michael Oliver", matchIDs[3], awayTeamID, homeTeamID))
+
+	// Taylor and Oliver both show two cards a game, Attwell shows none.
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "match_events" WHERE match_id IN (`)).
+		WillReturnRows(sqlmock.NewRows([]string{"match_id", "team_id", "type"}).
+			AddRow(matchIDs[0], homeTeamID, model.MatchEventTypeYellowCard).
+			AddRow(matchIDs[0], awayTeamID, model.MatchEventTypeYellowCard).
+			AddRow(matchIDs[1], homeTeamID, model.MatchEventTypeYellowCard).
+			AddRow(matchIDs[1], awayTeamID, model.MatchEventTypeRedCard).
+			AddRow(matchIDs[3], homeTeamID, model.MatchEventTypeYellowCard).
+			AddRow(matchIDs[3], homeTeamID, model.MatchEventTypeSecondYellow))
+
+	suite.response.getRankingsResp, suite.helper.err = suite.official.GetRankings(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Len(suite.T(), suite.response.getRankingsResp.Referees, 3)
+
+	referees := suite.response.getRankingsResp.Referees
+	require.Equal(suite.T(), "Anthony Taylor", referees[0].Name)
+	require.Equal(suite.T(), 1, referees[0].Rank)
+	require.Equal(suite.T(), "Michael Oliver", referees[1].Name)
+	require.Equal(suite.T(), 1, referees[1].Rank)
+	require.Equal(suite.T(), transporter.Split{Home: 1, Away: 3}, referees[1].Cards)
+	require.Equal(suite.T(), "Stuart Attwell", referees[2].Name)
+	require.Equal(suite.T(), 3, referees[2].Rank)
+}
+
+// TestGetRankingsSeasonOfAnotherCompetition ...
+func (suite *Suite) TestGetRankingsSeasonOfAnotherCompetition() {
+	params := param.GetRankings{
+		CompetitionID: uuid.NewV4(),
+		SeasonID:      uuid.NewV4().String(),
+		Metric:        model.RefereeMetricMatches,
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "competitions" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.CompetitionID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(params.CompetitionID, "Premier League"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seasons" WHERE "seasons"."competition_id" = $1`)).
+		WithArgs(params.CompetitionID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "competition_id"}).
+			AddRow(uuid.NewV4(), params.CompetitionID))
+
+	suite.response.getRankingsResp, suite.helper.err = suite.official.GetRankings(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "season does not belong to the competition")
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
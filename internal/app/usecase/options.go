@@ -7,9 +7,32 @@ package usecase
 import (
 	"github.com/harunnryd/skeltun/config"
 	"github.com/harunnryd/skeltun/internal/app/repo"
+	"github.com/harunnryd/skeltun/internal/app/usecase/availability"
+	"github.com/harunnryd/skeltun/internal/app/usecase/bracket"
+	"github.com/harunnryd/skeltun/internal/app/usecase/competition"
+	"github.com/harunnryd/skeltun/internal/app/usecase/contract"
+	"github.com/harunnryd/skeltun/internal/app/usecase/draw"
+	"github.com/harunnryd/skeltun/internal/app/usecase/fixture"
+	"github.com/harunnryd/skeltun/internal/app/usecase/group"
 	"github.com/harunnryd/skeltun/internal/app/usecase/hcheck"
+	"github.com/harunnryd/skeltun/internal/app/usecase/injury"
+	"github.com/harunnryd/skeltun/internal/app/usecase/leaderboard"
+	"github.com/harunnryd/skeltun/internal/app/usecase/lineup"
+	"github.com/harunnryd/skeltun/internal/app/usecase/loan"
+	"github.com/harunnryd/skeltun/internal/app/usecase/match"
+	"github.com/harunnryd/skeltun/internal/app/usecase/matchevent"
+	"github.com/harunnryd/skeltun/internal/app/usecase/official"
 	"github.com/harunnryd/skeltun/internal/app/usecase/player"
+	"github.com/harunnryd/skeltun/internal/app/usecase/season"
+	"github.com/harunnryd/skeltun/internal/app/usecase/shootoutkick"
+	"github.com/harunnryd/skeltun/internal/app/usecase/squad"
+	"github.com/harunnryd/skeltun/internal/app/usecase/standing"
+	"github.com/harunnryd/skeltun/internal/app/usecase/stat"
+	"github.com/harunnryd/skeltun/internal/app/usecase/suspension"
 	"github.com/harunnryd/skeltun/internal/app/usecase/team"
+	"github.com/harunnryd/skeltun/internal/app/usecase/transfer"
+	"github.com/harunnryd/skeltun/internal/app/usecase/travel"
+	"github.com/harunnryd/skeltun/internal/app/usecase/venue"
 	"github.com/harunnryd/skeltun/internal/pkg"
 	"github.com/harunnryd/skeltun/job"
 
@@ -47,10 +70,24 @@ func WithDependency(config config.IConfig) Option {
 			hcheck.WithJob(iJob),
 		)
 
+		usecase.contract = contract.New(
+			contract.WithConfig(config),
+			contract.WithRepo(iRepo),
+			contract.WithPkg(iPkg),
+		)
+
+		usecase.squad = squad.New(
+			squad.WithConfig(config),
+			squad.WithRepo(iRepo),
+			squad.WithPkg(iPkg),
+		)
+
 		usecase.player = player.New(
 			player.WithConfig(config),
 			player.WithRepo(iRepo),
 			player.WithPkg(iPkg),
+			player.WithContract(usecase.contract),
+			player.WithSquad(usecase.squad),
 		)
 
 		usecase.team = team.New(
@@ -58,5 +95,145 @@ func WithDependency(config config.IConfig) Option {
 			team.WithRepo(iRepo),
 			team.WithPkg(iPkg),
 		)
+
+		usecase.match = match.New(
+			match.WithConfig(config),
+			match.WithRepo(iRepo),
+			match.WithPkg(iPkg),
+			match.WithJob(iJob),
+		)
+
+		usecase.competition = competition.New(
+			competition.WithConfig(config),
+			competition.WithRepo(iRepo),
+			competition.WithPkg(iPkg),
+		)
+
+		usecase.season = season.New(
+			season.WithConfig(config),
+			season.WithRepo(iRepo),
+			season.WithPkg(iPkg),
+			season.WithContract(usecase.contract),
+		)
+
+		usecase.standing = standing.New(
+			standing.WithConfig(config),
+			standing.WithRepo(iRepo),
+			standing.WithPkg(iPkg),
+		)
+
+		usecase.fixture = fixture.New(
+			fixture.WithConfig(config),
+			fixture.WithRepo(iRepo),
+			fixture.WithPkg(iPkg),
+		)
+
+		usecase.injury = injury.New(
+			injury.WithConfig(config),
+			injury.WithRepo(iRepo),
+			injury.WithPkg(iPkg),
+		)
+
+		usecase.suspension = suspension.New(
+			suspension.WithConfig(config),
+			suspension.WithRepo(iRepo),
+			suspension.WithPkg(iPkg),
+		)
+
+		usecase.availability = availability.New(
+			availability.WithConfig(config),
+			availability.WithRepo(iRepo),
+			availability.WithPkg(iPkg),
+		)
+
+		usecase.lineup = lineup.New(
+			lineup.WithConfig(config),
+			lineup.WithRepo(iRepo),
+			lineup.WithPkg(iPkg),
+			lineup.WithAvailability(usecase.availability),
+		)
+
+		usecase.leaderboard = leaderboard.New(
+			leaderboard.WithConfig(config),
+			leaderboard.WithRepo(iRepo),
+			leaderboard.WithPkg(iPkg),
+			leaderboard.WithRedis(redisPool),
+		)
+
+		usecase.matchevent = matchevent.New(
+			matchevent.WithConfig(config),
+			matchevent.WithRepo(iRepo),
+			matchevent.WithPkg(iPkg),
+			matchevent.WithLineup(usecase.lineup),
+			matchevent.WithSuspension(usecase.suspension),
+			matchevent.WithJob(iJob),
+		)
+
+		usecase.shootoutkick = shootoutkick.New(
+			shootoutkick.WithConfig(config),
+			shootoutkick.WithRepo(iRepo),
+			shootoutkick.WithPkg(iPkg),
+		)
+
+		usecase.group = group.New(
+			group.WithConfig(config),
+			group.WithRepo(iRepo),
+			group.WithPkg(iPkg),
+			group.WithStanding(usecase.standing),
+		)
+
+		usecase.bracket = bracket.New(
+			bracket.WithConfig(config),
+			bracket.WithRepo(iRepo),
+			bracket.WithPkg(iPkg),
+			bracket.WithGroup(usecase.group),
+			bracket.WithMatch(usecase.match),
+		)
+
+		usecase.draw = draw.New(
+			draw.WithConfig(config),
+			draw.WithRepo(iRepo),
+			draw.WithPkg(iPkg),
+		)
+
+		usecase.transfer = transfer.New(
+			transfer.WithConfig(config),
+			transfer.WithRepo(iRepo),
+			transfer.WithPkg(iPkg),
+			transfer.WithContract(usecase.contract),
+			transfer.WithSquad(usecase.squad),
+		)
+
+		usecase.loan = loan.New(
+			loan.WithConfig(config),
+			loan.WithRepo(iRepo),
+			loan.WithPkg(iPkg),
+			loan.WithContract(usecase.contract),
+		)
+
+		usecase.stat = stat.New(
+			stat.WithConfig(config),
+			stat.WithRepo(iRepo),
+			stat.WithPkg(iPkg),
+			stat.WithLeaderboard(usecase.leaderboard),
+		)
+
+		usecase.venue = venue.New(
+			venue.WithConfig(config),
+			venue.WithRepo(iRepo),
+			venue.WithPkg(iPkg),
+		)
+
+		usecase.travel = travel.New(
+			travel.WithConfig(config),
+			travel.WithRepo(iRepo),
+			travel.WithPkg(iPkg),
+		)
+
+		usecase.official = official.New(
+			official.WithConfig(config),
+			official.WithRepo(iRepo),
+			official.WithPkg(iPkg),
+		)
 	}
 }
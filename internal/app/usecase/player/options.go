@@ -7,6 +7,8 @@ package player
 import (
 	"github.com/harunnryd/skeltun/config"
 	"github.com/harunnryd/skeltun/internal/app/repo"
+	"github.com/harunnryd/skeltun/internal/app/usecase/contract"
+	"github.com/harunnryd/skeltun/internal/app/usecase/squad"
 	"github.com/harunnryd/skeltun/internal/pkg"
 )
 
@@ -33,3 +35,17 @@ func WithPkg(pkg pkg.IPkg) Option {
 		player.pkg = pkg
 	}
 }
+
+// WithContract ...
+func WithContract(contract contract.IContract) Option {
+	return func(player *Player) {
+		player.contract = contract
+	}
+}
+
+// WithSquad ...
+func WithSquad(squad squad.ISquad) Option {
+	return func(player *Player) {
+		player.squad = squad
+	}
+}
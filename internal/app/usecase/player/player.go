@@ -6,12 +6,21 @@ package player
 
 import (
 	"context"
+	"errors"
+	"strconv"
+	"time"
 
 	"github.com/harunnryd/skeltun/config"
+	contractParam "github.com/harunnryd/skeltun/internal/app/handler/contract/param"
 	"github.com/harunnryd/skeltun/internal/app/handler/player/param"
 	"github.com/harunnryd/skeltun/internal/app/handler/player/transporter"
+	squadParam "github.com/harunnryd/skeltun/internal/app/handler/squad/param"
 	"github.com/harunnryd/skeltun/internal/app/repo"
+	"github.com/harunnryd/skeltun/internal/app/usecase/contract"
+	"github.com/harunnryd/skeltun/internal/app/usecase/squad"
 	"github.com/harunnryd/skeltun/internal/pkg"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
 )
 
 // IPlayer is an interface that stores the methods that Player struct will use.
@@ -39,9 +48,11 @@ type IPlayer interface {
 
 // Player is an struct that implements IPlayer methods.
 type Player struct {
-	config config.IConfig
-	repo   repo.IRepo
-	pkg    pkg.IPkg
+	config   config.IConfig
+	repo     repo.IRepo
+	pkg      pkg.IPkg
+	contract contract.IContract
+	squad    squad.ISquad
 }
 
 // New it returns instance of Player that implements IPlayer methods.
@@ -53,9 +64,41 @@ func New(opts ...Option) IPlayer {
 	return p
 }
 
-// DoCreate is used for record new player.
+// DoCreate is used for record new player along with the contract signed with the team, running from today.
+// A player without a contract is rejected, and so are a shirt number already worn by a teammate,
+// a player breaking the squad rules and a wage pushing the team over a salary cap.
 // It returns doCreateResp of transporter.DoCreate and any errors written.
 func (player *Player) DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error) {
+	if params.Contract == nil {
+		err = &iPkgError.ValidationError{Err: errors.New("player cannot join the team without a contract")}
+		return
+	}
+
+	if err = player.checkShirtNumber(ctx, params.Player); err != nil {
+		return
+	}
+
+	params.Date = time.Now()
+
+	if err = player.squad.CheckSquad(ctx, squadParam.CheckSquad{
+		TeamID: params.TeamID,
+		Date:   params.Date,
+		In: &squadParam.Member{
+			Nationality: params.Nationality,
+			Homegrown:   params.Homegrown,
+		},
+	}); err != nil {
+		return
+	}
+
+	if err = player.contract.CheckSalaryCap(ctx, contractParam.CheckSalaryCap{
+		TeamID: params.TeamID,
+		Date:   params.Date,
+		Wage:   params.Contract.Wage,
+	}); err != nil {
+		return
+	}
+
 	doCreateResp, err = player.repo.GetPlayer().DoCreate(ctx, params)
 	if err != nil {
 		return
@@ -76,8 +119,48 @@ func (player *Player) GetPlayers(ctx context.Context, params param.GetPlayers) (
 }
 
 // DoUpdate is used for update the record player.
+// A shirt number already worn by a teammate is rejected, and so is a new team,
+// players only move between teams with a transfer. A new nationality or homegrown
+// status has to keep the squad within the squad rules.
 // It returns doUpdateResp of transporter.DoUpdate and any errors written.
 func (player *Player) DoUpdate(ctx context.Context, params param.DoUpdate) (doUpdateResp transporter.DoUpdate, err error) {
+	getPlayerResp, err := player.repo.GetPlayer().GetPlayer(ctx, param.GetPlayer{ID: params.ID})
+	if err != nil {
+		return
+	}
+
+	if !uuid.Equal(getPlayerResp.ID, uuid.Nil) && !uuid.Equal(getPlayerResp.TeamID, params.TeamID) {
+		err = &iPkgError.ValidationError{Err: errors.New("player can only move to another team with a transfer")}
+		return
+	}
+
+	member := squadParam.Member{
+		ID:          params.ID,
+		Nationality: getPlayerResp.Nationality,
+		Homegrown:   getPlayerResp.Homegrown,
+	}
+
+	if params.Nationality != "" {
+		member.Nationality = params.Nationality
+	}
+
+	if params.Homegrown != nil {
+		member.Homegrown = params.Homegrown
+	}
+
+	if err = player.squad.CheckSquad(ctx, squadParam.CheckSquad{
+		TeamID: params.TeamID,
+		Date:   time.Now(),
+		In:     &member,
+		Out:    &squadParam.Member{ID: params.ID},
+	}); err != nil {
+		return
+	}
+
+	if err = player.checkShirtNumber(ctx, params.Player); err != nil {
+		return
+	}
+
 	doUpdateResp, err = player.repo.GetPlayer().DoUpdate(ctx, params)
 	if err != nil {
 		return
@@ -107,3 +190,26 @@ func (player *Player) GetPlayer(ctx context.Context, params param.GetPlayer) (ge
 
 	return
 }
+
+// checkShirtNumber is used for making sure no other player of the team wears the shirt number of the given player.
+// It returns any errors written.
+func (player *Player) checkShirtNumber(ctx context.Context, params param.Player) (err error) {
+	if params.ShirtNumber == nil {
+		return
+	}
+
+	getShirtNumberResp, err := player.repo.GetPlayer().GetShirtNumber(ctx, param.GetShirtNumber{
+		TeamID:      params.TeamID,
+		ShirtNumber: *params.ShirtNumber,
+	})
+	if err != nil {
+		return
+	}
+
+	if !uuid.Equal(getShirtNumberResp.ID, uuid.Nil) && !uuid.Equal(getShirtNumberResp.ID, params.ID) {
+		err = &iPkgError.ValidationError{Err: errors.New("shirt number " + strconv.Itoa(*params.ShirtNumber) + " is already taken in the team")}
+		return
+	}
+
+	return
+}
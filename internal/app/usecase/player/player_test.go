@@ -5,13 +5,20 @@ import (
 	"database/sql"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	contractParam "github.com/harunnryd/skeltun/internal/app/handler/contract/param"
 	"github.com/harunnryd/skeltun/internal/app/handler/player/param"
 	"github.com/harunnryd/skeltun/internal/app/handler/player/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
 	"github.com/harunnryd/skeltun/internal/app/repo"
+	iContractRepo "github.com/harunnryd/skeltun/internal/app/repo/contract"
 	iPlayerRepo "github.com/harunnryd/skeltun/internal/app/repo/player"
+	iSquadRepo "github.com/harunnryd/skeltun/internal/app/repo/squad"
+	"github.com/harunnryd/skeltun/internal/app/usecase/contract"
+	"github.com/harunnryd/skeltun/internal/app/usecase/squad"
 	"github.com/satori/uuid"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -25,9 +32,11 @@ type Suite struct {
 	mock      sqlmock.Sqlmock
 	pgsqlConn *gorm.DB
 
-	iPlayerRepo iPlayerRepo.IPlayer
-	iRepo       repo.IRepo
-	player      IPlayer
+	iPlayerRepo   iPlayerRepo.IPlayer
+	iContractRepo iContractRepo.IContract
+	iSquadRepo    iSquadRepo.ISquad
+	iRepo         repo.IRepo
+	player        IPlayer
 	helper
 	response
 }
@@ -69,32 +78,159 @@ func (suite *Suite) SetupSuite() {
 		iPlayerRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
 	)
 
+	suite.iContractRepo = iContractRepo.New(
+		iContractRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iSquadRepo = iSquadRepo.New(
+		iSquadRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
 	suite.iRepo = repo.New()
 	suite.iRepo.SetPlayer(suite.iPlayerRepo)
+	suite.iRepo.SetContract(suite.iContractRepo)
+	suite.iRepo.SetSquad(suite.iSquadRepo)
 
-	suite.player = New(WithRepo(suite.iRepo))
+	suite.player = New(
+		WithRepo(suite.iRepo),
+		WithContract(contract.New(contract.WithRepo(suite.iRepo))),
+		WithSquad(squad.New(squad.WithRepo(suite.iRepo))),
+	)
 }
 
 // TestDoCreate ...
 func (suite *Suite) TestDoCreate() {
+	shirtNumber, dateOfBirth := 9, time.Date(1995, 3, 14, 0, 0, 0, 0, time.UTC)
 	params := param.DoCreate{
 		Player: param.Player{
-			TeamID: uuid.NewV4(),
-			Name:   "John Doe",
+			TeamID:        uuid.NewV4(),
+			Name:          "John Doe",
+			Position:      model.PlayerPositionForward,
+			Role:          "ST",
+			ShirtNumber:   &shirtNumber,
+			DateOfBirth:   &dateOfBirth,
+			Nationality:   "ENG",
+			Height:        183,
+			Weight:        78,
+			PreferredFoot: model.PreferredFootRight,
+		},
+		Contract: &contractParam.Terms{
+			EndsAt: time.Now().AddDate(3, 0, 0),
+			Wage:   1200000,
 		},
 	}
 
-	suite.mock.MatchExpectationsInOrder(false)
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE team_id = $1 AND shirt_number = $2 AND deleted_at IS NULL LIMIT 1`)).
+		WithArgs(params.TeamID, shirtNumber).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`FROM "squad_rules"`)).
+		WithArgs(params.TeamID, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"season_id"}))
 
 	suite.mock.
-		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "players" ("created_at","updated_at","deleted_at","team_id","name") VALUES ($1,$2,$3,$4,$5) RETURNING "id"`)).
-		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.TeamID, params.Name).
+		ExpectQuery(regexp.QuoteMeta(`SELECT DISTINCT competitions.id AS competition_id,competitions.name AS competition_name,competitions.salary_cap FROM "competitions"`)).
+		WithArgs(uuid.Nil, sqlmock.AnyArg(), params.TeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"competition_id", "competition_name", "salary_cap"}))
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "players" ("created_at","updated_at","deleted_at","team_id","name","position","role","shirt_number","date_of_birth","nationality","height","weight","preferred_foot","homegrown") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14) RETURNING "id"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.TeamID, params.Name, params.Position, params.Role, shirtNumber, dateOfBirth, params.Nationality, params.Height, params.Weight, params.PreferredFoot, nil).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).
 			AddRow(uuid.NewV4()))
 
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "contracts"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.mock.ExpectCommit()
+
 	suite.response.doCreateResp, suite.helper.err = suite.player.DoCreate(context.Background(), params)
 
 	require.NoError(suite.T(), suite.helper.err)
+	require.NotNil(suite.T(), suite.response.doCreateResp.Contract)
+}
+
+// TestDoCreateSalaryCapExceeded ...
+func (suite *Suite) TestDoCreateSalaryCapExceeded() {
+	params := param.DoCreate{
+		Player: param.Player{
+			TeamID:   uuid.NewV4(),
+			Name:     "John Doe",
+			Position: model.PlayerPositionForward,
+		},
+		Contract: &contractParam.Terms{
+			EndsAt: time.Now().AddDate(3, 0, 0),
+			Wage:   3000000,
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`FROM "squad_rules"`)).
+		WithArgs(params.TeamID, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"season_id"}))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT DISTINCT competitions.id AS competition_id,competitions.name AS competition_name,competitions.salary_cap FROM "competitions"`)).
+		WithArgs(uuid.Nil, sqlmock.AnyArg(), params.TeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"competition_id", "competition_name", "salary_cap"}).
+			AddRow(uuid.NewV4(), "Premier League", 10000000))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(wage), 0) AS total FROM "contracts"`)).
+		WithArgs(params.TeamID, model.ContractStatusActive, sqlmock.AnyArg(), sqlmock.AnyArg(), uuid.Nil).
+		WillReturnRows(sqlmock.NewRows([]string{"total"}).
+			AddRow(8000000))
+
+	suite.response.doCreateResp, suite.helper.err = suite.player.DoCreate(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "team wages of 11000000 would exceed the salary cap of 10000000 in Premier League")
+}
+
+// TestDoCreateShirtNumberTaken ...
+func (suite *Suite) TestDoCreateShirtNumberTaken() {
+	shirtNumber := 10
+	params := param.DoCreate{
+		Player: param.Player{
+			TeamID:      uuid.NewV4(),
+			Name:        "John Doe",
+			Position:    model.PlayerPositionMidfielder,
+			ShirtNumber: &shirtNumber,
+		},
+		Contract: &contractParam.Terms{
+			EndsAt: time.Now().AddDate(3, 0, 0),
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE team_id = $1 AND shirt_number = $2 AND deleted_at IS NULL LIMIT 1`)).
+		WithArgs(params.TeamID, shirtNumber).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "team_id", "shirt_number"}).
+			AddRow(uuid.NewV4(), params.TeamID, shirtNumber))
+
+	suite.response.doCreateResp, suite.helper.err = suite.player.DoCreate(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "shirt number 10 is already taken in the team")
+}
+
+// TestDoCreateWithoutContract ...
+func (suite *Suite) TestDoCreateWithoutContract() {
+	params := param.DoCreate{
+		Player: param.Player{
+			TeamID:   uuid.NewV4(),
+			Name:     "John Doe",
+			Position: model.PlayerPositionMidfielder,
+		},
+	}
+
+	suite.response.doCreateResp, suite.helper.err = suite.player.DoCreate(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "player cannot join the team without a contract")
 }
 
 // TestGetPlayers ...
@@ -127,6 +263,45 @@ func (suite *Suite) TestGetPlayer() {
 	require.NoError(suite.T(), suite.helper.err)
 }
 
+// TestDoUpdateOwnShirtNumber ...
+func (suite *Suite) TestDoUpdateOwnShirtNumber() {
+	shirtNumber := 1
+	params := param.DoUpdate{
+		Player: param.Player{
+			ID:          uuid.NewV4(),
+			Name:        "John Wick",
+			TeamID:      uuid.NewV4(),
+			ShirtNumber: &shirtNumber,
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "team_id"}).
+			AddRow(params.ID, params.TeamID))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE team_id = $1 AND shirt_number = $2 AND deleted_at IS NULL LIMIT 1`)).
+		WithArgs(params.TeamID, shirtNumber).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "team_id", "shirt_number"}).
+			AddRow(params.ID, params.TeamID, shirtNumber))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`FROM "squad_rules"`)).
+		WithArgs(params.TeamID, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"season_id"}))
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "players" SET "updated_at"=$1,"team_id"=$2,"name"=$3,"shirt_number"=$4 WHERE id = $5`)).
+		WithArgs(sqlmock.AnyArg(), params.TeamID, params.Name, shirtNumber, params.ID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	suite.response.doUpdateResp, suite.helper.err = suite.player.DoUpdate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+}
+
 // TestDoUpdate ...
 func (suite *Suite) TestDoUpdate() {
 	params := param.DoUpdate{
@@ -139,6 +314,17 @@ func (suite *Suite) TestDoUpdate() {
 
 	suite.mock.MatchExpectationsInOrder(false)
 
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "team_id"}).
+			AddRow(params.ID, params.TeamID))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`FROM "squad_rules"`)).
+		WithArgs(params.TeamID, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"season_id"}))
+
 	suite.mock.
 		ExpectExec(regexp.QuoteMeta(`UPDATE "players" SET "updated_at"=$1,"team_id"=$2,"name"=$3 WHERE id = $4`)).
 		WithArgs(sqlmock.AnyArg(), params.TeamID, params.Name, params.ID).
@@ -149,6 +335,63 @@ func (suite *Suite) TestDoUpdate() {
 	require.NoError(suite.T(), suite.helper.err)
 }
 
+// TestDoUpdateSquadRuleBroken ...
+func (suite *Suite) TestDoUpdateSquadRuleBroken() {
+	homegrown := false
+	params := param.DoUpdate{
+		Player: param.Player{
+			ID:        uuid.NewV4(),
+			Name:      "John Wick",
+			TeamID:    uuid.NewV4(),
+			Homegrown: &homegrown,
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "team_id", "homegrown"}).
+			AddRow(params.ID, params.TeamID, true))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT squad_rules.*, seasons.name AS season_name FROM "squad_rules" JOIN seasons ON seasons.id = squad_rules.season_id JOIN season_teams ON season_teams.season_id = squad_rules.season_id WHERE season_teams.team_id = $1 AND seasons.end_date >= $2 ORDER BY seasons.start_date`)).
+		WithArgs(params.TeamID, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"season_id", "min_homegrown", "season_name"}).
+			AddRow(uuid.NewV4(), 2, "2021/22"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE team_id = $1 AND deleted_at IS NULL`)).
+		WithArgs(params.TeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "homegrown"}).
+			AddRow(params.ID, true).
+			AddRow(uuid.NewV4(), true))
+
+	suite.response.doUpdateResp, suite.helper.err = suite.player.DoUpdate(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "squad would drop to 1 homegrown players, below the minimum of 2 in 2021/22")
+}
+
+// TestDoUpdateTeamChanged ...
+func (suite *Suite) TestDoUpdateTeamChanged() {
+	params := param.DoUpdate{
+		Player: param.Player{
+			ID:     uuid.NewV4(),
+			Name:   "John Wick",
+			TeamID: uuid.NewV4(),
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "team_id"}).
+			AddRow(params.ID, uuid.NewV4()))
+
+	suite.response.doUpdateResp, suite.helper.err = suite.player.DoUpdate(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "player can only move to another team with a transfer")
+}
+
 func (suite *Suite) TestDoDelete() {
 	params := param.DoDelete{
 		ID: uuid.NewV4(),
@@ -0,0 +1,43 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package season
+
+import (
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	"github.com/harunnryd/skeltun/internal/app/usecase/contract"
+	"github.com/harunnryd/skeltun/internal/pkg"
+)
+
+// Option is a closure that is used for accessing the local variables.
+type Option func(season *Season)
+
+// WithConfig ...
+func WithConfig(config config.IConfig) Option {
+	return func(season *Season) {
+		season.config = config
+	}
+}
+
+// WithRepo ...
+func WithRepo(repo repo.IRepo) Option {
+	return func(season *Season) {
+		season.repo = repo
+	}
+}
+
+// WithPkg ...
+func WithPkg(pkg pkg.IPkg) Option {
+	return func(season *Season) {
+		season.pkg = pkg
+	}
+}
+
+// WithContract ...
+func WithContract(contract contract.IContract) Option {
+	return func(season *Season) {
+		season.contract = contract
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package season
+
+import (
+	"context"
+	"errors"
+
+	"github.com/harunnryd/skeltun/config"
+	contractParam "github.com/harunnryd/skeltun/internal/app/handler/contract/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/season/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/season/transporter"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	"github.com/harunnryd/skeltun/internal/app/usecase/contract"
+	"github.com/harunnryd/skeltun/internal/pkg"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// ISeason is an interface that stores the methods that Season struct will use.
+type ISeason interface {
+	// DoCreate is used for record new season.
+	// It returns doCreateResp of transporter.DoCreate and any errors written.
+	DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error)
+
+	// GetSeasons is used for getting all seasons of a competition.
+	// It returns getSeasonsResp of []transporter.GetSeasons and any errors written.
+	GetSeasons(ctx context.Context, params param.GetSeasons) (getSeasonsResp []transporter.GetSeasons, err error)
+
+	// DoUpdate is used for update the record season.
+	// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+	DoUpdate(ctx context.Context, params param.DoUpdate) (doUpdateResp transporter.DoUpdate, err error)
+
+	// DoDelete is used for delete the record season.
+	// It returns doDeleteResp of transporter.DoDelete and any errors written.
+	DoDelete(ctx context.Context, params param.DoDelete) (doDeleteResp transporter.DoDelete, err error)
+
+	// GetSeason is used for getting an season.
+	// It returns getSeasonResp of transporter.GetSeason and any errors written.
+	GetSeason(ctx context.Context, params param.GetSeason) (getSeasonResp transporter.GetSeason, err error)
+
+	// DoRegisterTeam is used for registering a team into the season.
+	// It returns doRegisterTeamResp of transporter.DoRegisterTeam and any errors written.
+	DoRegisterTeam(ctx context.Context, params param.DoRegisterTeam) (doRegisterTeamResp transporter.DoRegisterTeam, err error)
+
+	// GetSeasonTeams is used for getting all teams registered in the season.
+	// It returns getSeasonTeamsResp of []transporter.GetSeasonTeams and any errors written.
+	GetSeasonTeams(ctx context.Context, params param.GetSeasonTeams) (getSeasonTeamsResp []transporter.GetSeasonTeams, err error)
+
+	// DoUnregisterTeam is used for removing a team from the season.
+	// It returns doUnregisterTeamResp of transporter.DoUnregisterTeam and any errors written.
+	DoUnregisterTeam(ctx context.Context, params param.DoUnregisterTeam) (doUnregisterTeamResp transporter.DoUnregisterTeam, err error)
+}
+
+// Season is an struct that implements ISeason methods.
+type Season struct {
+	config   config.IConfig
+	repo     repo.IRepo
+	pkg      pkg.IPkg
+	contract contract.IContract
+}
+
+// New it returns instance of Season that implements ISeason methods.
+func New(opts ...Option) ISeason {
+	s := new(Season)
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// DoCreate is used for record new season.
+// It returns doCreateResp of transporter.DoCreate and any errors written.
+func (season *Season) DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error) {
+	doCreateResp, err = season.repo.GetSeason().DoCreate(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// GetSeasons is used for getting all seasons of a competition.
+// It returns getSeasonsResp of []transporter.GetSeasons and any errors written.
+func (season *Season) GetSeasons(ctx context.Context, params param.GetSeasons) (getSeasonsResp []transporter.GetSeasons, err error) {
+	getSeasonsResp, err = season.repo.GetSeason().GetSeasons(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// DoUpdate is used for update the record season.
+// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+func (season *Season) DoUpdate(ctx context.Context, params param.DoUpdate) (doUpdateResp transporter.DoUpdate, err error) {
+	doUpdateResp, err = season.repo.GetSeason().DoUpdate(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// DoDelete is used for delete the record season.
+// It returns doDeleteResp of transporter.DoDelete and any errors written.
+func (season *Season) DoDelete(ctx context.Context, params param.DoDelete) (doDeleteResp transporter.DoDelete, err error) {
+	doDeleteResp, err = season.repo.GetSeason().DoDelete(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// GetSeason is used for getting an season.
+// It returns getSeasonResp of transporter.GetSeason and any errors written.
+func (season *Season) GetSeason(ctx context.Context, params param.GetSeason) (getSeasonResp transporter.GetSeason, err error) {
+	getSeasonResp, err = season.repo.GetSeason().GetSeason(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// DoRegisterTeam is used for registering a team into the season.
+// A team paying more in wages on the opening day than the salary cap of the competition is rejected.
+// It returns doRegisterTeamResp of transporter.DoRegisterTeam and any errors written.
+func (season *Season) DoRegisterTeam(ctx context.Context, params param.DoRegisterTeam) (doRegisterTeamResp transporter.DoRegisterTeam, err error) {
+	getSeasonResp, err := season.repo.GetSeason().GetSeason(ctx, param.GetSeason{ID: params.SeasonID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getSeasonResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("season not found")}
+		return
+	}
+
+	if err = season.contract.CheckSalaryCap(ctx, contractParam.CheckSalaryCap{
+		TeamID:   params.TeamID,
+		Date:     getSeasonResp.StartDate,
+		SeasonID: params.SeasonID,
+	}); err != nil {
+		return
+	}
+
+	doRegisterTeamResp, err = season.repo.GetSeason().DoRegisterTeam(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// GetSeasonTeams is used for getting all teams registered in the season.
+// It returns getSeasonTeamsResp of []transporter.GetSeasonTeams and any errors written.
+func (season *Season) GetSeasonTeams(ctx context.Context, params param.GetSeasonTeams) (getSeasonTeamsResp []transporter.GetSeasonTeams, err error) {
+	getSeasonTeamsResp, err = season.repo.GetSeason().GetSeasonTeams(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// DoUnregisterTeam is used for removing a team from the season.
+// It returns doUnregisterTeamResp of transporter.DoUnregisterTeam and any errors written.
+func (season *Season) DoUnregisterTeam(ctx context.Context, params param.DoUnregisterTeam) (doUnregisterTeamResp transporter.DoUnregisterTeam, err error) {
+	doUnregisterTeamResp, err = season.repo.GetSeason().DoUnregisterTeam(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
@@ -0,0 +1,320 @@
+package season
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/handler/season/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/season/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	iContractRepo "github.com/harunnryd/skeltun/internal/app/repo/contract"
+	iSeasonRepo "github.com/harunnryd/skeltun/internal/app/repo/season"
+	"github.com/harunnryd/skeltun/internal/app/usecase/contract"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	mock      sqlmock.Sqlmock
+	pgsqlConn *gorm.DB
+
+	iSeasonRepo iSeasonRepo.ISeason
+	iRepo       repo.IRepo
+	season      ISeason
+	helper
+	response
+}
+
+type helper struct {
+	err error
+	db  *sql.DB
+}
+
+type response struct {
+	doCreateResp         transporter.DoCreate
+	getSeasonsResp       []transporter.GetSeasons
+	getSeasonResp        transporter.GetSeason
+	doDeleteResp         transporter.DoDelete
+	doUpdateResp         transporter.DoUpdate
+	doRegisterTeamResp   transporter.DoRegisterTeam
+	getSeasonTeamsResp   []transporter.GetSeasonTeams
+	doUnregisterTeamResp transporter.DoUnregisterTeam
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.iSeasonRepo = iSeasonRepo.New(
+		iSeasonRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iRepo = repo.New()
+	suite.iRepo.SetSeason(suite.iSeasonRepo)
+	suite.iRepo.SetContract(iContractRepo.New(
+		iContractRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	))
+
+	suite.season = New(
+		WithRepo(suite.iRepo),
+		WithContract(contract.New(contract.WithRepo(suite.iRepo))),
+	)
+}
+
+// TestDoCreate ...
+func (suite *Suite) TestDoCreate() {
+	params := param.DoCreate{
+		Season: param.Season{
+			CompetitionID: uuid.NewV4(),
+			Name:          "2020/21",
+			StartDate:     time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:       time.Date(2021, 5, 31, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	suite.mock.MatchExpectationsInOrder(false)
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "seasons" ("created_at","updated_at","deleted_at","competition_id","name","start_date","end_date") VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING "id"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.CompetitionID, params.Name, params.StartDate, params.EndDate).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.response.doCreateResp, suite.helper.err = suite.season.DoCreate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.Equal(suite.T(), "2020/21", suite.response.doCreateResp.Name)
+}
+
+// TestGetSeasons ...
+func (suite *Suite) TestGetSeasons() {
+	params := param.GetSeasons{
+		CompetitionID: uuid.NewV4(),
+		Pagination: param.Pagination{
+			Limit:  "10",
+			Offset: "1",
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seasons" WHERE competition_id = $1 ORDER BY start_date DESC LIMIT 10 OFFSET 1`)).
+		WithArgs(params.CompetitionID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "competition_id", "name"}).
+			AddRow(uuid.NewV4(), params.CompetitionID, "2020/21"))
+
+	suite.response.getSeasonsResp, suite.helper.err = suite.season.GetSeasons(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+}
+
+// TestGetSeason ...
+func (suite *Suite) TestGetSeason() {
+	params := param.GetSeason{ID: uuid.NewV4()}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seasons" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(params.ID, "2020/21"))
+
+	suite.response.getSeasonResp, suite.helper.err = suite.season.GetSeason(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+}
+
+// TestDoUpdate ...
+func (suite *Suite) TestDoUpdate() {
+	params := param.DoUpdate{
+		Season: param.Season{
+			ID:        uuid.NewV4(),
+			Name:      "2021/22",
+			StartDate: time.Date(2021, 8, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:   time.Date(2022, 5, 31, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	suite.mock.MatchExpectationsInOrder(false)
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "seasons" SET "updated_at"=$1,"name"=$2,"start_date"=$3,"end_date"=$4 WHERE id = $5`)).
+		WithArgs(sqlmock.AnyArg(), params.Name, params.StartDate, params.EndDate, params.ID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	suite.response.doUpdateResp, suite.helper.err = suite.season.DoUpdate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+}
+
+func (suite *Suite) TestDoDelete() {
+	params := param.DoDelete{
+		ID: uuid.NewV4(),
+	}
+
+	suite.mock.MatchExpectationsInOrder(false)
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`DELETE FROM "seasons" WHERE id = $1`)).
+		WithArgs(params.ID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	suite.response.doDeleteResp, suite.helper.err = suite.season.DoDelete(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+}
+
+// TestDoRegisterTeam ...
+func (suite *Suite) TestDoRegisterTeam() {
+	params := param.DoRegisterTeam{
+		SeasonTeam: param.SeasonTeam{
+			SeasonID: uuid.NewV4(),
+			TeamID:   uuid.NewV4(),
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seasons" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(params.SeasonID, "2020/21"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`FROM "competitions"`)).
+		WithArgs(params.SeasonID, sqlmock.AnyArg(), params.TeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"competition_id", "competition_name", "salary_cap"}))
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`INSERT INTO "season_teams" ("season_id","team_id","created_at") VALUES ($1,$2,$3) ON CONFLICT DO NOTHING`)).
+		WithArgs(params.SeasonID, params.TeamID, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	suite.response.doRegisterTeamResp, suite.helper.err = suite.season.DoRegisterTeam(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.Equal(suite.T(), params.TeamID, suite.response.doRegisterTeamResp.TeamID)
+}
+
+// TestDoRegisterTeamSalaryCapExceeded ...
+func (suite *Suite) TestDoRegisterTeamSalaryCapExceeded() {
+	startDate := time.Date(2021, time.August, 14, 0, 0, 0, 0, time.UTC)
+	params := param.DoRegisterTeam{
+		SeasonTeam: param.SeasonTeam{
+			SeasonID: uuid.NewV4(),
+			TeamID:   uuid.NewV4(),
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seasons" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "start_date"}).
+			AddRow(params.SeasonID, "2021/22", startDate))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`FROM "competitions"`)).
+		WithArgs(params.SeasonID, startDate, params.TeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"competition_id", "competition_name", "salary_cap"}).
+			AddRow(uuid.NewV4(), "Premier League", 50000000))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(wage), 0) AS total FROM "contracts"`)).
+		WithArgs(params.TeamID, model.ContractStatusActive, startDate, startDate, uuid.Nil).
+		WillReturnRows(sqlmock.NewRows([]string{"total"}).
+			AddRow(62000000))
+
+	suite.response.doRegisterTeamResp, suite.helper.err = suite.season.DoRegisterTeam(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "team wages of 62000000 would exceed the salary cap of 50000000 in Premier League")
+}
+
+// TestDoRegisterTeamSeasonNotFound ...
+func (suite *Suite) TestDoRegisterTeamSeasonNotFound() {
+	params := param.DoRegisterTeam{
+		SeasonTeam: param.SeasonTeam{
+			SeasonID: uuid.NewV4(),
+			TeamID:   uuid.NewV4(),
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seasons" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+
+	suite.response.doRegisterTeamResp, suite.helper.err = suite.season.DoRegisterTeam(context.Background(), params)
+
+	require.Error(suite.T(), suite.helper.err)
+}
+
+// TestGetSeasonTeams ...
+func (suite *Suite) TestGetSeasonTeams() {
+	params := param.GetSeasonTeams{SeasonID: uuid.NewV4()}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT "teams"."id","teams"."name","teams"."association" FROM "teams" JOIN season_teams ON season_teams.team_id = teams.id WHERE season_teams.season_id = $1 ORDER BY teams.name`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(uuid.NewV4(), "Arsenal"))
+
+	suite.response.getSeasonTeamsResp, suite.helper.err = suite.season.GetSeasonTeams(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+}
+
+// TestDoUnregisterTeam ...
+func (suite *Suite) TestDoUnregisterTeam() {
+	params := param.DoUnregisterTeam{
+		SeasonTeam: param.SeasonTeam{
+			SeasonID: uuid.NewV4(),
+			TeamID:   uuid.NewV4(),
+		},
+	}
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`DELETE FROM "season_teams" WHERE season_id = $1 AND team_id = $2`)).
+		WithArgs(params.SeasonID, params.TeamID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	suite.response.doUnregisterTeamResp, suite.helper.err = suite.season.DoUnregisterTeam(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
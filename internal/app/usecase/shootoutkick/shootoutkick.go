@@ -0,0 +1,104 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shootoutkick
+
+import (
+	"context"
+	"errors"
+
+	"github.com/harunnryd/skeltun/config"
+	matchParam "github.com/harunnryd/skeltun/internal/app/handler/match/param"
+	playerParam "github.com/harunnryd/skeltun/internal/app/handler/player/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/shootoutkick/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/shootoutkick/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	"github.com/harunnryd/skeltun/internal/pkg"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// IShootoutKick is an interface that stores the methods that ShootoutKick struct will use.
+type IShootoutKick interface {
+	// DoCreate is used for record new shootout kick and recomputing the shootout score.
+	// It returns doCreateResp of transporter.DoCreate and any errors written.
+	DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error)
+
+	// GetShootoutKicks is used for getting the kicks of a penalty shootout in the order they were taken.
+	// It returns getShootoutKicksResp of []transporter.GetShootoutKicks and any errors written.
+	GetShootoutKicks(ctx context.Context, params param.GetShootoutKicks) (getShootoutKicksResp []transporter.GetShootoutKicks, err error)
+}
+
+// ShootoutKick is an struct that implements IShootoutKick methods.
+type ShootoutKick struct {
+	config config.IConfig
+	repo   repo.IRepo
+	pkg    pkg.IPkg
+}
+
+// New it returns instance of ShootoutKick that implements IShootoutKick methods.
+func New(opts ...Option) IShootoutKick {
+	s := new(ShootoutKick)
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// DoCreate is used for record new shootout kick and recomputing the shootout score.
+// The kick is appended to the shootout, its sequence follows the kicks already taken.
+// It returns doCreateResp of transporter.DoCreate and any errors written.
+func (shootoutKick *ShootoutKick) DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error) {
+	getMatchResp, err := shootoutKick.repo.GetMatch().GetMatch(ctx, matchParam.GetMatch{ID: params.MatchID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getMatchResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("match not found")}
+		return
+	}
+
+	if getMatchResp.Status != model.MatchStatusPenalties {
+		err = &iPkgError.ValidationError{Err: errors.New("shootout kicks can only be recorded during penalties")}
+		return
+	}
+
+	getPlayerResp, err := shootoutKick.repo.GetPlayer().GetPlayer(ctx, playerParam.GetPlayer{ID: params.PlayerID})
+	if err != nil {
+		return
+	}
+
+	if !uuid.Equal(getPlayerResp.TeamID, getMatchResp.HomeTeamID) && !uuid.Equal(getPlayerResp.TeamID, getMatchResp.AwayTeamID) {
+		err = &iPkgError.ValidationError{Err: errors.New("player does not belong to either team")}
+		return
+	}
+
+	getShootoutKicksResp, err := shootoutKick.repo.GetShootoutKick().GetShootoutKicks(ctx, param.GetShootoutKicks{MatchID: params.MatchID})
+	if err != nil {
+		return
+	}
+
+	params.TeamID = getPlayerResp.TeamID
+	params.Sequence = len(getShootoutKicksResp) + 1
+
+	doCreateResp, err = shootoutKick.repo.GetShootoutKick().DoCreate(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// GetShootoutKicks is used for getting the kicks of a penalty shootout in the order they were taken.
+// It returns getShootoutKicksResp of []transporter.GetShootoutKicks and any errors written.
+func (shootoutKick *ShootoutKick) GetShootoutKicks(ctx context.Context, params param.GetShootoutKicks) (getShootoutKicksResp []transporter.GetShootoutKicks, err error) {
+	getShootoutKicksResp, err = shootoutKick.repo.GetShootoutKick().GetShootoutKicks(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
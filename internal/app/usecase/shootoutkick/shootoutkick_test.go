@@ -0,0 +1,193 @@
+package shootoutkick
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/handler/shootoutkick/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/shootoutkick/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	iMatchRepo "github.com/harunnryd/skeltun/internal/app/repo/match"
+	"github.com/harunnryd/skeltun/internal/app/repo/match/matchtest"
+	iPlayerRepo "github.com/harunnryd/skeltun/internal/app/repo/player"
+	iShootoutKickRepo "github.com/harunnryd/skeltun/internal/app/repo/shootoutkick"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	mock      sqlmock.Sqlmock
+	pgsqlConn *gorm.DB
+
+	iMatchRepo        iMatchRepo.IMatch
+	iPlayerRepo       iPlayerRepo.IPlayer
+	iShootoutKickRepo iShootoutKickRepo.IShootoutKick
+	iRepo             repo.IRepo
+	shootoutKick      IShootoutKick
+	helper
+	response
+}
+
+type helper struct {
+	err error
+	db  *sql.DB
+}
+
+type response struct {
+	doCreateResp         transporter.DoCreate
+	getShootoutKicksResp []transporter.GetShootoutKicks
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.iMatchRepo = iMatchRepo.New(
+		iMatchRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iPlayerRepo = iPlayerRepo.New(
+		iPlayerRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iShootoutKickRepo = iShootoutKickRepo.New(
+		iShootoutKickRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iRepo = repo.New()
+	suite.iRepo.SetMatch(suite.iMatchRepo)
+	suite.iRepo.SetPlayer(suite.iPlayerRepo)
+	suite.iRepo.SetShootoutKick(suite.iShootoutKickRepo)
+
+	suite.shootoutKick = New(WithRepo(suite.iRepo))
+}
+
+// TestDoCreate ...
+func (suite *Suite) TestDoCreate() {
+	homeTeamID, awayTeamID := uuid.NewV4(), uuid.NewV4()
+	scored := false
+	params := param.DoCreate{
+		ShootoutKick: param.ShootoutKick{
+			MatchID:  uuid.NewV4(),
+			PlayerID: uuid.NewV4(),
+			Scored:   &scored,
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "home_team_id", "away_team_id", "status"}).
+			AddRow(params.MatchID, homeTeamID, awayTeamID, model.MatchStatusPenalties))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.PlayerID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "team_id", "name"}).
+			AddRow(params.PlayerID, awayTeamID, "Roberto Baggio"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "shootout_kicks" WHERE match_id = $1 ORDER BY sequence`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "match_id", "sequence", "scored"}).
+			AddRow(uuid.NewV4(), params.MatchID, 1, true).
+			AddRow(uuid.NewV4(), params.MatchID, 2, true))
+
+	suite.mock.ExpectBegin()
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "shootout_kicks" ("created_at","updated_at","deleted_at","match_id","team_id","player_id","sequence","scored") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING "id"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.MatchID, awayTeamID, params.PlayerID, 3, false).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.mock.
+		ExpectQuery(`UPDATE matches SET (.+) RETURNING id, home_score, away_score, home_extra_time_score, away_extra_time_score, home_shootout_score, away_shootout_score`).
+		WithArgs(matchtest.SyncScoreArgs(params.MatchID)...).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "home_shootout_score", "away_shootout_score"}).
+			AddRow(params.MatchID, 1, 1))
+	suite.mock.ExpectCommit()
+
+	suite.response.doCreateResp, suite.helper.err = suite.shootoutKick.DoCreate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), awayTeamID, suite.response.doCreateResp.TeamID)
+	require.Equal(suite.T(), 3, suite.response.doCreateResp.Sequence)
+}
+
+// TestDoCreateNotInPenalties ...
+func (suite *Suite) TestDoCreateNotInPenalties() {
+	scored := true
+	params := param.DoCreate{
+		ShootoutKick: param.ShootoutKick{
+			MatchID:  uuid.NewV4(),
+			PlayerID: uuid.NewV4(),
+			Scored:   &scored,
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "home_team_id", "away_team_id", "status"}).
+			AddRow(params.MatchID, uuid.NewV4(), uuid.NewV4(), model.MatchStatusExtraTime))
+
+	suite.response.doCreateResp, suite.helper.err = suite.shootoutKick.DoCreate(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "shootout kicks can only be recorded during penalties")
+}
+
+// TestGetShootoutKicks ...
+func (suite *Suite) TestGetShootoutKicks() {
+	params := param.GetShootoutKicks{
+		MatchID: uuid.NewV4(),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "shootout_kicks" WHERE match_id = $1 ORDER BY sequence`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "match_id", "sequence", "scored"}).
+			AddRow(uuid.NewV4(), params.MatchID, 1, true))
+
+	suite.response.getShootoutKicksResp, suite.helper.err = suite.shootoutKick.GetShootoutKicks(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Len(suite.T(), suite.response.getShootoutKicksResp, 1)
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
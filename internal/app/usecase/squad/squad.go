@@ -0,0 +1,260 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package squad
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/harunnryd/skeltun/config"
+	seasonParam "github.com/harunnryd/skeltun/internal/app/handler/season/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/squad/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/squad/transporter"
+	teamParam "github.com/harunnryd/skeltun/internal/app/handler/team/param"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	"github.com/harunnryd/skeltun/internal/pkg"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// ISquad is an interface that stores the methods that Squad struct will use.
+type ISquad interface {
+	// DoUpdateRules is used for record the squad rules of a season, replacing the previous ones.
+	// It returns doUpdateRulesResp of transporter.DoUpdateRules and any errors written.
+	DoUpdateRules(ctx context.Context, params param.DoUpdateRules) (doUpdateRulesResp transporter.DoUpdateRules, err error)
+
+	// GetRules is used for getting the squad rules of a season.
+	// It returns getRulesResp of transporter.GetRules and any errors written.
+	GetRules(ctx context.Context, params param.GetRules) (getRulesResp transporter.GetRules, err error)
+
+	// GetCompliance is used for measuring the squad of a team against the rules of every season it plays in.
+	// It returns getComplianceResp of transporter.GetCompliance and any errors written.
+	GetCompliance(ctx context.Context, params param.GetCompliance) (getComplianceResp transporter.GetCompliance, err error)
+
+	// CheckSquad is used for making sure a player joining or leaving the squad of a team keeps it within the squad rules.
+	// It returns any errors written.
+	CheckSquad(ctx context.Context, params param.CheckSquad) (err error)
+}
+
+// Squad is an struct that implements ISquad methods.
+type Squad struct {
+	config config.IConfig
+	repo   repo.IRepo
+	pkg    pkg.IPkg
+}
+
+// New it returns instance of Squad that implements ISquad methods.
+func New(opts ...Option) ISquad {
+	s := new(Squad)
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// DoUpdateRules is used for record the squad rules of a season, replacing the previous ones.
+// It returns doUpdateRulesResp of transporter.DoUpdateRules and any errors written.
+func (squad *Squad) DoUpdateRules(ctx context.Context, params param.DoUpdateRules) (doUpdateRulesResp transporter.DoUpdateRules, err error) {
+	getSeasonResp, err := squad.repo.GetSeason().GetSeason(ctx, seasonParam.GetSeason{ID: params.SeasonID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getSeasonResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("season not found")}
+		return
+	}
+
+	doUpdateRulesResp, err = squad.repo.GetSquad().DoUpdateRules(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// GetRules is used for getting the squad rules of a season.
+// It returns getRulesResp of transporter.GetRules and any errors written.
+func (squad *Squad) GetRules(ctx context.Context, params param.GetRules) (getRulesResp transporter.GetRules, err error) {
+	getRulesResp, err = squad.repo.GetSquad().GetRules(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// GetCompliance is used for measuring the squad of a team against the rules of every season it plays in.
+// Every rule comes with the current count and whether the squad passes it.
+// It returns getComplianceResp of transporter.GetCompliance and any errors written.
+func (squad *Squad) GetCompliance(ctx context.Context, params param.GetCompliance) (getComplianceResp transporter.GetCompliance, err error) {
+	params.Date = time.Now()
+
+	getTeamResp, err := squad.repo.GetTeam().GetTeam(ctx, teamParam.GetTeam{ID: params.TeamID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getTeamResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("team not found")}
+		return
+	}
+
+	getTeamRulesResp, err := squad.repo.GetSquad().GetTeamRules(ctx, param.GetTeamRules{
+		TeamID: params.TeamID,
+		Date:   params.Date,
+	})
+	if err != nil {
+		return
+	}
+
+	members, err := squad.members(ctx, params.TeamID)
+	if err != nil {
+		return
+	}
+
+	getComplianceResp = transporter.GetCompliance{
+		TeamID: params.TeamID,
+		Rules:  comply(getTeamRulesResp, members),
+	}
+
+	return
+}
+
+// CheckSquad is used for making sure a player joining or leaving the squad of a team keeps it within the squad rules.
+// A move is rejected when it breaks a rule the squad passes, or takes it further away from one it already fails,
+// so a squad short of homegrown players can still sign more of them.
+// It returns any errors written.
+func (squad *Squad) CheckSquad(ctx context.Context, params param.CheckSquad) (err error) {
+	getTeamRulesResp, err := squad.repo.GetSquad().GetTeamRules(ctx, param.GetTeamRules{
+		TeamID: params.TeamID,
+		Date:   params.Date,
+	})
+	if err != nil {
+		return
+	}
+
+	if len(getTeamRulesResp) == 0 {
+		return
+	}
+
+	members, err := squad.members(ctx, params.TeamID)
+	if err != nil {
+		return
+	}
+
+	before := comply(getTeamRulesResp, members)
+	after := comply(getTeamRulesResp, move(members, params.In, params.Out))
+	for i := range after {
+		if after[i].Passed || !worse(before[i], after[i]) {
+			continue
+		}
+
+		err = &iPkgError.ValidationError{Err: errors.New(breach(after[i]))}
+		return
+	}
+
+	return
+}
+
+// members is used for getting every player in the squad of a team.
+// It returns members of []param.Member and any errors written.
+func (squad *Squad) members(ctx context.Context, teamID uuid.UUID) (members []param.Member, err error) {
+	getSquadResp, err := squad.repo.GetSquad().GetSquad(ctx, param.GetSquad{TeamID: teamID})
+	if err != nil {
+		return
+	}
+
+	for _, player := range getSquadResp {
+		members = append(members, param.Member{
+			ID:          player.ID,
+			Nationality: player.Nationality,
+			Homegrown:   player.Homegrown,
+		})
+	}
+
+	return
+}
+
+// comply is used for measuring a squad against the rules of every season, leaving out the rules that are not set.
+func comply(rules []transporter.GetTeamRules, members []param.Member) (compliance []transporter.Compliance) {
+	for _, rule := range rules {
+		measure := func(name string, limit, count int, passed bool) {
+			compliance = append(compliance, transporter.Compliance{
+				SeasonID:   rule.SeasonID,
+				SeasonName: rule.SeasonName,
+				Rule:       name,
+				Limit:      limit,
+				Count:      count,
+				Passed:     passed,
+			})
+		}
+
+		if rule.MaxPlayers > 0 {
+			measure(model.SquadRuleMaxPlayers, rule.MaxPlayers, len(members), len(members) <= rule.MaxPlayers)
+		}
+
+		if rule.MinHomegrown > 0 {
+			homegrown := 0
+			for _, member := range members {
+				if member.Homegrown != nil && *member.Homegrown {
+					homegrown++
+				}
+			}
+			measure(model.SquadRuleMinHomegrown, rule.MinHomegrown, homegrown, homegrown >= rule.MinHomegrown)
+		}
+
+		if rule.MinDomestic > 0 {
+			domestic := 0
+			for _, member := range members {
+				if member.Nationality == rule.Nationality {
+					domestic++
+				}
+			}
+			measure(model.SquadRuleMinDomestic, rule.MinDomestic, domestic, domestic >= rule.MinDomestic)
+		}
+	}
+
+	return
+}
+
+// move is used for getting the squad once the player out has left and the player in has joined.
+func move(members []param.Member, in, out *param.Member) (moved []param.Member) {
+	for _, member := range members {
+		if out != nil && uuid.Equal(member.ID, out.ID) {
+			continue
+		}
+		moved = append(moved, member)
+	}
+
+	if in != nil {
+		moved = append(moved, *in)
+	}
+
+	return
+}
+
+// worse is used for checking whether a squad got further away from passing a rule.
+func worse(before, after transporter.Compliance) bool {
+	if after.Rule == model.SquadRuleMaxPlayers {
+		return after.Count > before.Count
+	}
+	return after.Count < before.Count
+}
+
+// breach is used for describing a rule the squad would fail.
+func breach(c transporter.Compliance) string {
+	switch c.Rule {
+	case model.SquadRuleMaxPlayers:
+		return "squad of " + strconv.Itoa(c.Count) + " players would go over the limit of " + strconv.Itoa(c.Limit) + " in " + c.SeasonName
+	case model.SquadRuleMinHomegrown:
+		return "squad would drop to " + strconv.Itoa(c.Count) + " homegrown players, below the minimum of " + strconv.Itoa(c.Limit) + " in " + c.SeasonName
+	default:
+		return "squad would drop to " + strconv.Itoa(c.Count) + " domestic players, below the minimum of " + strconv.Itoa(c.Limit) + " in " + c.SeasonName
+	}
+}
@@ -0,0 +1,346 @@
+package squad
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/handler/squad/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/squad/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	iSeasonRepo "github.com/harunnryd/skeltun/internal/app/repo/season"
+	iSquadRepo "github.com/harunnryd/skeltun/internal/app/repo/squad"
+	iTeamRepo "github.com/harunnryd/skeltun/internal/app/repo/team"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	mock      sqlmock.Sqlmock
+	pgsqlConn *gorm.DB
+
+	iSquadRepo  iSquadRepo.ISquad
+	iSeasonRepo iSeasonRepo.ISeason
+	iTeamRepo   iTeamRepo.ITeam
+	iRepo       repo.IRepo
+	squad       ISquad
+	helper
+	response
+}
+
+type helper struct {
+	err error
+	db  *sql.DB
+}
+
+type response struct {
+	doUpdateRulesResp transporter.DoUpdateRules
+	getComplianceResp transporter.GetCompliance
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.iSquadRepo = iSquadRepo.New(
+		iSquadRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iSeasonRepo = iSeasonRepo.New(
+		iSeasonRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iTeamRepo = iTeamRepo.New(
+		iTeamRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iRepo = repo.New()
+	suite.iRepo.SetSquad(suite.iSquadRepo)
+	suite.iRepo.SetSeason(suite.iSeasonRepo)
+	suite.iRepo.SetTeam(suite.iTeamRepo)
+
+	suite.squad = New(WithRepo(suite.iRepo))
+}
+
+// TestDoUpdateRules ...
+func (suite *Suite) TestDoUpdateRules() {
+	params := param.DoUpdateRules{
+		Rules: param.Rules{
+			SeasonID:     uuid.NewV4(),
+			MaxPlayers:   25,
+			MinHomegrown: 8,
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seasons" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(params.SeasonID, "2021/22"))
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`INSERT INTO "squad_rules"`)).
+		WithArgs(params.SeasonID, 25, 8, 0, "", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	suite.response.doUpdateRulesResp, suite.helper.err = suite.squad.DoUpdateRules(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), params.SeasonID, suite.response.doUpdateRulesResp.SeasonID)
+}
+
+// TestDoUpdateRulesSeasonNotFound ...
+func (suite *Suite) TestDoUpdateRulesSeasonNotFound() {
+	params := param.DoUpdateRules{
+		Rules: param.Rules{
+			SeasonID:   uuid.NewV4(),
+			MaxPlayers: 25,
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seasons" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.response.doUpdateRulesResp, suite.helper.err = suite.squad.DoUpdateRules(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "season not found")
+}
+
+// TestGetCompliance ...
+func (suite *Suite) TestGetCompliance() {
+	params := param.GetCompliance{
+		TeamID: uuid.NewV4(),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "teams" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.TeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(params.TeamID, "Liverpool"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE "players"."team_id" = $1`)).
+		WithArgs(params.TeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`FROM "squad_rules"`)).
+		WithArgs(params.TeamID, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"season_id", "max_players", "min_homegrown", "min_domestic", "nationality", "season_name"}).
+			AddRow(uuid.NewV4(), 2, 0, 2, "ENG", "2021/22"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE team_id = $1 AND deleted_at IS NULL`)).
+		WithArgs(params.TeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "nationality"}).
+			AddRow(uuid.NewV4(), "ENG").
+			AddRow(uuid.NewV4(), "FRA"))
+
+	suite.response.getComplianceResp, suite.helper.err = suite.squad.GetCompliance(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Len(suite.T(), suite.response.getComplianceResp.Rules, 2)
+	require.True(suite.T(), suite.response.getComplianceResp.Rules[0].Passed)
+	require.Equal(suite.T(), 1, suite.response.getComplianceResp.Rules[1].Count)
+	require.False(suite.T(), suite.response.getComplianceResp.Rules[1].Passed)
+}
+
+// TestCheckSquadOverLimit ...
+func (suite *Suite) TestCheckSquadOverLimit() {
+	params := param.CheckSquad{
+		TeamID: uuid.NewV4(),
+		Date:   time.Date(2021, time.August, 1, 0, 0, 0, 0, time.UTC),
+		In:     &param.Member{ID: uuid.NewV4(), Nationality: "FRA"},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`FROM "squad_rules"`)).
+		WithArgs(params.TeamID, params.Date).
+		WillReturnRows(sqlmock.NewRows([]string{"season_id", "max_players", "season_name"}).
+			AddRow(uuid.NewV4(), 2, "2021/22"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE team_id = $1 AND deleted_at IS NULL`)).
+		WithArgs(params.TeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "nationality"}).
+			AddRow(uuid.NewV4(), "ENG").
+			AddRow(uuid.NewV4(), "ENG"))
+
+	suite.helper.err = suite.squad.CheckSquad(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "squad of 3 players would go over the limit of 2 in 2021/22")
+}
+
+// TestCheckSquadAlreadyShort ...
+func (suite *Suite) TestCheckSquadAlreadyShort() {
+	homegrown := true
+	params := param.CheckSquad{
+		TeamID: uuid.NewV4(),
+		Date:   time.Date(2021, time.August, 1, 0, 0, 0, 0, time.UTC),
+		In:     &param.Member{ID: uuid.NewV4(), Nationality: "ENG", Homegrown: &homegrown},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`FROM "squad_rules"`)).
+		WithArgs(params.TeamID, params.Date).
+		WillReturnRows(sqlmock.NewRows([]string{"season_id", "min_homegrown", "season_name"}).
+			AddRow(uuid.NewV4(), 8, "2021/22"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE team_id = $1 AND deleted_at IS NULL`)).
+		WithArgs(params.TeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "nationality", "homegrown"}).
+			AddRow(uuid.NewV4(), "ENG", true))
+
+	suite.helper.err = suite.squad.CheckSquad(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+}
+
+// TestCheckSquadWithoutRules ...
+func (suite *Suite) TestCheckSquadWithoutRules() {
+	params := param.CheckSquad{
+		TeamID: uuid.NewV4(),
+		Date:   time.Date(2021, time.August, 1, 0, 0, 0, 0, time.UTC),
+		Out:    &param.Member{ID: uuid.NewV4()},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`FROM "squad_rules"`)).
+		WithArgs(params.TeamID, params.Date).
+		WillReturnRows(sqlmock.NewRows([]string{"season_id"}))
+
+	suite.helper.err = suite.squad.CheckSquad(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+// TestComply ...
+func TestComply(t *testing.T) {
+	yes, no := true, false
+	rules := []transporter.GetTeamRules{
+		{
+			Rules:      transporter.Rules{SeasonID: uuid.NewV4(), MaxPlayers: 3, MinHomegrown: 2, MinDomestic: 1, Nationality: "ENG"},
+			SeasonName: "2021/22",
+		},
+	}
+
+	tests := []struct {
+		name    string
+		members []param.Member
+		want    []bool
+	}{
+		{
+			name: "empty squad",
+			want: []bool{true, false, false},
+		},
+		{
+			name: "unknown homegrown status is not counted",
+			members: []param.Member{
+				{ID: uuid.NewV4(), Nationality: "ENG", Homegrown: &yes},
+				{ID: uuid.NewV4(), Nationality: "FRA"},
+				{ID: uuid.NewV4(), Nationality: "FRA", Homegrown: &no},
+			},
+			want: []bool{true, false, true},
+		},
+		{
+			name: "over the limit",
+			members: []param.Member{
+				{ID: uuid.NewV4(), Nationality: "ENG", Homegrown: &yes},
+				{ID: uuid.NewV4(), Nationality: "ENG", Homegrown: &yes},
+				{ID: uuid.NewV4(), Nationality: "FRA"},
+				{ID: uuid.NewV4(), Nationality: "FRA"},
+			},
+			want: []bool{false, true, true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compliance := comply(rules, tt.members)
+			require.Len(t, compliance, len(tt.want))
+			for i := range compliance {
+				require.Equal(t, tt.want[i], compliance[i].Passed, compliance[i].Rule)
+			}
+		})
+	}
+}
+
+// TestWorse ...
+func TestWorse(t *testing.T) {
+	tests := []struct {
+		name   string
+		before transporter.Compliance
+		after  transporter.Compliance
+		want   bool
+	}{
+		{
+			name:   "squad grows past the limit",
+			before: transporter.Compliance{Rule: model.SquadRuleMaxPlayers, Count: 26},
+			after:  transporter.Compliance{Rule: model.SquadRuleMaxPlayers, Count: 27},
+			want:   true,
+		},
+		{
+			name:   "squad shrinks towards the limit",
+			before: transporter.Compliance{Rule: model.SquadRuleMaxPlayers, Count: 27},
+			after:  transporter.Compliance{Rule: model.SquadRuleMaxPlayers, Count: 26},
+		},
+		{
+			name:   "homegrown player leaves",
+			before: transporter.Compliance{Rule: model.SquadRuleMinHomegrown, Count: 8},
+			after:  transporter.Compliance{Rule: model.SquadRuleMinHomegrown, Count: 7},
+			want:   true,
+		},
+		{
+			name:   "domestic count unchanged",
+			before: transporter.Compliance{Rule: model.SquadRuleMinDomestic, Count: 3},
+			after:  transporter.Compliance{Rule: model.SquadRuleMinDomestic, Count: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, worse(tt.before, tt.after))
+		})
+	}
+}
@@ -0,0 +1,319 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package standing
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/harunnryd/skeltun/config"
+	competitionParam "github.com/harunnryd/skeltun/internal/app/handler/competition/param"
+	matchParam "github.com/harunnryd/skeltun/internal/app/handler/match/param"
+	matchTransporter "github.com/harunnryd/skeltun/internal/app/handler/match/transporter"
+	matchEventParam "github.com/harunnryd/skeltun/internal/app/handler/matchevent/param"
+	matchEventTransporter "github.com/harunnryd/skeltun/internal/app/handler/matchevent/transporter"
+	seasonParam "github.com/harunnryd/skeltun/internal/app/handler/season/param"
+	seasonTransporter "github.com/harunnryd/skeltun/internal/app/handler/season/transporter"
+	"github.com/harunnryd/skeltun/internal/app/handler/standing/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/standing/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	"github.com/harunnryd/skeltun/internal/pkg"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// IStanding is an interface that stores the methods that Standing struct will use.
+type IStanding interface {
+	// GetStandings is used for getting the league table of a season.
+	// It returns getStandingsResp of []transporter.GetStandings and any errors written.
+	GetStandings(ctx context.Context, params param.GetStandings) (getStandingsResp []transporter.GetStandings, err error)
+}
+
+// Standing is an struct that implements IStanding methods.
+type Standing struct {
+	config config.IConfig
+	repo   repo.IRepo
+	pkg    pkg.IPkg
+}
+
+// points holds how many points each match result is worth.
+type points struct {
+	win  int
+	draw int
+	loss int
+}
+
+// New it returns instance of Standing that implements IStanding methods.
+func New(opts ...Option) IStanding {
+	s := new(Standing)
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// GetStandings is used for getting the league table of a season.
+// When TeamIDs is given the table only ranks those teams on the matches played between them,
+// which is how the table of a single group is built.
+// It returns getStandingsResp of []transporter.GetStandings and any errors written.
+func (standing *Standing) GetStandings(ctx context.Context, params param.GetStandings) (getStandingsResp []transporter.GetStandings, err error) {
+	getSeasonResp, err := standing.repo.GetSeason().GetSeason(ctx, seasonParam.GetSeason{ID: params.SeasonID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getSeasonResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("season not found")}
+		return
+	}
+
+	getSeasonTeamsResp, err := standing.repo.GetSeason().GetSeasonTeams(ctx, seasonParam.GetSeasonTeams{SeasonID: params.SeasonID})
+	if err != nil {
+		return
+	}
+
+	getSeasonMatchesResp, err := standing.repo.GetMatch().GetSeasonMatches(ctx, matchParam.GetSeasonMatches{
+		SeasonID: params.SeasonID,
+		Status:   model.MatchStatusFinished,
+	})
+	if err != nil {
+		return
+	}
+
+	getCompetitionResp, err := standing.repo.GetCompetition().GetCompetition(ctx, competitionParam.GetCompetition{ID: getSeasonResp.CompetitionID})
+	if err != nil {
+		return
+	}
+
+	getSeasonMatchEventsResp, err := standing.repo.GetMatchEvent().GetSeasonMatchEvents(ctx, matchEventParam.GetSeasonMatchEvents{
+		SeasonID: params.SeasonID,
+		Types:    model.MatchEventCardTypes,
+	})
+	if err != nil {
+		return
+	}
+
+	if len(params.TeamIDs) > 0 {
+		getSeasonTeamsResp, getSeasonMatchesResp = among(params.TeamIDs, getSeasonTeamsResp, getSeasonMatchesResp)
+	}
+
+	rules := []string(getCompetitionResp.Tiebreakers)
+	if len(rules) == 0 {
+		rules = model.DefaultTiebreakers
+	}
+
+	getStandingsResp = compute(getSeasonTeamsResp, getSeasonMatchesResp, points{
+		win:  standing.config.GetInt("standings.points.win"),
+		draw: standing.config.GetInt("standings.points.draw"),
+		loss: standing.config.GetInt("standings.points.loss"),
+	}, rules, fairPlay(getSeasonMatchesResp, getSeasonMatchEventsResp))
+
+	return
+}
+
+// among is used for keeping only the given teams and the matches played between them.
+func among(teamIDs []uuid.UUID, teams []seasonTransporter.GetSeasonTeams, matches []matchTransporter.GetSeasonMatches) (amongTeams []seasonTransporter.GetSeasonTeams, amongMatches []matchTransporter.GetSeasonMatches) {
+	kept := make(map[uuid.UUID]bool, len(teamIDs))
+	for _, teamID := range teamIDs {
+		kept[teamID] = true
+	}
+
+	for _, team := range teams {
+		if kept[team.ID] {
+			amongTeams = append(amongTeams, team)
+		}
+	}
+
+	for _, match := range matches {
+		if kept[match.HomeTeamID] && kept[match.AwayTeamID] {
+			amongMatches = append(amongMatches, match)
+		}
+	}
+
+	return
+}
+
+// fairPlayPenalties maps every card type to the fair-play points it costs the team.
+var fairPlayPenalties = map[string]int{
+	model.MatchEventTypeYellowCard:   1,
+	model.MatchEventTypeSecondYellow: 3,
+	model.MatchEventTypeRedCard:      4,
+}
+
+// fairPlay is used for summing the fair-play points of every team over the finished matches.
+func fairPlay(matches []matchTransporter.GetSeasonMatches, events []matchEventTransporter.GetSeasonMatchEvents) map[uuid.UUID]int {
+	finished := make(map[uuid.UUID]bool)
+	for _, match := range matches {
+		finished[match.ID] = true
+	}
+
+	penalties := make(map[uuid.UUID]int)
+	for _, event := range events {
+		if finished[event.MatchID] {
+			penalties[event.TeamID] += fairPlayPenalties[event.Type]
+		}
+	}
+
+	return penalties
+}
+
+// tiebreaker is used for scoring the teams of a tied group, higher values rank first.
+type tiebreaker func(group []transporter.GetStandings, matches []matchTransporter.GetSeasonMatches, pts points) map[uuid.UUID]int
+
+// tiebreakers maps every rule name to its tiebreaker.
+var tiebreakers = map[string]tiebreaker{
+	model.TiebreakerHeadToHead: headToHead,
+	model.TiebreakerGoalDifference: func(group []transporter.GetStandings, _ []matchTransporter.GetSeasonMatches, _ points) map[uuid.UUID]int {
+		return valueOf(group, func(row transporter.GetStandings) int { return row.GoalDifference })
+	},
+	model.TiebreakerGoalsScored: func(group []transporter.GetStandings, _ []matchTransporter.GetSeasonMatches, _ points) map[uuid.UUID]int {
+		return valueOf(group, func(row transporter.GetStandings) int { return row.GoalsFor })
+	},
+	model.TiebreakerAwayGoals: func(group []transporter.GetStandings, _ []matchTransporter.GetSeasonMatches, _ points) map[uuid.UUID]int {
+		return valueOf(group, func(row transporter.GetStandings) int { return row.AwayGoalsFor })
+	},
+	// Fewer fair-play points ranks higher, as every card adds to the total.
+	model.TiebreakerFairPlay: func(group []transporter.GetStandings, _ []matchTransporter.GetSeasonMatches, _ points) map[uuid.UUID]int {
+		return valueOf(group, func(row transporter.GetStandings) int { return -row.FairPlayPoints })
+	},
+}
+
+// compute is used for building the league table out of the finished matches.
+// Teams level on points are separated by the given tiebreaker rules in order,
+// teams still level once every rule has been applied share the same rank.
+func compute(teams []seasonTransporter.GetSeasonTeams, matches []matchTransporter.GetSeasonMatches, pts points, rules []string, penalties map[uuid.UUID]int) (standings []transporter.GetStandings) {
+	rows := make(map[uuid.UUID]*transporter.GetStandings)
+	row := func(teamID uuid.UUID) *transporter.GetStandings {
+		if _, ok := rows[teamID]; !ok {
+			rows[teamID] = &transporter.GetStandings{Standing: transporter.Standing{TeamID: teamID}}
+		}
+		return rows[teamID]
+	}
+
+	for _, team := range teams {
+		row(team.ID).TeamName = team.Name
+	}
+
+	for _, match := range matches {
+		home, away := row(match.HomeTeamID), row(match.AwayTeamID)
+		record(home, match.HomeScore, match.AwayScore, pts)
+		record(away, match.AwayScore, match.HomeScore, pts)
+		away.AwayGoalsFor += match.AwayScore
+	}
+
+	var all []transporter.GetStandings
+	for _, r := range rows {
+		r.FairPlayPoints = penalties[r.TeamID]
+		all = append(all, *r)
+	}
+
+	sort.SliceStable(all, func(i, j int) bool {
+		if all[i].Points != all[j].Points {
+			return all[i].Points > all[j].Points
+		}
+		return all[i].TeamName < all[j].TeamName
+	})
+
+	for i, j := 0, 0; i < len(all); i = j {
+		for j = i; j < len(all) && all[j].Points == all[i].Points; j++ {
+		}
+		standings = append(standings, breakTies(all[i:j], matches, pts, rules)...)
+	}
+
+	for i := range standings {
+		standings[i].Rank = i + 1
+		if i > 0 && standings[i].Points == standings[i-1].Points && standings[i].SeparatedBy == "" {
+			standings[i].Rank = standings[i-1].Rank
+		}
+	}
+
+	return
+}
+
+// breakTies is used for ordering a group of teams level on points.
+// The first rule splits the group into smaller groups of equal value, each of them
+// is then ordered by the remaining rules. The team heading every group but the first
+// records the rule that separated it from the team above.
+func breakTies(group []transporter.GetStandings, matches []matchTransporter.GetSeasonMatches, pts points, rules []string) (ordered []transporter.GetStandings) {
+	if len(group) < 2 || len(rules) == 0 {
+		return group
+	}
+
+	rule, ok := tiebreakers[rules[0]]
+	if !ok {
+		return breakTies(group, matches, pts, rules[1:])
+	}
+
+	values := rule(group, matches, pts)
+	group = append([]transporter.GetStandings(nil), group...)
+	sort.SliceStable(group, func(i, j int) bool {
+		return values[group[i].TeamID] > values[group[j].TeamID]
+	})
+
+	for i, j := 0, 0; i < len(group); i = j {
+		for j = i; j < len(group) && values[group[j].TeamID] == values[group[i].TeamID]; j++ {
+		}
+
+		tied := breakTies(group[i:j], matches, pts, rules[1:])
+		if i > 0 {
+			tied[0].SeparatedBy = rules[0]
+		}
+		ordered = append(ordered, tied...)
+	}
+
+	return
+}
+
+// headToHead is used for scoring a tied group by the points earned in the matches between its teams.
+func headToHead(group []transporter.GetStandings, matches []matchTransporter.GetSeasonMatches, pts points) map[uuid.UUID]int {
+	rows := make(map[uuid.UUID]*transporter.GetStandings)
+	for _, row := range group {
+		rows[row.TeamID] = &transporter.GetStandings{}
+	}
+
+	for _, match := range matches {
+		home, isHome := rows[match.HomeTeamID]
+		away, isAway := rows[match.AwayTeamID]
+		if !isHome || !isAway {
+			continue
+		}
+
+		record(home, match.HomeScore, match.AwayScore, pts)
+		record(away, match.AwayScore, match.HomeScore, pts)
+	}
+
+	return valueOf(group, func(row transporter.GetStandings) int { return rows[row.TeamID].Points })
+}
+
+// valueOf is used for scoring every team of a group with the given function.
+func valueOf(group []transporter.GetStandings, fn func(row transporter.GetStandings) int) map[uuid.UUID]int {
+	values := make(map[uuid.UUID]int)
+	for _, row := range group {
+		values[row.TeamID] = fn(row)
+	}
+	return values
+}
+
+// record is used for adding a single match result into the team row.
+func record(row *transporter.GetStandings, scored, conceded int, pts points) {
+	row.Played++
+	row.GoalsFor += scored
+	row.GoalsAgainst += conceded
+	row.GoalDifference = row.GoalsFor - row.GoalsAgainst
+
+	switch {
+	case scored > conceded:
+		row.Won++
+		row.Points += pts.win
+	case scored == conceded:
+		row.Drawn++
+		row.Points += pts.draw
+	default:
+		row.Lost++
+		row.Points += pts.loss
+	}
+}
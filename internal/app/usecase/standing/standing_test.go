@@ -0,0 +1,386 @@
+package standing
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/config/configtest"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	matchTransporter "github.com/harunnryd/skeltun/internal/app/handler/match/transporter"
+	matchEventTransporter "github.com/harunnryd/skeltun/internal/app/handler/matchevent/transporter"
+	seasonTransporter "github.com/harunnryd/skeltun/internal/app/handler/season/transporter"
+	"github.com/harunnryd/skeltun/internal/app/handler/standing/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/standing/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	iCompetitionRepo "github.com/harunnryd/skeltun/internal/app/repo/competition"
+	iMatchRepo "github.com/harunnryd/skeltun/internal/app/repo/match"
+	iMatchEventRepo "github.com/harunnryd/skeltun/internal/app/repo/matchevent"
+	iSeasonRepo "github.com/harunnryd/skeltun/internal/app/repo/season"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	mock      sqlmock.Sqlmock
+	pgsqlConn *gorm.DB
+
+	iCompetitionRepo iCompetitionRepo.ICompetition
+	iMatchRepo       iMatchRepo.IMatch
+	iMatchEventRepo  iMatchEventRepo.IMatchEvent
+	iSeasonRepo      iSeasonRepo.ISeason
+	iRepo            repo.IRepo
+	standing         IStanding
+	helper
+	response
+}
+
+type helper struct {
+	err error
+	db  *sql.DB
+}
+
+type response struct {
+	getStandingsResp []transporter.GetStandings
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.iMatchRepo = iMatchRepo.New(
+		iMatchRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iMatchEventRepo = iMatchEventRepo.New(
+		iMatchEventRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iSeasonRepo = iSeasonRepo.New(
+		iSeasonRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iCompetitionRepo = iCompetitionRepo.New(
+		iCompetitionRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iRepo = repo.New()
+	suite.iRepo.SetCompetition(suite.iCompetitionRepo)
+	suite.iRepo.SetMatch(suite.iMatchRepo)
+	suite.iRepo.SetMatchEvent(suite.iMatchEventRepo)
+	suite.iRepo.SetSeason(suite.iSeasonRepo)
+
+	suite.standing = New(WithConfig(configtest.Fake{
+		"standings.points.win":  3,
+		"standings.points.draw": 1,
+		"standings.points.loss": 0,
+	}), WithRepo(suite.iRepo))
+}
+
+// TestGetStandings ...
+func (suite *Suite) TestGetStandings() {
+	params := param.GetStandings{SeasonID: uuid.NewV4()}
+	competitionID := uuid.NewV4()
+	arsenal, chelsea := uuid.NewV4(), uuid.NewV4()
+	matchID := uuid.NewV4()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seasons" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "competition_id", "name"}).
+			AddRow(params.SeasonID, competitionID, "2020/21"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT "teams"."id","teams"."name","teams"."association" FROM "teams" JOIN season_teams ON season_teams.team_id = teams.id WHERE season_teams.season_id = $1 ORDER BY teams.name`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(arsenal, "Arsenal").
+			AddRow(chelsea, "Chelsea"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE (season_id = $1 AND tie_id IS NULL) AND status = $2 ORDER BY kickoff_at`)).
+		WithArgs(params.SeasonID, model.MatchStatusFinished).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "home_team_id", "away_team_id", "status", "home_score", "away_score"}).
+			AddRow(matchID, chelsea, arsenal, model.MatchStatusFinished, 0, 2))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "competitions" WHERE id = $1 LIMIT 1`)).
+		WithArgs(competitionID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "tiebreakers"}).
+			AddRow(competitionID, "Premier League", `{"head_to_head","goal_difference"}`))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seasons" WHERE "seasons"."competition_id" = $1`)).
+		WithArgs(competitionID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "competition_id", "name"}).
+			AddRow(params.SeasonID, competitionID, "2020/21"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT "match_events"."id","match_events"."match_id","match_events"."team_id","match_events"."player_id","match_events"."related_player_id","match_events"."type","match_events"."period","match_events"."minute" FROM "match_events" JOIN matches ON matches.id = match_events.match_id WHERE matches.season_id = $1 AND match_events.type IN ($2,$3,$4)`)).
+		WithArgs(params.SeasonID, model.MatchEventTypeYellowCard, model.MatchEventTypeSecondYellow, model.MatchEventTypeRedCard).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "match_id", "team_id", "type"}).
+			AddRow(uuid.NewV4(), matchID, arsenal, model.MatchEventTypeYellowCard).
+			AddRow(uuid.NewV4(), matchID, chelsea, model.MatchEventTypeRedCard))
+
+	suite.response.getStandingsResp, suite.helper.err = suite.standing.GetStandings(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.Len(suite.T(), suite.response.getStandingsResp, 2)
+	require.Equal(suite.T(), arsenal, suite.response.getStandingsResp[0].TeamID)
+	require.Equal(suite.T(), 3, suite.response.getStandingsResp[0].Points)
+	require.Equal(suite.T(), 1, suite.response.getStandingsResp[0].FairPlayPoints)
+	require.Equal(suite.T(), 4, suite.response.getStandingsResp[1].FairPlayPoints)
+}
+
+// TestGetStandingsSeasonNotFound ...
+func (suite *Suite) TestGetStandingsSeasonNotFound() {
+	params := param.GetStandings{SeasonID: uuid.NewV4()}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seasons" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+
+	suite.response.getStandingsResp, suite.helper.err = suite.standing.GetStandings(context.Background(), params)
+
+	require.Error(suite.T(), suite.helper.err)
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+// TestCompute ...
+func TestCompute(t *testing.T) {
+	a, b, c, d := uuid.NewV4(), uuid.NewV4(), uuid.NewV4(), uuid.NewV4()
+	teams := []seasonTransporter.GetSeasonTeams{
+		{Team: seasonTransporter.Team{ID: a, Name: "A"}},
+		{Team: seasonTransporter.Team{ID: b, Name: "B"}},
+		{Team: seasonTransporter.Team{ID: c, Name: "C"}},
+		{Team: seasonTransporter.Team{ID: d, Name: "D"}},
+	}
+	result := func(home, away uuid.UUID, homeScore, awayScore int) matchTransporter.GetSeasonMatches {
+		return matchTransporter.GetSeasonMatches{Match: matchTransporter.Match{
+			HomeTeamID: home,
+			AwayTeamID: away,
+			HomeScore:  homeScore,
+			AwayScore:  awayScore,
+		}}
+	}
+
+	tests := []struct {
+		name    string
+		matches []matchTransporter.GetSeasonMatches
+		pts     points
+		rules   []string
+		cards   map[uuid.UUID]int
+		order   []uuid.UUID
+		ranks   []int
+		points  []int
+		reasons []string
+	}{
+		{
+			name:   "no matches played",
+			order:  []uuid.UUID{a, b, c, d},
+			ranks:  []int{1, 1, 1, 1},
+			points: []int{0, 0, 0, 0},
+			pts:    points{win: 3, draw: 1},
+			rules:  model.DefaultTiebreakers,
+		},
+		{
+			name:    "clear winner",
+			matches: []matchTransporter.GetSeasonMatches{result(a, b, 2, 0), result(c, d, 0, 1)},
+			order:   []uuid.UUID{a, d, c, b},
+			ranks:   []int{1, 2, 3, 4},
+			points:  []int{3, 3, 0, 0},
+			pts:     points{win: 3, draw: 1},
+			rules:   model.DefaultTiebreakers,
+		},
+		{
+			name:    "level on points separated by goal difference",
+			matches: []matchTransporter.GetSeasonMatches{result(a, c, 1, 0), result(b, d, 3, 0)},
+			order:   []uuid.UUID{b, a, c, d},
+			ranks:   []int{1, 2, 3, 4},
+			points:  []int{3, 3, 0, 0},
+			reasons: []string{"", model.TiebreakerGoalDifference, "", model.TiebreakerGoalDifference},
+			pts:     points{win: 3, draw: 1},
+			rules:   model.DefaultTiebreakers,
+		},
+		{
+			name:    "level on points and goal difference separated by goals scored",
+			matches: []matchTransporter.GetSeasonMatches{result(a, c, 1, 0), result(b, d, 3, 2)},
+			order:   []uuid.UUID{b, a, d, c},
+			ranks:   []int{1, 2, 3, 4},
+			points:  []int{3, 3, 0, 0},
+			reasons: []string{"", model.TiebreakerGoalsScored, "", model.TiebreakerGoalsScored},
+			pts:     points{win: 3, draw: 1},
+			rules:   model.DefaultTiebreakers,
+		},
+		{
+			name:    "fully level teams share a rank",
+			matches: []matchTransporter.GetSeasonMatches{result(a, c, 1, 1), result(b, d, 1, 1)},
+			order:   []uuid.UUID{a, b, c, d},
+			ranks:   []int{1, 1, 1, 1},
+			points:  []int{1, 1, 1, 1},
+			pts:     points{win: 3, draw: 1},
+			rules:   model.DefaultTiebreakers,
+		},
+		{
+			name:    "shared rank skips the following position",
+			matches: []matchTransporter.GetSeasonMatches{result(a, d, 2, 0), result(b, c, 2, 0), result(c, d, 1, 0)},
+			order:   []uuid.UUID{a, b, c, d},
+			ranks:   []int{1, 1, 3, 4},
+			points:  []int{3, 3, 3, 0},
+			pts:     points{win: 3, draw: 1},
+			rules:   model.DefaultTiebreakers,
+		},
+		{
+			name:    "custom points per result",
+			matches: []matchTransporter.GetSeasonMatches{result(a, b, 1, 0), result(c, d, 2, 2)},
+			order:   []uuid.UUID{a, c, d, b},
+			ranks:   []int{1, 2, 2, 4},
+			points:  []int{2, 1, 1, 0},
+			pts:     points{win: 2, draw: 1},
+			rules:   model.DefaultTiebreakers,
+		},
+		{
+			name:    "head to head ahead of goal difference",
+			matches: []matchTransporter.GetSeasonMatches{result(a, b, 1, 0), result(b, c, 4, 0), result(c, d, 0, 0)},
+			order:   []uuid.UUID{a, b, d, c},
+			ranks:   []int{1, 2, 3, 4},
+			points:  []int{3, 3, 1, 1},
+			reasons: []string{"", model.TiebreakerHeadToHead, "", model.TiebreakerGoalDifference},
+			pts:     points{win: 3, draw: 1},
+			rules:   []string{model.TiebreakerHeadToHead, model.TiebreakerGoalDifference},
+		},
+		{
+			name:    "away goals after goal difference",
+			matches: []matchTransporter.GetSeasonMatches{result(c, a, 1, 2), result(b, d, 2, 1)},
+			order:   []uuid.UUID{a, b, d, c},
+			ranks:   []int{1, 2, 3, 4},
+			points:  []int{3, 3, 0, 0},
+			reasons: []string{"", model.TiebreakerAwayGoals, "", model.TiebreakerAwayGoals},
+			pts:     points{win: 3, draw: 1},
+			rules:   []string{model.TiebreakerGoalDifference, model.TiebreakerAwayGoals},
+		},
+		{
+			name:    "still level after every rule share a rank",
+			matches: []matchTransporter.GetSeasonMatches{result(a, c, 2, 0), result(b, d, 1, 0)},
+			order:   []uuid.UUID{a, b, c, d},
+			ranks:   []int{1, 1, 3, 3},
+			points:  []int{3, 3, 0, 0},
+			reasons: []string{"", "", "", ""},
+			pts:     points{win: 3, draw: 1},
+			rules:   []string{model.TiebreakerHeadToHead},
+		},
+		{
+			name:    "fewer fair-play points after goal difference",
+			matches: []matchTransporter.GetSeasonMatches{result(a, c, 1, 0), result(b, d, 1, 0)},
+			cards:   map[uuid.UUID]int{a: 4, b: 1},
+			order:   []uuid.UUID{b, a, c, d},
+			ranks:   []int{1, 2, 3, 3},
+			points:  []int{3, 3, 0, 0},
+			reasons: []string{"", model.TiebreakerFairPlay, "", ""},
+			pts:     points{win: 3, draw: 1},
+			rules:   []string{model.TiebreakerGoalDifference, model.TiebreakerFairPlay, model.TiebreakerGoalsScored},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			standings := compute(teams, tt.matches, tt.pts, tt.rules, tt.cards)
+
+			require.Len(t, standings, len(tt.order))
+			for i, standing := range standings {
+				require.Equal(t, tt.order[i], standing.TeamID, "position %d", i+1)
+				require.Equal(t, tt.ranks[i], standing.Rank, "position %d", i+1)
+				require.Equal(t, tt.points[i], standing.Points, "position %d", i+1)
+				if tt.reasons != nil {
+					require.Equal(t, tt.reasons[i], standing.SeparatedBy, "position %d", i+1)
+				}
+			}
+		})
+	}
+}
+
+// TestAmong ...
+func TestAmong(t *testing.T) {
+	arsenalID, chelseaID, evertonID := uuid.NewV4(), uuid.NewV4(), uuid.NewV4()
+	teams := []seasonTransporter.GetSeasonTeams{
+		{Team: seasonTransporter.Team{ID: arsenalID, Name: "Arsenal"}},
+		{Team: seasonTransporter.Team{ID: chelseaID, Name: "Chelsea"}},
+		{Team: seasonTransporter.Team{ID: evertonID, Name: "Everton"}},
+	}
+	matches := []matchTransporter.GetSeasonMatches{
+		{Match: matchTransporter.Match{HomeTeamID: arsenalID, AwayTeamID: chelseaID}},
+		{Match: matchTransporter.Match{HomeTeamID: chelseaID, AwayTeamID: evertonID}},
+		{Match: matchTransporter.Match{HomeTeamID: evertonID, AwayTeamID: arsenalID}},
+	}
+
+	amongTeams, amongMatches := among([]uuid.UUID{arsenalID, evertonID}, teams, matches)
+
+	require.Len(t, amongTeams, 2)
+	require.Equal(t, "Arsenal", amongTeams[0].Name)
+	require.Equal(t, "Everton", amongTeams[1].Name)
+	require.Len(t, amongMatches, 1)
+	require.Equal(t, evertonID, amongMatches[0].HomeTeamID)
+}
+
+// TestFairPlay ...
+func TestFairPlay(t *testing.T) {
+	home, away := uuid.NewV4(), uuid.NewV4()
+	finished, live := uuid.NewV4(), uuid.NewV4()
+	card := func(matchID, teamID uuid.UUID, eventType string) matchEventTransporter.GetSeasonMatchEvents {
+		return matchEventTransporter.GetSeasonMatchEvents{MatchEvent: matchEventTransporter.MatchEvent{
+			MatchID: matchID,
+			TeamID:  teamID,
+			Type:    eventType,
+		}}
+	}
+
+	penalties := fairPlay(
+		[]matchTransporter.GetSeasonMatches{{Match: matchTransporter.Match{ID: finished}}},
+		[]matchEventTransporter.GetSeasonMatchEvents{
+			card(finished, home, model.MatchEventTypeYellowCard),
+			card(finished, home, model.MatchEventTypeSecondYellow),
+			card(finished, away, model.MatchEventTypeRedCard),
+			card(live, away, model.MatchEventTypeRedCard),
+		},
+	)
+
+	require.Equal(t, 4, penalties[home])
+	require.Equal(t, 4, penalties[away])
+}
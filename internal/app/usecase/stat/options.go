@@ -0,0 +1,43 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	"github.com/harunnryd/skeltun/internal/app/usecase/leaderboard"
+	"github.com/harunnryd/skeltun/internal/pkg"
+)
+
+// Option is a closure that is used for accessing the local variables.
+type Option func(stat *Stat)
+
+// WithConfig ...
+func WithConfig(config config.IConfig) Option {
+	return func(stat *Stat) {
+		stat.config = config
+	}
+}
+
+// WithRepo ...
+func WithRepo(repo repo.IRepo) Option {
+	return func(stat *Stat) {
+		stat.repo = repo
+	}
+}
+
+// WithPkg ...
+func WithPkg(pkg pkg.IPkg) Option {
+	return func(stat *Stat) {
+		stat.pkg = pkg
+	}
+}
+
+// WithLeaderboard ...
+func WithLeaderboard(leaderboard leaderboard.ILeaderboard) Option {
+	return func(stat *Stat) {
+		stat.leaderboard = leaderboard
+	}
+}
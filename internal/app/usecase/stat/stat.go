@@ -0,0 +1,300 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+
+	"github.com/harunnryd/skeltun/config"
+	leaderboardParam "github.com/harunnryd/skeltun/internal/app/handler/leaderboard/param"
+	matchParam "github.com/harunnryd/skeltun/internal/app/handler/match/param"
+	playerParam "github.com/harunnryd/skeltun/internal/app/handler/player/param"
+	seasonParam "github.com/harunnryd/skeltun/internal/app/handler/season/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/stat/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/stat/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	"github.com/harunnryd/skeltun/internal/app/usecase/leaderboard"
+	"github.com/harunnryd/skeltun/internal/pkg"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+const (
+	// regularTimeLength is the number of minutes in regular time.
+	regularTimeLength = 90
+	// extraTimeLength is the number of minutes in both periods of extra time together.
+	extraTimeLength = 30
+)
+
+// IStat is an interface that stores the methods that Stat struct will use.
+type IStat interface {
+	// DoRefresh is used for rebuilding the player stats of the season a finished match belongs to.
+	// It returns doRefreshResp of transporter.DoRefresh and any errors written.
+	DoRefresh(ctx context.Context, params param.DoRefresh) (doRefreshResp transporter.DoRefresh, err error)
+
+	// GetPlayerStats is used for getting the stats of a player over a season.
+	// It returns getPlayerStatsResp of transporter.GetPlayerStats and any errors written.
+	GetPlayerStats(ctx context.Context, params param.GetPlayerStats) (getPlayerStatsResp transporter.GetPlayerStats, err error)
+}
+
+// Stat is an struct that implements IStat methods.
+type Stat struct {
+	config      config.IConfig
+	repo        repo.IRepo
+	pkg         pkg.IPkg
+	leaderboard leaderboard.ILeaderboard
+}
+
+// New it returns instance of Stat that implements IStat methods.
+func New(opts ...Option) IStat {
+	s := new(Stat)
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// DoRefresh is used for rebuilding the player stats of the season a finished match belongs to.
+// It is queued when a match finishes and again when an event of a finished match is recorded or deleted.
+// Every finished match of the season is tallied again, so corrected events are picked up as well.
+// Friendlies do not belong to a season and are left out.
+// The cached leaderboards of the season are dropped once the stats are rebuilt.
+// It returns doRefreshResp of transporter.DoRefresh and any errors written.
+func (stat *Stat) DoRefresh(ctx context.Context, params param.DoRefresh) (doRefreshResp transporter.DoRefresh, err error) {
+	getMatchResp, err := stat.repo.GetMatch().GetMatch(ctx, matchParam.GetMatch{ID: params.MatchID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getMatchResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("match not found")}
+		return
+	}
+
+	if getMatchResp.SeasonID == nil {
+		return
+	}
+
+	params.SeasonID = *getMatchResp.SeasonID
+
+	getMatchesResp, err := stat.repo.GetStat().GetMatches(ctx, param.GetMatches{SeasonID: params.SeasonID})
+	if err != nil {
+		return
+	}
+
+	if len(getMatchesResp) > 0 {
+		matchIDs := make([]uuid.UUID, 0, len(getMatchesResp))
+		for _, match := range getMatchesResp {
+			matchIDs = append(matchIDs, match.ID)
+		}
+
+		getLineupPlayersResp, err := stat.repo.GetStat().GetLineupPlayers(ctx, param.GetLineupPlayers{MatchIDs: matchIDs})
+		if err != nil {
+			return doRefreshResp, err
+		}
+
+		getMatchEventsResp, err := stat.repo.GetStat().GetMatchEvents(ctx, param.GetMatchEvents{MatchIDs: matchIDs})
+		if err != nil {
+			return doRefreshResp, err
+		}
+
+		params.Stats = tally(getMatchesResp, getLineupPlayersResp, getMatchEventsResp)
+	}
+
+	doRefreshResp, err = stat.repo.GetStat().DoRefresh(ctx, params)
+	if err != nil {
+		return
+	}
+
+	// The stats are in by now, so a Redis failure is no reason to fail the refresh:
+	// the cached leaderboards expire on their own.
+	if _, invalidateErr := stat.leaderboard.DoInvalidate(ctx, leaderboardParam.DoInvalidate{SeasonID: params.SeasonID}); invalidateErr != nil {
+		log.Printf("could not drop the cached leaderboards of season %s: %v", params.SeasonID, invalidateErr)
+	}
+
+	return
+}
+
+// GetPlayerStats is used for getting the stats of a player over a season.
+// A player who has not played in the season gets every stat at zero.
+// It returns getPlayerStatsResp of transporter.GetPlayerStats and any errors written.
+func (stat *Stat) GetPlayerStats(ctx context.Context, params param.GetPlayerStats) (getPlayerStatsResp transporter.GetPlayerStats, err error) {
+	getPlayerResp, err := stat.repo.GetPlayer().GetPlayer(ctx, playerParam.GetPlayer{ID: params.PlayerID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getPlayerResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("player not found")}
+		return
+	}
+
+	getSeasonResp, err := stat.repo.GetSeason().GetSeason(ctx, seasonParam.GetSeason{ID: params.SeasonID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getSeasonResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("season not found")}
+		return
+	}
+
+	getPlayerStatsResp, err = stat.repo.GetStat().GetPlayerStats(ctx, params)
+	if err != nil {
+		return
+	}
+
+	getPlayerStatsResp.SeasonID = params.SeasonID
+	getPlayerStatsResp.PlayerID = params.PlayerID
+
+	return
+}
+
+// tally is used for adding up the stats of every player over the given matches.
+// Appearances, minutes and clean sheets come from the team sheets and the substitutions,
+// goals, assists and cards from the rest of the events.
+// A clean sheet goes to every player of a team that did not concede who played at least
+// model.PlayerStatCleanSheetMinutes of the match.
+func tally(matches []transporter.GetMatches, lineupPlayers []transporter.GetLineupPlayers, events []transporter.GetMatchEvents) (stats []param.Stat) {
+	totals := make(map[uuid.UUID]*param.Stat)
+	total := func(playerID uuid.UUID) *param.Stat {
+		if _, ok := totals[playerID]; !ok {
+			totals[playerID] = &param.Stat{PlayerID: playerID}
+		}
+		return totals[playerID]
+	}
+
+	for _, match := range matches {
+		var matchLineupPlayers []transporter.GetLineupPlayers
+		for _, lineupPlayer := range lineupPlayers {
+			if uuid.Equal(lineupPlayer.MatchID, match.ID) {
+				matchLineupPlayers = append(matchLineupPlayers, lineupPlayer)
+			}
+		}
+
+		var matchEvents []transporter.GetMatchEvents
+		for _, event := range events {
+			if uuid.Equal(event.MatchID, match.ID) {
+				matchEvents = append(matchEvents, event)
+			}
+		}
+
+		conceded := map[uuid.UUID]int{
+			match.HomeTeamID: match.AwayScore + match.AwayExtraTimeScore,
+			match.AwayTeamID: match.HomeScore + match.HomeExtraTimeScore,
+		}
+
+		for playerID, played := range minutes(match, matchLineupPlayers, matchEvents) {
+			playerStat := total(playerID)
+			playerStat.Appearances++
+			playerStat.MinutesPlayed += played.minutes
+
+			if played.starter {
+				playerStat.Starts++
+			}
+
+			if conceded[played.teamID] == 0 && played.minutes >= model.PlayerStatCleanSheetMinutes {
+				playerStat.CleanSheets++
+			}
+		}
+
+		for _, event := range matchEvents {
+			switch event.Type {
+			case model.MatchEventTypeGoal, model.MatchEventTypePenalty:
+				total(event.PlayerID).Goals++
+				if event.Type == model.MatchEventTypeGoal && event.RelatedPlayerID != nil {
+					total(*event.RelatedPlayerID).Assists++
+				}
+			case model.MatchEventTypeYellowCard:
+				total(event.PlayerID).YellowCards++
+			case model.MatchEventTypeSecondYellow, model.MatchEventTypeRedCard:
+				total(event.PlayerID).RedCards++
+			}
+		}
+	}
+
+	stats = make([]param.Stat, 0, len(totals))
+	for _, playerStat := range totals {
+		stats = append(stats, *playerStat)
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].PlayerID.String() < stats[j].PlayerID.String()
+	})
+
+	return
+}
+
+// appearance is the part a player took in a single match.
+type appearance struct {
+	teamID  uuid.UUID
+	starter bool
+	minutes int
+}
+
+// minutes is used for working out how long every player was on the pitch in a match.
+// Starters come on at kickoff and substitutes at the minute of their substitution, both of them
+// stay on until they are taken off, sent off or the final whistle.
+// Teams without a team sheet only count the substitutes coming on.
+func minutes(match transporter.GetMatches, lineupPlayers []transporter.GetLineupPlayers, events []transporter.GetMatchEvents) map[uuid.UUID]appearance {
+	length := regularTimeLength
+	if match.ExtraTime {
+		length += extraTimeLength
+	}
+
+	clamp := func(minute int) int {
+		if minute > length {
+			return length
+		}
+		return minute
+	}
+
+	appearances := make(map[uuid.UUID]appearance)
+	cameOn := make(map[uuid.UUID]int)
+	wentOff := make(map[uuid.UUID]int)
+
+	for _, lineupPlayer := range lineupPlayers {
+		if lineupPlayer.Starter {
+			appearances[lineupPlayer.PlayerID] = appearance{teamID: lineupPlayer.TeamID, starter: true}
+			cameOn[lineupPlayer.PlayerID] = 0
+		}
+	}
+
+	for _, event := range events {
+		switch event.Type {
+		case model.MatchEventTypeSubstitution:
+			if _, ok := wentOff[event.PlayerID]; !ok {
+				wentOff[event.PlayerID] = clamp(event.Minute)
+			}
+
+			if event.RelatedPlayerID != nil {
+				if _, ok := appearances[*event.RelatedPlayerID]; !ok {
+					appearances[*event.RelatedPlayerID] = appearance{teamID: event.TeamID}
+					cameOn[*event.RelatedPlayerID] = clamp(event.Minute)
+				}
+			}
+		case model.MatchEventTypeSecondYellow, model.MatchEventTypeRedCard:
+			if _, ok := wentOff[event.PlayerID]; !ok {
+				wentOff[event.PlayerID] = clamp(event.Minute)
+			}
+		}
+	}
+
+	for playerID, played := range appearances {
+		off, ok := wentOff[playerID]
+		if !ok || off < cameOn[playerID] {
+			off = length
+		}
+
+		played.minutes = off - cameOn[playerID]
+		appearances[playerID] = played
+	}
+
+	return appearances
+}
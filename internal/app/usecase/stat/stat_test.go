@@ -0,0 +1,357 @@
+package stat
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/handler/stat/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/stat/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	iMatchRepo "github.com/harunnryd/skeltun/internal/app/repo/match"
+	iPlayerRepo "github.com/harunnryd/skeltun/internal/app/repo/player"
+	iSeasonRepo "github.com/harunnryd/skeltun/internal/app/repo/season"
+	iStatRepo "github.com/harunnryd/skeltun/internal/app/repo/stat"
+	"github.com/harunnryd/skeltun/internal/app/usecase/leaderboard"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	mock      sqlmock.Sqlmock
+	pgsqlConn *gorm.DB
+
+	iStatRepo   iStatRepo.IStat
+	iMatchRepo  iMatchRepo.IMatch
+	iPlayerRepo iPlayerRepo.IPlayer
+	iSeasonRepo iSeasonRepo.ISeason
+	iRepo       repo.IRepo
+	stat        IStat
+	helper
+	response
+}
+
+type helper struct {
+	err error
+	db  *sql.DB
+}
+
+type response struct {
+	doRefreshResp      transporter.DoRefresh
+	getPlayerStatsResp transporter.GetPlayerStats
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.iStatRepo = iStatRepo.New(
+		iStatRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iMatchRepo = iMatchRepo.New(
+		iMatchRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iPlayerRepo = iPlayerRepo.New(
+		iPlayerRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iSeasonRepo = iSeasonRepo.New(
+		iSeasonRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iRepo = repo.New()
+	suite.iRepo.SetStat(suite.iStatRepo)
+	suite.iRepo.SetMatch(suite.iMatchRepo)
+	suite.iRepo.SetPlayer(suite.iPlayerRepo)
+	suite.iRepo.SetSeason(suite.iSeasonRepo)
+
+	suite.stat = New(
+		WithRepo(suite.iRepo),
+		WithLeaderboard(leaderboard.New(leaderboard.WithRepo(suite.iRepo))),
+	)
+}
+
+// TestDoRefresh ...
+func (suite *Suite) TestDoRefresh() {
+	seasonID, homeTeamID, awayTeamID := uuid.NewV4(), uuid.NewV4(), uuid.NewV4()
+	goalkeeperID, strikerID := uuid.NewV4(), uuid.NewV4()
+	params := param.DoRefresh{
+		MatchID: uuid.NewV4(),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "season_id", "status"}).
+			AddRow(params.MatchID, seasonID, model.MatchStatusFinished))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT *, EXISTS (SELECT 1 FROM match_transitions`)).
+		WithArgs(model.MatchStatusExtraTime, seasonID, model.MatchStatusFinished).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "home_team_id", "away_team_id", "home_score", "away_score", "extra_time"}).
+			AddRow(params.MatchID, homeTeamID, awayTeamID, 1, 0, false))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "lineup_players" WHERE match_id IN ($1)`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"match_id", "team_id", "player_id", "starter"}).
+			AddRow(params.MatchID, homeTeamID, goalkeeperID, true).
+			AddRow(params.MatchID, homeTeamID, strikerID, true))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "match_events" WHERE match_id IN ($1) AND deleted_at IS NULL ORDER BY minute, created_at`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"match_id", "team_id", "player_id", "type", "minute"}).
+			AddRow(params.MatchID, homeTeamID, strikerID, model.MatchEventTypeGoal, 23))
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`DELETE FROM "player_stats" WHERE season_id = $1`)).
+		WithArgs(seasonID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`INSERT INTO "player_stats"`)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	suite.mock.ExpectCommit()
+
+	suite.response.doRefreshResp, suite.helper.err = suite.stat.DoRefresh(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), seasonID, suite.response.doRefreshResp.SeasonID)
+	require.Equal(suite.T(), 2, suite.response.doRefreshResp.Players)
+}
+
+// TestDoRefreshFriendly ...
+func (suite *Suite) TestDoRefreshFriendly() {
+	params := param.DoRefresh{
+		MatchID: uuid.NewV4(),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).
+			AddRow(params.MatchID, model.MatchStatusFinished))
+
+	suite.response.doRefreshResp, suite.helper.err = suite.stat.DoRefresh(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), 0, suite.response.doRefreshResp.Players)
+}
+
+// TestDoRefreshMatchNotFound ...
+func (suite *Suite) TestDoRefreshMatchNotFound() {
+	params := param.DoRefresh{
+		MatchID: uuid.NewV4(),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.response.doRefreshResp, suite.helper.err = suite.stat.DoRefresh(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "match not found")
+}
+
+// TestGetPlayerStats ...
+func (suite *Suite) TestGetPlayerStats() {
+	params := param.GetPlayerStats{
+		PlayerID: uuid.NewV4(),
+		SeasonID: uuid.NewV4(),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.PlayerID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(params.PlayerID, "John Doe"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seasons" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(params.SeasonID, "2021/22"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "player_stats" WHERE season_id = $1 AND player_id = $2 LIMIT 1`)).
+		WithArgs(params.SeasonID, params.PlayerID).
+		WillReturnRows(sqlmock.NewRows([]string{"season_id", "player_id"}))
+
+	suite.response.getPlayerStatsResp, suite.helper.err = suite.stat.GetPlayerStats(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), params.PlayerID, suite.response.getPlayerStatsResp.PlayerID)
+	require.Equal(suite.T(), params.SeasonID, suite.response.getPlayerStatsResp.SeasonID)
+	require.Equal(suite.T(), 0, suite.response.getPlayerStatsResp.Appearances)
+}
+
+// TestGetPlayerStatsPlayerNotFound ...
+func (suite *Suite) TestGetPlayerStatsPlayerNotFound() {
+	params := param.GetPlayerStats{
+		PlayerID: uuid.NewV4(),
+		SeasonID: uuid.NewV4(),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.PlayerID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.response.getPlayerStatsResp, suite.helper.err = suite.stat.GetPlayerStats(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "player not found")
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+// TestMinutes ...
+func TestMinutes(t *testing.T) {
+	teamID := uuid.NewV4()
+	starterID, substituteID, sentOffID := uuid.NewV4(), uuid.NewV4(), uuid.NewV4()
+
+	lineupPlayers := []transporter.GetLineupPlayers{
+		{TeamID: teamID, PlayerID: starterID, Starter: true},
+		{TeamID: teamID, PlayerID: sentOffID, Starter: true},
+		{TeamID: teamID, PlayerID: substituteID},
+	}
+
+	event := func(playerID uuid.UUID, eventType string, minute int, relatedPlayerID *uuid.UUID) transporter.GetMatchEvents {
+		return transporter.GetMatchEvents{TeamID: teamID, PlayerID: playerID, Type: eventType, Minute: minute, RelatedPlayerID: relatedPlayerID}
+	}
+
+	tests := []struct {
+		name      string
+		extraTime bool
+		events    []transporter.GetMatchEvents
+		want      map[uuid.UUID]int
+	}{
+		{
+			name: "starters play the whole match",
+			want: map[uuid.UUID]int{starterID: 90, sentOffID: 90},
+		},
+		{
+			name:      "starters play extra time too",
+			extraTime: true,
+			want:      map[uuid.UUID]int{starterID: 120, sentOffID: 120},
+		},
+		{
+			name: "substitution",
+			events: []transporter.GetMatchEvents{
+				event(starterID, model.MatchEventTypeSubstitution, 70, &substituteID),
+			},
+			want: map[uuid.UUID]int{starterID: 70, substituteID: 20, sentOffID: 90},
+		},
+		{
+			name: "sending off",
+			events: []transporter.GetMatchEvents{
+				event(sentOffID, model.MatchEventTypeYellowCard, 12, nil),
+				event(sentOffID, model.MatchEventTypeSecondYellow, 55, nil),
+			},
+			want: map[uuid.UUID]int{starterID: 90, sentOffID: 55},
+		},
+		{
+			name: "substitute sent off in stoppage time",
+			events: []transporter.GetMatchEvents{
+				event(starterID, model.MatchEventTypeSubstitution, 80, &substituteID),
+				event(substituteID, model.MatchEventTypeRedCard, 93, nil),
+			},
+			want: map[uuid.UUID]int{starterID: 80, substituteID: 10, sentOffID: 90},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appearances := minutes(transporter.GetMatches{ExtraTime: tt.extraTime}, lineupPlayers, tt.events)
+			require.Len(t, appearances, len(tt.want))
+			for playerID, want := range tt.want {
+				require.Equal(t, want, appearances[playerID].minutes)
+			}
+		})
+	}
+}
+
+// TestTally ...
+func TestTally(t *testing.T) {
+	homeTeamID, awayTeamID := uuid.NewV4(), uuid.NewV4()
+	goalkeeperID, strikerID, substituteID := uuid.NewV4(), uuid.NewV4(), uuid.NewV4()
+	firstMatchID, secondMatchID := uuid.NewV4(), uuid.NewV4()
+
+	matches := []transporter.GetMatches{
+		{ID: firstMatchID, HomeTeamID: homeTeamID, AwayTeamID: awayTeamID, HomeScore: 2},
+		{ID: secondMatchID, HomeTeamID: awayTeamID, AwayTeamID: homeTeamID, HomeScore: 1, AwayScore: 1},
+	}
+
+	lineupPlayers := []transporter.GetLineupPlayers{
+		{MatchID: firstMatchID, TeamID: homeTeamID, PlayerID: goalkeeperID, Starter: true},
+		{MatchID: firstMatchID, TeamID: homeTeamID, PlayerID: strikerID, Starter: true},
+		{MatchID: firstMatchID, TeamID: homeTeamID, PlayerID: substituteID},
+		{MatchID: secondMatchID, TeamID: homeTeamID, PlayerID: goalkeeperID, Starter: true},
+		{MatchID: secondMatchID, TeamID: homeTeamID, PlayerID: substituteID, Starter: true},
+	}
+
+	events := []transporter.GetMatchEvents{
+		{MatchID: firstMatchID, TeamID: homeTeamID, PlayerID: strikerID, Type: model.MatchEventTypeGoal, Minute: 10},
+		{MatchID: firstMatchID, TeamID: homeTeamID, PlayerID: strikerID, Type: model.MatchEventTypeYellowCard, Minute: 40},
+		{MatchID: firstMatchID, TeamID: homeTeamID, PlayerID: strikerID, Type: model.MatchEventTypeSubstitution, Minute: 75, RelatedPlayerID: &substituteID},
+		{MatchID: firstMatchID, TeamID: homeTeamID, PlayerID: substituteID, Type: model.MatchEventTypeGoal, Minute: 88, RelatedPlayerID: &goalkeeperID},
+		{MatchID: secondMatchID, TeamID: homeTeamID, PlayerID: substituteID, Type: model.MatchEventTypePenalty, Minute: 30},
+	}
+
+	stats := make(map[uuid.UUID]param.Stat)
+	for _, playerStat := range tally(matches, lineupPlayers, events) {
+		stats[playerStat.PlayerID] = playerStat
+	}
+
+	require.Len(t, stats, 3)
+	require.Equal(t, param.Stat{
+		PlayerID: goalkeeperID, Appearances: 2, Starts: 2, MinutesPlayed: 180, Assists: 1, CleanSheets: 1,
+	}, stats[goalkeeperID])
+	require.Equal(t, param.Stat{
+		PlayerID: strikerID, Appearances: 1, Starts: 1, MinutesPlayed: 75, Goals: 1, YellowCards: 1, CleanSheets: 1,
+	}, stats[strikerID])
+	require.Equal(t, param.Stat{
+		PlayerID: substituteID, Appearances: 2, Starts: 1, MinutesPlayed: 105, Goals: 2,
+	}, stats[substituteID])
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package suspension
+
+import (
+	"context"
+	"errors"
+
+	"github.com/harunnryd/skeltun/config"
+	matchParam "github.com/harunnryd/skeltun/internal/app/handler/match/param"
+	playerParam "github.com/harunnryd/skeltun/internal/app/handler/player/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/suspension/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/suspension/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	"github.com/harunnryd/skeltun/internal/pkg"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// ISuspension is an interface that stores the methods that Suspension struct will use.
+type ISuspension interface {
+	// GetSuspensions is used for getting all suspensions of a player, the latest first.
+	// It returns getSuspensionsResp of []transporter.GetSuspensions and any errors written.
+	GetSuspensions(ctx context.Context, params param.GetSuspensions) (getSuspensionsResp []transporter.GetSuspensions, err error)
+
+	// GetPenalty is used for working out the suspension a card about to be shown to a player calls for, if any.
+	// It returns getPenaltyResp of transporter.GetPenalty and any errors written.
+	GetPenalty(ctx context.Context, params param.GetPenalty) (getPenaltyResp transporter.GetPenalty, err error)
+
+	// DoServe is used for counting a finished match down from the suspensions of the players of both teams.
+	// It returns doServeResp of transporter.DoServe and any errors written.
+	DoServe(ctx context.Context, params param.DoServe) (doServeResp transporter.DoServe, err error)
+}
+
+// Suspension is an struct that implements ISuspension methods.
+type Suspension struct {
+	config config.IConfig
+	repo   repo.IRepo
+	pkg    pkg.IPkg
+}
+
+// New it returns instance of Suspension that implements ISuspension methods.
+func New(opts ...Option) ISuspension {
+	s := new(Suspension)
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// GetSuspensions is used for getting all suspensions of a player, the latest first.
+// It returns getSuspensionsResp of []transporter.GetSuspensions and any errors written.
+func (suspension *Suspension) GetSuspensions(ctx context.Context, params param.GetSuspensions) (getSuspensionsResp []transporter.GetSuspensions, err error) {
+	getPlayerResp, err := suspension.repo.GetPlayer().GetPlayer(ctx, playerParam.GetPlayer{ID: params.PlayerID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getPlayerResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("player not found")}
+		return
+	}
+
+	getSuspensionsResp, err = suspension.repo.GetSuspension().GetSuspensions(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// GetPenalty is used for working out the suspension a card about to be shown to a player calls for, if any.
+// A red card or a second yellow always does, a yellow card only when it takes the yellow cards of the player
+// over the season to a multiple of the configured threshold. Matches outside of a season are not penalised.
+// The card is not recorded yet, so the suspension can be recorded along with it; an empty reason means none.
+// It returns getPenaltyResp of transporter.GetPenalty and any errors written.
+func (suspension *Suspension) GetPenalty(ctx context.Context, params param.GetPenalty) (getPenaltyResp transporter.GetPenalty, err error) {
+	if params.SeasonID == nil {
+		return
+	}
+
+	var reason string
+	switch params.Type {
+	case model.MatchEventTypeRedCard:
+		reason = model.SuspensionReasonRedCard
+	case model.MatchEventTypeSecondYellow:
+		reason = model.SuspensionReasonSecondYellow
+	case model.MatchEventTypeYellowCard:
+		threshold := suspension.config.GetInt("suspensions.yellow_cards.threshold")
+		if threshold <= 0 {
+			return
+		}
+
+		countYellowCardsResp, err := suspension.repo.GetSuspension().CountYellowCards(ctx, param.CountYellowCards{
+			PlayerID: params.PlayerID,
+			SeasonID: *params.SeasonID,
+		})
+		if err != nil {
+			return getPenaltyResp, err
+		}
+
+		// The card about to be shown counts towards the threshold as well.
+		if (countYellowCardsResp.Total+1)%int64(threshold) != 0 {
+			return getPenaltyResp, nil
+		}
+
+		reason = model.SuspensionReasonYellowCards
+	default:
+		return
+	}
+
+	getPenaltyResp = transporter.GetPenalty{
+		Reason:           reason,
+		MatchesRemaining: suspension.matches(reason),
+	}
+
+	return
+}
+
+// DoServe is used for counting a finished match down from the suspensions of the players of both teams.
+// Only suspensions picked up in the season of the match are served by it.
+// It returns doServeResp of transporter.DoServe and any errors written.
+func (suspension *Suspension) DoServe(ctx context.Context, params param.DoServe) (doServeResp transporter.DoServe, err error) {
+	getMatchResp, err := suspension.repo.GetMatch().GetMatch(ctx, matchParam.GetMatch{ID: params.MatchID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getMatchResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("match not found")}
+		return
+	}
+
+	if getMatchResp.Status != model.MatchStatusFinished || getMatchResp.SeasonID == nil {
+		return
+	}
+
+	params.SeasonID = *getMatchResp.SeasonID
+	params.TeamIDs = []uuid.UUID{getMatchResp.HomeTeamID, getMatchResp.AwayTeamID}
+
+	doServeResp, err = suspension.repo.GetSuspension().DoServe(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// matches is used for getting how many matches a suspension for the given reason lasts, one unless configured otherwise.
+func (suspension *Suspension) matches(reason string) int {
+	if matches := suspension.config.GetInt("suspensions." + reason + ".matches"); matches > 0 {
+		return matches
+	}
+	return 1
+}
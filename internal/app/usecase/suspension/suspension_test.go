@@ -0,0 +1,224 @@
+package suspension
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/config/configtest"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/handler/suspension/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/suspension/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	iMatchRepo "github.com/harunnryd/skeltun/internal/app/repo/match"
+	iPlayerRepo "github.com/harunnryd/skeltun/internal/app/repo/player"
+	iSuspensionRepo "github.com/harunnryd/skeltun/internal/app/repo/suspension"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	mock      sqlmock.Sqlmock
+	pgsqlConn *gorm.DB
+
+	iMatchRepo      iMatchRepo.IMatch
+	iPlayerRepo     iPlayerRepo.IPlayer
+	iSuspensionRepo iSuspensionRepo.ISuspension
+	iRepo           repo.IRepo
+	suspension      ISuspension
+	helper
+	response
+}
+
+type helper struct {
+	err error
+	db  *sql.DB
+}
+
+type response struct {
+	getPenaltyResp transporter.GetPenalty
+	doServeResp    transporter.DoServe
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.iMatchRepo = iMatchRepo.New(
+		iMatchRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iPlayerRepo = iPlayerRepo.New(
+		iPlayerRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iSuspensionRepo = iSuspensionRepo.New(
+		iSuspensionRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iRepo = repo.New()
+	suite.iRepo.SetMatch(suite.iMatchRepo)
+	suite.iRepo.SetPlayer(suite.iPlayerRepo)
+	suite.iRepo.SetSuspension(suite.iSuspensionRepo)
+
+	suite.suspension = New(WithConfig(configtest.Fake{
+		"suspensions.yellow_cards.threshold": 5,
+		"suspensions.red_card.matches":       3,
+	}), WithRepo(suite.iRepo))
+}
+
+// penaltyParams returns a card of the given type about to be shown in a match of a season.
+func penaltyParams(cardType string) param.GetPenalty {
+	seasonID := uuid.NewV4()
+	return param.GetPenalty{
+		SeasonID: &seasonID,
+		PlayerID: uuid.NewV4(),
+		Type:     cardType,
+	}
+}
+
+// TestGetPenaltyRedCard ...
+func (suite *Suite) TestGetPenaltyRedCard() {
+	params := penaltyParams(model.MatchEventTypeRedCard)
+
+	suite.response.getPenaltyResp, suite.helper.err = suite.suspension.GetPenalty(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), model.SuspensionReasonRedCard, suite.response.getPenaltyResp.Reason)
+	require.Equal(suite.T(), 3, suite.response.getPenaltyResp.MatchesRemaining)
+}
+
+// TestGetPenaltySecondYellow ...
+func (suite *Suite) TestGetPenaltySecondYellow() {
+	params := penaltyParams(model.MatchEventTypeSecondYellow)
+
+	suite.response.getPenaltyResp, suite.helper.err = suite.suspension.GetPenalty(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), 1, suite.response.getPenaltyResp.MatchesRemaining)
+}
+
+// TestGetPenaltyYellowCards ...
+func (suite *Suite) TestGetPenaltyYellowCards() {
+	params := penaltyParams(model.MatchEventTypeYellowCard)
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) AS total FROM "match_events"`)).
+		WithArgs(*params.SeasonID, params.PlayerID, model.MatchEventTypeYellowCard).
+		WillReturnRows(sqlmock.NewRows([]string{"total"}).
+			AddRow(9))
+
+	suite.response.getPenaltyResp, suite.helper.err = suite.suspension.GetPenalty(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), model.SuspensionReasonYellowCards, suite.response.getPenaltyResp.Reason)
+}
+
+// TestGetPenaltyYellowCardsBelowThreshold ...
+func (suite *Suite) TestGetPenaltyYellowCardsBelowThreshold() {
+	params := penaltyParams(model.MatchEventTypeYellowCard)
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) AS total FROM "match_events"`)).
+		WithArgs(*params.SeasonID, params.PlayerID, model.MatchEventTypeYellowCard).
+		WillReturnRows(sqlmock.NewRows([]string{"total"}).
+			AddRow(5))
+
+	suite.response.getPenaltyResp, suite.helper.err = suite.suspension.GetPenalty(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Empty(suite.T(), suite.response.getPenaltyResp.Reason)
+}
+
+// TestGetPenaltyFriendly ...
+func (suite *Suite) TestGetPenaltyFriendly() {
+	params := penaltyParams(model.MatchEventTypeRedCard)
+	params.SeasonID = nil
+
+	suite.response.getPenaltyResp, suite.helper.err = suite.suspension.GetPenalty(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Empty(suite.T(), suite.response.getPenaltyResp.Reason)
+}
+
+// TestDoServe ...
+func (suite *Suite) TestDoServe() {
+	params := param.DoServe{MatchID: uuid.NewV4()}
+	seasonID, homeTeamID, awayTeamID := uuid.NewV4(), uuid.NewV4(), uuid.NewV4()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "season_id", "home_team_id", "away_team_id", "status"}).
+			AddRow(params.MatchID, seasonID, homeTeamID, awayTeamID, model.MatchStatusFinished))
+
+	suspensionID := uuid.NewV4()
+	suite.mock.ExpectBegin()
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO suspension_matches`)).
+		WithArgs(params.MatchID, sqlmock.AnyArg(), seasonID, params.MatchID, homeTeamID, awayTeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"suspension_id"}).
+			AddRow(suspensionID))
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "suspensions"`)).
+		WithArgs(params.MatchID, sqlmock.AnyArg(), suspensionID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mock.ExpectCommit()
+
+	suite.response.doServeResp, suite.helper.err = suite.suspension.DoServe(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), int64(1), suite.response.doServeResp.Served)
+}
+
+// TestDoServeNotFinished ...
+func (suite *Suite) TestDoServeNotFinished() {
+	params := param.DoServe{MatchID: uuid.NewV4()}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.MatchID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "season_id", "home_team_id", "away_team_id", "status"}).
+			AddRow(params.MatchID, uuid.NewV4(), uuid.NewV4(), uuid.NewV4(), model.MatchStatusSecondHalf))
+
+	suite.response.doServeResp, suite.helper.err = suite.suspension.DoServe(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), int64(0), suite.response.doServeResp.Served)
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
@@ -6,12 +6,16 @@ package team
 
 import (
 	"context"
+	"errors"
 
 	"github.com/harunnryd/skeltun/config"
 	"github.com/harunnryd/skeltun/internal/app/handler/team/param"
 	"github.com/harunnryd/skeltun/internal/app/handler/team/transporter"
+	venueParam "github.com/harunnryd/skeltun/internal/app/handler/venue/param"
 	"github.com/harunnryd/skeltun/internal/app/repo"
 	"github.com/harunnryd/skeltun/internal/pkg"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
 )
 
 // ITeam is an interface that stores the methods that Team struct will use.
@@ -56,6 +60,10 @@ func New(opts ...Option) ITeam {
 // DoCreate is used for record new team.
 // It returns doCreateResp of transporter.DoCreate and any errors written.
 func (team *Team) DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error) {
+	if err = team.checkVenue(ctx, params.VenueID); err != nil {
+		return
+	}
+
 	doCreateResp, err = team.repo.GetTeam().DoCreate(ctx, params)
 	if err != nil {
 		return
@@ -78,6 +86,10 @@ func (team *Team) GetTeams(ctx context.Context, params param.GetTeams) (getTeams
 // DoCreate is used for update the record team.
 // It returns doUpdateResp of transporter.DoUpdate and any errors written.
 func (team *Team) DoUpdate(ctx context.Context, params param.DoUpdate) (doUpdateResp transporter.DoUpdate, err error) {
+	if err = team.checkVenue(ctx, params.VenueID); err != nil {
+		return
+	}
+
 	doUpdateResp, err = team.repo.GetTeam().DoUpdate(ctx, params)
 	if err != nil {
 		return
@@ -107,3 +119,24 @@ func (team *Team) GetTeam(ctx context.Context, params param.GetTeam) (getTeamRes
 
 	return
 }
+
+// checkVenue is used for making sure the home ground of a team exists.
+// Teams without a home ground are not checked.
+// It returns any errors written.
+func (team *Team) checkVenue(ctx context.Context, venueID *uuid.UUID) (err error) {
+	if venueID == nil {
+		return
+	}
+
+	getVenueResp, err := team.repo.GetVenue().GetVenue(ctx, venueParam.GetVenue{ID: *venueID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getVenueResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("venue not found")}
+		return
+	}
+
+	return
+}
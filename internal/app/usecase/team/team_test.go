@@ -14,6 +14,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/harunnryd/skeltun/internal/app/driver/db"
 	iTeamRepo "github.com/harunnryd/skeltun/internal/app/repo/team"
+	iVenueRepo "github.com/harunnryd/skeltun/internal/app/repo/venue"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"gorm.io/driver/postgres"
@@ -26,9 +27,10 @@ type Suite struct {
 	mock      sqlmock.Sqlmock
 	pgsqlConn *gorm.DB
 
-	iTeamRepo iTeamRepo.ITeam
-	iRepo     repo.IRepo
-	team      ITeam
+	iTeamRepo  iTeamRepo.ITeam
+	iVenueRepo iVenueRepo.IVenue
+	iRepo      repo.IRepo
+	team       ITeam
 	helper
 	response
 }
@@ -70,8 +72,13 @@ func (suite *Suite) SetupSuite() {
 		iTeamRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
 	)
 
+	suite.iVenueRepo = iVenueRepo.New(
+		iVenueRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
 	suite.iRepo = repo.New()
 	suite.iRepo.SetTeam(suite.iTeamRepo)
+	suite.iRepo.SetVenue(suite.iVenueRepo)
 
 	suite.team = New(WithRepo(suite.iRepo))
 }
@@ -87,8 +94,8 @@ func (suite *Suite) TestDoCreate() {
 	suite.mock.MatchExpectationsInOrder(false)
 
 	suite.mock.
-		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "teams" ("created_at","updated_at","deleted_at","name") VALUES ($1,$2,$3,$4) RETURNING "id"`)).
-		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.Name).
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "teams" ("created_at","updated_at","deleted_at","name","association","venue_id") VALUES ($1,$2,$3,$4,$5,$6) RETURNING "id"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.Name, params.Association, nil).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).
 			AddRow(uuid.NewV4()))
 
@@ -99,6 +106,26 @@ func (suite *Suite) TestDoCreate() {
 	require.NotNil(suite.T(), suite.response.doCreateResp)
 }
 
+// TestDoCreateVenueNotFound ...
+func (suite *Suite) TestDoCreateVenueNotFound() {
+	venueID := uuid.NewV4()
+	params := param.DoCreate{
+		Team: param.Team{
+			Name:    "Arsenal",
+			VenueID: &venueID,
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`FROM "venues" WHERE id = $1 AND deleted_at IS NULL LIMIT 1`)).
+		WithArgs(venueID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.response.doCreateResp, suite.helper.err = suite.team.DoCreate(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "venue not found")
+}
+
 // TestGetTeams ...
 func (suite *Suite) TestGetTeams() {
 	suite.mock.
@@ -0,0 +1,51 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transfer
+
+import (
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	"github.com/harunnryd/skeltun/internal/app/usecase/contract"
+	"github.com/harunnryd/skeltun/internal/app/usecase/squad"
+	"github.com/harunnryd/skeltun/internal/pkg"
+)
+
+// Option is a closure that is used for accessing the local variables.
+type Option func(transfer *Transfer)
+
+// WithConfig ...
+func WithConfig(config config.IConfig) Option {
+	return func(transfer *Transfer) {
+		transfer.config = config
+	}
+}
+
+// WithRepo ...
+func WithRepo(repo repo.IRepo) Option {
+	return func(transfer *Transfer) {
+		transfer.repo = repo
+	}
+}
+
+// WithPkg ...
+func WithPkg(pkg pkg.IPkg) Option {
+	return func(transfer *Transfer) {
+		transfer.pkg = pkg
+	}
+}
+
+// WithContract ...
+func WithContract(contract contract.IContract) Option {
+	return func(transfer *Transfer) {
+		transfer.contract = contract
+	}
+}
+
+// WithSquad ...
+func WithSquad(squad squad.ISquad) Option {
+	return func(transfer *Transfer) {
+		transfer.squad = squad
+	}
+}
@@ -0,0 +1,299 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transfer
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/harunnryd/skeltun/config"
+	contractParam "github.com/harunnryd/skeltun/internal/app/handler/contract/param"
+	loanParam "github.com/harunnryd/skeltun/internal/app/handler/loan/param"
+	playerParam "github.com/harunnryd/skeltun/internal/app/handler/player/param"
+	seasonParam "github.com/harunnryd/skeltun/internal/app/handler/season/param"
+	squadParam "github.com/harunnryd/skeltun/internal/app/handler/squad/param"
+	teamParam "github.com/harunnryd/skeltun/internal/app/handler/team/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/transfer/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/transfer/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	"github.com/harunnryd/skeltun/internal/app/usecase/contract"
+	"github.com/harunnryd/skeltun/internal/app/usecase/squad"
+	"github.com/harunnryd/skeltun/internal/pkg"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// ITransfer is an interface that stores the methods that Transfer struct will use.
+type ITransfer interface {
+	// DoCreate is used for moving a player to another team.
+	// It returns doCreateResp of transporter.DoCreate and any errors written.
+	DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error)
+
+	// GetTransfers is used for getting all transfers of a player, the oldest first.
+	// It returns getTransfersResp of []transporter.GetTransfers and any errors written.
+	GetTransfers(ctx context.Context, params param.GetTransfers) (getTransfersResp []transporter.GetTransfers, err error)
+
+	// GetCareer is used for getting the club history of a player.
+	// It returns getCareerResp of transporter.GetCareer and any errors written.
+	GetCareer(ctx context.Context, params param.GetCareer) (getCareerResp transporter.GetCareer, err error)
+
+	// DoCreateWindow is used for record new transfer window of a season.
+	// It returns doCreateWindowResp of transporter.DoCreateWindow and any errors written.
+	DoCreateWindow(ctx context.Context, params param.DoCreateWindow) (doCreateWindowResp transporter.DoCreateWindow, err error)
+
+	// GetWindows is used for getting all transfer windows of a season.
+	// It returns getWindowsResp of []transporter.GetWindows and any errors written.
+	GetWindows(ctx context.Context, params param.GetWindows) (getWindowsResp []transporter.GetWindows, err error)
+}
+
+// Transfer is an struct that implements ITransfer methods.
+type Transfer struct {
+	config   config.IConfig
+	repo     repo.IRepo
+	pkg      pkg.IPkg
+	contract contract.IContract
+	squad    squad.ISquad
+}
+
+// New it returns instance of Transfer that implements ITransfer methods.
+func New(opts ...Option) ITransfer {
+	t := new(Transfer)
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// DoCreate is used for moving a player to another team.
+// The transfer is only accepted while a transfer window is open
+// in a season the new team is registered in. A player on loan
+// has to go back to the parent club, or be bought, before moving again.
+// A loan needs a valid contract with the parent club, any other transfer
+// a wage that keeps the new team under its salary caps. Both teams have to
+// stay within the squad rules once the player has moved.
+// The player moves at once, so the transfer is dated now, a backdated or
+// future-dated transfer would slip through a closed window.
+// It returns doCreateResp of transporter.DoCreate and any errors written.
+func (transfer *Transfer) DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error) {
+	params.Date = time.Now()
+
+	getPlayerResp, err := transfer.repo.GetPlayer().GetPlayer(ctx, playerParam.GetPlayer{ID: params.PlayerID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getPlayerResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("player not found")}
+		return
+	}
+
+	if uuid.Equal(getPlayerResp.TeamID, params.ToTeamID) {
+		err = &iPkgError.ValidationError{Err: errors.New("player already plays for the team")}
+		return
+	}
+
+	if params.Type == model.TransferTypeLoan && uuid.Equal(getPlayerResp.TeamID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("player without a team cannot go on loan")}
+		return
+	}
+
+	getActiveLoanResp, err := transfer.repo.GetLoan().GetActiveLoan(ctx, loanParam.GetActiveLoan{PlayerID: params.PlayerID})
+	if err != nil {
+		return
+	}
+
+	if !uuid.Equal(getActiveLoanResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("player is on loan")}
+		return
+	}
+
+	getTeamResp, err := transfer.repo.GetTeam().GetTeam(ctx, teamParam.GetTeam{ID: params.ToTeamID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getTeamResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("team not found")}
+		return
+	}
+
+	getOpenWindowResp, err := transfer.repo.GetTransfer().GetOpenWindow(ctx, param.GetOpenWindow{
+		TeamID: params.ToTeamID,
+		Date:   params.Date,
+	})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getOpenWindowResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("transfer window is closed")}
+		return
+	}
+
+	if params.Type == model.TransferTypeLoan {
+		err = transfer.contract.CheckContract(ctx, contractParam.CheckContract{
+			PlayerID: params.PlayerID,
+			TeamID:   getPlayerResp.TeamID,
+			Date:     params.Date,
+		})
+	} else {
+		err = transfer.contract.CheckSalaryCap(ctx, contractParam.CheckSalaryCap{
+			TeamID: params.ToTeamID,
+			Date:   params.Date,
+			Wage:   params.Contract.Wage,
+		})
+	}
+	if err != nil {
+		return
+	}
+
+	member := squadParam.Member{
+		ID:          params.PlayerID,
+		Nationality: getPlayerResp.Nationality,
+		Homegrown:   getPlayerResp.Homegrown,
+	}
+
+	if !uuid.Equal(getPlayerResp.TeamID, uuid.Nil) {
+		if err = transfer.squad.CheckSquad(ctx, squadParam.CheckSquad{
+			TeamID: getPlayerResp.TeamID,
+			Date:   params.Date,
+			Out:    &member,
+		}); err != nil {
+			return
+		}
+	}
+
+	if err = transfer.squad.CheckSquad(ctx, squadParam.CheckSquad{
+		TeamID: params.ToTeamID,
+		Date:   params.Date,
+		In:     &member,
+	}); err != nil {
+		return
+	}
+
+	if !uuid.Equal(getPlayerResp.TeamID, uuid.Nil) {
+		fromTeamID := getPlayerResp.TeamID
+		params.FromTeamID = &fromTeamID
+	}
+
+	doCreateResp, err = transfer.repo.GetTransfer().DoCreate(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// GetTransfers is used for getting all transfers of a player, the oldest first.
+// It returns getTransfersResp of []transporter.GetTransfers and any errors written.
+func (transfer *Transfer) GetTransfers(ctx context.Context, params param.GetTransfers) (getTransfersResp []transporter.GetTransfers, err error) {
+	getTransfersResp, err = transfer.repo.GetTransfer().GetTransfers(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// GetCareer is used for getting the club history of a player.
+// A player that never moved has a single spell at the current team.
+// It returns getCareerResp of transporter.GetCareer and any errors written.
+func (transfer *Transfer) GetCareer(ctx context.Context, params param.GetCareer) (getCareerResp transporter.GetCareer, err error) {
+	getPlayerResp, err := transfer.repo.GetPlayer().GetPlayer(ctx, playerParam.GetPlayer{ID: params.PlayerID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getPlayerResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("player not found")}
+		return
+	}
+
+	getTransfersResp, err := transfer.repo.GetTransfer().GetTransfers(ctx, param.GetTransfers{PlayerID: params.PlayerID})
+	if err != nil {
+		return
+	}
+
+	getCareerResp.PlayerID = getPlayerResp.ID
+	getCareerResp.PlayerName = getPlayerResp.Name
+	getCareerResp.Spells = career(getTransfersResp)
+
+	if len(getTransfersResp) == 0 && !uuid.Equal(getPlayerResp.TeamID, uuid.Nil) {
+		getTeamResp, err := transfer.repo.GetTeam().GetTeam(ctx, teamParam.GetTeam{ID: getPlayerResp.TeamID})
+		if err != nil {
+			return getCareerResp, err
+		}
+
+		getCareerResp.Spells = append(getCareerResp.Spells, transporter.Spell{
+			TeamID:   getTeamResp.ID,
+			TeamName: getTeamResp.Name,
+		})
+	}
+
+	return
+}
+
+// DoCreateWindow is used for record new transfer window of a season.
+// It returns doCreateWindowResp of transporter.DoCreateWindow and any errors written.
+func (transfer *Transfer) DoCreateWindow(ctx context.Context, params param.DoCreateWindow) (doCreateWindowResp transporter.DoCreateWindow, err error) {
+	getSeasonResp, err := transfer.repo.GetSeason().GetSeason(ctx, seasonParam.GetSeason{ID: params.SeasonID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getSeasonResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("season not found")}
+		return
+	}
+
+	doCreateWindowResp, err = transfer.repo.GetTransfer().DoCreateWindow(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// GetWindows is used for getting all transfer windows of a season.
+// It returns getWindowsResp of []transporter.GetWindows and any errors written.
+func (transfer *Transfer) GetWindows(ctx context.Context, params param.GetWindows) (getWindowsResp []transporter.GetWindows, err error) {
+	getWindowsResp, err = transfer.repo.GetTransfer().GetWindows(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// career is used for turning the transfers of a player, the oldest first, into spells at every club.
+// The club the player left on the first transfer makes the opening spell, with no known start.
+// The spell at the current club has no end.
+func career(transfers []transporter.GetTransfers) (spells []transporter.Spell) {
+	for i, t := range transfers {
+		date := t.Date
+		if i == 0 && t.FromTeamID != nil {
+			spells = append(spells, transporter.Spell{
+				TeamID:   *t.FromTeamID,
+				TeamName: t.FromTeamName,
+				Until:    &date,
+			})
+		}
+
+		if i > 0 {
+			spells[len(spells)-1].Until = &date
+		}
+
+		spells = append(spells, transporter.Spell{
+			TeamID:   t.ToTeamID,
+			TeamName: t.ToTeamName,
+			From:     &date,
+			Type:     t.Type,
+		})
+	}
+
+	return
+}
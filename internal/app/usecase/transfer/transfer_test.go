@@ -0,0 +1,512 @@
+package transfer
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	contractParam "github.com/harunnryd/skeltun/internal/app/handler/contract/param"
+	loanParam "github.com/harunnryd/skeltun/internal/app/handler/loan/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/transfer/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/transfer/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	iContractRepo "github.com/harunnryd/skeltun/internal/app/repo/contract"
+	iLoanRepo "github.com/harunnryd/skeltun/internal/app/repo/loan"
+	iPlayerRepo "github.com/harunnryd/skeltun/internal/app/repo/player"
+	iSquadRepo "github.com/harunnryd/skeltun/internal/app/repo/squad"
+	iTeamRepo "github.com/harunnryd/skeltun/internal/app/repo/team"
+	iTransferRepo "github.com/harunnryd/skeltun/internal/app/repo/transfer"
+	"github.com/harunnryd/skeltun/internal/app/usecase/contract"
+	"github.com/harunnryd/skeltun/internal/app/usecase/squad"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	mock      sqlmock.Sqlmock
+	pgsqlConn *gorm.DB
+
+	iTransferRepo iTransferRepo.ITransfer
+	iPlayerRepo   iPlayerRepo.IPlayer
+	iTeamRepo     iTeamRepo.ITeam
+	iLoanRepo     iLoanRepo.ILoan
+	iContractRepo iContractRepo.IContract
+	iSquadRepo    iSquadRepo.ISquad
+	iRepo         repo.IRepo
+	transfer      ITransfer
+	helper
+	response
+}
+
+type helper struct {
+	err error
+	db  *sql.DB
+}
+
+type response struct {
+	doCreateResp  transporter.DoCreate
+	getCareerResp transporter.GetCareer
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.iTransferRepo = iTransferRepo.New(
+		iTransferRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iPlayerRepo = iPlayerRepo.New(
+		iPlayerRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iTeamRepo = iTeamRepo.New(
+		iTeamRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iLoanRepo = iLoanRepo.New(
+		iLoanRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iContractRepo = iContractRepo.New(
+		iContractRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iSquadRepo = iSquadRepo.New(
+		iSquadRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iRepo = repo.New()
+	suite.iRepo.SetTransfer(suite.iTransferRepo)
+	suite.iRepo.SetPlayer(suite.iPlayerRepo)
+	suite.iRepo.SetTeam(suite.iTeamRepo)
+	suite.iRepo.SetLoan(suite.iLoanRepo)
+	suite.iRepo.SetContract(suite.iContractRepo)
+	suite.iRepo.SetSquad(suite.iSquadRepo)
+
+	suite.transfer = New(
+		WithRepo(suite.iRepo),
+		WithContract(contract.New(contract.WithRepo(suite.iRepo))),
+		WithSquad(squad.New(squad.WithRepo(suite.iRepo))),
+	)
+}
+
+// today is used for ending the terms of a transfer relative to the day the test runs.
+func today() time.Time {
+	return time.Now().UTC().Truncate(24 * time.Hour)
+}
+
+// TestDoCreate ...
+func (suite *Suite) TestDoCreate() {
+	fromTeamID, transferID := uuid.NewV4(), uuid.NewV4()
+	params := param.DoCreate{
+		PlayerID: uuid.NewV4(),
+		ToTeamID: uuid.NewV4(),
+		Fee:      5000000,
+		Type:     model.TransferTypePermanent,
+		Contract: &contractParam.Terms{
+			EndsAt: today().AddDate(3, 0, 0),
+			Wage:   2500000,
+		},
+	}
+
+	suite.mock.MatchExpectationsInOrder(false)
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.PlayerID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "team_id", "name"}).
+			AddRow(params.PlayerID, fromTeamID, "John Doe"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "loans" WHERE player_id = $1 AND status = $2 LIMIT 1`)).
+		WithArgs(params.PlayerID, model.LoanStatusActive).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "teams" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.ToTeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(params.ToTeamID, "Arsenal"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE "players"."team_id" = $1`)).
+		WithArgs(params.ToTeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`FROM "transfer_windows" JOIN season_teams`)).
+		WithArgs(params.ToTeamID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`FROM "competitions"`)).
+		WithArgs(uuid.Nil, sqlmock.AnyArg(), params.ToTeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"competition_id", "competition_name", "salary_cap"}))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`FROM "squad_rules"`)).
+		WithArgs(fromTeamID, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"season_id"}))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`FROM "squad_rules"`)).
+		WithArgs(params.ToTeamID, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"season_id"}))
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "transfers"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), params.PlayerID, fromTeamID, params.ToTeamID, sqlmock.AnyArg(), params.Fee, params.Type).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(transferID))
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "players"`)).
+		WithArgs(nil, params.ToTeamID, sqlmock.AnyArg(), params.PlayerID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "contracts"`)).
+		WithArgs(model.ContractStatusTerminated, sqlmock.AnyArg(), params.PlayerID, model.ContractStatusActive).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "contracts"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.mock.ExpectCommit()
+
+	suite.response.doCreateResp, suite.helper.err = suite.transfer.DoCreate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), transferID, suite.response.doCreateResp.ID)
+	require.Equal(suite.T(), fromTeamID, *suite.response.doCreateResp.FromTeamID)
+}
+
+// TestDoCreateWindowClosed ...
+func (suite *Suite) TestDoCreateWindowClosed() {
+	params := param.DoCreate{
+		PlayerID: uuid.NewV4(),
+		ToTeamID: uuid.NewV4(),
+		Type:     model.TransferTypeFree,
+	}
+
+	suite.mock.MatchExpectationsInOrder(false)
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.PlayerID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "team_id", "name"}).
+			AddRow(params.PlayerID, uuid.NewV4(), "John Doe"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "loans" WHERE player_id = $1 AND status = $2 LIMIT 1`)).
+		WithArgs(params.PlayerID, model.LoanStatusActive).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "teams" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.ToTeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(params.ToTeamID, "Arsenal"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE "players"."team_id" = $1`)).
+		WithArgs(params.ToTeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`FROM "transfer_windows" JOIN season_teams`)).
+		WithArgs(params.ToTeamID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.response.doCreateResp, suite.helper.err = suite.transfer.DoCreate(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "transfer window is closed")
+}
+
+// TestDoCreateLoan ...
+func (suite *Suite) TestDoCreateLoan() {
+	fromTeamID, transferID, loanID := uuid.NewV4(), uuid.NewV4(), uuid.NewV4()
+	params := param.DoCreate{
+		PlayerID: uuid.NewV4(),
+		ToTeamID: uuid.NewV4(),
+		Type:     model.TransferTypeLoan,
+		Loan: &loanParam.Terms{
+			EndsAt:       today().AddDate(0, 6, 0),
+			RecallClause: true,
+		},
+	}
+
+	suite.mock.MatchExpectationsInOrder(false)
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.PlayerID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "team_id", "name"}).
+			AddRow(params.PlayerID, fromTeamID, "John Doe"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "loans" WHERE player_id = $1 AND status = $2 LIMIT 1`)).
+		WithArgs(params.PlayerID, model.LoanStatusActive).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "teams" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.ToTeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(params.ToTeamID, "Chelsea"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE "players"."team_id" = $1`)).
+		WithArgs(params.ToTeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`FROM "transfer_windows" JOIN season_teams`)).
+		WithArgs(params.ToTeamID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "contracts" WHERE player_id = $1 AND team_id = $2 AND status = $3 AND starts_at <= $4 AND ends_at >= $5 LIMIT 1`)).
+		WithArgs(params.PlayerID, fromTeamID, model.ContractStatusActive, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`FROM "squad_rules"`)).
+		WithArgs(fromTeamID, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"season_id"}))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`FROM "squad_rules"`)).
+		WithArgs(params.ToTeamID, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"season_id"}))
+
+	suite.mock.ExpectBegin()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "transfers"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(transferID))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`INSERT INTO "loans" ("created_at","updated_at","deleted_at","transfer_id","player_id","parent_team_id","loan_team_id","starts_at","ends_at","recall_clause","option_to_buy","option_fee","status","ended_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14) RETURNING "id"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), transferID, params.PlayerID, fromTeamID, params.ToTeamID, sqlmock.AnyArg(), params.Loan.EndsAt, true, false, int64(0), model.LoanStatusActive, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(loanID))
+
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(`UPDATE "players"`)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	suite.mock.ExpectCommit()
+
+	suite.response.doCreateResp, suite.helper.err = suite.transfer.DoCreate(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.NotNil(suite.T(), suite.response.doCreateResp.Loan)
+	require.Equal(suite.T(), loanID, suite.response.doCreateResp.Loan.ID)
+	require.Equal(suite.T(), fromTeamID, suite.response.doCreateResp.Loan.ParentTeamID)
+}
+
+// TestDoCreateLoanWithoutContract ...
+func (suite *Suite) TestDoCreateLoanWithoutContract() {
+	fromTeamID := uuid.NewV4()
+	params := param.DoCreate{
+		PlayerID: uuid.NewV4(),
+		ToTeamID: uuid.NewV4(),
+		Type:     model.TransferTypeLoan,
+		Loan: &loanParam.Terms{
+			EndsAt: today().AddDate(0, 6, 0),
+		},
+	}
+
+	suite.mock.MatchExpectationsInOrder(false)
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.PlayerID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "team_id", "name"}).
+			AddRow(params.PlayerID, fromTeamID, "John Doe"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "loans" WHERE player_id = $1 AND status = $2 LIMIT 1`)).
+		WithArgs(params.PlayerID, model.LoanStatusActive).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "teams" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.ToTeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(params.ToTeamID, "Chelsea"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE "players"."team_id" = $1`)).
+		WithArgs(params.ToTeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`FROM "transfer_windows" JOIN season_teams`)).
+		WithArgs(params.ToTeamID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "contracts"`)).
+		WithArgs(params.PlayerID, fromTeamID, model.ContractStatusActive, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.response.doCreateResp, suite.helper.err = suite.transfer.DoCreate(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "player has no valid contract with the team")
+}
+
+// TestDoCreatePlayerOnLoan ...
+func (suite *Suite) TestDoCreatePlayerOnLoan() {
+	params := param.DoCreate{
+		PlayerID: uuid.NewV4(),
+		ToTeamID: uuid.NewV4(),
+		Type:     model.TransferTypeFree,
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.PlayerID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "team_id", "name"}).
+			AddRow(params.PlayerID, uuid.NewV4(), "John Doe"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "loans" WHERE player_id = $1 AND status = $2 LIMIT 1`)).
+		WithArgs(params.PlayerID, model.LoanStatusActive).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow(uuid.NewV4()))
+
+	suite.response.doCreateResp, suite.helper.err = suite.transfer.DoCreate(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "player is on loan")
+}
+
+// TestGetCareer ...
+func (suite *Suite) TestGetCareer() {
+	teamID := uuid.NewV4()
+	params := param.GetCareer{
+		PlayerID: uuid.NewV4(),
+	}
+
+	suite.mock.MatchExpectationsInOrder(false)
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.PlayerID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "team_id", "name"}).
+			AddRow(params.PlayerID, teamID, "John Doe"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`FROM "transfers"`)).
+		WithArgs(params.PlayerID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "teams" WHERE id = $1 LIMIT 1`)).
+		WithArgs(teamID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(teamID, "Arsenal"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE "players"."team_id" = $1`)).
+		WithArgs(teamID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.response.getCareerResp, suite.helper.err = suite.transfer.GetCareer(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), []transporter.Spell{{TeamID: teamID, TeamName: "Arsenal"}}, suite.response.getCareerResp.Spells)
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+// TestCareer ...
+func TestCareer(t *testing.T) {
+	arsenalID, chelseaID, fulhamID := uuid.NewV4(), uuid.NewV4(), uuid.NewV4()
+	january := time.Date(2021, time.January, 15, 0, 0, 0, 0, time.UTC)
+	july := time.Date(2021, time.July, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		transfers []transporter.GetTransfers
+		want      []transporter.Spell
+	}{
+		{
+			name: "no transfers",
+		},
+		{
+			name: "joined without a club",
+			transfers: []transporter.GetTransfers{
+				{Transfer: transporter.Transfer{ToTeamID: arsenalID, Date: january, Type: model.TransferTypeFree}, ToTeamName: "Arsenal"},
+			},
+			want: []transporter.Spell{
+				{TeamID: arsenalID, TeamName: "Arsenal", From: &january, Type: model.TransferTypeFree},
+			},
+		},
+		{
+			name: "loaned out and sold",
+			transfers: []transporter.GetTransfers{
+				{Transfer: transporter.Transfer{FromTeamID: &arsenalID, ToTeamID: chelseaID, Date: january, Type: model.TransferTypeLoan}, FromTeamName: "Arsenal", ToTeamName: "Chelsea"},
+				{Transfer: transporter.Transfer{FromTeamID: &chelseaID, ToTeamID: fulhamID, Date: july, Type: model.TransferTypePermanent}, FromTeamName: "Chelsea", ToTeamName: "Fulham"},
+			},
+			want: []transporter.Spell{
+				{TeamID: arsenalID, TeamName: "Arsenal", Until: &january},
+				{TeamID: chelseaID, TeamName: "Chelsea", From: &january, Until: &july, Type: model.TransferTypeLoan},
+				{TeamID: fulhamID, TeamName: "Fulham", From: &july, Type: model.TransferTypePermanent},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, career(tt.transfers))
+		})
+	}
+}
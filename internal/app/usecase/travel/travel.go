@@ -0,0 +1,189 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package travel
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/harunnryd/skeltun/config"
+	matchParam "github.com/harunnryd/skeltun/internal/app/handler/match/param"
+	matchTransporter "github.com/harunnryd/skeltun/internal/app/handler/match/transporter"
+	seasonParam "github.com/harunnryd/skeltun/internal/app/handler/season/param"
+	teamParam "github.com/harunnryd/skeltun/internal/app/handler/team/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/travel/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/travel/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	"github.com/harunnryd/skeltun/internal/pkg"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// restDays is the minimum rest between two matches when it is not configured.
+const restDays = 3
+
+// ITravel is an interface that stores the methods that Travel struct will use.
+type ITravel interface {
+	// GetTravel is used for getting the away trips of a team over a season and the rest it gets between matches.
+	// It returns getTravelResp of transporter.GetTravel and any errors written.
+	GetTravel(ctx context.Context, params param.GetTravel) (getTravelResp transporter.GetTravel, err error)
+}
+
+// Travel is an struct that implements ITravel methods.
+type Travel struct {
+	config config.IConfig
+	repo   repo.IRepo
+	pkg    pkg.IPkg
+}
+
+// New it returns instance of Travel that implements ITravel methods.
+func New(opts ...Option) ITravel {
+	t := new(Travel)
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// GetTravel is used for getting the away trips of a team over a season and the rest it gets between matches.
+// Trips are measured one way from the home ground of the team to the venue, while the total counts the way back too.
+// Trips where either ground is unknown are left out of the total and counted apart. Every run of matches with less rest
+// in between than the configured minimum is reported as congested. Postponed and cancelled matches are left out.
+// It returns getTravelResp of transporter.GetTravel and any errors written.
+func (travel *Travel) GetTravel(ctx context.Context, params param.GetTravel) (getTravelResp transporter.GetTravel, err error) {
+	getTeamResp, err := travel.repo.GetTeam().GetTeam(ctx, teamParam.GetTeam{ID: params.TeamID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getTeamResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("team not found")}
+		return
+	}
+
+	getSeasonResp, err := travel.repo.GetSeason().GetSeason(ctx, seasonParam.GetSeason{ID: params.SeasonID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getSeasonResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("season not found")}
+		return
+	}
+
+	getTeamMatchesResp, err := travel.repo.GetMatch().GetTeamMatches(ctx, matchParam.GetTeamMatches{
+		TeamID:   params.TeamID,
+		SeasonID: params.SeasonID,
+	})
+	if err != nil {
+		return
+	}
+
+	played := make([]matchTransporter.GetTeamMatches, 0, len(getTeamMatchesResp))
+	for _, match := range getTeamMatchesResp {
+		if match.Status == model.MatchStatusPostponed || match.Status == model.MatchStatusCancelled {
+			continue
+		}
+		played = append(played, match)
+	}
+
+	getTravelResp = report(params, played, travel.restDays())
+
+	return
+}
+
+// restDays is used for getting how many days a team should rest between two matches.
+func (travel *Travel) restDays() int {
+	if travel.config == nil || travel.config.GetInt("travel.rest.days") <= 0 {
+		return restDays
+	}
+	return travel.config.GetInt("travel.rest.days")
+}
+
+// report is used for following a team through its matches in the order they are played,
+// adding up the away trips there and back and grouping the matches played too close together into stretches.
+func report(params param.GetTravel, matches []matchTransporter.GetTeamMatches, minimum int) (getTravelResp transporter.GetTravel) {
+	getTravelResp.TeamID = params.TeamID
+	getTravelResp.SeasonID = params.SeasonID
+	getTravelResp.MinimumRestDays = minimum
+	getTravelResp.Trips = make([]transporter.Trip, 0)
+	getTravelResp.Rests = make([]transporter.Rest, 0)
+	getTravelResp.Congestion = make([]transporter.Stretch, 0)
+
+	total := 0.0
+	congested := false
+	for i, match := range matches {
+		if uuid.Equal(match.AwayTeamID, params.TeamID) {
+			trip := transporter.Trip{
+				MatchID:    match.ID,
+				OpponentID: match.HomeTeamID,
+				KickoffAt:  match.KickoffAt,
+				Venue:      match.Venue,
+				VenueID:    match.VenueID,
+			}
+
+			if match.Distance != nil {
+				km := kilometres(*match.Distance)
+				trip.Kilometres = &km
+				total += 2 * *match.Distance
+			} else {
+				getTravelResp.UnknownGroundTrips++
+			}
+
+			getTravelResp.Trips = append(getTravelResp.Trips, trip)
+		}
+
+		if i == 0 {
+			continue
+		}
+
+		previous := matches[i-1]
+		rest := transporter.Rest{
+			FromMatchID: previous.ID,
+			ToMatchID:   match.ID,
+			Days:        days(previous.KickoffAt, match.KickoffAt),
+		}
+		rest.Congested = rest.Days < minimum
+		getTravelResp.Rests = append(getTravelResp.Rests, rest)
+
+		if !rest.Congested {
+			congested = false
+			continue
+		}
+
+		if !congested {
+			getTravelResp.Congestion = append(getTravelResp.Congestion, transporter.Stretch{
+				StartedAt: previous.KickoffAt,
+				MatchIDs:  []uuid.UUID{previous.ID},
+			})
+			congested = true
+		}
+
+		stretch := &getTravelResp.Congestion[len(getTravelResp.Congestion)-1]
+		stretch.EndedAt = match.KickoffAt
+		stretch.MatchIDs = append(stretch.MatchIDs, match.ID)
+	}
+
+	getTravelResp.TotalKilometres = kilometres(total)
+
+	return
+}
+
+// kilometres is used for turning a distance in metres into kilometres, rounded to one decimal.
+func kilometres(metres float64) float64 {
+	return math.Round(metres/100) / 10
+}
+
+// days is used for counting the calendar days from one kickoff to another, so a Saturday to Tuesday is three days
+// whatever time either match kicks off.
+func days(from, to time.Time) int {
+	from, to = from.UTC(), to.UTC()
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(to.Sub(from).Hours() / 24)
+}
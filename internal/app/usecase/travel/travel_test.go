@@ -0,0 +1,179 @@
+package travel
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/config/configtest"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/handler/travel/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/travel/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	iMatchRepo "github.com/harunnryd/skeltun/internal/app/repo/match"
+	iSeasonRepo "github.com/harunnryd/skeltun/internal/app/repo/season"
+	iTeamRepo "github.com/harunnryd/skeltun/internal/app/repo/team"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	mock      sqlmock.Sqlmock
+	pgsqlConn *gorm.DB
+
+	iMatchRepo  iMatchRepo.IMatch
+	iSeasonRepo iSeasonRepo.ISeason
+	iTeamRepo   iTeamRepo.ITeam
+	iRepo       repo.IRepo
+	travel      ITravel
+	helper
+	response
+}
+
+type helper struct {
+	err error
+	db  *sql.DB
+}
+
+type response struct {
+	getTravelResp transporter.GetTravel
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.iMatchRepo = iMatchRepo.New(
+		iMatchRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iSeasonRepo = iSeasonRepo.New(
+		iSeasonRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iTeamRepo = iTeamRepo.New(
+		iTeamRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iRepo = repo.New()
+	suite.iRepo.SetMatch(suite.iMatchRepo)
+	suite.iRepo.SetSeason(suite.iSeasonRepo)
+	suite.iRepo.SetTeam(suite.iTeamRepo)
+
+	suite.travel = New(WithConfig(configtest.Fake{
+		"travel.rest.days": 3,
+	}), WithRepo(suite.iRepo))
+}
+
+// TestGetTravel ...
+func (suite *Suite) TestGetTravel() {
+	params := param.GetTravel{
+		TeamID:   uuid.NewV4(),
+		SeasonID: uuid.NewV4(),
+	}
+	matchIDs := []uuid.UUID{uuid.NewV4(), uuid.NewV4(), uuid.NewV4(), uuid.NewV4(), uuid.NewV4(), uuid.NewV4()}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "teams" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.TeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(params.TeamID, "Manchester United"))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "players" WHERE "players"."team_id" = $1`)).
+		WithArgs(params.TeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "team_id", "name"}))
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seasons" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.SeasonID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(params.SeasonID, "2021/22"))
+
+	// Tuesday to Thursday to Saturday is a congested week, while the postponed match in between is not played.
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`WHERE matches.season_id = $2 AND (matches.home_team_id = $3 OR matches.away_team_id = $4) AND matches.deleted_at IS NULL ORDER BY matches.kickoff_at`)).
+		WithArgs(params.TeamID, params.SeasonID, params.TeamID, params.TeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "home_team_id", "away_team_id", "kickoff_at", "venue", "status", "distance"}).
+			AddRow(matchIDs[0], params.TeamID, uuid.NewV4(), time.Date(2021, time.August, 14, 15, 0, 0, 0, time.UTC), "Old Trafford", model.MatchStatusFinished, 0).
+			AddRow(matchIDs[1], uuid.NewV4(), params.TeamID, time.Date(2021, time.August, 17, 19, 45, 0, 0, time.UTC), "Anfield", model.MatchStatusFinished, 49717.3).
+			AddRow(matchIDs[2], uuid.NewV4(), params.TeamID, time.Date(2021, time.August, 19, 20, 0, 0, 0, time.UTC), "Villa Park", model.MatchStatusFinished, 120345.8).
+			AddRow(matchIDs[3], params.TeamID, uuid.NewV4(), time.Date(2021, time.August, 21, 12, 30, 0, 0, time.UTC), "Old Trafford", model.MatchStatusFinished, 0).
+			AddRow(matchIDs[4], params.TeamID, uuid.NewV4(), time.Date(2021, time.August, 24, 19, 45, 0, 0, time.UTC), "Old Trafford", model.MatchStatusPostponed, 0).
+			AddRow(matchIDs[5], uuid.NewV4(), params.TeamID, time.Date(2021, time.August, 28, 15, 0, 0, 0, time.UTC), "", model.MatchStatusScheduled, nil))
+
+	suite.response.getTravelResp, suite.helper.err = suite.travel.GetTravel(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Equal(suite.T(), 3, suite.response.getTravelResp.MinimumRestDays)
+	require.Equal(suite.T(), 340.1, suite.response.getTravelResp.TotalKilometres)
+	require.Equal(suite.T(), 1, suite.response.getTravelResp.UnknownGroundTrips)
+
+	require.Len(suite.T(), suite.response.getTravelResp.Trips, 3)
+	require.Equal(suite.T(), 49.7, *suite.response.getTravelResp.Trips[0].Kilometres)
+	require.Nil(suite.T(), suite.response.getTravelResp.Trips[2].Kilometres)
+
+	require.Equal(suite.T(), []transporter.Rest{
+		{FromMatchID: matchIDs[0], ToMatchID: matchIDs[1], Days: 3},
+		{FromMatchID: matchIDs[1], ToMatchID: matchIDs[2], Days: 2, Congested: true},
+		{FromMatchID: matchIDs[2], ToMatchID: matchIDs[3], Days: 2, Congested: true},
+		{FromMatchID: matchIDs[3], ToMatchID: matchIDs[5], Days: 7},
+	}, suite.response.getTravelResp.Rests)
+
+	require.Len(suite.T(), suite.response.getTravelResp.Congestion, 1)
+	require.Equal(suite.T(), []uuid.UUID{matchIDs[1], matchIDs[2], matchIDs[3]}, suite.response.getTravelResp.Congestion[0].MatchIDs)
+}
+
+// TestGetTravelTeamNotFound ...
+func (suite *Suite) TestGetTravelTeamNotFound() {
+	params := param.GetTravel{
+		TeamID:   uuid.NewV4(),
+		SeasonID: uuid.NewV4(),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "teams" WHERE id = $1 LIMIT 1`)).
+		WithArgs(params.TeamID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.response.getTravelResp, suite.helper.err = suite.travel.GetTravel(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "team not found")
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
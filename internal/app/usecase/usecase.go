@@ -5,9 +5,32 @@
 package usecase
 
 import (
+	"github.com/harunnryd/skeltun/internal/app/usecase/availability"
+	"github.com/harunnryd/skeltun/internal/app/usecase/bracket"
+	"github.com/harunnryd/skeltun/internal/app/usecase/competition"
+	"github.com/harunnryd/skeltun/internal/app/usecase/contract"
+	"github.com/harunnryd/skeltun/internal/app/usecase/draw"
+	"github.com/harunnryd/skeltun/internal/app/usecase/fixture"
+	"github.com/harunnryd/skeltun/internal/app/usecase/group"
 	"github.com/harunnryd/skeltun/internal/app/usecase/hcheck"
+	"github.com/harunnryd/skeltun/internal/app/usecase/injury"
+	"github.com/harunnryd/skeltun/internal/app/usecase/leaderboard"
+	"github.com/harunnryd/skeltun/internal/app/usecase/lineup"
+	"github.com/harunnryd/skeltun/internal/app/usecase/loan"
+	"github.com/harunnryd/skeltun/internal/app/usecase/match"
+	"github.com/harunnryd/skeltun/internal/app/usecase/matchevent"
+	"github.com/harunnryd/skeltun/internal/app/usecase/official"
 	"github.com/harunnryd/skeltun/internal/app/usecase/player"
+	"github.com/harunnryd/skeltun/internal/app/usecase/season"
+	"github.com/harunnryd/skeltun/internal/app/usecase/shootoutkick"
+	"github.com/harunnryd/skeltun/internal/app/usecase/squad"
+	"github.com/harunnryd/skeltun/internal/app/usecase/standing"
+	"github.com/harunnryd/skeltun/internal/app/usecase/stat"
+	"github.com/harunnryd/skeltun/internal/app/usecase/suspension"
 	"github.com/harunnryd/skeltun/internal/app/usecase/team"
+	"github.com/harunnryd/skeltun/internal/app/usecase/transfer"
+	"github.com/harunnryd/skeltun/internal/app/usecase/travel"
+	"github.com/harunnryd/skeltun/internal/app/usecase/venue"
 )
 
 // IUseCase ...
@@ -20,13 +43,105 @@ type IUseCase interface {
 
 	// GetTeam it returns instance of team.Team that implements team.ITeam methods.
 	GetTeam() team.ITeam
+
+	// GetMatch it returns instance of match.Match that implements match.IMatch methods.
+	GetMatch() match.IMatch
+
+	// GetCompetition it returns instance of competition.Competition that implements competition.ICompetition methods.
+	GetCompetition() competition.ICompetition
+
+	// GetSeason it returns instance of season.Season that implements season.ISeason methods.
+	GetSeason() season.ISeason
+
+	// GetStanding it returns instance of standing.Standing that implements standing.IStanding methods.
+	GetStanding() standing.IStanding
+
+	// GetFixture it returns instance of fixture.Fixture that implements fixture.IFixture methods.
+	GetFixture() fixture.IFixture
+
+	// GetMatchEvent it returns instance of matchevent.MatchEvent that implements matchevent.IMatchEvent methods.
+	GetMatchEvent() matchevent.IMatchEvent
+
+	// GetShootoutKick it returns instance of shootoutkick.ShootoutKick that implements shootoutkick.IShootoutKick methods.
+	GetShootoutKick() shootoutkick.IShootoutKick
+
+	// GetBracket it returns instance of bracket.Bracket that implements bracket.IBracket methods.
+	GetBracket() bracket.IBracket
+
+	// GetDraw it returns instance of draw.Draw that implements draw.IDraw methods.
+	GetDraw() draw.IDraw
+
+	// GetGroup it returns instance of group.Group that implements group.IGroup methods.
+	GetGroup() group.IGroup
+
+	// GetTransfer it returns instance of transfer.Transfer that implements transfer.ITransfer methods.
+	GetTransfer() transfer.ITransfer
+
+	// GetLoan it returns instance of loan.Loan that implements loan.ILoan methods.
+	GetLoan() loan.ILoan
+
+	// GetContract it returns instance of contract.Contract that implements contract.IContract methods.
+	GetContract() contract.IContract
+
+	// GetSquad it returns instance of squad.Squad that implements squad.ISquad methods.
+	GetSquad() squad.ISquad
+
+	// GetLineup it returns instance of lineup.Lineup that implements lineup.ILineup methods.
+	GetLineup() lineup.ILineup
+
+	// GetStat it returns instance of stat.Stat that implements stat.IStat methods.
+	GetStat() stat.IStat
+
+	// GetLeaderboard it returns instance of leaderboard.Leaderboard that implements leaderboard.ILeaderboard methods.
+	GetLeaderboard() leaderboard.ILeaderboard
+
+	// GetInjury it returns instance of injury.Injury that implements injury.IInjury methods.
+	GetInjury() injury.IInjury
+
+	// GetSuspension it returns instance of suspension.Suspension that implements suspension.ISuspension methods.
+	GetSuspension() suspension.ISuspension
+
+	// GetAvailability it returns instance of availability.Availability that implements availability.IAvailability methods.
+	GetAvailability() availability.IAvailability
+
+	// GetVenue it returns instance of venue.Venue that implements venue.IVenue methods.
+	GetVenue() venue.IVenue
+
+	// GetTravel it returns instance of travel.Travel that implements travel.ITravel methods.
+	GetTravel() travel.ITravel
+
+	// GetOfficial it returns instance of official.Official that implements official.IOfficial methods.
+	GetOfficial() official.IOfficial
 }
 
 // UseCase ...
 type UseCase struct {
-	hcheck hcheck.IHcheck
-	player player.IPlayer
-	team   team.ITeam
+	hcheck       hcheck.IHcheck
+	player       player.IPlayer
+	team         team.ITeam
+	match        match.IMatch
+	competition  competition.ICompetition
+	season       season.ISeason
+	standing     standing.IStanding
+	fixture      fixture.IFixture
+	matchevent   matchevent.IMatchEvent
+	shootoutkick shootoutkick.IShootoutKick
+	bracket      bracket.IBracket
+	draw         draw.IDraw
+	group        group.IGroup
+	transfer     transfer.ITransfer
+	loan         loan.ILoan
+	contract     contract.IContract
+	squad        squad.ISquad
+	lineup       lineup.ILineup
+	stat         stat.IStat
+	leaderboard  leaderboard.ILeaderboard
+	injury       injury.IInjury
+	suspension   suspension.ISuspension
+	availability availability.IAvailability
+	venue        venue.IVenue
+	travel       travel.ITravel
+	official     official.IOfficial
 }
 
 // New ...
@@ -52,3 +167,118 @@ func (usecase *UseCase) GetPlayer() player.IPlayer {
 func (usecase *UseCase) GetTeam() team.ITeam {
 	return usecase.team
 }
+
+// GetMatch it returns instance of match.Match that implements match.IMatch methods.
+func (usecase *UseCase) GetMatch() match.IMatch {
+	return usecase.match
+}
+
+// GetCompetition it returns instance of competition.Competition that implements competition.ICompetition methods.
+func (usecase *UseCase) GetCompetition() competition.ICompetition {
+	return usecase.competition
+}
+
+// GetSeason it returns instance of season.Season that implements season.ISeason methods.
+func (usecase *UseCase) GetSeason() season.ISeason {
+	return usecase.season
+}
+
+// GetStanding it returns instance of standing.Standing that implements standing.IStanding methods.
+func (usecase *UseCase) GetStanding() standing.IStanding {
+	return usecase.standing
+}
+
+// GetFixture it returns instance of fixture.Fixture that implements fixture.IFixture methods.
+func (usecase *UseCase) GetFixture() fixture.IFixture {
+	return usecase.fixture
+}
+
+// GetMatchEvent it returns instance of matchevent.MatchEvent that implements matchevent.IMatchEvent methods.
+func (usecase *UseCase) GetMatchEvent() matchevent.IMatchEvent {
+	return usecase.matchevent
+}
+
+// GetShootoutKick it returns instance of shootoutkick.ShootoutKick that implements shootoutkick.IShootoutKick methods.
+func (usecase *UseCase) GetShootoutKick() shootoutkick.IShootoutKick {
+	return usecase.shootoutkick
+}
+
+// GetBracket it returns instance of bracket.Bracket that implements bracket.IBracket methods.
+func (usecase *UseCase) GetBracket() bracket.IBracket {
+	return usecase.bracket
+}
+
+// GetDraw it returns instance of draw.Draw that implements draw.IDraw methods.
+func (usecase *UseCase) GetDraw() draw.IDraw {
+	return usecase.draw
+}
+
+// GetGroup it returns instance of group.Group that implements group.IGroup methods.
+func (usecase *UseCase) GetGroup() group.IGroup {
+	return usecase.group
+}
+
+// GetTransfer it returns instance of transfer.Transfer that implements transfer.ITransfer methods.
+func (usecase *UseCase) GetTransfer() transfer.ITransfer {
+	return usecase.transfer
+}
+
+// GetLoan it returns instance of loan.Loan that implements loan.ILoan methods.
+func (usecase *UseCase) GetLoan() loan.ILoan {
+	return usecase.loan
+}
+
+// GetContract it returns instance of contract.Contract that implements contract.IContract methods.
+func (usecase *UseCase) GetContract() contract.IContract {
+	return usecase.contract
+}
+
+// GetSquad it returns instance of squad.Squad that implements squad.ISquad methods.
+func (usecase *UseCase) GetSquad() squad.ISquad {
+	return usecase.squad
+}
+
+// GetLineup it returns instance of lineup.Lineup that implements lineup.ILineup methods.
+func (usecase *UseCase) GetLineup() lineup.ILineup {
+	return usecase.lineup
+}
+
+// GetStat it returns instance of stat.Stat that implements stat.IStat methods.
+func (usecase *UseCase) GetStat() stat.IStat {
+	return usecase.stat
+}
+
+// GetLeaderboard it returns instance of leaderboard.Leaderboard that implements leaderboard.ILeaderboard methods.
+func (usecase *UseCase) GetLeaderboard() leaderboard.ILeaderboard {
+	return usecase.leaderboard
+}
+
+// GetInjury it returns instance of injury.Injury that implements injury.IInjury methods.
+func (usecase *UseCase) GetInjury() injury.IInjury {
+	return usecase.injury
+}
+
+// GetSuspension it returns instance of suspension.Suspension that implements suspension.ISuspension methods.
+func (usecase *UseCase) GetSuspension() suspension.ISuspension {
+	return usecase.suspension
+}
+
+// GetAvailability it returns instance of availability.Availability that implements availability.IAvailability methods.
+func (usecase *UseCase) GetAvailability() availability.IAvailability {
+	return usecase.availability
+}
+
+// GetVenue it returns instance of venue.Venue that implements venue.IVenue methods.
+func (usecase *UseCase) GetVenue() venue.IVenue {
+	return usecase.venue
+}
+
+// GetTravel it returns instance of travel.Travel that implements travel.ITravel methods.
+func (usecase *UseCase) GetTravel() travel.ITravel {
+	return usecase.travel
+}
+
+// GetOfficial it returns instance of official.Official that implements official.IOfficial methods.
+func (usecase *UseCase) GetOfficial() official.IOfficial {
+	return usecase.official
+}
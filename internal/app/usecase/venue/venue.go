@@ -0,0 +1,112 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package venue
+
+import (
+	"context"
+	"errors"
+
+	"github.com/harunnryd/skeltun/config"
+	"github.com/harunnryd/skeltun/internal/app/handler/venue/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/venue/transporter"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	"github.com/harunnryd/skeltun/internal/pkg"
+	iPkgError "github.com/harunnryd/skeltun/internal/pkg/errors"
+	"github.com/satori/uuid"
+)
+
+// IVenue is an interface that stores the methods that Venue struct will use.
+type IVenue interface {
+	// DoCreate is used for record new venue.
+	// It returns doCreateResp of transporter.DoCreate and any errors written.
+	DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error)
+
+	// GetVenue is used for getting a venue.
+	// It returns getVenueResp of transporter.GetVenue and any errors written.
+	GetVenue(ctx context.Context, params param.GetVenue) (getVenueResp transporter.GetVenue, err error)
+
+	// DoUpdate is used for update the record venue.
+	// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+	DoUpdate(ctx context.Context, params param.DoUpdate) (doUpdateResp transporter.DoUpdate, err error)
+
+	// GetNearby is used for getting the venues within a radius of a point, the closest first.
+	// It returns getNearbyResp of []transporter.GetNearby and any errors written.
+	GetNearby(ctx context.Context, params param.GetNearby) (getNearbyResp []transporter.GetNearby, err error)
+}
+
+// Venue is an struct that implements IVenue methods.
+type Venue struct {
+	config config.IConfig
+	repo   repo.IRepo
+	pkg    pkg.IPkg
+}
+
+// New it returns instance of Venue that implements IVenue methods.
+func New(opts ...Option) IVenue {
+	v := new(Venue)
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+// DoCreate is used for record new venue.
+// It returns doCreateResp of transporter.DoCreate and any errors written.
+func (venue *Venue) DoCreate(ctx context.Context, params param.DoCreate) (doCreateResp transporter.DoCreate, err error) {
+	doCreateResp, err = venue.repo.GetVenue().DoCreate(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// GetVenue is used for getting a venue.
+// It returns getVenueResp of transporter.GetVenue and any errors written.
+func (venue *Venue) GetVenue(ctx context.Context, params param.GetVenue) (getVenueResp transporter.GetVenue, err error) {
+	getVenueResp, err = venue.repo.GetVenue().GetVenue(ctx, params)
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getVenueResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("venue not found")}
+		return
+	}
+
+	return
+}
+
+// DoUpdate is used for update the record venue.
+// It returns doUpdateResp of transporter.DoUpdate and any errors written.
+func (venue *Venue) DoUpdate(ctx context.Context, params param.DoUpdate) (doUpdateResp transporter.DoUpdate, err error) {
+	getVenueResp, err := venue.repo.GetVenue().GetVenue(ctx, param.GetVenue{ID: params.ID})
+	if err != nil {
+		return
+	}
+
+	if uuid.Equal(getVenueResp.ID, uuid.Nil) {
+		err = &iPkgError.ValidationError{Err: errors.New("venue not found")}
+		return
+	}
+
+	doUpdateResp, err = venue.repo.GetVenue().DoUpdate(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// GetNearby is used for getting the venues within a radius of a point, the closest first.
+// It returns getNearbyResp of []transporter.GetNearby and any errors written.
+func (venue *Venue) GetNearby(ctx context.Context, params param.GetNearby) (getNearbyResp []transporter.GetNearby, err error) {
+	getNearbyResp, err = venue.repo.GetVenue().GetNearby(ctx, params)
+	if err != nil {
+		return
+	}
+
+	return
+}
@@ -0,0 +1,146 @@
+package venue
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/harunnryd/skeltun/internal/app/driver/db"
+	"github.com/harunnryd/skeltun/internal/app/handler/venue/param"
+	"github.com/harunnryd/skeltun/internal/app/handler/venue/transporter"
+	"github.com/harunnryd/skeltun/internal/app/model"
+	"github.com/harunnryd/skeltun/internal/app/repo"
+	iVenueRepo "github.com/harunnryd/skeltun/internal/app/repo/venue"
+	"github.com/satori/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Suite ...
+type Suite struct {
+	suite.Suite
+	mock      sqlmock.Sqlmock
+	pgsqlConn *gorm.DB
+
+	iVenueRepo iVenueRepo.IVenue
+	iRepo      repo.IRepo
+	venue      IVenue
+	helper
+	response
+}
+
+type helper struct {
+	err error
+	db  *sql.DB
+}
+
+type response struct {
+	getVenueResp  transporter.GetVenue
+	doUpdateResp  transporter.DoUpdate
+	getNearbyResp []transporter.GetNearby
+}
+
+// SetupSuite ...
+func (suite *Suite) SetupSuite() {
+	suite.helper.db, suite.mock, suite.helper.err = sqlmock.New()
+	require.NoError(suite.T(), suite.helper.err)
+
+	suite.pgsqlConn, suite.helper.err = gorm.Open(postgres.New(postgres.Config{
+		Conn:                 suite.helper.db,
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+
+	require.NoError(suite.T(), suite.helper.err)
+
+	require.NotNil(suite.T(), suite.pgsqlConn)
+
+	suite.pgsqlConn.Debug()
+
+	suite.iVenueRepo = iVenueRepo.New(
+		iVenueRepo.WithDatabase(db.PgsqlDialectParam, suite.pgsqlConn),
+	)
+
+	suite.iRepo = repo.New()
+	suite.iRepo.SetVenue(suite.iVenueRepo)
+
+	suite.venue = New(WithRepo(suite.iRepo))
+}
+
+// TestGetVenueNotFound ...
+func (suite *Suite) TestGetVenueNotFound() {
+	params := param.GetVenue{ID: uuid.NewV4()}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`FROM "venues" WHERE id = $1 AND deleted_at IS NULL LIMIT 1`)).
+		WithArgs(params.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.response.getVenueResp, suite.helper.err = suite.venue.GetVenue(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "venue not found")
+}
+
+// TestDoUpdateNotFound ...
+func (suite *Suite) TestDoUpdateNotFound() {
+	latitude, longitude := 53.4308, -2.9608
+	params := param.DoUpdate{
+		Venue: param.Venue{
+			ID:        uuid.NewV4(),
+			Name:      "Anfield",
+			Latitude:  &latitude,
+			Longitude: &longitude,
+			Surface:   model.VenueSurfaceGrass,
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`FROM "venues" WHERE id = $1 AND deleted_at IS NULL LIMIT 1`)).
+		WithArgs(params.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suite.response.doUpdateResp, suite.helper.err = suite.venue.DoUpdate(context.Background(), params)
+
+	require.EqualError(suite.T(), suite.helper.err, "venue not found")
+}
+
+// TestGetNearby ...
+func (suite *Suite) TestGetNearby() {
+	params := param.GetNearby{
+		Lat:    "51.4817",
+		Lng:    "-0.191",
+		Radius: "10000",
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(`WHERE deleted_at IS NULL AND ST_DWithin(location, ST_SetSRID(ST_MakePoint($3, $4), 4326)::geography, $5) ORDER BY distance, name`)).
+		WithArgs(-0.191, 51.4817, -0.191, 51.4817, 10000.0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "city", "latitude", "longitude", "capacity", "surface", "distance"}).
+			AddRow(uuid.NewV4(), "Stamford Bridge", "London", 51.4817, -0.191, 40343, model.VenueSurfaceHybrid, 0).
+			AddRow(uuid.NewV4(), "Craven Cottage", "London", 51.4749, -0.2217, 22384, model.VenueSurfaceGrass, 2290.4))
+
+	suite.response.getNearbyResp, suite.helper.err = suite.venue.GetNearby(context.Background(), params)
+
+	require.NoError(suite.T(), suite.helper.err)
+	require.Len(suite.T(), suite.response.getNearbyResp, 2)
+	require.Equal(suite.T(), "Craven Cottage", suite.response.getNearbyResp[1].Name)
+}
+
+// AfterTest ...
+func (suite *Suite) AfterTest(_, _ string) {
+	require.NoError(suite.T(), suite.mock.ExpectationsWereMet())
+	suite.helper.err = nil
+}
+
+// Suite We need this function to kick off the test suite, otherwise
+// "go test" won't know about our tests
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
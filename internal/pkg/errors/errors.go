@@ -33,3 +33,13 @@ type URLNotFoundError struct {
 func (r *URLNotFoundError) Error() string {
 	return r.Err.Error()
 }
+
+// InvalidTransitionError ...
+type InvalidTransitionError struct {
+	Err error
+}
+
+// Error ...
+func (r *InvalidTransitionError) Error() string {
+	return r.Err.Error()
+}
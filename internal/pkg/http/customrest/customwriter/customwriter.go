@@ -110,6 +110,14 @@ func (customWriter *CustomWriter) WriteError(w http.ResponseWriter, r *http.Requ
 		)
 	}
 
+	if _, ok := err.(*pkgerrors.InvalidTransitionError); ok {
+		response = failed.New(
+			failed.WithHTTPStatus(http.StatusConflict),
+			failed.WithResponseCode("0009"),
+			failed.WithResponseDesc(err.Error()),
+		)
+	}
+
 	if _, ok := err.(*pkgerrors.URLNotFoundError); ok {
 		response = transporter.ErrURLNotFound
 	}
@@ -0,0 +1,23 @@
+// Copyright (c) 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package jobtest provides a job.IJob for tests, recording the jobs instead of sending them to redis.
+package jobtest
+
+import "github.com/gocraft/work"
+
+// Fake is a job.IJob recording every job queued or dispatched, along with its args.
+type Fake struct {
+	Queued []work.Q
+}
+
+// Dispatch ...
+func (job *Fake) Dispatch(jobName string, secondsFromNow int64, args work.Q) {
+	job.Queue(jobName, args)
+}
+
+// Queue ...
+func (job *Fake) Queue(jobName string, args work.Q) {
+	job.Queued = append(job.Queued, work.Q{"name": jobName, "args": args})
+}